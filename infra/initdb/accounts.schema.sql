@@ -5,33 +5,182 @@ CREATE TABLE
         id UUID PRIMARY KEY DEFAULT gen_random_uuid (),
         name TEXT NOT NULL,
         account_no TEXT NOT NULL,
-        balance DOUBLE PRECISION NOT NULL DEFAULT 0,
-        reserved DOUBLE PRECISION NOT NULL DEFAULT 0,
+        -- amounts are stored in minor units (e.g. paise) of the account currency
+        balance BIGINT NOT NULL DEFAULT 0,
+        reserved BIGINT NOT NULL DEFAULT 0,
+        -- approved overdraft; balance may go down to -credit_limit
+        credit_limit BIGINT NOT NULL DEFAULT 0 CHECK (credit_limit >= 0),
+        currency CHAR(3) NOT NULL DEFAULT 'INR',
+        status VARCHAR(10) CHECK (status IN ('ACTIVE', 'FROZEN', 'DORMANT', 'CLOSED')) NOT NULL DEFAULT 'ACTIVE',
+        status_reason TEXT,
+        closed_at TIMESTAMP,
         created_at TIMESTAMP DEFAULT NOW (),
         updated_at TIMESTAMP DEFAULT NOW ()
     );
 
 CREATE INDEX IF NOT EXISTS idx_accounts_account_id ON accounts (account_no);
 
-CREATE TYPE reservation_status_enum AS ENUM ('PENDING', 'CONFIRMED', 'FAILED');
+CREATE TYPE reservation_status_enum AS ENUM ('PENDING', 'CONFIRMED', 'FAILED', 'EXPIRED');
 
 CREATE TABLE
     IF NOT EXISTS reservations (
         reference_id VARCHAR(100) PRIMARY KEY,
         payer_id VARCHAR(64) NOT NULL,
         payee_id VARCHAR(64) NOT NULL,
-        amount DOUBLE PRECISION NOT NULL,
+        amount BIGINT NOT NULL,
+        currency CHAR(3) NOT NULL DEFAULT 'INR',
+        -- set for cross-currency reservations: the amount locked by the FX quote
+        payee_amount BIGINT,
+        payee_currency CHAR(3),
+        quote_id UUID,
+        -- sums of the partial captures so far, in payer and payee currency
+        captured_amount BIGINT NOT NULL DEFAULT 0,
+        payee_captured_amount BIGINT NOT NULL DEFAULT 0,
+        -- sums of the refunds of captured funds, in payer and payee currency
+        refunded_amount BIGINT NOT NULL DEFAULT 0,
+        payee_refunded_amount BIGINT NOT NULL DEFAULT 0,
+        -- escrow reservations are captured into the ESCROW:<currency> ledger account;
+        -- what is held there now, in payer and payee currency
+        escrow BOOLEAN NOT NULL DEFAULT false,
+        escrow_amount BIGINT NOT NULL DEFAULT 0,
+        escrow_payee_amount BIGINT NOT NULL DEFAULT 0,
+        -- held from the payee for open disputes, in payer and payee currency
+        disputed_amount BIGINT NOT NULL DEFAULT 0,
+        disputed_payee_amount BIGINT NOT NULL DEFAULT 0,
         status reservation_status_enum DEFAULT 'PENDING',
+        -- pending holds are released and marked EXPIRED after this
+        expires_at TIMESTAMP,
         created_at TIMESTAMP DEFAULT NOW (),
         updated_at TIMESTAMP DEFAULT NOW ()
     );
 
-CREATE TABLE ledger (
-    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
-    payer_id UUID,
-    payee_id UUID,
-    amount NUMERIC(12,2) NOT NULL,
-    reference_id VARCHAR(100) UNIQUE NOT NULL,
-    status VARCHAR(20) CHECK (status IN ('INITIATED', 'COMPLETED', 'FAILED')) NOT NULL,
-    created_at TIMESTAMP DEFAULT now()
+CREATE INDEX IF NOT EXISTS idx_reservations_pending_expires_at ON reservations (expires_at) WHERE status = 'PENDING';
+
+-- payees of a split reservation; reservations.payee_id is the payee of leg 1.
+-- Captures and refunds are spread over the legs and tracked here per leg.
+CREATE TABLE IF NOT EXISTS reservation_legs (
+    reference_id VARCHAR(100) NOT NULL REFERENCES reservations (reference_id),
+    leg_no INT NOT NULL,
+    payee_id VARCHAR(64) NOT NULL,
+    amount BIGINT NOT NULL,
+    captured_amount BIGINT NOT NULL DEFAULT 0,
+    refunded_amount BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (reference_id, leg_no)
+);
+
+-- refunds of captured reservations; refund_id makes the Refund RPC idempotent
+CREATE TABLE IF NOT EXISTS refunds (
+    refund_id VARCHAR(100) PRIMARY KEY,
+    reference_id VARCHAR(100) NOT NULL REFERENCES reservations (reference_id),
+    amount BIGINT NOT NULL,
+    currency CHAR(3) NOT NULL,
+    payee_amount BIGINT NOT NULL,
+    payee_currency CHAR(3) NOT NULL,
+    legs JSONB, -- per-payee amounts of a split reservation
+    created_at TIMESTAMP DEFAULT NOW ()
+);
+
+-- releases of escrowed funds to the payee and refunds of them to the payer; the id
+-- makes ReleaseEscrow and RefundEscrow idempotent
+CREATE TABLE IF NOT EXISTS escrow_movements (
+    id VARCHAR(100) PRIMARY KEY,
+    reference_id VARCHAR(100) NOT NULL REFERENCES reservations (reference_id),
+    kind VARCHAR(10) CHECK (kind IN ('RELEASE', 'REFUND')) NOT NULL,
+    amount BIGINT NOT NULL,
+    currency CHAR(3) NOT NULL,
+    payee_amount BIGINT NOT NULL,
+    payee_currency CHAR(3) NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW ()
+);
+
+-- disputed amounts held from the payee in the DISPUTE:<currency> ledger account
+-- until the dispute is won (back to the payee) or lost (to the payer); the id
+-- makes HoldDispute and CloseDisputeHold idempotent
+CREATE TABLE IF NOT EXISTS disputes (
+    id VARCHAR(100) PRIMARY KEY,
+    reference_id VARCHAR(100) NOT NULL REFERENCES reservations (reference_id),
+    amount BIGINT NOT NULL,
+    currency CHAR(3) NOT NULL,
+    payee_amount BIGINT NOT NULL,
+    payee_currency CHAR(3) NOT NULL,
+    status VARCHAR(10) CHECK (status IN ('HELD', 'WON', 'LOST')) NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW (),
+    closed_at TIMESTAMP
+);
+
+-- captures made with a capture_id; a Transfer repeated with the same id returns
+-- the recorded capture instead of capturing again
+CREATE TABLE IF NOT EXISTS captures (
+    capture_id VARCHAR(100) PRIMARY KEY,
+    reference_id VARCHAR(100) NOT NULL REFERENCES reservations (reference_id),
+    amount BIGINT NOT NULL,
+    currency CHAR(3) NOT NULL,
+    payee_amount BIGINT NOT NULL,
+    payee_currency CHAR(3) NOT NULL,
+    released_amount BIGINT NOT NULL DEFAULT 0, -- returned to the payer by a final capture
+    legs JSONB, -- per-payee amounts of a split reservation
+    created_at TIMESTAMP DEFAULT NOW ()
+);
+
+-- Double-entry journal. Every money movement is one entry whose postings balance
+-- (sum of debits = sum of credits per currency). accounts.balance and
+-- accounts.reserved are projections of the AVAILABLE and RESERVED postings.
+-- account_id is an accounts.id or the ledger-only 'EXTERNAL' account.
+CREATE TABLE IF NOT EXISTS journal_entries (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid (),
+    reference_id VARCHAR(100),
+    entry_type VARCHAR(30) NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW ()
+);
+
+CREATE INDEX IF NOT EXISTS idx_journal_entries_reference_id ON journal_entries (reference_id);
+CREATE INDEX IF NOT EXISTS idx_journal_entries_created_at ON journal_entries (created_at, id);
+
+CREATE TABLE IF NOT EXISTS postings (
+    id BIGSERIAL PRIMARY KEY,
+    entry_id UUID NOT NULL REFERENCES journal_entries (id),
+    account_id VARCHAR(64) NOT NULL,
+    bucket VARCHAR(10) CHECK (bucket IN ('AVAILABLE', 'RESERVED')) NOT NULL,
+    direction VARCHAR(6) CHECK (direction IN ('DEBIT', 'CREDIT')) NOT NULL,
+    amount BIGINT NOT NULL CHECK (amount > 0),
+    currency CHAR(3) NOT NULL,
+    -- part of an AVAILABLE debit that took the balance below zero
+    overdraft_amount BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT NOW ()
+);
+
+CREATE INDEX IF NOT EXISTS idx_postings_account_id ON postings (account_id, bucket);
+CREATE INDEX IF NOT EXISTS idx_postings_entry_id ON postings (entry_id);
+
+-- rate = units of quote_currency per one unit of base_currency
+CREATE TABLE IF NOT EXISTS fx_rates (
+    base_currency CHAR(3) NOT NULL,
+    quote_currency CHAR(3) NOT NULL,
+    rate NUMERIC(24, 12) NOT NULL CHECK (rate > 0),
+    updated_at TIMESTAMP DEFAULT NOW (),
+    PRIMARY KEY (base_currency, quote_currency)
+);
+
+CREATE TABLE IF NOT EXISTS fx_quotes (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid (),
+    from_currency CHAR(3) NOT NULL,
+    to_currency CHAR(3) NOT NULL,
+    rate NUMERIC(24, 12) NOT NULL,
+    source_amount BIGINT NOT NULL,
+    target_amount BIGINT NOT NULL,
+    status VARCHAR(10) CHECK (status IN ('OPEN', 'USED')) NOT NULL DEFAULT 'OPEN',
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW ()
+);
+
+-- balance_snapshots hold the balances made up of all journal entries created
+-- before as_of; point-in-time balances replay postings on top of the latest one.
+CREATE TABLE IF NOT EXISTS balance_snapshots (
+    account_id VARCHAR(64) NOT NULL,
+    as_of TIMESTAMP NOT NULL,
+    balance BIGINT NOT NULL,
+    reserved BIGINT NOT NULL,
+    currency CHAR(3) NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW (),
+    PRIMARY KEY (account_id, as_of)
 );
@@ -4,18 +4,72 @@ CREATE TABLE payment_intents (
   reference_id VARCHAR(100) UNIQUE NOT NULL,
   payer_id VARCHAR(100) NOT NULL,
   payee_id VARCHAR(100) NOT NULL,
-  amount NUMERIC(12,2) NOT NULL,
-  status VARCHAR(20) CHECK (status IN ('AUTHORIZED', 'CAPTURED', 'FAILED')) NOT NULL,
+  amount BIGINT NOT NULL, -- minor units
+  currency CHAR(3) NOT NULL DEFAULT 'INR',
+  -- what the payee receives; differs from amount/currency for cross-currency intents
+  payee_amount BIGINT,
+  payee_currency CHAR(3),
+  quote_id VARCHAR(64),
+  captured_amount BIGINT NOT NULL DEFAULT 0, -- sum of the captures so far
+  refunded_amount BIGINT NOT NULL DEFAULT 0, -- sum of the refunds so far
+  status VARCHAR(20) CHECK (status IN ('PENDING_REVIEW', 'AUTHORIZED', 'PARTIALLY_CAPTURED', 'CAPTURED', 'PARTIALLY_REFUNDED', 'REFUNDED', 'FAILED', 'EXPIRED', 'CANCELED')) NOT NULL,
+  cancel_reason VARCHAR(50),
+  -- when the funds hold in accounts-service runs out
+  expires_at TIMESTAMP,
+  -- escrow intents credit their captures to escrow until released or refunded
+  escrow BOOLEAN NOT NULL DEFAULT false,
+  escrow_amount BIGINT NOT NULL DEFAULT 0, -- captured and held in escrow now
+  escrow_release_at TIMESTAMP, -- released automatically from then on; NULL for on request only
+  disputed_amount BIGINT NOT NULL DEFAULT 0, -- held from the payee for open disputes
+  hold_ttl_seconds BIGINT, -- hold requested by an intent held for risk review, reserved once approved
   created_at TIMESTAMP DEFAULT now(),
   updated_at TIMESTAMP DEFAULT now()
 );
 
 
--- payments table (capture creates two rows with same reference_id)
+CREATE INDEX IF NOT EXISTS idx_payment_intents_created_at ON payment_intents (created_at, id);
+CREATE INDEX IF NOT EXISTS idx_payment_intents_payer_id ON payment_intents (payer_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_payment_intents_payee_id ON payment_intents (payee_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_payment_intents_escrow_release_at ON payment_intents (escrow_release_at) WHERE escrow_amount > 0;
+
+-- decisions of the risk rules, made before the funds of an intent are reserved
+CREATE TABLE IF NOT EXISTS risk_evaluations (
+    id BIGSERIAL PRIMARY KEY,
+    reference_id VARCHAR(100) NOT NULL, -- not a foreign key: denied intents are never stored
+    payer_id VARCHAR(100) NOT NULL,
+    payee_id VARCHAR(100) NOT NULL,
+    amount BIGINT NOT NULL,
+    currency CHAR(3) NOT NULL,
+    decision VARCHAR(10) CHECK (decision IN ('ALLOW', 'REVIEW', 'DENY')) NOT NULL,
+    reasons TEXT[] NOT NULL DEFAULT '{}',
+    rules_version VARCHAR(64), -- of the rules file; NULL when no rules were loaded
+    created_at TIMESTAMP DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_risk_evaluations_reference_id ON risk_evaluations (reference_id);
+CREATE INDEX IF NOT EXISTS idx_risk_evaluations_payer_id ON risk_evaluations (payer_id, id);
+
+-- payees of a split intent; payment_intents.payee_id is the payee of leg 1
+CREATE TABLE IF NOT EXISTS payment_legs (
+    reference_id VARCHAR(100) NOT NULL REFERENCES payment_intents (reference_id),
+    leg_no INT NOT NULL,
+    payee_id VARCHAR(100) NOT NULL,
+    amount BIGINT NOT NULL, -- minor units of the intent currency
+    PRIMARY KEY (reference_id, leg_no)
+);
+
+CREATE INDEX IF NOT EXISTS idx_payment_legs_payee_id ON payment_legs (payee_id);
+
+
+-- payments table (every capture creates a DEBIT and a CREDIT row with the same
+-- capture_id, one CREDIT per payee of a split intent; a refund creates the
+-- reverse rows with the refund id as capture_id)
 CREATE TABLE IF NOT EXISTS payments (
     id SERIAL PRIMARY KEY,
+    capture_id VARCHAR(100) NOT NULL,
     account_id VARCHAR(50) NOT NULL,
-    amount NUMERIC(12, 2) NOT NULL,
+    amount BIGINT NOT NULL,
+    currency CHAR(3) NOT NULL DEFAULT 'INR',
     txn_type VARCHAR(10) CHECK (txn_type IN ('DEBIT', 'CREDIT')) NOT NULL,
     status VARCHAR(20) DEFAULT 'SUCCESS',
     reference_id VARCHAR(100) NOT NULL,
@@ -23,9 +77,121 @@ CREATE TABLE IF NOT EXISTS payments (
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 );
 
--- Create a unique index on (reference_id, txn_type)
-CREATE UNIQUE INDEX IF NOT EXISTS idx_payments_reference_txn_type
-    ON payments (reference_id, txn_type);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_payments_capture_txn_type
+    ON payments (capture_id, txn_type, account_id);
+
+CREATE INDEX IF NOT EXISTS idx_payments_reference_id ON payments (reference_id);
+
+
+-- refunds of captured payments
+CREATE TABLE IF NOT EXISTS refunds (
+    id VARCHAR(100) PRIMARY KEY,
+    reference_id VARCHAR(100) NOT NULL REFERENCES payment_intents (reference_id),
+    idempotency_key VARCHAR(100) NOT NULL,
+    amount BIGINT NOT NULL, -- returned to the payer
+    currency CHAR(3) NOT NULL,
+    payee_amount BIGINT, -- taken back from the payee
+    payee_currency CHAR(3),
+    reason TEXT,
+    status VARCHAR(20) CHECK (status IN ('PENDING', 'SUCCEEDED', 'FAILED')) NOT NULL,
+    message TEXT,
+    created_at TIMESTAMP DEFAULT now(),
+    updated_at TIMESTAMP DEFAULT now(),
+    UNIQUE (reference_id, idempotency_key)
+);
+
+
+-- releases of escrowed funds to the payee and refunds of them to the payer;
+-- PENDING until accounts-service has moved the money
+CREATE TABLE IF NOT EXISTS escrow_movements (
+    id VARCHAR(100) PRIMARY KEY, -- the capture_id of the payments rows
+    reference_id VARCHAR(100) NOT NULL REFERENCES payment_intents (reference_id),
+    kind VARCHAR(10) CHECK (kind IN ('RELEASE', 'REFUND')) NOT NULL,
+    idempotency_key VARCHAR(100) NOT NULL,
+    amount BIGINT NOT NULL, -- taken out of escrow
+    currency CHAR(3) NOT NULL,
+    payee_amount BIGINT, -- credited to the payee by a release
+    payee_currency CHAR(3),
+    reason TEXT,
+    status VARCHAR(20) CHECK (status IN ('PENDING', 'SUCCEEDED', 'FAILED')) NOT NULL,
+    message TEXT,
+    created_at TIMESTAMP DEFAULT now(),
+    updated_at TIMESTAMP DEFAULT now(),
+    UNIQUE (reference_id, kind, idempotency_key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_escrow_movements_pending ON escrow_movements (updated_at) WHERE status = 'PENDING';
+
+-- chargebacks: OPENING until accounts-service has held the disputed amount from
+-- the payee, then OPEN, UNDER_REVIEW once evidence is submitted, and WON or LOST
+CREATE TABLE IF NOT EXISTS disputes (
+    id VARCHAR(100) PRIMARY KEY, -- the capture_id of the payments rows that open the hold
+    reference_id VARCHAR(100) NOT NULL REFERENCES payment_intents (reference_id),
+    idempotency_key VARCHAR(100) NOT NULL,
+    amount BIGINT NOT NULL, -- disputed, in the intent currency
+    currency CHAR(3) NOT NULL,
+    payee_amount BIGINT, -- held from the payee, set once OPEN
+    payee_currency CHAR(3),
+    reason TEXT,
+    status VARCHAR(20) CHECK (status IN ('OPENING', 'OPEN', 'UNDER_REVIEW', 'WON', 'LOST', 'FAILED')) NOT NULL,
+    outcome VARCHAR(10) CHECK (outcome IN ('WON', 'LOST')), -- set while the resolution is in progress
+    message TEXT,
+    created_at TIMESTAMP DEFAULT now(),
+    updated_at TIMESTAMP DEFAULT now(),
+    resolved_at TIMESTAMP,
+    UNIQUE (reference_id, idempotency_key)
+);
+
+CREATE TABLE IF NOT EXISTS dispute_evidence (
+    id BIGSERIAL PRIMARY KEY,
+    dispute_id VARCHAR(100) NOT NULL REFERENCES disputes (id),
+    description TEXT NOT NULL,
+    content TEXT,
+    submitted_by VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_dispute_evidence_dispute_id ON dispute_evidence (dispute_id, id);
+
+
+-- capture sagas: STARTED before accounts-service moves the money, TRANSFERRED
+-- once it has, COMPLETED when the payments rows, outbox event and intent status
+-- are written; FAILED when accounts-service refused the transfer
+CREATE TABLE IF NOT EXISTS captures (
+    id VARCHAR(100) PRIMARY KEY, -- the capture_id of the payments rows and event
+    reference_id VARCHAR(100) NOT NULL REFERENCES payment_intents (reference_id),
+    requested_amount BIGINT NOT NULL, -- 0 captures everything left
+    final BOOLEAN NOT NULL DEFAULT false,
+    step VARCHAR(20) CHECK (step IN ('STARTED', 'TRANSFERRED', 'COMPLETED', 'FAILED')) NOT NULL,
+    amount BIGINT, -- set once TRANSFERRED
+    currency CHAR(3) NOT NULL,
+    payee_amount BIGINT,
+    payee_currency CHAR(3) NOT NULL,
+    captured_total BIGINT, -- captured on the intent so far, including this capture
+    remaining_amount BIGINT,
+    legs JSONB, -- per-payee amounts of a split intent, set once TRANSFERRED
+    message TEXT,
+    attempts INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT now(),
+    updated_at TIMESTAMP DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_captures_in_flight ON captures (updated_at) WHERE step IN ('STARTED', 'TRANSFERRED');
+CREATE INDEX IF NOT EXISTS idx_captures_reference_id ON captures (reference_id);
+
+
+-- every status change of a payment intent; from_status is NULL for the first one
+CREATE TABLE IF NOT EXISTS payment_status_history (
+    id BIGSERIAL PRIMARY KEY,
+    reference_id VARCHAR(100) NOT NULL REFERENCES payment_intents (reference_id),
+    from_status VARCHAR(20),
+    to_status VARCHAR(20) NOT NULL,
+    actor VARCHAR(100) NOT NULL,
+    reason TEXT,
+    created_at TIMESTAMP DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_payment_status_history_reference_id ON payment_status_history (reference_id, id);
 
 
 -- outbox events table
@@ -39,10 +205,106 @@ CREATE TABLE IF NOT EXISTS outbox_events (
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 );
 
+CREATE INDEX IF NOT EXISTS idx_outbox_events_reference_id ON outbox_events ((payload->>'reference_id'));
+
+
+-- webhooks: endpoints registered by payees, one delivery per event and endpoint,
+-- and a log of every attempt to send it
+CREATE TABLE IF NOT EXISTS webhook_endpoints (
+    id VARCHAR(100) PRIMARY KEY,
+    account_id VARCHAR(100) NOT NULL, -- the payee notified
+    url TEXT NOT NULL,
+    secret VARCHAR(100) NOT NULL,
+    event_types TEXT[] NOT NULL DEFAULT '{}', -- empty subscribes to every event type
+    active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMP DEFAULT now(),
+    updated_at TIMESTAMP DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_endpoints_account_id ON webhook_endpoints (account_id);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id BIGSERIAL PRIMARY KEY,
+    endpoint_id VARCHAR(100) NOT NULL REFERENCES webhook_endpoints (id),
+    event_id VARCHAR(100) NOT NULL,
+    event_type VARCHAR(50) NOT NULL,
+    reference_id VARCHAR(100),
+    payload JSONB NOT NULL,
+    status VARCHAR(20) CHECK (status IN ('PENDING', 'DELIVERED', 'FAILED')) NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    last_status_code INT,
+    last_error TEXT,
+    next_attempt_at TIMESTAMP NOT NULL,
+    expires_at TIMESTAMP NOT NULL, -- retries stop after this
+    created_at TIMESTAMP DEFAULT now(),
+    updated_at TIMESTAMP DEFAULT now(),
+    UNIQUE (endpoint_id, event_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_due ON webhook_deliveries (next_attempt_at) WHERE status = 'PENDING';
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_reference_id ON webhook_deliveries (reference_id);
+
+CREATE TABLE IF NOT EXISTS webhook_delivery_attempts (
+    id BIGSERIAL PRIMARY KEY,
+    delivery_id BIGINT NOT NULL REFERENCES webhook_deliveries (id),
+    attempt INT NOT NULL,
+    status_code INT, -- NULL when the endpoint did not respond
+    error TEXT,
+    duration_ms BIGINT NOT NULL,
+    created_at TIMESTAMP DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_delivery_attempts_delivery_id ON webhook_delivery_attempts (delivery_id);
+
 
--- idempotency keys (store final response)
+-- idempotency keys, scoped per caller and RPC; a request holds its key
+-- IN_PROGRESS until it completes and stores its response
 CREATE TABLE idempotency_keys (
-  key VARCHAR(100) PRIMARY KEY,
+  principal VARCHAR(100) NOT NULL DEFAULT '', -- the authenticated caller
+  operation VARCHAR(50) NOT NULL,
+  key VARCHAR(100) NOT NULL,
+  request_hash CHAR(64), -- sha256 of the request; NULL for keys stored before requests were hashed
+  status VARCHAR(20) CHECK (status IN ('IN_PROGRESS', 'COMPLETED')) NOT NULL,
+  response JSONB,
+  locked_until TIMESTAMP,
   created_at TIMESTAMP DEFAULT now(),
-  response JSONB
+  expires_at TIMESTAMP NOT NULL,
+  PRIMARY KEY (principal, operation, key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires_at ON idempotency_keys (expires_at);
+
+
+-- bulk payout files; every row is paid by an intent that is captured at once,
+-- and a reference is paid out only once across all batches
+CREATE TABLE IF NOT EXISTS payout_batches (
+    id VARCHAR(100) PRIMARY KEY,
+    format VARCHAR(10) CHECK (format IN ('CSV', 'JSONL')) NOT NULL,
+    status VARCHAR(20) CHECK (status IN ('PROCESSING', 'COMPLETED')) NOT NULL,
+    actor VARCHAR(100) NOT NULL, -- who submitted the file
+    total_rows INT NOT NULL,
+    created_at TIMESTAMP DEFAULT now(),
+    updated_at TIMESTAMP DEFAULT now(),
+    completed_at TIMESTAMP
 );
+
+CREATE TABLE IF NOT EXISTS payout_rows (
+    batch_id VARCHAR(100) NOT NULL REFERENCES payout_batches (id),
+    line INT NOT NULL, -- line of the row in the submitted file
+    reference VARCHAR(100) NOT NULL, -- reference_id of the intent paying it
+    payer_id VARCHAR(100) NOT NULL,
+    payee_id VARCHAR(100) NOT NULL,
+    amount BIGINT NOT NULL,
+    currency CHAR(3), -- NULL for the payer's currency
+    status VARCHAR(20) CHECK (status IN ('PENDING', 'SUCCEEDED', 'FAILED')) NOT NULL,
+    capture_id VARCHAR(100),
+    message TEXT,
+    attempts INT NOT NULL DEFAULT 0,
+    locked_until TIMESTAMP NOT NULL DEFAULT now(), -- a claimed row is not picked up again before this
+    created_at TIMESTAMP DEFAULT now(),
+    updated_at TIMESTAMP DEFAULT now(),
+    PRIMARY KEY (batch_id, line)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_payout_rows_reference ON payout_rows (reference);
+CREATE INDEX IF NOT EXISTS idx_payout_rows_pending ON payout_rows (created_at, line) WHERE status = 'PENDING';
@@ -2,11 +2,17 @@ CREATE TABLE IF NOT EXISTS settlements (
     id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
     payer_id UUID,
     payee_id UUID,
-    amount NUMERIC(12,2) NOT NULL,
-    reference_id VARCHAR(100) UNIQUE NOT NULL,
+    amount BIGINT NOT NULL, -- minor units
+    currency CHAR(3) NOT NULL DEFAULT 'INR',
+    reference_id VARCHAR(100) NOT NULL,
+    capture_id VARCHAR(100) NOT NULL,
+    leg INT NOT NULL DEFAULT 0, -- leg of a split payment, 0 for a single payee
+    kind VARCHAR(10) CHECK (kind IN ('CAPTURE', 'CLAWBACK')) NOT NULL DEFAULT 'CAPTURE', -- CLAWBACK of a lost dispute
     status VARCHAR(20) CHECK (status IN ('PENDING', 'SETTLED', 'FAILED')) DEFAULT 'PENDING',
     created_at TIMESTAMP DEFAULT now(),
-    updated_at TIMESTAMP DEFAULT now()
+    updated_at TIMESTAMP DEFAULT now(),
+    -- one settlement per capture of a payment and payee leg
+    CONSTRAINT settlements_capture_id_leg_key UNIQUE (capture_id, leg)
 );
 
 CREATE INDEX IF NOT EXISTS idx_settlement_reference_id ON settlements(reference_id);
@@ -0,0 +1,37 @@
+-- Convert floating point / NUMERIC amounts into BIGINT minor units.
+-- Every existing row predates multi-currency support and is in INR (2 decimals).
+BEGIN;
+
+ALTER TABLE accounts ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'INR';
+ALTER TABLE reservations ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'INR';
+ALTER TABLE ledger ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'INR';
+
+DO $$
+BEGIN
+    IF (SELECT data_type FROM information_schema.columns
+        WHERE table_name = 'accounts' AND column_name = 'balance') <> 'bigint' THEN
+        ALTER TABLE accounts
+            ALTER COLUMN balance DROP DEFAULT,
+            ALTER COLUMN reserved DROP DEFAULT;
+        ALTER TABLE accounts
+            ALTER COLUMN balance TYPE BIGINT USING ROUND(balance::NUMERIC * 100)::BIGINT,
+            ALTER COLUMN reserved TYPE BIGINT USING ROUND(reserved::NUMERIC * 100)::BIGINT;
+        ALTER TABLE accounts
+            ALTER COLUMN balance SET DEFAULT 0,
+            ALTER COLUMN reserved SET DEFAULT 0;
+    END IF;
+
+    IF (SELECT data_type FROM information_schema.columns
+        WHERE table_name = 'reservations' AND column_name = 'amount') <> 'bigint' THEN
+        ALTER TABLE reservations
+            ALTER COLUMN amount TYPE BIGINT USING ROUND(amount::NUMERIC * 100)::BIGINT;
+    END IF;
+
+    IF (SELECT data_type FROM information_schema.columns
+        WHERE table_name = 'ledger' AND column_name = 'amount') <> 'bigint' THEN
+        ALTER TABLE ledger
+            ALTER COLUMN amount TYPE BIGINT USING ROUND(amount * 100)::BIGINT;
+    END IF;
+END $$;
+
+COMMIT;
@@ -0,0 +1,49 @@
+-- Replace the single-row-per-reference ledger with a double-entry journal and
+-- open every existing account with entries against the EXTERNAL account so that
+-- balances can be verified against postings from now on.
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS journal_entries (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid (),
+    reference_id VARCHAR(100),
+    entry_type VARCHAR(30) NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW ()
+);
+
+CREATE INDEX IF NOT EXISTS idx_journal_entries_reference_id ON journal_entries (reference_id);
+
+CREATE TABLE IF NOT EXISTS postings (
+    id BIGSERIAL PRIMARY KEY,
+    entry_id UUID NOT NULL REFERENCES journal_entries (id),
+    account_id VARCHAR(64) NOT NULL,
+    bucket VARCHAR(10) CHECK (bucket IN ('AVAILABLE', 'RESERVED')) NOT NULL,
+    direction VARCHAR(6) CHECK (direction IN ('DEBIT', 'CREDIT')) NOT NULL,
+    amount BIGINT NOT NULL CHECK (amount > 0),
+    currency CHAR(3) NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW ()
+);
+
+CREATE INDEX IF NOT EXISTS idx_postings_account_id ON postings (account_id, bucket);
+CREATE INDEX IF NOT EXISTS idx_postings_entry_id ON postings (entry_id);
+
+-- one opening entry per account that has no postings yet
+CREATE TEMP TABLE opening ON COMMIT DROP AS
+SELECT gen_random_uuid () AS entry_id, a.id::TEXT AS account_id, a.balance, a.reserved, a.currency
+FROM accounts a
+WHERE (a.balance <> 0 OR a.reserved <> 0)
+  AND NOT EXISTS (SELECT 1 FROM postings p WHERE p.account_id = a.id::TEXT);
+
+INSERT INTO journal_entries (id, entry_type)
+SELECT entry_id, 'OPENING_BALANCE' FROM opening;
+
+INSERT INTO postings (entry_id, account_id, bucket, direction, amount, currency)
+SELECT entry_id, 'EXTERNAL', 'AVAILABLE', 'DEBIT', balance + reserved, currency FROM opening
+UNION ALL
+SELECT entry_id, account_id, 'AVAILABLE', 'CREDIT', balance, currency FROM opening WHERE balance > 0
+UNION ALL
+SELECT entry_id, account_id, 'RESERVED', 'CREDIT', reserved, currency FROM opening WHERE reserved > 0;
+
+-- the old table is kept for reference only
+ALTER TABLE IF EXISTS ledger RENAME TO ledger_legacy;
+
+COMMIT;
@@ -0,0 +1,29 @@
+-- Exchange rates, locked FX quotes and cross-currency reservations.
+BEGIN;
+
+ALTER TABLE reservations
+    ADD COLUMN IF NOT EXISTS payee_amount BIGINT,
+    ADD COLUMN IF NOT EXISTS payee_currency CHAR(3),
+    ADD COLUMN IF NOT EXISTS quote_id UUID;
+
+CREATE TABLE IF NOT EXISTS fx_rates (
+    base_currency CHAR(3) NOT NULL,
+    quote_currency CHAR(3) NOT NULL,
+    rate NUMERIC(24, 12) NOT NULL CHECK (rate > 0),
+    updated_at TIMESTAMP DEFAULT NOW (),
+    PRIMARY KEY (base_currency, quote_currency)
+);
+
+CREATE TABLE IF NOT EXISTS fx_quotes (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid (),
+    from_currency CHAR(3) NOT NULL,
+    to_currency CHAR(3) NOT NULL,
+    rate NUMERIC(24, 12) NOT NULL,
+    source_amount BIGINT NOT NULL,
+    target_amount BIGINT NOT NULL,
+    status VARCHAR(10) CHECK (status IN ('OPEN', 'USED')) NOT NULL DEFAULT 'OPEN',
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW ()
+);
+
+COMMIT;
@@ -0,0 +1,13 @@
+-- Account lifecycle: ACTIVE, FROZEN, DORMANT, CLOSED.
+BEGIN;
+
+ALTER TABLE accounts
+    ADD COLUMN IF NOT EXISTS status VARCHAR(10) NOT NULL DEFAULT 'ACTIVE',
+    ADD COLUMN IF NOT EXISTS status_reason TEXT,
+    ADD COLUMN IF NOT EXISTS closed_at TIMESTAMP;
+
+ALTER TABLE accounts DROP CONSTRAINT IF EXISTS accounts_status_check;
+ALTER TABLE accounts ADD CONSTRAINT accounts_status_check
+    CHECK (status IN ('ACTIVE', 'FROZEN', 'DORMANT', 'CLOSED'));
+
+COMMIT;
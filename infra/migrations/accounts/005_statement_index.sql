@@ -0,0 +1,2 @@
+-- Statements page through journal entries by (created_at, id).
+CREATE INDEX IF NOT EXISTS idx_journal_entries_created_at ON journal_entries (created_at, id);
@@ -0,0 +1,10 @@
+-- Daily balance snapshots for point-in-time balance queries.
+CREATE TABLE IF NOT EXISTS balance_snapshots (
+    account_id VARCHAR(64) NOT NULL,
+    as_of TIMESTAMP NOT NULL,
+    balance BIGINT NOT NULL,
+    reserved BIGINT NOT NULL,
+    currency CHAR(3) NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW (),
+    PRIMARY KEY (account_id, as_of)
+);
@@ -0,0 +1,13 @@
+-- Per-account overdraft limit and the overdraft portion of each debit posting.
+BEGIN;
+
+ALTER TABLE accounts
+    ADD COLUMN IF NOT EXISTS credit_limit BIGINT NOT NULL DEFAULT 0;
+
+ALTER TABLE accounts DROP CONSTRAINT IF EXISTS accounts_credit_limit_check;
+ALTER TABLE accounts ADD CONSTRAINT accounts_credit_limit_check CHECK (credit_limit >= 0);
+
+ALTER TABLE postings
+    ADD COLUMN IF NOT EXISTS overdraft_amount BIGINT NOT NULL DEFAULT 0;
+
+COMMIT;
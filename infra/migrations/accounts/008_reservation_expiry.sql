@@ -0,0 +1,16 @@
+-- Reservation holds expire and are released by the accounts-service sweeper.
+-- Existing pending reservations get the default RESERVATION_TTL_SECONDS (900)
+-- from their creation, so holds left behind before expiry existed are released
+-- by the first sweep.
+BEGIN;
+
+ALTER TYPE reservation_status_enum ADD VALUE IF NOT EXISTS 'EXPIRED';
+
+ALTER TABLE reservations ADD COLUMN IF NOT EXISTS expires_at TIMESTAMP;
+
+UPDATE reservations SET expires_at = COALESCE(created_at, now()) + INTERVAL '900 seconds'
+WHERE status = 'PENDING' AND expires_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_reservations_pending_expires_at ON reservations (expires_at) WHERE status = 'PENDING';
+
+COMMIT;
@@ -0,0 +1,13 @@
+-- Reservations can be captured in several parts.
+BEGIN;
+
+ALTER TABLE reservations
+    ADD COLUMN IF NOT EXISTS captured_amount BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS payee_captured_amount BIGINT NOT NULL DEFAULT 0;
+
+-- confirmed reservations were captured in full
+UPDATE reservations
+SET captured_amount = amount, payee_captured_amount = COALESCE(payee_amount, amount)
+WHERE status = 'CONFIRMED' AND captured_amount = 0;
+
+COMMIT;
@@ -0,0 +1,18 @@
+-- Refunds of captured reservations.
+BEGIN;
+
+ALTER TABLE reservations
+    ADD COLUMN IF NOT EXISTS refunded_amount BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS payee_refunded_amount BIGINT NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS refunds (
+    refund_id VARCHAR(100) PRIMARY KEY,
+    reference_id VARCHAR(100) NOT NULL REFERENCES reservations (reference_id),
+    amount BIGINT NOT NULL,
+    currency CHAR(3) NOT NULL,
+    payee_amount BIGINT NOT NULL,
+    payee_currency CHAR(3) NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW ()
+);
+
+COMMIT;
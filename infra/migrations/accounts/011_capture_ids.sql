@@ -0,0 +1,11 @@
+-- Transfers can carry a capture_id that makes them safe to retry.
+CREATE TABLE IF NOT EXISTS captures (
+    capture_id VARCHAR(100) PRIMARY KEY,
+    reference_id VARCHAR(100) NOT NULL REFERENCES reservations (reference_id),
+    amount BIGINT NOT NULL,
+    currency CHAR(3) NOT NULL,
+    payee_amount BIGINT NOT NULL,
+    payee_currency CHAR(3) NOT NULL,
+    released_amount BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT NOW ()
+);
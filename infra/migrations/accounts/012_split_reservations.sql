@@ -0,0 +1,17 @@
+-- Split reservations: one hold on the payer paid out to several payees.
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS reservation_legs (
+    reference_id VARCHAR(100) NOT NULL REFERENCES reservations (reference_id),
+    leg_no INT NOT NULL,
+    payee_id VARCHAR(64) NOT NULL,
+    amount BIGINT NOT NULL,
+    captured_amount BIGINT NOT NULL DEFAULT 0,
+    refunded_amount BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (reference_id, leg_no)
+);
+
+ALTER TABLE captures ADD COLUMN IF NOT EXISTS legs JSONB;
+ALTER TABLE refunds ADD COLUMN IF NOT EXISTS legs JSONB;
+
+COMMIT;
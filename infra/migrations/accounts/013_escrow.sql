@@ -0,0 +1,20 @@
+-- Escrow reservations: captures are held in escrow until released or refunded.
+BEGIN;
+
+ALTER TABLE reservations
+    ADD COLUMN IF NOT EXISTS escrow BOOLEAN NOT NULL DEFAULT false,
+    ADD COLUMN IF NOT EXISTS escrow_amount BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS escrow_payee_amount BIGINT NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS escrow_movements (
+    id VARCHAR(100) PRIMARY KEY,
+    reference_id VARCHAR(100) NOT NULL REFERENCES reservations (reference_id),
+    kind VARCHAR(10) CHECK (kind IN ('RELEASE', 'REFUND')) NOT NULL,
+    amount BIGINT NOT NULL,
+    currency CHAR(3) NOT NULL,
+    payee_amount BIGINT NOT NULL,
+    payee_currency CHAR(3) NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW ()
+);
+
+COMMIT;
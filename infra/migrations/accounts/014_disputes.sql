@@ -0,0 +1,20 @@
+-- Disputes: disputed amounts are held from the payee until won or lost.
+BEGIN;
+
+ALTER TABLE reservations
+    ADD COLUMN IF NOT EXISTS disputed_amount BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS disputed_payee_amount BIGINT NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS disputes (
+    id VARCHAR(100) PRIMARY KEY,
+    reference_id VARCHAR(100) NOT NULL REFERENCES reservations (reference_id),
+    amount BIGINT NOT NULL,
+    currency CHAR(3) NOT NULL,
+    payee_amount BIGINT NOT NULL,
+    payee_currency CHAR(3) NOT NULL,
+    status VARCHAR(10) CHECK (status IN ('HELD', 'WON', 'LOST')) NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW (),
+    closed_at TIMESTAMP
+);
+
+COMMIT;
@@ -0,0 +1,32 @@
+-- Convert NUMERIC(12,2) amounts into BIGINT minor units.
+-- Every existing row predates multi-currency support and is in INR (2 decimals).
+BEGIN;
+
+ALTER TABLE payment_intents ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'INR';
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'INR';
+
+DO $$
+BEGIN
+    IF (SELECT data_type FROM information_schema.columns
+        WHERE table_name = 'payment_intents' AND column_name = 'amount') <> 'bigint' THEN
+        ALTER TABLE payment_intents
+            ALTER COLUMN amount TYPE BIGINT USING ROUND(amount * 100)::BIGINT;
+    END IF;
+
+    IF (SELECT data_type FROM information_schema.columns
+        WHERE table_name = 'payments' AND column_name = 'amount') <> 'bigint' THEN
+        ALTER TABLE payments
+            ALTER COLUMN amount TYPE BIGINT USING ROUND(amount * 100)::BIGINT;
+    END IF;
+END $$;
+
+-- Outbox payloads still waiting to be published carry a float "amount" and were
+-- written with the payer/payee JSON keys swapped.
+UPDATE outbox_events
+SET payload = payload || jsonb_build_object(
+        'amount', jsonb_build_object('minor', ROUND((payload->>'amount')::NUMERIC * 100)::BIGINT, 'currency', 'INR'),
+        'payer_id', payload->'payee_id',
+        'payee_id', payload->'payer_id')
+WHERE status = 'PENDING' AND jsonb_typeof(payload->'amount') = 'number';
+
+COMMIT;
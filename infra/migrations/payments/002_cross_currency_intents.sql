@@ -0,0 +1,9 @@
+-- Payee leg of cross-currency payment intents.
+BEGIN;
+
+ALTER TABLE payment_intents
+    ADD COLUMN IF NOT EXISTS payee_amount BIGINT,
+    ADD COLUMN IF NOT EXISTS payee_currency CHAR(3),
+    ADD COLUMN IF NOT EXISTS quote_id VARCHAR(64);
+
+COMMIT;
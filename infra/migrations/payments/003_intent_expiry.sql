@@ -0,0 +1,14 @@
+-- Payment intents expire together with their funds hold. Existing authorized
+-- intents get the same default expiry as the backfilled accounts-service holds.
+BEGIN;
+
+ALTER TABLE payment_intents ADD COLUMN IF NOT EXISTS expires_at TIMESTAMP;
+
+UPDATE payment_intents SET expires_at = COALESCE(created_at, now()) + INTERVAL '900 seconds'
+WHERE status = 'AUTHORIZED' AND expires_at IS NULL;
+
+ALTER TABLE payment_intents DROP CONSTRAINT IF EXISTS payment_intents_status_check;
+ALTER TABLE payment_intents ADD CONSTRAINT payment_intents_status_check
+    CHECK (status IN ('AUTHORIZED', 'CAPTURED', 'FAILED', 'EXPIRED'));
+
+COMMIT;
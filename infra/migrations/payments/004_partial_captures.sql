@@ -0,0 +1,20 @@
+-- A payment intent can be captured in several parts; payments rows are keyed by capture.
+BEGIN;
+
+ALTER TABLE payment_intents ADD COLUMN IF NOT EXISTS captured_amount BIGINT NOT NULL DEFAULT 0;
+UPDATE payment_intents SET captured_amount = amount WHERE status = 'CAPTURED' AND captured_amount = 0;
+
+ALTER TABLE payment_intents DROP CONSTRAINT IF EXISTS payment_intents_status_check;
+ALTER TABLE payment_intents ADD CONSTRAINT payment_intents_status_check
+    CHECK (status IN ('AUTHORIZED', 'PARTIALLY_CAPTURED', 'CAPTURED', 'FAILED', 'EXPIRED'));
+
+-- existing captures were single full captures identified by the reference
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS capture_id VARCHAR(100);
+UPDATE payments SET capture_id = reference_id WHERE capture_id IS NULL;
+ALTER TABLE payments ALTER COLUMN capture_id SET NOT NULL;
+
+DROP INDEX IF EXISTS idx_payments_reference_txn_type;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_payments_capture_txn_type ON payments (capture_id, txn_type);
+CREATE INDEX IF NOT EXISTS idx_payments_reference_id ON payments (reference_id);
+
+COMMIT;
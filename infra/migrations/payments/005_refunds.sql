@@ -0,0 +1,26 @@
+-- Full and partial refunds of captured payments.
+BEGIN;
+
+ALTER TABLE payment_intents ADD COLUMN IF NOT EXISTS refunded_amount BIGINT NOT NULL DEFAULT 0;
+
+ALTER TABLE payment_intents DROP CONSTRAINT IF EXISTS payment_intents_status_check;
+ALTER TABLE payment_intents ADD CONSTRAINT payment_intents_status_check
+    CHECK (status IN ('AUTHORIZED', 'PARTIALLY_CAPTURED', 'CAPTURED', 'REFUNDED', 'FAILED', 'EXPIRED'));
+
+CREATE TABLE IF NOT EXISTS refunds (
+    id VARCHAR(100) PRIMARY KEY,
+    reference_id VARCHAR(100) NOT NULL REFERENCES payment_intents (reference_id),
+    idempotency_key VARCHAR(100) NOT NULL,
+    amount BIGINT NOT NULL,
+    currency CHAR(3) NOT NULL,
+    payee_amount BIGINT,
+    payee_currency CHAR(3),
+    reason TEXT,
+    status VARCHAR(20) CHECK (status IN ('PENDING', 'SUCCEEDED', 'FAILED')) NOT NULL,
+    message TEXT,
+    created_at TIMESTAMP DEFAULT now(),
+    updated_at TIMESTAMP DEFAULT now(),
+    UNIQUE (reference_id, idempotency_key)
+);
+
+COMMIT;
@@ -0,0 +1,10 @@
+-- Authorized payment intents can be canceled.
+BEGIN;
+
+ALTER TABLE payment_intents ADD COLUMN IF NOT EXISTS cancel_reason VARCHAR(50);
+
+ALTER TABLE payment_intents DROP CONSTRAINT IF EXISTS payment_intents_status_check;
+ALTER TABLE payment_intents ADD CONSTRAINT payment_intents_status_check
+    CHECK (status IN ('AUTHORIZED', 'PARTIALLY_CAPTURED', 'CAPTURED', 'REFUNDED', 'FAILED', 'EXPIRED', 'CANCELED'));
+
+COMMIT;
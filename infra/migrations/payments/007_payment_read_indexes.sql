@@ -0,0 +1,5 @@
+-- Indexes for GetPayment and ListPayments.
+CREATE INDEX IF NOT EXISTS idx_payment_intents_created_at ON payment_intents (created_at, id);
+CREATE INDEX IF NOT EXISTS idx_payment_intents_payer_id ON payment_intents (payer_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_payment_intents_payee_id ON payment_intents (payee_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_outbox_events_reference_id ON outbox_events ((payload->>'reference_id'));
@@ -0,0 +1,30 @@
+-- Payment status changes go through a state machine and are recorded. Existing
+-- intents get one history row with their current status.
+BEGIN;
+
+ALTER TABLE payment_intents DROP CONSTRAINT IF EXISTS payment_intents_status_check;
+ALTER TABLE payment_intents ADD CONSTRAINT payment_intents_status_check
+    CHECK (status IN ('AUTHORIZED', 'PARTIALLY_CAPTURED', 'CAPTURED', 'PARTIALLY_REFUNDED', 'REFUNDED', 'FAILED', 'EXPIRED', 'CANCELED'));
+
+CREATE TABLE IF NOT EXISTS payment_status_history (
+    id BIGSERIAL PRIMARY KEY,
+    reference_id VARCHAR(100) NOT NULL REFERENCES payment_intents (reference_id),
+    from_status VARCHAR(20),
+    to_status VARCHAR(20) NOT NULL,
+    actor VARCHAR(100) NOT NULL,
+    reason TEXT,
+    created_at TIMESTAMP DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_payment_status_history_reference_id ON payment_status_history (reference_id, id);
+
+-- intents refunded in part so far stayed CAPTURED
+UPDATE payment_intents SET status = 'PARTIALLY_REFUNDED'
+WHERE status = 'CAPTURED' AND refunded_amount > 0 AND refunded_amount < captured_amount;
+
+INSERT INTO payment_status_history (reference_id, from_status, to_status, actor, reason, created_at)
+SELECT reference_id, NULL, status, 'migration', 'status before history was recorded', updated_at
+FROM payment_intents i
+WHERE NOT EXISTS (SELECT 1 FROM payment_status_history h WHERE h.reference_id = i.reference_id);
+
+COMMIT;
@@ -0,0 +1,21 @@
+-- Idempotency keys are scoped per RPC, fingerprint the request and are locked
+-- while the request runs. Existing keys were reference IDs of CreatePaymentIntent.
+BEGIN;
+
+ALTER TABLE idempotency_keys ADD COLUMN IF NOT EXISTS operation VARCHAR(50) NOT NULL DEFAULT 'CreatePaymentIntent';
+ALTER TABLE idempotency_keys ALTER COLUMN operation DROP DEFAULT;
+ALTER TABLE idempotency_keys ADD COLUMN IF NOT EXISTS request_hash CHAR(64);
+ALTER TABLE idempotency_keys ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'COMPLETED'
+    CHECK (status IN ('IN_PROGRESS', 'COMPLETED'));
+ALTER TABLE idempotency_keys ALTER COLUMN status DROP DEFAULT;
+ALTER TABLE idempotency_keys ADD COLUMN IF NOT EXISTS locked_until TIMESTAMP;
+ALTER TABLE idempotency_keys ADD COLUMN IF NOT EXISTS expires_at TIMESTAMP;
+UPDATE idempotency_keys SET expires_at = created_at + INTERVAL '1 day' WHERE expires_at IS NULL;
+ALTER TABLE idempotency_keys ALTER COLUMN expires_at SET NOT NULL;
+
+ALTER TABLE idempotency_keys DROP CONSTRAINT IF EXISTS idempotency_keys_pkey;
+ALTER TABLE idempotency_keys ADD PRIMARY KEY (operation, key);
+
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires_at ON idempotency_keys (expires_at);
+
+COMMIT;
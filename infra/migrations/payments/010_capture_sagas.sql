@@ -0,0 +1,26 @@
+-- Captures are persisted sagas so a crash between the accounts-service transfer
+-- and the local writes can be recovered.
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS captures (
+    id VARCHAR(100) PRIMARY KEY,
+    reference_id VARCHAR(100) NOT NULL REFERENCES payment_intents (reference_id),
+    requested_amount BIGINT NOT NULL,
+    final BOOLEAN NOT NULL DEFAULT false,
+    step VARCHAR(20) CHECK (step IN ('STARTED', 'TRANSFERRED', 'COMPLETED', 'FAILED')) NOT NULL,
+    amount BIGINT,
+    currency CHAR(3) NOT NULL,
+    payee_amount BIGINT,
+    payee_currency CHAR(3) NOT NULL,
+    captured_total BIGINT,
+    remaining_amount BIGINT,
+    message TEXT,
+    attempts INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT now(),
+    updated_at TIMESTAMP DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_captures_in_flight ON captures (updated_at) WHERE step IN ('STARTED', 'TRANSFERRED');
+CREATE INDEX IF NOT EXISTS idx_captures_reference_id ON captures (reference_id);
+
+COMMIT;
@@ -0,0 +1,50 @@
+-- Signed webhooks to payee endpoints with retries and a delivery log.
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS webhook_endpoints (
+    id VARCHAR(100) PRIMARY KEY,
+    account_id VARCHAR(100) NOT NULL, -- the payee notified
+    url TEXT NOT NULL,
+    secret VARCHAR(100) NOT NULL,
+    event_types TEXT[] NOT NULL DEFAULT '{}', -- empty subscribes to every event type
+    active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMP DEFAULT now(),
+    updated_at TIMESTAMP DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_endpoints_account_id ON webhook_endpoints (account_id);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id BIGSERIAL PRIMARY KEY,
+    endpoint_id VARCHAR(100) NOT NULL REFERENCES webhook_endpoints (id),
+    event_id VARCHAR(100) NOT NULL,
+    event_type VARCHAR(50) NOT NULL,
+    reference_id VARCHAR(100),
+    payload JSONB NOT NULL,
+    status VARCHAR(20) CHECK (status IN ('PENDING', 'DELIVERED', 'FAILED')) NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    last_status_code INT,
+    last_error TEXT,
+    next_attempt_at TIMESTAMP NOT NULL,
+    expires_at TIMESTAMP NOT NULL, -- retries stop after this
+    created_at TIMESTAMP DEFAULT now(),
+    updated_at TIMESTAMP DEFAULT now(),
+    UNIQUE (endpoint_id, event_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_due ON webhook_deliveries (next_attempt_at) WHERE status = 'PENDING';
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_reference_id ON webhook_deliveries (reference_id);
+
+CREATE TABLE IF NOT EXISTS webhook_delivery_attempts (
+    id BIGSERIAL PRIMARY KEY,
+    delivery_id BIGINT NOT NULL REFERENCES webhook_deliveries (id),
+    attempt INT NOT NULL,
+    status_code INT, -- NULL when the endpoint did not respond
+    error TEXT,
+    duration_ms BIGINT NOT NULL,
+    created_at TIMESTAMP DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_delivery_attempts_delivery_id ON webhook_delivery_attempts (delivery_id);
+
+COMMIT;
@@ -0,0 +1,36 @@
+-- Bulk payout files, validated up front and paid row by row.
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS payout_batches (
+    id VARCHAR(100) PRIMARY KEY,
+    format VARCHAR(10) CHECK (format IN ('CSV', 'JSONL')) NOT NULL,
+    status VARCHAR(20) CHECK (status IN ('PROCESSING', 'COMPLETED')) NOT NULL,
+    actor VARCHAR(100) NOT NULL, -- who submitted the file
+    total_rows INT NOT NULL,
+    created_at TIMESTAMP DEFAULT now(),
+    updated_at TIMESTAMP DEFAULT now(),
+    completed_at TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS payout_rows (
+    batch_id VARCHAR(100) NOT NULL REFERENCES payout_batches (id),
+    line INT NOT NULL, -- line of the row in the submitted file
+    reference VARCHAR(100) NOT NULL, -- reference_id of the intent paying it
+    payer_id VARCHAR(100) NOT NULL,
+    payee_id VARCHAR(100) NOT NULL,
+    amount BIGINT NOT NULL,
+    currency CHAR(3), -- NULL for the payer's currency
+    status VARCHAR(20) CHECK (status IN ('PENDING', 'SUCCEEDED', 'FAILED')) NOT NULL,
+    capture_id VARCHAR(100),
+    message TEXT,
+    attempts INT NOT NULL DEFAULT 0,
+    locked_until TIMESTAMP NOT NULL DEFAULT now(), -- a claimed row is not picked up again before this
+    created_at TIMESTAMP DEFAULT now(),
+    updated_at TIMESTAMP DEFAULT now(),
+    PRIMARY KEY (batch_id, line)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_payout_rows_reference ON payout_rows (reference);
+CREATE INDEX IF NOT EXISTS idx_payout_rows_pending ON payout_rows (created_at, line) WHERE status = 'PENDING';
+
+COMMIT;
@@ -0,0 +1,20 @@
+-- Split payments: intents with several payees, one CREDIT row per payee.
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS payment_legs (
+    reference_id VARCHAR(100) NOT NULL REFERENCES payment_intents (reference_id),
+    leg_no INT NOT NULL,
+    payee_id VARCHAR(100) NOT NULL,
+    amount BIGINT NOT NULL,
+    PRIMARY KEY (reference_id, leg_no)
+);
+
+CREATE INDEX IF NOT EXISTS idx_payment_legs_payee_id ON payment_legs (payee_id);
+
+ALTER TABLE captures ADD COLUMN IF NOT EXISTS legs JSONB;
+
+DROP INDEX IF EXISTS idx_payments_capture_txn_type;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_payments_capture_txn_type
+    ON payments (capture_id, txn_type, account_id);
+
+COMMIT;
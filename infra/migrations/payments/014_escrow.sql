@@ -0,0 +1,30 @@
+-- Escrow intents: captures are held in escrow until released or refunded.
+BEGIN;
+
+ALTER TABLE payment_intents
+    ADD COLUMN IF NOT EXISTS escrow BOOLEAN NOT NULL DEFAULT false,
+    ADD COLUMN IF NOT EXISTS escrow_amount BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS escrow_release_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_payment_intents_escrow_release_at ON payment_intents (escrow_release_at) WHERE escrow_amount > 0;
+
+CREATE TABLE IF NOT EXISTS escrow_movements (
+    id VARCHAR(100) PRIMARY KEY,
+    reference_id VARCHAR(100) NOT NULL REFERENCES payment_intents (reference_id),
+    kind VARCHAR(10) CHECK (kind IN ('RELEASE', 'REFUND')) NOT NULL,
+    idempotency_key VARCHAR(100) NOT NULL,
+    amount BIGINT NOT NULL,
+    currency CHAR(3) NOT NULL,
+    payee_amount BIGINT,
+    payee_currency CHAR(3),
+    reason TEXT,
+    status VARCHAR(20) CHECK (status IN ('PENDING', 'SUCCEEDED', 'FAILED')) NOT NULL,
+    message TEXT,
+    created_at TIMESTAMP DEFAULT now(),
+    updated_at TIMESTAMP DEFAULT now(),
+    UNIQUE (reference_id, kind, idempotency_key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_escrow_movements_pending ON escrow_movements (updated_at) WHERE status = 'PENDING';
+
+COMMIT;
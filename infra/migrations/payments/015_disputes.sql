@@ -0,0 +1,35 @@
+-- Disputes: chargebacks held from the payee until won or lost.
+BEGIN;
+
+ALTER TABLE payment_intents ADD COLUMN IF NOT EXISTS disputed_amount BIGINT NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS disputes (
+    id VARCHAR(100) PRIMARY KEY,
+    reference_id VARCHAR(100) NOT NULL REFERENCES payment_intents (reference_id),
+    idempotency_key VARCHAR(100) NOT NULL,
+    amount BIGINT NOT NULL,
+    currency CHAR(3) NOT NULL,
+    payee_amount BIGINT,
+    payee_currency CHAR(3),
+    reason TEXT,
+    status VARCHAR(20) CHECK (status IN ('OPENING', 'OPEN', 'UNDER_REVIEW', 'WON', 'LOST', 'FAILED')) NOT NULL,
+    outcome VARCHAR(10) CHECK (outcome IN ('WON', 'LOST')),
+    message TEXT,
+    created_at TIMESTAMP DEFAULT now(),
+    updated_at TIMESTAMP DEFAULT now(),
+    resolved_at TIMESTAMP,
+    UNIQUE (reference_id, idempotency_key)
+);
+
+CREATE TABLE IF NOT EXISTS dispute_evidence (
+    id BIGSERIAL PRIMARY KEY,
+    dispute_id VARCHAR(100) NOT NULL REFERENCES disputes (id),
+    description TEXT NOT NULL,
+    content TEXT,
+    submitted_by VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_dispute_evidence_dispute_id ON dispute_evidence (dispute_id, id);
+
+COMMIT;
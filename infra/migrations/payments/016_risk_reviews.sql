@@ -0,0 +1,26 @@
+-- Risk rules: intents can be held in PENDING_REVIEW, and every decision is recorded.
+BEGIN;
+
+ALTER TABLE payment_intents ADD COLUMN IF NOT EXISTS hold_ttl_seconds BIGINT;
+
+ALTER TABLE payment_intents DROP CONSTRAINT IF EXISTS payment_intents_status_check;
+ALTER TABLE payment_intents ADD CONSTRAINT payment_intents_status_check
+    CHECK (status IN ('PENDING_REVIEW', 'AUTHORIZED', 'PARTIALLY_CAPTURED', 'CAPTURED', 'PARTIALLY_REFUNDED', 'REFUNDED', 'FAILED', 'EXPIRED', 'CANCELED'));
+
+CREATE TABLE IF NOT EXISTS risk_evaluations (
+    id BIGSERIAL PRIMARY KEY,
+    reference_id VARCHAR(100) NOT NULL,
+    payer_id VARCHAR(100) NOT NULL,
+    payee_id VARCHAR(100) NOT NULL,
+    amount BIGINT NOT NULL,
+    currency CHAR(3) NOT NULL,
+    decision VARCHAR(10) CHECK (decision IN ('ALLOW', 'REVIEW', 'DENY')) NOT NULL,
+    reasons TEXT[] NOT NULL DEFAULT '{}',
+    rules_version VARCHAR(64),
+    created_at TIMESTAMP DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_risk_evaluations_reference_id ON risk_evaluations (reference_id);
+CREATE INDEX IF NOT EXISTS idx_risk_evaluations_payer_id ON risk_evaluations (payer_id, id);
+
+COMMIT;
@@ -0,0 +1,10 @@
+-- Idempotency keys belong to the authenticated caller that sent them. Keys
+-- stored before callers were authenticated keep an empty principal.
+BEGIN;
+
+ALTER TABLE idempotency_keys ADD COLUMN IF NOT EXISTS principal VARCHAR(100) NOT NULL DEFAULT '';
+
+ALTER TABLE idempotency_keys DROP CONSTRAINT IF EXISTS idempotency_keys_pkey;
+ALTER TABLE idempotency_keys ADD PRIMARY KEY (principal, operation, key);
+
+COMMIT;
@@ -0,0 +1,16 @@
+-- Convert NUMERIC(12,2) amounts into BIGINT minor units.
+-- Every existing row predates multi-currency support and is in INR (2 decimals).
+BEGIN;
+
+ALTER TABLE settlements ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'INR';
+
+DO $$
+BEGIN
+    IF (SELECT data_type FROM information_schema.columns
+        WHERE table_name = 'settlements' AND column_name = 'amount') <> 'bigint' THEN
+        ALTER TABLE settlements
+            ALTER COLUMN amount TYPE BIGINT USING ROUND(amount * 100)::BIGINT;
+    END IF;
+END $$;
+
+COMMIT;
@@ -0,0 +1,12 @@
+-- One settlement per capture instead of one per payment reference.
+BEGIN;
+
+ALTER TABLE settlements ADD COLUMN IF NOT EXISTS capture_id VARCHAR(100);
+UPDATE settlements SET capture_id = reference_id WHERE capture_id IS NULL;
+ALTER TABLE settlements ALTER COLUMN capture_id SET NOT NULL;
+
+ALTER TABLE settlements DROP CONSTRAINT IF EXISTS settlements_reference_id_key;
+ALTER TABLE settlements DROP CONSTRAINT IF EXISTS settlements_capture_id_key;
+ALTER TABLE settlements ADD CONSTRAINT settlements_capture_id_key UNIQUE (capture_id);
+
+COMMIT;
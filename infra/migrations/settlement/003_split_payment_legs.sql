@@ -0,0 +1,10 @@
+-- One settlement per capture and payee leg, for split payments.
+BEGIN;
+
+ALTER TABLE settlements ADD COLUMN IF NOT EXISTS leg INT NOT NULL DEFAULT 0;
+
+ALTER TABLE settlements DROP CONSTRAINT IF EXISTS settlements_capture_id_key;
+ALTER TABLE settlements DROP CONSTRAINT IF EXISTS settlements_capture_id_leg_key;
+ALTER TABLE settlements ADD CONSTRAINT settlements_capture_id_leg_key UNIQUE (capture_id, leg);
+
+COMMIT;
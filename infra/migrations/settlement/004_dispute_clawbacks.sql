@@ -0,0 +1,9 @@
+-- Settlements that claw a lost dispute back from the payee.
+BEGIN;
+
+ALTER TABLE settlements ADD COLUMN IF NOT EXISTS kind VARCHAR(10) NOT NULL DEFAULT 'CAPTURE';
+
+ALTER TABLE settlements DROP CONSTRAINT IF EXISTS settlements_kind_check;
+ALTER TABLE settlements ADD CONSTRAINT settlements_kind_check CHECK (kind IN ('CAPTURE', 'CLAWBACK'));
+
+COMMIT;
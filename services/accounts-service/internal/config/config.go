@@ -2,13 +2,25 @@ package config
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/parasagrawal71/bank-settlement-system/shared/env"
+	"github.com/parasagrawal71/bank-settlement-system/shared/mtls"
 )
 
 type Config struct {
-	DBUrl    string
-	GRPCPort string
+	DBUrl      string
+	GRPCPort   string
+	FXQuoteTTL time.Duration
+	// accounts without activity for this long are marked DORMANT
+	DormantAfter time.Duration
+	// holds are released automatically after ReservationTTL unless the caller
+	// asks for another TTL, which is capped at MaxReservationTTL
+	ReservationTTL           time.Duration
+	MaxReservationTTL        time.Duration
+	ReservationSweepInterval time.Duration
+	// server certificate, client CA and peer allow-list
+	TLS mtls.Config
 }
 
 type DBConfig struct {
@@ -36,5 +48,12 @@ func Load() *Config {
 	db := fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=%s", dbConfig.DBUser, dbConfig.DBPassword, dbConfig.DBHost, dbConfig.DBPort, dbConfig.DBName, dbConfig.SSLMode)
 
 	port := env.GetEnvString("ACCOUNTS_GRPC_PORT", "")
-	return &Config{DBUrl: db, GRPCPort: port}
+	quoteTTL := time.Duration(env.GetEnvInt("FX_QUOTE_TTL_SECONDS", 60)) * time.Second
+	dormantAfter := time.Duration(env.GetEnvInt("DORMANT_AFTER_DAYS", 365)) * 24 * time.Hour
+	reservationTTL := time.Duration(env.GetEnvInt("RESERVATION_TTL_SECONDS", 900)) * time.Second
+	maxReservationTTL := time.Duration(env.GetEnvInt("RESERVATION_MAX_TTL_SECONDS", 7*24*3600)) * time.Second
+	sweepInterval := time.Duration(env.GetEnvInt("RESERVATION_SWEEP_INTERVAL_SECONDS", 30)) * time.Second
+	return &Config{DBUrl: db, GRPCPort: port, FXQuoteTTL: quoteTTL, DormantAfter: dormantAfter,
+		ReservationTTL: reservationTTL, MaxReservationTTL: maxReservationTTL, ReservationSweepInterval: sweepInterval,
+		TLS: mtls.ConfigFromEnv("ACCOUNTS")}
 }
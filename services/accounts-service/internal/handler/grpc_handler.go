@@ -2,127 +2,303 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/parasagrawal71/bank-settlement-system/services/accounts-service/internal/config"
 	"github.com/parasagrawal71/bank-settlement-system/services/accounts-service/internal/repository"
 	pb "github.com/parasagrawal71/bank-settlement-system/services/accounts-service/proto"
+	"github.com/parasagrawal71/bank-settlement-system/shared/money"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type AccountHandler struct {
-	repo *repository.Repository
+	repo              *repository.Repository
+	fxQuoteTTL        time.Duration
+	reservationTTL    time.Duration
+	maxReservationTTL time.Duration
 	pb.UnimplementedAccountServiceServer
 }
 
-func NewAccountHandler(pool *pgxpool.Pool) *AccountHandler {
-	return &AccountHandler{repo: repository.NewRepository(pool)}
+func NewAccountHandler(pool *pgxpool.Pool, cfg *config.Config) *AccountHandler {
+	return &AccountHandler{
+		repo:              repository.NewRepository(pool),
+		fxQuoteTTL:        cfg.FXQuoteTTL,
+		reservationTTL:    cfg.ReservationTTL,
+		maxReservationTTL: cfg.MaxReservationTTL,
+	}
+}
+
+func toAccountResponse(acct *repository.Account) *pb.AccountResponse {
+	return &pb.AccountResponse{
+		AccountId:     acct.ID,
+		Name:          acct.Name,
+		AccountNo:     acct.AccountNo,
+		Balance:       acct.Balance.Amount,
+		Reserved:      acct.Reserved.Amount,
+		Currency:      acct.Balance.Currency,
+		Status:        acct.Status,
+		StatusReason:  acct.StatusReason,
+		CreditLimit:   acct.CreditLimit.Amount,
+		Available:     acct.Balance.Amount + acct.CreditLimit.Amount,
+		OverdraftUsed: max(0, -acct.Balance.Amount),
+	}
+}
+
+func fromPbLegs(legs []*pb.PayeeLeg) []repository.Leg {
+	var out []repository.Leg
+	for _, l := range legs {
+		out = append(out, repository.Leg{PayeeID: l.PayeeId, Amount: l.Amount})
+	}
+	return out
+}
+
+func toPbLegs(legs []repository.Leg) []*pb.PayeeLeg {
+	var out []*pb.PayeeLeg
+	for _, l := range legs {
+		out = append(out, &pb.PayeeLeg{PayeeId: l.PayeeID, Amount: l.Amount})
+	}
+	return out
 }
 
-// CreateAccount creates a new account with the given name, account_no and initial balance.
+// failureReason returns the machine readable reason of a typed repository error.
+func failureReason(err error) string {
+	var stateErr *repository.AccountStateError
+	if errors.As(err, &stateErr) {
+		return stateErr.Reason()
+	}
+	if errors.Is(err, repository.ErrInsufficientFunds) {
+		return "INSUFFICIENT_FUNDS"
+	}
+	if errors.Is(err, repository.ErrReservationExpired) {
+		return "RESERVATION_EXPIRED"
+	}
+	if errors.Is(err, repository.ErrCaptureExceedsHold) {
+		return "CAPTURE_EXCEEDS_HOLD"
+	}
+	if errors.Is(err, repository.ErrRefundExceedsCapture) {
+		return "REFUND_EXCEEDS_CAPTURE"
+	}
+	if errors.Is(err, repository.ErrInvalidSplit) {
+		return "INVALID_SPLIT"
+	}
+	if errors.Is(err, repository.ErrNotEscrow) {
+		return "NOT_ESCROW"
+	}
+	if errors.Is(err, repository.ErrEscrowExceedsFunds) {
+		return "ESCROW_EXCEEDS_FUNDS"
+	}
+	if errors.Is(err, repository.ErrNotDisputable) {
+		return "NOT_DISPUTABLE"
+	}
+	if errors.Is(err, repository.ErrDisputeClosed) {
+		return "DISPUTE_CLOSED"
+	}
+	if errors.Is(err, repository.ErrAccountNotClosable) {
+		return "ACCOUNT_NOT_CLOSABLE"
+	}
+	if errors.Is(err, repository.ErrAccountNotFound) {
+		return "ACCOUNT_NOT_FOUND"
+	}
+	if errors.Is(err, repository.ErrRateNotFound) {
+		return "RATE_NOT_FOUND"
+	}
+	if errors.Is(err, repository.ErrQuoteInvalid) {
+		return "QUOTE_INVALID"
+	}
+	if errors.Is(err, money.ErrCurrencyMismatch) {
+		return "CURRENCY_MISMATCH"
+	}
+	if errors.Is(err, money.ErrUnsupportedCurrency) {
+		return "UNSUPPORTED_CURRENCY"
+	}
+	if errors.Is(err, money.ErrInvalidAmount) || errors.Is(err, money.ErrOverflow) {
+		return "INVALID_AMOUNT"
+	}
+	if errors.Is(err, money.ErrInvalidRate) {
+		return "INVALID_RATE"
+	}
+	if errors.Is(err, repository.ErrReservationNotFound) {
+		return "RESERVATION_NOT_FOUND"
+	}
+	if errors.Is(err, repository.ErrReservationExists) {
+		return "RESERVATION_EXISTS"
+	}
+	if errors.Is(err, repository.ErrReservationNotPending) {
+		return "RESERVATION_NOT_PENDING"
+	}
+	if errors.Is(err, repository.ErrDisputeNotFound) {
+		return "DISPUTE_NOT_FOUND"
+	}
+	if errors.Is(err, repository.ErrMovementIDReused) {
+		return "MOVEMENT_ID_REUSED"
+	}
+	return ""
+}
+
+// unresolved reports whether a failed money movement is not a business
+// rejection: the database failed, or the caller went away, and the movement
+// may or may not have been committed. Those are returned as a status rather
+// than a FAILED response, so a FAILED response always carries a reason and
+// always means nothing moved.
+func unresolved(err error) bool {
+	return failureReason(err) == ""
+}
+
+// grpcError turns typed repository and money errors into a status that carries
+// the reason as ErrorInfo: NotFound for a missing account or rate,
+// InvalidArgument for bad amounts and currencies and FailedPrecondition for the
+// rest. A canceled or timed-out caller gets Canceled or DeadlineExceeded, and
+// any other error is Internal.
+func grpcError(err error) error {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return status.FromContextError(err).Err()
+	}
+	reason := failureReason(err)
+	if reason == "" {
+		return status.Error(codes.Internal, err.Error())
+	}
+	code := codes.FailedPrecondition
+	switch reason {
+	case "ACCOUNT_NOT_FOUND", "RATE_NOT_FOUND", "RESERVATION_NOT_FOUND", "DISPUTE_NOT_FOUND":
+		code = codes.NotFound
+	case "UNSUPPORTED_CURRENCY", "INVALID_AMOUNT", "INVALID_RATE":
+		code = codes.InvalidArgument
+	}
+	st, detailErr := status.New(code, err.Error()).
+		WithDetails(&errdetails.ErrorInfo{Reason: reason, Domain: "accounts"})
+	if detailErr != nil {
+		return status.Error(code, err.Error())
+	}
+	return st.Err()
+}
+
+// CreateAccount creates a new account with the given name, account_no, currency and initial balance.
 func (h *AccountHandler) CreateAccount(ctx context.Context, req *pb.CreateAccountRequest) (*pb.AccountResponse, error) {
 	if req.Name == "" || req.AccountNo == "" {
-		return nil, fmt.Errorf("name and account_id required")
+		return nil, status.Error(codes.InvalidArgument, "name and account_id required")
+	}
+	if req.InitialBalance < 0 {
+		return nil, status.Error(codes.InvalidArgument, "initial_balance must not be negative")
 	}
-	acct, err := h.repo.CreateAccount(ctx, req.Name, req.AccountNo,
-		req.InitialBalance)
+	initialBalance, err := money.New(req.InitialBalance, req.Currency)
 	if err != nil {
-		return nil, err
+		return nil, grpcError(err)
 	}
-	return &pb.AccountResponse{
-		AccountId: acct.ID,
-		Name:      acct.Name,
-		AccountNo: acct.AccountNo,
-		Balance:   acct.Balance,
-		Reserved:  acct.Reserved,
-	}, nil
+	acct, err := h.repo.CreateAccount(ctx, req.Name, req.AccountNo, initialBalance)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return toAccountResponse(acct), nil
 }
 
 // GetAccount fetches an account given its account_id.
 func (h *AccountHandler) GetAccount(ctx context.Context, req *pb.GetAccountRequest) (*pb.AccountResponse, error) {
 	if req.AccountId == "" {
-		return nil, fmt.Errorf("account_id required")
+		return nil, status.Error(codes.InvalidArgument, "account_id required")
 	}
 	acct, err := h.repo.GetAccount(ctx, req.AccountId)
 	if err != nil {
-		return nil, err
+		return nil, grpcError(err)
 	}
 	if acct == nil {
-		return nil, fmt.Errorf("account not found")
+		return nil, status.Error(codes.NotFound, "account not found")
 	}
-	return &pb.AccountResponse{
-		AccountId: acct.ID,
-		Name:      acct.Name,
-		AccountNo: acct.AccountNo,
-		Balance:   acct.Balance,
-		Reserved:  acct.Reserved,
-	}, nil
+	return toAccountResponse(acct), nil
 }
 
 // UpdateBalance updates the balance of an account given its account_id, amount and is_credit flag.
 func (h *AccountHandler) UpdateBalance(ctx context.Context, req *pb.UpdateBalanceRequest) (*pb.AccountResponse, error) {
 	if req.AccountId == "" {
-		return nil, fmt.Errorf("account_id required")
+		return nil, status.Error(codes.InvalidArgument, "account_id required")
 	}
-	acct, err := h.repo.UpdateBalance(ctx, req.AccountId, req.Amount,
+	if req.Amount <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "amount must be positive")
+	}
+	amount, err := money.New(req.Amount, req.Currency)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	acct, err := h.repo.UpdateBalance(ctx, req.AccountId, amount,
 		req.IsCredit)
 	if err != nil {
-		return nil, err
+		return nil, grpcError(err)
 	}
-	return &pb.AccountResponse{
-		AccountId: acct.ID,
-		Name:      acct.Name,
-		AccountNo: acct.AccountNo,
-		Balance:   acct.Balance,
-		Reserved:  acct.Reserved,
-	}, nil
+	return toAccountResponse(acct), nil
 }
 
 // ListAccounts returns a list of accounts.
 func (h *AccountHandler) ListAccounts(ctx context.Context, req *pb.ListAccountsRequest) (*pb.ListAccountsResponse, error) {
 	list, err := h.repo.ListAccounts(ctx)
 	if err != nil {
-		return nil, err
+		return nil, grpcError(err)
 	}
 	resp := &pb.ListAccountsResponse{}
 	for _, a := range list {
-		resp.Accounts = append(resp.Accounts, &pb.AccountResponse{
-			AccountId: a.ID,
-			Name:      a.Name,
-			AccountNo: a.AccountNo,
-			Balance:   a.Balance,
-			Reserved:  a.Reserved,
-		})
+		resp.Accounts = append(resp.Accounts, toAccountResponse(a))
 	}
 	return resp, nil
 }
 
 // Reserve funds temporarily for a transfer
 func (h *AccountHandler) ReserveFunds(ctx context.Context, req *pb.ReserveRequest) (*pb.ReserveResponse, error) {
-	err := h.repo.ReserveFunds(ctx, req.ReferenceId, req.PayerId, req.PayeeId, req.Amount)
+	ttl := h.reservationTTL
+	if req.HoldTtlSeconds > 0 {
+		ttl = min(time.Duration(req.HoldTtlSeconds)*time.Second, h.maxReservationTTL)
+	}
+	expiresAt := time.Now().Add(ttl)
+
+	amount, err := money.New(req.Amount, req.Currency)
+	if err == nil && amount.Amount <= 0 {
+		err = money.ErrInvalidAmount
+	}
+	if err == nil {
+		expiresAt, err = h.repo.ReserveFunds(ctx, req.ReferenceId, req.PayerId, req.PayeeId, amount, req.QuoteId, expiresAt,
+			fromPbLegs(req.Legs), req.Escrow)
+	}
 	if err != nil {
+		if unresolved(err) {
+			return nil, grpcError(err)
+		}
 		return &pb.ReserveResponse{
 			Status:  "FAILED",
 			Message: fmt.Sprintf("reservation failed: %v", err),
+			Reason:  failureReason(err),
 		}, nil
 	}
 	return &pb.ReserveResponse{
-		Status:  "SUCCESS",
-		Message: "funds reserved successfully",
+		Status:    "SUCCESS",
+		Message:   "funds reserved successfully",
+		ExpiresAt: expiresAt.Unix(),
 	}, nil
 }
 
-// Transfer funds from one account to another
+// Transfer captures all or part of a reservation and credits the payee
 func (h *AccountHandler) Transfer(ctx context.Context, req *pb.TransferRequest) (*pb.TransferResponse, error) {
-	err := h.repo.Transfer(ctx, req.ReferenceId)
+	capture, err := h.repo.Transfer(ctx, req.ReferenceId, req.CaptureId, req.Amount, req.Final)
 	if err != nil {
+		if unresolved(err) {
+			return nil, grpcError(err)
+		}
 		return &pb.TransferResponse{
 			Status:  "FAILED",
 			Message: fmt.Sprintf("transfer failed: %v", err),
+			Reason:  failureReason(err),
 		}, nil
 	}
 	return &pb.TransferResponse{
-		Status:  "SUCCESS",
-		Message: "transfer successful",
+		Status:          "SUCCESS",
+		Message:         "transfer successful",
+		Amount:          capture.Amount.Amount,
+		PayeeAmount:     capture.PayeeAmount.Amount,
+		CapturedAmount:  capture.Captured.Amount,
+		ReleasedAmount:  capture.Released.Amount,
+		RemainingAmount: capture.Remaining.Amount,
+		Legs:            toPbLegs(capture.Legs),
 	}, nil
 }
 
@@ -130,9 +306,13 @@ func (h *AccountHandler) Transfer(ctx context.Context, req *pb.TransferRequest)
 func (h *AccountHandler) ReleaseFunds(ctx context.Context, req *pb.ReleaseRequest) (*pb.ReleaseResponse, error) {
 	err := h.repo.ReleaseFunds(ctx, req.ReferenceId)
 	if err != nil {
+		if unresolved(err) {
+			return nil, grpcError(err)
+		}
 		return &pb.ReleaseResponse{
 			Status:  "FAILED",
 			Message: fmt.Sprintf("release failed: %v", err),
+			Reason:  failureReason(err),
 		}, nil
 	}
 	return &pb.ReleaseResponse{
@@ -140,3 +320,310 @@ func (h *AccountHandler) ReleaseFunds(ctx context.Context, req *pb.ReleaseReques
 		Message: "release successful",
 	}, nil
 }
+
+// SetRate stores the exchange rate for a currency pair.
+func (h *AccountHandler) SetRate(ctx context.Context, req *pb.SetRateRequest) (*pb.RateResponse, error) {
+	base, err := money.NormalizeCurrency(req.BaseCurrency)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	quote, err := money.NormalizeCurrency(req.QuoteCurrency)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	if base == quote {
+		return nil, status.Error(codes.InvalidArgument, "base_currency and quote_currency must differ")
+	}
+	if _, err := money.ParseRate(req.Rate); err != nil {
+		return nil, grpcError(err)
+	}
+	rate, err := h.repo.SetRate(ctx, base, quote, req.Rate)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &pb.RateResponse{
+		BaseCurrency:  rate.BaseCurrency,
+		QuoteCurrency: rate.QuoteCurrency,
+		Rate:          rate.Rate,
+		UpdatedAt:     rate.UpdatedAt.Unix(),
+	}, nil
+}
+
+// GetQuote converts an amount at the current rate and locks the result for a short time.
+func (h *AccountHandler) GetQuote(ctx context.Context, req *pb.GetQuoteRequest) (*pb.QuoteResponse, error) {
+	if req.Amount <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "amount must be positive")
+	}
+	source, err := money.New(req.Amount, req.FromCurrency)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	to, err := money.NormalizeCurrency(req.ToCurrency)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	if source.Currency == to {
+		return nil, status.Error(codes.InvalidArgument, "from_currency and to_currency must differ")
+	}
+	quote, err := h.repo.CreateQuote(ctx, source, to, time.Now().Add(h.fxQuoteTTL))
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &pb.QuoteResponse{
+		QuoteId:      quote.ID,
+		FromCurrency: quote.Source.Currency,
+		ToCurrency:   quote.Target.Currency,
+		Rate:         quote.Rate,
+		SourceAmount: quote.Source.Amount,
+		TargetAmount: quote.Target.Amount,
+		ExpiresAt:    quote.ExpiresAt.Unix(),
+	}, nil
+}
+
+// FreezeAccount blocks all money movements on an account except releasing its holds.
+func (h *AccountHandler) FreezeAccount(ctx context.Context, req *pb.AccountStatusRequest) (*pb.AccountResponse, error) {
+	if req.AccountId == "" {
+		return nil, status.Error(codes.InvalidArgument, "account_id required")
+	}
+	acct, err := h.repo.FreezeAccount(ctx, req.AccountId, req.Reason)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return toAccountResponse(acct), nil
+}
+
+// UnfreezeAccount makes a frozen account active again.
+func (h *AccountHandler) UnfreezeAccount(ctx context.Context, req *pb.AccountStatusRequest) (*pb.AccountResponse, error) {
+	if req.AccountId == "" {
+		return nil, status.Error(codes.InvalidArgument, "account_id required")
+	}
+	acct, err := h.repo.UnfreezeAccount(ctx, req.AccountId, req.Reason)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return toAccountResponse(acct), nil
+}
+
+// CloseAccount closes an account, optionally sweeping its balance to another account.
+func (h *AccountHandler) CloseAccount(ctx context.Context, req *pb.CloseAccountRequest) (*pb.AccountResponse, error) {
+	if req.AccountId == "" {
+		return nil, status.Error(codes.InvalidArgument, "account_id required")
+	}
+	acct, err := h.repo.CloseAccount(ctx, req.AccountId, req.SweepToAccountId, req.Reason)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return toAccountResponse(acct), nil
+}
+
+// GetAccountStatement returns the account's ledger entries for a period with running balances.
+func (h *AccountHandler) GetAccountStatement(ctx context.Context, req *pb.GetAccountStatementRequest) (*pb.AccountStatementResponse, error) {
+	if req.AccountId == "" {
+		return nil, status.Error(codes.InvalidArgument, "account_id required")
+	}
+	to := time.Now()
+	if req.To > 0 {
+		to = time.Unix(req.To, 0)
+	}
+	from := time.Unix(req.From, 0)
+	if !from.Before(to) {
+		return nil, status.Error(codes.InvalidArgument, "from must be before to")
+	}
+	pageSize := int(req.PageSize)
+	if pageSize <= 0 {
+		pageSize = 50
+	}
+	if pageSize > 500 {
+		pageSize = 500
+	}
+
+	st, err := h.repo.GetStatement(ctx, req.AccountId, from.UTC(), to.UTC(), req.PageToken, pageSize)
+	if err != nil {
+		if errors.Is(err, repository.ErrInvalidPageToken) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, grpcError(err)
+	}
+	resp := &pb.AccountStatementResponse{
+		AccountId:       st.AccountID,
+		Currency:        st.Currency,
+		OpeningBalance:  st.OpeningBalance,
+		OpeningReserved: st.OpeningReserved,
+		ClosingBalance:  st.ClosingBalance,
+		ClosingReserved: st.ClosingReserved,
+		NextPageToken:   st.NextPageToken,
+	}
+	for _, e := range st.Entries {
+		resp.Entries = append(resp.Entries, &pb.StatementEntry{
+			EntryId:         e.EntryID,
+			EntryType:       e.EntryType,
+			ReferenceId:     e.ReferenceID,
+			CounterpartyId:  e.CounterpartyID,
+			BalanceChange:   e.BalanceChange,
+			ReservedChange:  e.ReservedChange,
+			RunningBalance:  e.RunningBalance,
+			RunningReserved: e.RunningReserved,
+			OverdraftDrawn:  e.OverdraftDrawn,
+			CreatedAt:       e.CreatedAt.Unix(),
+		})
+	}
+	return resp, nil
+}
+
+// GetBalanceAsOf returns the balance and reserved amount of an account at a point in time.
+func (h *AccountHandler) GetBalanceAsOf(ctx context.Context, req *pb.GetBalanceAsOfRequest) (*pb.BalanceAsOfResponse, error) {
+	if req.AccountId == "" || req.Timestamp <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "account_id and timestamp required")
+	}
+	b, err := h.repo.GetBalanceAsOf(ctx, req.AccountId, time.Unix(req.Timestamp, 0).UTC())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	resp := &pb.BalanceAsOfResponse{
+		AccountId:       b.AccountID,
+		Currency:        b.Currency,
+		Balance:         b.Balance,
+		Reserved:        b.Reserved,
+		AsOf:            b.AsOf.Unix(),
+		EntriesReplayed: int32(b.EntriesReplayed),
+	}
+	if !b.SnapshotAt.IsZero() {
+		resp.SnapshotAt = b.SnapshotAt.Unix()
+	}
+	return resp, nil
+}
+
+// SetCreditLimit sets the approved overdraft of an account.
+func (h *AccountHandler) SetCreditLimit(ctx context.Context, req *pb.SetCreditLimitRequest) (*pb.AccountResponse, error) {
+	if req.AccountId == "" {
+		return nil, status.Error(codes.InvalidArgument, "account_id required")
+	}
+	if req.CreditLimit < 0 {
+		return nil, status.Error(codes.InvalidArgument, "credit_limit must not be negative")
+	}
+	limit, err := money.New(req.CreditLimit, req.Currency)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	acct, err := h.repo.SetCreditLimit(ctx, req.AccountId, limit)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return toAccountResponse(acct), nil
+}
+
+// ListOverdrawnAccounts returns the accounts that are currently using their overdraft.
+func (h *AccountHandler) ListOverdrawnAccounts(ctx context.Context, req *pb.ListOverdrawnAccountsRequest) (*pb.ListAccountsResponse, error) {
+	list, err := h.repo.ListOverdrawnAccounts(ctx)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	resp := &pb.ListAccountsResponse{}
+	for _, a := range list {
+		resp.Accounts = append(resp.Accounts, toAccountResponse(a))
+	}
+	return resp, nil
+}
+
+// Refund moves captured funds of a reservation back from the payee to the payer.
+func (h *AccountHandler) Refund(ctx context.Context, req *pb.RefundRequest) (*pb.RefundResponse, error) {
+	if req.ReferenceId == "" || req.RefundId == "" {
+		return nil, status.Error(codes.InvalidArgument, "reference_id and refund_id required")
+	}
+	refund, err := h.repo.Refund(ctx, req.ReferenceId, req.RefundId, req.Amount)
+	if err != nil {
+		if unresolved(err) {
+			return nil, grpcError(err)
+		}
+		return &pb.RefundResponse{
+			Status:  "FAILED",
+			Message: fmt.Sprintf("refund failed: %v", err),
+			Reason:  failureReason(err),
+		}, nil
+	}
+	return &pb.RefundResponse{
+		Status:         "SUCCESS",
+		Message:        "refund successful",
+		Amount:         refund.Amount.Amount,
+		PayeeAmount:    refund.PayeeAmount.Amount,
+		RefundedAmount: refund.Refunded.Amount,
+		Legs:           toPbLegs(refund.Legs),
+	}, nil
+}
+
+// ReleaseEscrow pays funds held in escrow for an escrow reservation out to the payee.
+func (h *AccountHandler) ReleaseEscrow(ctx context.Context, req *pb.EscrowMovementRequest) (*pb.EscrowMovementResponse, error) {
+	return h.moveEscrow(ctx, req, "release", h.repo.ReleaseEscrow)
+}
+
+// RefundEscrow returns funds held in escrow for an escrow reservation to the payer.
+func (h *AccountHandler) RefundEscrow(ctx context.Context, req *pb.EscrowMovementRequest) (*pb.EscrowMovementResponse, error) {
+	return h.moveEscrow(ctx, req, "escrow refund", h.repo.RefundEscrow)
+}
+
+func (h *AccountHandler) moveEscrow(ctx context.Context, req *pb.EscrowMovementRequest, what string,
+	move func(ctx context.Context, referenceID, movementID string, amount int64) (*repository.EscrowMovement, error)) (*pb.EscrowMovementResponse, error) {
+	if req.ReferenceId == "" || req.MovementId == "" || req.Amount < 0 {
+		return nil, status.Error(codes.InvalidArgument, "reference_id and movement_id required, amount must not be negative")
+	}
+	m, err := move(ctx, req.ReferenceId, req.MovementId, req.Amount)
+	if err != nil {
+		if unresolved(err) {
+			return nil, grpcError(err)
+		}
+		return &pb.EscrowMovementResponse{
+			Status:  "FAILED",
+			Message: fmt.Sprintf("%s failed: %v", what, err),
+			Reason:  failureReason(err),
+		}, nil
+	}
+	return &pb.EscrowMovementResponse{
+		Status:       "SUCCESS",
+		Message:      what + " successful",
+		Amount:       m.Amount.Amount,
+		PayeeAmount:  m.PayeeAmount.Amount,
+		EscrowAmount: m.Escrowed.Amount,
+	}, nil
+}
+
+// HoldDispute takes a disputed amount from the payee of a reservation into the
+// dispute hold account.
+func (h *AccountHandler) HoldDispute(ctx context.Context, req *pb.DisputeHoldRequest) (*pb.DisputeHoldResponse, error) {
+	if req.ReferenceId == "" || req.DisputeId == "" || req.Amount < 0 {
+		return nil, status.Error(codes.InvalidArgument, "reference_id and dispute_id required, amount must not be negative")
+	}
+	hold, err := h.repo.HoldDispute(ctx, req.ReferenceId, req.DisputeId, req.Amount)
+	if err != nil && unresolved(err) {
+		return nil, grpcError(err)
+	}
+	return disputeHoldResponse(hold, "dispute hold", err), nil
+}
+
+// CloseDisputeHold returns a held dispute amount to the payee of a won dispute
+// or the payer of a lost one.
+func (h *AccountHandler) CloseDisputeHold(ctx context.Context, req *pb.CloseDisputeHoldRequest) (*pb.DisputeHoldResponse, error) {
+	if req.ReferenceId == "" || req.DisputeId == "" {
+		return nil, status.Error(codes.InvalidArgument, "reference_id and dispute_id required")
+	}
+	hold, err := h.repo.CloseDisputeHold(ctx, req.ReferenceId, req.DisputeId, req.Won)
+	if err != nil && unresolved(err) {
+		return nil, grpcError(err)
+	}
+	return disputeHoldResponse(hold, "closing dispute hold", err), nil
+}
+
+func disputeHoldResponse(hold *repository.DisputeHold, what string, err error) *pb.DisputeHoldResponse {
+	if err != nil {
+		return &pb.DisputeHoldResponse{
+			Status:  "FAILED",
+			Message: fmt.Sprintf("%s failed: %v", what, err),
+			Reason:  failureReason(err),
+		}
+	}
+	return &pb.DisputeHoldResponse{
+		Status:      "SUCCESS",
+		Message:     what + " successful",
+		Amount:      hold.Amount.Amount,
+		PayeeAmount: hold.PayeeAmount.Amount,
+	}
+}
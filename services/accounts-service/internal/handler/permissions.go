@@ -0,0 +1,40 @@
+package handler
+
+import (
+	pb "github.com/parasagrawal71/bank-settlement-system/services/accounts-service/proto"
+	"github.com/parasagrawal71/bank-settlement-system/shared/auth"
+)
+
+var (
+	adminOnly     = []string{auth.RoleAdmin}
+	backOffice    = []string{auth.RoleAdmin, auth.RoleOperator}
+	readers       = []string{auth.RoleAdmin, auth.RoleOperator, auth.RoleClient, auth.RolePaymentsService}
+	fundsMovement = []string{auth.RoleAdmin, auth.RolePaymentsService}
+)
+
+// Permissions lists who may call each RPC. Balances only move through
+// payments-service, apart from the adjustments of UpdateBalance, which only an
+// admin may make.
+var Permissions = auth.Permissions{
+	pb.AccountService_CreateAccount_FullMethodName:         backOffice,
+	pb.AccountService_GetAccount_FullMethodName:            readers,
+	pb.AccountService_UpdateBalance_FullMethodName:         adminOnly,
+	pb.AccountService_ListAccounts_FullMethodName:          backOffice,
+	pb.AccountService_ReserveFunds_FullMethodName:          fundsMovement,
+	pb.AccountService_Transfer_FullMethodName:              fundsMovement,
+	pb.AccountService_ReleaseFunds_FullMethodName:          fundsMovement,
+	pb.AccountService_SetRate_FullMethodName:               adminOnly,
+	pb.AccountService_GetQuote_FullMethodName:              readers,
+	pb.AccountService_FreezeAccount_FullMethodName:         backOffice,
+	pb.AccountService_UnfreezeAccount_FullMethodName:       backOffice,
+	pb.AccountService_CloseAccount_FullMethodName:          adminOnly,
+	pb.AccountService_GetAccountStatement_FullMethodName:   readers,
+	pb.AccountService_GetBalanceAsOf_FullMethodName:        readers,
+	pb.AccountService_SetCreditLimit_FullMethodName:        adminOnly,
+	pb.AccountService_ListOverdrawnAccounts_FullMethodName: backOffice,
+	pb.AccountService_Refund_FullMethodName:                fundsMovement,
+	pb.AccountService_ReleaseEscrow_FullMethodName:         fundsMovement,
+	pb.AccountService_RefundEscrow_FullMethodName:          fundsMovement,
+	pb.AccountService_HoldDispute_FullMethodName:           fundsMovement,
+	pb.AccountService_CloseDisputeHold_FullMethodName:      fundsMovement,
+}
@@ -4,23 +4,31 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/big"
 	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
-	"google.golang.org/grpc/codes"
+	"github.com/parasagrawal71/bank-settlement-system/shared/money"
 )
 
 type Account struct {
-	ID        string
-	Name      string
-	AccountNo string
-	Balance   float64
-	Reserved  float64
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID           string
+	Name         string
+	AccountNo    string
+	Balance      money.Money
+	Reserved     money.Money
+	CreditLimit  money.Money
+	Status       string
+	StatusReason string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
 }
 
+var ErrAccountNotFound = errors.New("account not found")
+
+const accountColumns = `id, name, account_no, balance, reserved, credit_limit, currency, status, COALESCE(status_reason, ''), created_at, updated_at`
+
 type Repository struct {
 	pool *pgxpool.Pool
 }
@@ -30,46 +38,73 @@ func NewRepository(pool *pgxpool.Pool) *Repository {
 }
 
 func (r *Repository) CreateAccount(ctx context.Context, name, accountNo string,
-	initialBalance float64) (*Account, error) {
+	initialBalance money.Money) (*Account, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
 	var id string
-	var reserved float64
-	var created time.Time
-	var updated time.Time
 	sql :=
-		`INSERT INTO accounts (name, account_no, balance) VALUES ($1, $2, $3) RETURNING
-id, reserved, created_at, updated_at`
-	row := r.pool.QueryRow(ctx, sql, name, accountNo, initialBalance)
-	if err := row.Scan(&id, &reserved, &created, &updated); err != nil {
+		`INSERT INTO accounts (name, account_no, currency) VALUES ($1, $2, $3) RETURNING id`
+	if err := tx.QueryRow(ctx, sql, name, accountNo, initialBalance.Currency).Scan(&id); err != nil {
 		return nil, fmt.Errorf("insert account: %w", err)
 	}
-	return &Account{
-		ID:        id,
-		Name:      name,
-		AccountNo: accountNo,
-		Balance:   initialBalance,
-		Reserved:  reserved,
-		CreatedAt: created,
-		UpdatedAt: updated,
-	}, nil
+
+	// the opening balance is funded from outside the system
+	if initialBalance.IsPositive() {
+		_, err = r.postEntry(ctx, tx, JournalEntry{
+			EntryType: EntryOpeningBalance,
+			Postings: []Posting{
+				{AccountID: ExternalAccountID, Bucket: BucketAvailable, Direction: Debit, Amount: initialBalance},
+				{AccountID: id, Bucket: BucketAvailable, Direction: Credit, Amount: initialBalance},
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit tx: %w", err)
+	}
+	return r.GetAccount(ctx, id)
 }
 
 func (r *Repository) GetAccount(ctx context.Context, id string) (*Account, error) {
 	sql :=
-		`SELECT id, name, account_no, balance, reserved, created_at, updated_at FROM accounts WHERE id = $1`
+		`SELECT ` + accountColumns + ` FROM accounts WHERE id = $1`
 	row := r.pool.QueryRow(ctx, sql, id)
-	var a Account
-	if err := row.Scan(&a.ID, &a.Name, &a.AccountNo, &a.Balance, &a.Reserved, &a.CreatedAt, &a.UpdatedAt); err != nil {
+	a, err := scanAccount(row)
+	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, nil
 		}
 		return nil, fmt.Errorf("get account: %w", err)
 	}
+	return a, nil
+}
+
+// scanAccount reads a row selected with accountColumns.
+func scanAccount(row pgx.Row) (*Account, error) {
+	var a Account
+	var balance, reserved, creditLimit int64
+	var currency string
+	if err := row.Scan(&a.ID, &a.Name, &a.AccountNo, &balance, &reserved, &creditLimit, &currency, &a.Status, &a.StatusReason,
+		&a.CreatedAt, &a.UpdatedAt); err != nil {
+		return nil, err
+	}
+	a.Balance = money.Money{Amount: balance, Currency: currency}
+	a.Reserved = money.Money{Amount: reserved, Currency: currency}
+	a.CreditLimit = money.Money{Amount: creditLimit, Currency: currency}
 	return &a, nil
 }
 
-// UpdateBalance performs a debit or credit atomically using SELECT FOR UPDATE semantics
+// UpdateBalance performs a debit or credit atomically using SELECT FOR UPDATE semantics.
+// The money comes from or goes to the EXTERNAL ledger account.
 func (r *Repository) UpdateBalance(ctx context.Context, id string,
-	amount float64, isCredit bool) (*Account, error) {
+	amount money.Money, isCredit bool) (*Account, error) {
 	tx, err := r.pool.Begin(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("begin tx: %w", err)
@@ -81,27 +116,35 @@ func (r *Repository) UpdateBalance(ctx context.Context, id string,
 	}()
 
 	// Lock row
-	var curBalance float64
-	q := `SELECT balance FROM accounts WHERE id = $1 FOR UPDATE`
+	var curBalance money.Money
+	var creditLimit int64
+	q := `SELECT balance, credit_limit, currency FROM accounts WHERE id = $1 FOR UPDATE`
 	row := tx.QueryRow(ctx, q, id)
-	if err := row.Scan(&curBalance); err != nil {
+	if err := row.Scan(&curBalance.Amount, &creditLimit, &curBalance.Currency); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, fmt.Errorf("account not found")
+			return nil, ErrAccountNotFound
 		}
 		return nil, fmt.Errorf("select for update: %w", err)
 	}
-	newBal := curBalance
+	if curBalance.Currency != amount.Currency {
+		return nil, fmt.Errorf("%w: account is %s, amount is %s", money.ErrCurrencyMismatch, curBalance.Currency, amount.Currency)
+	}
+
+	from, to := id, ExternalAccountID
 	if isCredit {
-		newBal = curBalance + amount
-	} else {
-		if curBalance < amount {
-			return nil, fmt.Errorf("insufficient funds: have %.2f need %.2f", curBalance, amount)
-		}
-		newBal = curBalance - amount
+		from, to = ExternalAccountID, id
+	} else if err := checkFunds(curBalance, creditLimit, amount); err != nil {
+		return nil, err
 	}
-	updateQ := `UPDATE accounts SET balance = $1 WHERE id = $2`
-	if _, err := tx.Exec(ctx, updateQ, newBal, id); err != nil {
-		return nil, fmt.Errorf("update balance: %w", err)
+	_, err = r.postEntry(ctx, tx, JournalEntry{
+		EntryType: EntryAdjustment,
+		Postings: []Posting{
+			{AccountID: from, Bucket: BucketAvailable, Direction: Debit, Amount: amount},
+			{AccountID: to, Bucket: BucketAvailable, Direction: Credit, Amount: amount},
+		},
+	})
+	if err != nil {
+		return nil, err
 	}
 	if err := tx.Commit(ctx); err != nil {
 		return nil, fmt.Errorf("commit tx: %w", err)
@@ -114,7 +157,7 @@ func (r *Repository) UpdateBalance(ctx context.Context, id string,
 
 func (r *Repository) ListAccounts(ctx context.Context) ([]*Account, error) {
 	sql :=
-		`SELECT id, name, account_no, balance, reserved, created_at, updated_at FROM accounts ORDER BY
+		`SELECT ` + accountColumns + ` FROM accounts ORDER BY
 created_at DESC LIMIT 1000`
 	rows, err := r.pool.Query(ctx, sql)
 	if err != nil {
@@ -123,118 +166,454 @@ created_at DESC LIMIT 1000`
 	defer rows.Close()
 	res := make([]*Account, 0)
 	for rows.Next() {
-		var a Account
-		if err := rows.Scan(&a.ID, &a.Name, &a.AccountNo, &a.Balance, &a.Reserved,
-			&a.CreatedAt, &a.UpdatedAt); err != nil {
+		a, err := scanAccount(rows)
+		if err != nil {
 			return nil, fmt.Errorf("scan row: %w", err)
 		}
-		res = append(res, &a)
+		res = append(res, a)
 	}
 	return res, nil
 }
 
-// Reserve funds temporarily
-func (r *Repository) ReserveFunds(ctx context.Context, referenceID string, payerID string, payeeID string, amount float64) error {
+// Reserve funds temporarily: moves the amount from the payer's available
+// balance into its reserved bucket. When the payee account is in another
+// currency, quoteID must reference an open FX quote for exactly this amount; the
+// converted payee amount is locked on the reservation. The hold is released by
+// ExpireReservations once expiresAt has passed. With legs the hold is split
+// over several payees in the payer's currency; the legs must add up to amount
+// and payeeID is the payee of the first leg. An escrow reservation is captured
+// into escrow rather than paid to the payee; it cannot be split.
+func (r *Repository) ReserveFunds(ctx context.Context, referenceID string, payerID string, payeeID string, amount money.Money, quoteID string, expiresAt time.Time, legs []Leg, escrow bool) (time.Time, error) {
 	tx, err := r.pool.Begin(ctx)
 	if err != nil {
-		return err
+		return time.Time{}, err
 	}
 	defer tx.Rollback(ctx)
 
+	if len(legs) > 0 {
+		if escrow {
+			return time.Time{}, fmt.Errorf("%w: escrow reservations have a single payee", ErrInvalidSplit)
+		}
+		if err := checkLegs(legs, amount); err != nil {
+			return time.Time{}, err
+		}
+		if quoteID != "" {
+			return time.Time{}, fmt.Errorf("%w: split payments cannot use an fx quote", ErrInvalidSplit)
+		}
+		if payeeID != "" && payeeID != legs[0].PayeeID {
+			return time.Time{}, fmt.Errorf("%w: payee_id is not the payee of the first leg", ErrInvalidSplit)
+		}
+		payeeID = legs[0].PayeeID
+	}
+
+	// a repeat of a reservation that went through gets it back, so a caller
+	// that lost the response can ask again under the same reference
+	if held, err := heldReservation(ctx, tx, referenceID, payerID, payeeID, amount, legs, escrow); err != nil || !held.IsZero() {
+		return held, err
+	}
+
 	// check payee account exists
-	var payee_id string
-	err = tx.QueryRow(ctx, "SELECT id FROM accounts WHERE id=$1", payeeID).Scan(&payee_id)
+	var payee_id, payeeCurrency, payeeStatus string
+	err = tx.QueryRow(ctx, "SELECT id, currency, status FROM accounts WHERE id=$1", payeeID).Scan(&payee_id, &payeeCurrency, &payeeStatus)
 	if err != nil {
-		return fmt.Errorf("payee account not found: %w", err)
+		return time.Time{}, accountLookupError("payee", payeeID, err)
+	}
+	if err := checkOpen(payeeID, payeeStatus); err != nil {
+		return time.Time{}, err
 	}
 
-	var balance, reserved float64
-	err = tx.QueryRow(ctx, "SELECT balance, reserved FROM accounts WHERE id=$1", payerID).Scan(&balance, &reserved)
+	var balance, creditLimit int64
+	var payerCurrency, payerStatus string
+	err = tx.QueryRow(ctx, "SELECT balance, credit_limit, currency, status FROM accounts WHERE id=$1 FOR UPDATE", payerID).Scan(&balance, &creditLimit, &payerCurrency, &payerStatus)
 	if err != nil {
-		return fmt.Errorf("payer account not found: %w", err)
+		return time.Time{}, accountLookupError("payer", payerID, err)
+	}
+	if err := checkOpen(payerID, payerStatus); err != nil {
+		return time.Time{}, err
 	}
 
-	if balance < amount {
-		return fmt.Errorf("insufficient funds")
+	if amount.Currency != payerCurrency {
+		return time.Time{}, fmt.Errorf("%w: payer %s, amount %s", money.ErrCurrencyMismatch, payerCurrency, amount.Currency)
 	}
 
-	_, err = tx.Exec(ctx, `
-		UPDATE accounts SET balance = balance - $1, reserved = reserved + $1 WHERE id = $2
-	`, amount, payerID)
-	if err != nil {
-		return err
+	if err := checkFunds(money.Money{Amount: balance, Currency: payerCurrency}, creditLimit, amount); err != nil {
+		return time.Time{}, err
 	}
 
-	_, err = tx.Exec(ctx, `
-		INSERT INTO reservations (reference_id, payer_id, payee_id, amount, status)
-		VALUES ($1, $2, $3, $4, 'PENDING')
-	`, referenceID, payerID, payeeID, amount)
+	// the first leg is the payee checked above
+	for _, l := range legs[min(1, len(legs)):] {
+		var currency, status string
+		err = tx.QueryRow(ctx, "SELECT currency, status FROM accounts WHERE id=$1", l.PayeeID).Scan(&currency, &status)
+		if err != nil {
+			return time.Time{}, accountLookupError("payee", l.PayeeID, err)
+		}
+		if err := checkOpen(l.PayeeID, status); err != nil {
+			return time.Time{}, err
+		}
+		if currency != payerCurrency {
+			return time.Time{}, fmt.Errorf("%w: payer %s, payee %s in a split payment", money.ErrCurrencyMismatch, payerCurrency, currency)
+		}
+	}
+	if len(legs) > 0 && payeeCurrency != payerCurrency {
+		return time.Time{}, fmt.Errorf("%w: payer %s, payee %s in a split payment", money.ErrCurrencyMismatch, payerCurrency, payeeCurrency)
+	}
+
+	payeeAmount := amount
+	var quote *string
+	if payeeCurrency != payerCurrency {
+		if quoteID == "" {
+			return time.Time{}, fmt.Errorf("%w: payer %s, payee %s and no fx quote given", money.ErrCurrencyMismatch, payerCurrency, payeeCurrency)
+		}
+		if payeeAmount, err = useQuote(ctx, tx, quoteID, amount, payeeCurrency); err != nil {
+			return time.Time{}, err
+		}
+		quote = &quoteID
+	}
+
+	tag, err := tx.Exec(ctx, `
+		INSERT INTO reservations (reference_id, payer_id, payee_id, amount, currency, payee_amount, payee_currency, quote_id, status, expires_at, escrow)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, 'PENDING', $9, $10)
+		ON CONFLICT (reference_id) DO NOTHING
+	`, referenceID, payerID, payeeID, amount.Amount, amount.Currency, payeeAmount.Amount, payeeAmount.Currency, quote, expiresAt, escrow)
 	if err != nil {
-		return err
+		return time.Time{}, fmt.Errorf("insert reservation: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return time.Time{}, ErrReservationExists
+	}
+	if err := insertLegsTx(ctx, tx, referenceID, legs); err != nil {
+		return time.Time{}, err
 	}
 
-	// Add to ledger
-	_, err = tx.Exec(ctx, `
-		INSERT INTO ledger (payer_id, payee_id, amount, reference_id, status)
-		VALUES ($1, $2, $3, $4, 'INITIATED')
-	`, payerID, payeeID, amount, referenceID)
+	_, err = r.postEntry(ctx, tx, JournalEntry{
+		ReferenceID: referenceID,
+		EntryType:   EntryReserve,
+		Postings: []Posting{
+			{AccountID: payerID, Bucket: BucketAvailable, Direction: Debit, Amount: amount},
+			{AccountID: payerID, Bucket: BucketReserved, Direction: Credit, Amount: amount},
+		},
+	})
 	if err != nil {
-		return err
+		return time.Time{}, err
 	}
 
-	return tx.Commit(ctx)
+	if err := tx.Commit(ctx); err != nil {
+		return time.Time{}, err
+	}
+	return expiresAt, nil
 }
 
-// Final transfer: move funds between payer and payee
-func (r *Repository) Transfer(ctx context.Context, referenceID string) error {
-	tx, err := r.pool.Begin(ctx)
+// heldReservation returns the expiry of the PENDING reservation referenceID
+// when it is between the same accounts, split the same way, for the same amount
+// and kind, and zero when there is none. A reservation of the reference that
+// does not match, or is no longer pending, is ErrReservationExists.
+func heldReservation(ctx context.Context, tx pgx.Tx, referenceID, payerID, payeeID string, amount money.Money, legs []Leg, escrow bool) (time.Time, error) {
+	var heldPayer, heldPayee, currency, status string
+	var heldAmount int64
+	var heldEscrow bool
+	var expiresAt *time.Time
+	err := tx.QueryRow(ctx, `
+		SELECT payer_id, payee_id, amount, currency, escrow, status, expires_at FROM reservations WHERE reference_id = $1
+	`, referenceID).Scan(&heldPayer, &heldPayee, &heldAmount, &currency, &heldEscrow, &status, &expiresAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return time.Time{}, nil
+	}
 	if err != nil {
-		return err
+		return time.Time{}, fmt.Errorf("get reservation: %w", err)
 	}
-	defer tx.Rollback(ctx)
+	if status != "PENDING" || heldPayer != payerID || heldPayee != payeeID || heldAmount != amount.Amount || currency != amount.Currency ||
+		heldEscrow != escrow || expiresAt == nil {
+		return time.Time{}, ErrReservationExists
+	}
+	heldLegs, err := reservationLegs(ctx, tx, referenceID)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if len(heldLegs) != len(legs) {
+		return time.Time{}, ErrReservationExists
+	}
+	for i, l := range heldLegs {
+		if l.PayeeID != legs[i].PayeeID || l.Amount != legs[i].Amount {
+			return time.Time{}, ErrReservationExists
+		}
+	}
+	return *expiresAt, nil
+}
 
-	// Ensure reservation exists
+type reservation struct {
+	PayerID     string
+	PayeeID     string
+	Amount      money.Money
+	PayeeAmount money.Money
+	// sums of the partial captures so far, in payer and payee currency
+	Captured      int64
+	PayeeCaptured int64
+	Expired       bool
+	Escrow        bool // captures go into escrow instead of to the payee
+}
+
+// remaining is the part of the hold that has not been captured yet.
+func (res *reservation) remaining() money.Money {
+	return money.Money{Amount: res.Amount.Amount - res.Captured, Currency: res.Amount.Currency}
+}
+
+// payeeShare is what the payee receives for capturing part of the hold. The
+// capture that takes the rest of the hold gets the rest of the payee amount, so
+// rounding of cross-currency partial captures never loses a minor unit.
+func (res *reservation) payeeShare(part money.Money) money.Money {
+	if part.Amount == res.remaining().Amount {
+		return money.Money{Amount: res.PayeeAmount.Amount - res.PayeeCaptured, Currency: res.PayeeAmount.Currency}
+	}
+	if res.PayeeAmount.Currency == res.Amount.Currency {
+		return money.Money{Amount: part.Amount, Currency: res.PayeeAmount.Currency}
+	}
+	share := new(big.Int).Mul(big.NewInt(res.PayeeAmount.Amount), big.NewInt(part.Amount))
+	share.Quo(share, big.NewInt(res.Amount.Amount))
+	return money.Money{Amount: share.Int64(), Currency: res.PayeeAmount.Currency}
+}
+
+// accountLookupError tells a missing account apart from a failed query.
+func accountLookupError(role, id string, err error) error {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("%w: %s %s", ErrAccountNotFound, role, id)
+	}
+	return fmt.Errorf("get %s account: %w", role, err)
+}
+
+// pendingReservation locks a reservation and checks that it is still PENDING.
+func pendingReservation(ctx context.Context, tx pgx.Tx, referenceID string) (*reservation, error) {
 	var status string
-	var payerID string
-	var payeeID string
-	var amount float64
-	err = tx.QueryRow(ctx, "SELECT status, payer_id, payee_id, amount FROM reservations WHERE reference_id=$1", referenceID).Scan(&status, &payerID, &payeeID, &amount)
+	var res reservation
+	err := tx.QueryRow(ctx, `
+		SELECT status, payer_id, payee_id, amount, currency,
+			COALESCE(payee_amount, amount), COALESCE(payee_currency, currency),
+			captured_amount, payee_captured_amount,
+			COALESCE(expires_at <= now(), false), escrow
+		FROM reservations WHERE reference_id=$1 FOR UPDATE
+	`, referenceID).Scan(&status, &res.PayerID, &res.PayeeID, &res.Amount.Amount, &res.Amount.Currency,
+		&res.PayeeAmount.Amount, &res.PayeeAmount.Currency, &res.Captured, &res.PayeeCaptured, &res.Expired, &res.Escrow)
 	if err != nil {
-		return err
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrReservationNotFound
+		}
+		return nil, fmt.Errorf("lock reservation: %w", err)
 	}
 	if status != "PENDING" {
-		return fmt.Errorf("reservation not pending or already processed: %s", codes.FailedPrecondition)
+		return nil, fmt.Errorf("%w: %s", ErrReservationNotPending, status)
+	}
+	return &res, nil
+}
+
+// transferPostings moves amount out of the payer's reserved bucket and credits
+// payeeAmount to the payee. A currency change is routed through the FX position
+// accounts so that the entry balances in both currencies.
+func transferPostings(payerID, payeeID string, amount, payeeAmount money.Money) []Posting {
+	if amount.Currency == payeeAmount.Currency {
+		return []Posting{
+			{AccountID: payerID, Bucket: BucketReserved, Direction: Debit, Amount: amount},
+			{AccountID: payeeID, Bucket: BucketAvailable, Direction: Credit, Amount: payeeAmount},
+		}
+	}
+	return []Posting{
+		{AccountID: payerID, Bucket: BucketReserved, Direction: Debit, Amount: amount},
+		{AccountID: FXAccountID(amount.Currency), Bucket: BucketAvailable, Direction: Credit, Amount: amount},
+		{AccountID: FXAccountID(payeeAmount.Currency), Bucket: BucketAvailable, Direction: Debit, Amount: payeeAmount},
+		{AccountID: payeeID, Bucket: BucketAvailable, Direction: Credit, Amount: payeeAmount},
 	}
+}
+
+var ErrCaptureExceedsHold = errors.New("capture amount exceeds the remaining hold")
 
-	// Debit payer (release reserved funds)
-	_, err = tx.Exec(ctx, "UPDATE accounts SET reserved = reserved - $1 WHERE id=$2", amount, payerID)
+// Capture is the outcome of one Transfer against a reservation.
+type Capture struct {
+	Amount      money.Money // taken from the payer's hold
+	PayeeAmount money.Money // credited to the payee
+	Captured    money.Money // captured so far, including this capture
+	Released    money.Money // returned to the payer by a final capture
+	Remaining   money.Money // still on hold
+	Legs        []Leg       // what each payee of a split reservation got, in leg order
+}
+
+// Transfer captures amount of the payer's hold and credits the payee. An amount
+// of 0 captures whatever is left of the hold. The reservation stays PENDING for
+// further captures until the hold is used up or final is set, in which case the
+// rest of the hold is released back to the payer. A non-empty captureID makes
+// the call idempotent: repeating it returns the capture that was already made.
+// A split reservation credits every leg in the same entry, in proportion to
+// what each leg has left to capture. An escrow reservation credits the escrow
+// account; PayeeAmount is then what the payee gets once the capture is released.
+func (r *Repository) Transfer(ctx context.Context, referenceID, captureID string, amount int64, final bool) (*Capture, error) {
+	tx, err := r.pool.Begin(ctx)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	if captureID != "" {
+		c, err := recordedCapture(ctx, tx, referenceID, captureID)
+		if err != nil || c != nil {
+			return c, err
+		}
 	}
 
-	// Credit payee
-	_, err = tx.Exec(ctx, "UPDATE accounts SET balance = balance + $1 WHERE id=$2", amount, payeeID)
+	// Ensure reservation exists
+	res, err := pendingReservation(ctx, tx, referenceID)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	// an expired hold is about to be released by the sweeper; don't capture it
+	if res.Expired {
+		return nil, ErrReservationExpired
 	}
 
-	// Update reservation
-	_, err = tx.Exec(ctx, "UPDATE reservations SET status='CONFIRMED' WHERE reference_id=$1", referenceID)
+	remaining := res.remaining()
+	if amount == 0 {
+		amount = remaining.Amount
+	}
+	if amount < 0 || amount > remaining.Amount {
+		return nil, fmt.Errorf("%w: capture %d, remaining %s", ErrCaptureExceedsHold, amount, remaining)
+	}
+	part := money.Money{Amount: amount, Currency: remaining.Currency}
+	c := Capture{Amount: part, PayeeAmount: res.payeeShare(part)}
+
+	legs, err := reservationLegs(ctx, tx, referenceID)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	postings := transferPostings(res.PayerID, res.PayeeID, c.Amount, c.PayeeAmount)
+	if res.Escrow {
+		postings = escrowTransferPostings(res.PayerID, c.Amount)
+	}
+	if len(legs) > 0 {
+		c.Legs = captureLegs(legs, amount)
+		postings = splitTransferPostings(res.PayerID, part, c.Legs)
+		if err := addToLegsTx(ctx, tx, referenceID, "captured_amount", c.Legs); err != nil {
+			return nil, err
+		}
+	}
+	_, err = r.postEntry(ctx, tx, JournalEntry{
+		ReferenceID: referenceID,
+		EntryType:   EntryTransfer,
+		Postings:    postings,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	c.Captured = money.Money{Amount: res.Captured + amount, Currency: part.Currency}
+	c.Remaining = money.Money{Amount: remaining.Amount - amount, Currency: part.Currency}
+	c.Released = money.Money{Currency: part.Currency}
+	if final && c.Remaining.IsPositive() {
+		if err := r.postRelease(ctx, tx, referenceID, res.PayerID, c.Remaining); err != nil {
+			return nil, err
+		}
+		c.Released = c.Remaining
+		c.Remaining.Amount = 0
+	}
+
+	if captureID != "" {
+		legsDoc, err := legsJSON(c.Legs)
+		if err != nil {
+			return nil, err
+		}
+		_, err = tx.Exec(ctx, `
+			INSERT INTO captures (capture_id, reference_id, amount, currency, payee_amount, payee_currency, released_amount, legs)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		`, captureID, referenceID, c.Amount.Amount, c.Amount.Currency, c.PayeeAmount.Amount, c.PayeeAmount.Currency, c.Released.Amount,
+			legsDoc)
+		if err != nil {
+			return nil, fmt.Errorf("insert capture: %w", err)
+		}
 	}
 
-	// Update ledger
-	_, err = tx.Exec(ctx, "UPDATE ledger SET status='COMPLETED' WHERE reference_id=$1", referenceID)
+	status := "PENDING"
+	if c.Remaining.IsZero() {
+		status = "CONFIRMED"
+	}
+	_, err = tx.Exec(ctx, `
+		UPDATE reservations SET captured_amount=$2, payee_captured_amount=$3, status=$4,
+			escrow_amount = escrow_amount + CASE WHEN escrow THEN $5 ELSE 0 END,
+			escrow_payee_amount = escrow_payee_amount + CASE WHEN escrow THEN $6 ELSE 0 END,
+			updated_at=now()
+		WHERE reference_id=$1
+	`, referenceID, c.Captured.Amount, res.PayeeCaptured+c.PayeeAmount.Amount, status, c.Amount.Amount, c.PayeeAmount.Amount)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return tx.Commit(ctx)
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// recordedCapture locks the reservation and returns the capture made under
+// captureID, or nil if there is none yet. Captured and Remaining are the current
+// totals of the reservation.
+func recordedCapture(ctx context.Context, tx pgx.Tx, referenceID, captureID string) (*Capture, error) {
+	var status string
+	var captured, remaining int64
+	err := tx.QueryRow(ctx, `
+		SELECT status, captured_amount, amount - captured_amount FROM reservations WHERE reference_id=$1 FOR UPDATE
+	`, referenceID).Scan(&status, &captured, &remaining)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrReservationNotFound
+		}
+		return nil, fmt.Errorf("lock reservation: %w", err)
+	}
+	var c Capture
+	var legsDoc []byte
+	err = tx.QueryRow(ctx, `
+		SELECT amount, currency, payee_amount, payee_currency, released_amount, legs
+		FROM captures WHERE capture_id=$1 AND reference_id=$2
+	`, captureID, referenceID).Scan(&c.Amount.Amount, &c.Amount.Currency, &c.PayeeAmount.Amount, &c.PayeeAmount.Currency,
+		&c.Released.Amount, &legsDoc)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get capture: %w", err)
+	}
+	if c.Legs, err = parseLegsJSON(legsDoc); err != nil {
+		return nil, err
+	}
+	if status != "PENDING" {
+		remaining = 0
+	}
+	c.Released.Currency = c.Amount.Currency
+	c.Captured = money.Money{Amount: captured, Currency: c.Amount.Currency}
+	c.Remaining = money.Money{Amount: remaining, Currency: c.Amount.Currency}
+	return &c, nil
 }
 
-// Release funds
+var (
+	ErrReservationNotPending = errors.New("reservation not pending or already processed")
+	ErrReservationNotFound   = errors.New("reservation not found")
+	ErrReservationExists     = errors.New("reference_id is already reserved")
+)
+
+// Release funds: return the reserved amount to the payer's available balance.
+// Releasing a hold that was already released or has expired is a no-op, so
+// callers can retry safely.
 func (r *Repository) ReleaseFunds(ctx context.Context, referenceID string) error {
+	err := r.release(ctx, referenceID, "FAILED")
+	if !errors.Is(err, ErrReservationNotPending) {
+		return err
+	}
+	var status string
+	if err := r.pool.QueryRow(ctx, `SELECT status FROM reservations WHERE reference_id=$1`, referenceID).Scan(&status); err != nil {
+		return fmt.Errorf("get reservation: %w", err)
+	}
+	if status == "FAILED" || status == "EXPIRED" {
+		return nil
+	}
+	return err
+}
+
+// release returns what is left of a pending hold to the payer and moves the
+// reservation to status. A hold that was partly captured ends up CONFIRMED.
+func (r *Repository) release(ctx context.Context, referenceID, status string) error {
 	tx, err := r.pool.Begin(ctx)
 	if err != nil {
 		return err
@@ -242,35 +621,36 @@ func (r *Repository) ReleaseFunds(ctx context.Context, referenceID string) error
 	defer tx.Rollback(ctx)
 
 	// Ensure reservation exists
-	var status string
-	var payerID string
-	var payeeID string
-	var amount float64
-	err = tx.QueryRow(ctx, "SELECT status, payer_id, payee_id, amount FROM reservations WHERE reference_id=$1", referenceID).Scan(&status, &payerID, &payeeID, &amount)
+	res, err := pendingReservation(ctx, tx, referenceID)
 	if err != nil {
 		return err
 	}
-	if status != "PENDING" {
-		return fmt.Errorf("reservation not pending or already processed: %s", codes.FailedPrecondition)
-	}
 
-	// Debit payer (release reserved funds)
-	_, err = tx.Exec(ctx, "UPDATE accounts SET reserved = reserved - $1, balance = balance + $1 WHERE id=$2", amount, payerID)
-	if err != nil {
+	if err := r.postRelease(ctx, tx, referenceID, res.PayerID, res.remaining()); err != nil {
 		return err
 	}
-
-	// Update reservation
-	_, err = tx.Exec(ctx, "UPDATE reservations SET status='FAILED' WHERE reference_id=$1", referenceID)
-	if err != nil {
-		return err
+	if res.Captured > 0 {
+		status = "CONFIRMED"
 	}
 
-	// Update ledger
-	_, err = tx.Exec(ctx, "UPDATE ledger SET status='FAILED' WHERE reference_id=$1", referenceID)
+	// Update reservation
+	_, err = tx.Exec(ctx, "UPDATE reservations SET status=$2, updated_at=now() WHERE reference_id=$1", referenceID, status)
 	if err != nil {
 		return err
 	}
 
 	return tx.Commit(ctx)
 }
+
+// postRelease moves amount from the payer's reserved bucket back to available.
+func (r *Repository) postRelease(ctx context.Context, tx pgx.Tx, referenceID, payerID string, amount money.Money) error {
+	_, err := r.postEntry(ctx, tx, JournalEntry{
+		ReferenceID: referenceID,
+		EntryType:   EntryRelease,
+		Postings: []Posting{
+			{AccountID: payerID, Bucket: BucketReserved, Direction: Debit, Amount: amount},
+			{AccountID: payerID, Bucket: BucketAvailable, Direction: Credit, Amount: amount},
+		},
+	})
+	return err
+}
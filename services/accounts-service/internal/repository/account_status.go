@@ -0,0 +1,190 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	StatusActive  = "ACTIVE"
+	StatusFrozen  = "FROZEN"
+	StatusDormant = "DORMANT"
+	StatusClosed  = "CLOSED"
+)
+
+const EntryClosingSweep = "CLOSING_SWEEP"
+
+// AccountStateError is returned when an operation is refused because of the
+// lifecycle state of an account.
+type AccountStateError struct {
+	AccountID string
+	Status    string
+}
+
+func (e *AccountStateError) Error() string {
+	return fmt.Sprintf("account %s is %s", e.AccountID, e.Status)
+}
+
+// Reason is the machine readable failure code, e.g. ACCOUNT_FROZEN.
+func (e *AccountStateError) Reason() string {
+	return "ACCOUNT_" + e.Status
+}
+
+var ErrAccountNotClosable = errors.New("account cannot be closed")
+
+// checkPostable refuses postings on closed accounts and on frozen accounts,
+// except for releases which only hand a hold back to its owner.
+func checkPostable(accountID, status, entryType string) error {
+	switch status {
+	case StatusClosed:
+		return &AccountStateError{AccountID: accountID, Status: status}
+	case StatusFrozen:
+		if entryType != EntryRelease {
+			return &AccountStateError{AccountID: accountID, Status: status}
+		}
+	}
+	return nil
+}
+
+// checkOpen refuses frozen and closed accounts.
+func checkOpen(accountID, status string) error {
+	if status == StatusFrozen || status == StatusClosed {
+		return &AccountStateError{AccountID: accountID, Status: status}
+	}
+	return nil
+}
+
+func (r *Repository) FreezeAccount(ctx context.Context, id, reason string) (*Account, error) {
+	return r.setStatus(ctx, id, StatusFrozen, reason, StatusActive, StatusDormant)
+}
+
+func (r *Repository) UnfreezeAccount(ctx context.Context, id, reason string) (*Account, error) {
+	return r.setStatus(ctx, id, StatusActive, reason, StatusFrozen)
+}
+
+// setStatus moves the account to status if it currently is in one of from.
+func (r *Repository) setStatus(ctx context.Context, id, status, reason string, from ...string) (*Account, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var current string
+	err = tx.QueryRow(ctx, `SELECT status FROM accounts WHERE id = $1 FOR UPDATE`, id).Scan(&current)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrAccountNotFound
+		}
+		return nil, fmt.Errorf("lock account: %w", err)
+	}
+	if !slices.Contains(from, current) {
+		return nil, &AccountStateError{AccountID: id, Status: current}
+	}
+	_, err = tx.Exec(ctx, `
+		UPDATE accounts SET status = $2, status_reason = $3, updated_at = now() WHERE id = $1
+	`, id, status, reason)
+	if err != nil {
+		return nil, fmt.Errorf("update status: %w", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit tx: %w", err)
+	}
+	return r.GetAccount(ctx, id)
+}
+
+// CloseAccount closes an account that is not the payer or a payee of a pending
+// reservation, or of funds held in escrow. A remaining balance is swept to
+// sweepTo first; without sweepTo the balance must be zero.
+func (r *Repository) CloseAccount(ctx context.Context, id, sweepTo, reason string) (*Account, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	acct, err := scanAccount(tx.QueryRow(ctx, `
+		SELECT `+accountColumns+` FROM accounts WHERE id = $1 FOR UPDATE
+	`, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrAccountNotFound
+		}
+		return nil, fmt.Errorf("lock account: %w", err)
+	}
+	if err := checkOpen(id, acct.Status); err != nil {
+		return nil, err
+	}
+
+	// captures and escrow movements still to come would pay a closed account
+	var pending int
+	err = tx.QueryRow(ctx, `
+		SELECT COUNT(*) FROM reservations r
+		WHERE (r.status = 'PENDING' OR r.escrow_amount > 0)
+			AND (r.payer_id = $1 OR r.payee_id = $1
+				OR EXISTS (SELECT 1 FROM reservation_legs l WHERE l.reference_id = r.reference_id AND l.payee_id = $1))
+	`, id).Scan(&pending)
+	if err != nil {
+		return nil, fmt.Errorf("count reservations: %w", err)
+	}
+	if pending > 0 || !acct.Reserved.IsZero() {
+		return nil, fmt.Errorf("%w: %d pending reservations or escrowed payments", ErrAccountNotClosable, pending)
+	}
+
+	if !acct.Balance.IsZero() {
+		if sweepTo == "" {
+			return nil, fmt.Errorf("%w: balance is %s and no sweep account given", ErrAccountNotClosable, acct.Balance)
+		}
+		if sweepTo == id {
+			return nil, fmt.Errorf("%w: cannot sweep an account into itself", ErrAccountNotClosable)
+		}
+		if acct.Balance.IsNegative() {
+			return nil, fmt.Errorf("%w: balance is negative (%s)", ErrAccountNotClosable, acct.Balance)
+		}
+		_, err = r.postEntry(ctx, tx, JournalEntry{
+			EntryType: EntryClosingSweep,
+			Postings: []Posting{
+				{AccountID: id, Bucket: BucketAvailable, Direction: Debit, Amount: acct.Balance},
+				{AccountID: sweepTo, Bucket: BucketAvailable, Direction: Credit, Amount: acct.Balance},
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE accounts SET status = 'CLOSED', status_reason = $2, closed_at = now(), updated_at = now() WHERE id = $1
+	`, id, reason)
+	if err != nil {
+		return nil, fmt.Errorf("close account: %w", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit tx: %w", err)
+	}
+	return r.GetAccount(ctx, id)
+}
+
+// MarkDormant moves active accounts without any activity since idleFor into
+// DORMANT, unless it takes part in a pending reservation or escrowed payment.
+// Any later posting on the account makes it ACTIVE again.
+func (r *Repository) MarkDormant(ctx context.Context, idleFor time.Duration) (int64, error) {
+	tag, err := r.pool.Exec(ctx, `
+		UPDATE accounts SET status = 'DORMANT', status_reason = 'no activity', updated_at = now()
+		WHERE status = 'ACTIVE' AND updated_at < $1
+			AND NOT EXISTS (
+				SELECT 1 FROM reservations r
+				WHERE (r.status = 'PENDING' OR r.escrow_amount > 0)
+					AND (r.payer_id = accounts.id::TEXT OR r.payee_id = accounts.id::TEXT
+						OR EXISTS (SELECT 1 FROM reservation_legs l WHERE l.reference_id = r.reference_id AND l.payee_id = accounts.id::TEXT)))
+	`, time.Now().Add(-idleFor))
+	if err != nil {
+		return 0, fmt.Errorf("mark dormant: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
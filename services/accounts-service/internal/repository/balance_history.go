@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type BalanceAsOf struct {
+	AccountID       string
+	Currency        string
+	Balance         int64
+	Reserved        int64
+	AsOf            time.Time
+	SnapshotAt      time.Time // zero when replayed from the first posting
+	EntriesReplayed int
+}
+
+// GetBalanceAsOf returns the balance and reserved amount of an account including
+// every journal entry created at or before asOf. It starts from the latest
+// snapshot taken at or before asOf and replays the postings after it.
+func (r *Repository) GetBalanceAsOf(ctx context.Context, accountID string, asOf time.Time) (*BalanceAsOf, error) {
+	acct, err := r.GetAccount(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if acct == nil {
+		return nil, ErrAccountNotFound
+	}
+	res := BalanceAsOf{AccountID: accountID, Currency: acct.Balance.Currency, AsOf: asOf}
+
+	var snapshotAt time.Time
+	err = r.pool.QueryRow(ctx, `
+		SELECT as_of, balance, reserved FROM balance_snapshots
+		WHERE account_id = $1 AND as_of <= $2
+		ORDER BY as_of DESC LIMIT 1
+	`, accountID, asOf).Scan(&snapshotAt, &res.Balance, &res.Reserved)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("get snapshot: %w", err)
+	}
+	res.SnapshotAt = snapshotAt
+
+	var balance, reserved int64
+	err = r.pool.QueryRow(ctx, `
+		SELECT
+			COALESCE(SUM(CASE WHEN p.bucket = 'AVAILABLE' THEN `+signedPosting+` END), 0)::BIGINT,
+			COALESCE(SUM(CASE WHEN p.bucket = 'RESERVED' THEN `+signedPosting+` END), 0)::BIGINT,
+			COUNT(DISTINCT j.id)
+		FROM postings p
+		JOIN journal_entries j ON j.id = p.entry_id
+		WHERE p.account_id = $1 AND j.created_at >= $2 AND j.created_at <= $3
+	`, accountID, snapshotAt, asOf).Scan(&balance, &reserved, &res.EntriesReplayed)
+	if err != nil {
+		return nil, fmt.Errorf("replay postings: %w", err)
+	}
+	res.Balance += balance
+	res.Reserved += reserved
+	return &res, nil
+}
+
+// SnapshotBalances stores, for every account, the balances made up of all entries
+// created before asOf. Existing snapshots for asOf are left untouched, so the job
+// can be re-run safely.
+func (r *Repository) SnapshotBalances(ctx context.Context, asOf time.Time) (int64, error) {
+	tag, err := r.pool.Exec(ctx, `
+		INSERT INTO balance_snapshots (account_id, as_of, balance, reserved, currency)
+		SELECT a.id::TEXT, $1, COALESCE(s.balance, 0), COALESCE(s.reserved, 0), a.currency
+		FROM accounts a
+		LEFT JOIN (
+			SELECT p.account_id,
+				SUM(CASE WHEN p.bucket = 'AVAILABLE' THEN `+signedPosting+` ELSE 0 END)::BIGINT AS balance,
+				SUM(CASE WHEN p.bucket = 'RESERVED' THEN `+signedPosting+` ELSE 0 END)::BIGINT AS reserved
+			FROM postings p
+			JOIN journal_entries j ON j.id = p.entry_id
+			WHERE j.created_at < $1
+			GROUP BY p.account_id
+		) s ON s.account_id = a.id::TEXT
+		WHERE a.created_at < $1
+		ON CONFLICT (account_id, as_of) DO NOTHING
+	`, asOf)
+	if err != nil {
+		return 0, fmt.Errorf("snapshot balances: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/parasagrawal71/bank-settlement-system/shared/money"
+)
+
+func TestReservationPartialCaptures(t *testing.T) {
+	tests := []struct {
+		name        string
+		amount      money.Money
+		payeeAmount money.Money
+		parts       []int64
+		remaining   []int64
+		payee       []int64
+	}{
+		{"same currency", money.Money{Amount: 1000, Currency: "INR"}, money.Money{Amount: 1000, Currency: "INR"},
+			[]int64{300, 300, 400}, []int64{700, 400, 0}, []int64{300, 300, 400}},
+		{"single full capture", money.Money{Amount: 1000, Currency: "INR"}, money.Money{Amount: 1000, Currency: "INR"},
+			[]int64{1000}, []int64{0}, []int64{1000}},
+		{"cross currency rounds down", money.Money{Amount: 300, Currency: "USD"}, money.Money{Amount: 25000, Currency: "INR"},
+			[]int64{100, 100}, []int64{200, 100}, []int64{8333, 8333}},
+		{"last capture takes the rest", money.Money{Amount: 300, Currency: "USD"}, money.Money{Amount: 25000, Currency: "INR"},
+			[]int64{100, 100, 100}, []int64{200, 100, 0}, []int64{8333, 8333, 8334}},
+		{"one minor unit", money.Money{Amount: 3, Currency: "USD"}, money.Money{Amount: 250, Currency: "INR"},
+			[]int64{1, 1, 1}, []int64{2, 1, 0}, []int64{83, 83, 84}},
+		{"to fewer decimals", money.Money{Amount: 1001, Currency: "USD"}, money.Money{Amount: 1501, Currency: "JPY"},
+			[]int64{500, 501}, []int64{501, 0}, []int64{749, 752}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := &reservation{Amount: tt.amount, PayeeAmount: tt.payeeAmount}
+			var paid int64
+			for i, p := range tt.parts {
+				part := money.Money{Amount: p, Currency: tt.amount.Currency}
+				share := res.payeeShare(part)
+				if share.Currency != tt.payeeAmount.Currency || share.Amount != tt.payee[i] {
+					t.Fatalf("capture %d of %d: payee share %v, want %d %s", i+1, p, share, tt.payee[i], tt.payeeAmount.Currency)
+				}
+				res.Captured += p
+				res.PayeeCaptured += share.Amount
+				paid += share.Amount
+				if got := res.remaining(); got.Amount != tt.remaining[i] || got.Currency != tt.amount.Currency {
+					t.Fatalf("after capture %d: remaining %v, want %d", i+1, got, tt.remaining[i])
+				}
+			}
+			if res.remaining().Amount == 0 && paid != tt.payeeAmount.Amount {
+				t.Fatalf("captures paid the payee %d in all, want %d", paid, tt.payeeAmount.Amount)
+			}
+		})
+	}
+}
@@ -0,0 +1,236 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/parasagrawal71/bank-settlement-system/shared/money"
+)
+
+// disputeAccountPrefix names the ledger-only dispute hold account of a currency,
+// e.g. DISPUTE:INR. A disputed amount is taken from the payee into it and stays
+// there until the dispute is won (back to the payee) or lost (to the payer).
+const disputeAccountPrefix = "DISPUTE:"
+
+func DisputeAccountID(currency string) string {
+	return disputeAccountPrefix + currency
+}
+
+const (
+	EntryDisputeHold = "DISPUTE_HOLD"
+	EntryDisputeWon  = "DISPUTE_WON"
+	EntryDisputeLost = "DISPUTE_LOST"
+)
+
+// Statuses of a dispute hold.
+const (
+	DisputeHeld = "HELD"
+	DisputeWon  = "WON"
+	DisputeLost = "LOST"
+)
+
+var (
+	ErrNotDisputable   = errors.New("amount exceeds what can be disputed")
+	ErrDisputeClosed   = errors.New("dispute hold is already closed")
+	ErrDisputeNotFound = errors.New("dispute not found")
+)
+
+// DisputeHold is the amount of a disputed payment held from its payee.
+type DisputeHold struct {
+	ID          string
+	Amount      money.Money // disputed, payer currency
+	PayeeAmount money.Money // held from the payee
+	Status      string      // HELD, WON or LOST
+}
+
+// HoldDispute takes amount (payer currency, 0 for everything disputable) of what
+// a reservation paid its payee into the dispute hold account of the payee
+// currency. Only what the payee has received and not refunded can be disputed,
+// and split reservations cannot be disputed. A chargeback is not the payee's
+// choice, so the hold is taken even when it overdraws the payee. disputeID makes
+// the call idempotent: repeating it returns the hold that was already made.
+func (r *Repository) HoldDispute(ctx context.Context, referenceID, disputeID string, amount int64) (*DisputeHold, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var payeeID string
+	var disputable, payeeDisputable money.Money
+	err = tx.QueryRow(ctx, `
+		SELECT payee_id, currency, COALESCE(payee_currency, currency),
+			captured_amount - escrow_amount - refunded_amount - disputed_amount,
+			payee_captured_amount - escrow_payee_amount - payee_refunded_amount - disputed_payee_amount
+		FROM reservations WHERE reference_id = $1 FOR UPDATE
+	`, referenceID).Scan(&payeeID, &disputable.Currency, &payeeDisputable.Currency,
+		&disputable.Amount, &payeeDisputable.Amount)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrReservationNotFound
+		}
+		return nil, fmt.Errorf("lock reservation: %w", err)
+	}
+
+	hold, err := getDisputeHold(ctx, tx, referenceID, disputeID)
+	if err != nil || hold != nil {
+		return hold, err
+	}
+
+	legs, err := reservationLegs(ctx, tx, referenceID)
+	if err != nil {
+		return nil, err
+	}
+	if len(legs) > 0 {
+		return nil, fmt.Errorf("%w: split payments cannot be disputed", ErrNotDisputable)
+	}
+	if amount == 0 {
+		amount = disputable.Amount
+	}
+	if amount <= 0 || amount > disputable.Amount {
+		return nil, fmt.Errorf("%w: %d, disputable %s", ErrNotDisputable, amount, disputable)
+	}
+	hold = &DisputeHold{ID: disputeID, Amount: money.Money{Amount: amount, Currency: disputable.Currency},
+		PayeeAmount: money.Money{Currency: payeeDisputable.Currency}, Status: DisputeHeld}
+	// disputing everything left takes the rest of the payee side too
+	switch {
+	case amount == disputable.Amount:
+		hold.PayeeAmount.Amount = payeeDisputable.Amount
+	case payeeDisputable.Currency == disputable.Currency:
+		hold.PayeeAmount.Amount = amount
+	default:
+		share := new(big.Int).Mul(big.NewInt(payeeDisputable.Amount), big.NewInt(amount))
+		hold.PayeeAmount.Amount = share.Quo(share, big.NewInt(disputable.Amount)).Int64()
+	}
+
+	_, err = r.postEntry(ctx, tx, JournalEntry{
+		ReferenceID: referenceID,
+		EntryType:   EntryDisputeHold,
+		Postings: []Posting{
+			{AccountID: payeeID, Bucket: BucketAvailable, Direction: Debit, Amount: hold.PayeeAmount},
+			{AccountID: DisputeAccountID(hold.PayeeAmount.Currency), Bucket: BucketAvailable, Direction: Credit, Amount: hold.PayeeAmount},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO disputes (id, reference_id, amount, currency, payee_amount, payee_currency, status)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`, disputeID, referenceID, hold.Amount.Amount, hold.Amount.Currency, hold.PayeeAmount.Amount, hold.PayeeAmount.Currency, hold.Status)
+	if err != nil {
+		return nil, fmt.Errorf("insert dispute: %w", err)
+	}
+	_, err = tx.Exec(ctx, `
+		UPDATE reservations SET disputed_amount = disputed_amount + $2, disputed_payee_amount = disputed_payee_amount + $3,
+			updated_at = now()
+		WHERE reference_id = $1
+	`, referenceID, hold.Amount.Amount, hold.PayeeAmount.Amount)
+	if err != nil {
+		return nil, fmt.Errorf("update reservation: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit tx: %w", err)
+	}
+	return hold, nil
+}
+
+// CloseDisputeHold returns a held dispute amount to the payee when the dispute
+// was won, or to the payer, at the rate of the original quote, when it was lost.
+// A lost dispute counts as a refund of the captured amount. Closing a hold the
+// way it was already closed returns it unchanged; closing it the other way fails
+// with ErrDisputeClosed.
+func (r *Repository) CloseDisputeHold(ctx context.Context, referenceID, disputeID string, won bool) (*DisputeHold, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var payerID, payeeID string
+	err = tx.QueryRow(ctx, `
+		SELECT payer_id, payee_id FROM reservations WHERE reference_id = $1 FOR UPDATE
+	`, referenceID).Scan(&payerID, &payeeID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrReservationNotFound
+		}
+		return nil, fmt.Errorf("lock reservation: %w", err)
+	}
+
+	hold, err := getDisputeHold(ctx, tx, referenceID, disputeID)
+	if err != nil {
+		return nil, err
+	}
+	if hold == nil {
+		return nil, fmt.Errorf("%w: %s", ErrDisputeNotFound, disputeID)
+	}
+	status := DisputeLost
+	if won {
+		status = DisputeWon
+	}
+	if hold.Status == status {
+		return hold, nil
+	}
+	if hold.Status != DisputeHeld {
+		return nil, fmt.Errorf("%w: dispute %s was %s", ErrDisputeClosed, disputeID, hold.Status)
+	}
+
+	holdAccount := DisputeAccountID(hold.PayeeAmount.Currency)
+	entry := JournalEntry{ReferenceID: referenceID, EntryType: EntryDisputeWon, Postings: []Posting{
+		{AccountID: holdAccount, Bucket: BucketAvailable, Direction: Debit, Amount: hold.PayeeAmount},
+		{AccountID: payeeID, Bucket: BucketAvailable, Direction: Credit, Amount: hold.PayeeAmount},
+	}}
+	refunded := money.Money{Currency: hold.Amount.Currency}
+	payeeRefunded := money.Money{Currency: hold.PayeeAmount.Currency}
+	if !won {
+		// the hold account stands in for the payee of a refund
+		entry = JournalEntry{ReferenceID: referenceID, EntryType: EntryDisputeLost,
+			Postings: refundPostings(payerID, holdAccount, hold.Amount, hold.PayeeAmount)}
+		refunded, payeeRefunded = hold.Amount, hold.PayeeAmount
+	}
+	if _, err := r.postEntry(ctx, tx, entry); err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec(ctx, `UPDATE disputes SET status = $2, closed_at = now() WHERE id = $1`, disputeID, status)
+	if err != nil {
+		return nil, fmt.Errorf("update dispute: %w", err)
+	}
+	_, err = tx.Exec(ctx, `
+		UPDATE reservations SET disputed_amount = disputed_amount - $2, disputed_payee_amount = disputed_payee_amount - $3,
+			refunded_amount = refunded_amount + $4, payee_refunded_amount = payee_refunded_amount + $5, updated_at = now()
+		WHERE reference_id = $1
+	`, referenceID, hold.Amount.Amount, hold.PayeeAmount.Amount, refunded.Amount, payeeRefunded.Amount)
+	if err != nil {
+		return nil, fmt.Errorf("update reservation: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit tx: %w", err)
+	}
+	hold.Status = status
+	return hold, nil
+}
+
+// getDisputeHold returns a dispute hold of a reservation, or nil. The caller
+// holds the reservation row lock.
+func getDisputeHold(ctx context.Context, tx pgx.Tx, referenceID, disputeID string) (*DisputeHold, error) {
+	hold := DisputeHold{ID: disputeID}
+	err := tx.QueryRow(ctx, `
+		SELECT amount, currency, payee_amount, payee_currency, status FROM disputes WHERE id = $1 AND reference_id = $2
+	`, disputeID, referenceID).Scan(&hold.Amount.Amount, &hold.Amount.Currency, &hold.PayeeAmount.Amount,
+		&hold.PayeeAmount.Currency, &hold.Status)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get dispute: %w", err)
+	}
+	return &hold, nil
+}
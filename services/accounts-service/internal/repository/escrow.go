@@ -0,0 +1,180 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/parasagrawal71/bank-settlement-system/shared/money"
+)
+
+// escrowAccountPrefix names the ledger-only escrow account of a currency, e.g.
+// ESCROW:INR. Captures of escrow reservations are credited to it and stay there
+// until they are released to the payee or refunded to the payer.
+const escrowAccountPrefix = "ESCROW:"
+
+func EscrowAccountID(currency string) string {
+	return escrowAccountPrefix + currency
+}
+
+const (
+	EntryEscrowRelease = "ESCROW_RELEASE"
+	EntryEscrowRefund  = "ESCROW_REFUND"
+)
+
+// Kinds of escrow movements.
+const (
+	EscrowRelease = "RELEASE"
+	EscrowRefund  = "REFUND"
+)
+
+var (
+	ErrNotEscrow          = errors.New("reservation is not an escrow reservation")
+	ErrEscrowExceedsFunds = errors.New("amount exceeds the funds held in escrow")
+	ErrMovementIDReused   = errors.New("movement_id belongs to another kind of escrow movement")
+)
+
+// EscrowMovement is the outcome of releasing or refunding funds held in escrow.
+type EscrowMovement struct {
+	ID          string
+	Kind        string
+	Amount      money.Money // taken out of escrow
+	PayeeAmount money.Money // credited to the payee by a release
+	Escrowed    money.Money // left in escrow
+}
+
+// escrowTransferPostings moves a capture of an escrow reservation from the payer's
+// hold into the escrow account of the payer currency.
+func escrowTransferPostings(payerID string, amount money.Money) []Posting {
+	return []Posting{
+		{AccountID: payerID, Bucket: BucketReserved, Direction: Debit, Amount: amount},
+		{AccountID: EscrowAccountID(amount.Currency), Bucket: BucketAvailable, Direction: Credit, Amount: amount},
+	}
+}
+
+// escrowReleasePostings pays amount out of escrow and credits payeeAmount to the
+// payee, through the FX position accounts for a cross-currency reservation.
+func escrowReleasePostings(payeeID string, amount, payeeAmount money.Money) []Posting {
+	postings := transferPostings(EscrowAccountID(amount.Currency), payeeID, amount, payeeAmount)
+	postings[0].Bucket = BucketAvailable
+	return postings
+}
+
+// escrowPayeeShare is the payee side of taking amount out of escrow. Taking out
+// the rest of the escrow takes the rest of the payee side too, so rounding of
+// cross-currency partial releases never loses a minor unit.
+func escrowPayeeShare(amount int64, escrowed, payeeEscrowed money.Money) int64 {
+	switch {
+	case amount == escrowed.Amount:
+		return payeeEscrowed.Amount
+	case payeeEscrowed.Currency == escrowed.Currency:
+		return amount
+	}
+	share := new(big.Int).Mul(big.NewInt(payeeEscrowed.Amount), big.NewInt(amount))
+	return share.Quo(share, big.NewInt(escrowed.Amount)).Int64()
+}
+
+// ReleaseEscrow pays amount (payer currency, 0 for everything) of the funds an
+// escrow reservation holds in escrow out to the payee.
+func (r *Repository) ReleaseEscrow(ctx context.Context, referenceID, movementID string, amount int64) (*EscrowMovement, error) {
+	return r.moveEscrow(ctx, referenceID, movementID, EscrowRelease, amount)
+}
+
+// RefundEscrow returns amount (payer currency, 0 for everything) of the funds an
+// escrow reservation holds in escrow to the payer. It counts as a refund of the
+// captured amount.
+func (r *Repository) RefundEscrow(ctx context.Context, referenceID, movementID string, amount int64) (*EscrowMovement, error) {
+	return r.moveEscrow(ctx, referenceID, movementID, EscrowRefund, amount)
+}
+
+// moveEscrow takes funds out of escrow. movementID makes the call idempotent:
+// repeating it returns the movement that was already made.
+func (r *Repository) moveEscrow(ctx context.Context, referenceID, movementID, kind string, amount int64) (*EscrowMovement, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var escrow bool
+	var payerID, payeeID string
+	var escrowed, payeeEscrowed money.Money
+	err = tx.QueryRow(ctx, `
+		SELECT escrow, payer_id, payee_id, escrow_amount, currency, escrow_payee_amount, COALESCE(payee_currency, currency)
+		FROM reservations WHERE reference_id = $1 FOR UPDATE
+	`, referenceID).Scan(&escrow, &payerID, &payeeID, &escrowed.Amount, &escrowed.Currency,
+		&payeeEscrowed.Amount, &payeeEscrowed.Currency)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrReservationNotFound
+		}
+		return nil, fmt.Errorf("lock reservation: %w", err)
+	}
+	if !escrow {
+		return nil, ErrNotEscrow
+	}
+
+	m := EscrowMovement{ID: movementID, Kind: kind, Amount: money.Money{Currency: escrowed.Currency},
+		PayeeAmount: money.Money{Currency: payeeEscrowed.Currency}, Escrowed: escrowed}
+	var recordedKind string
+	err = tx.QueryRow(ctx, `
+		SELECT kind, amount, payee_amount FROM escrow_movements WHERE id = $1 AND reference_id = $2
+	`, movementID, referenceID).Scan(&recordedKind, &m.Amount.Amount, &m.PayeeAmount.Amount)
+	if err == nil {
+		if recordedKind != kind {
+			return nil, fmt.Errorf("%w: %s is a %s", ErrMovementIDReused, movementID, recordedKind)
+		}
+		return &m, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("get escrow movement: %w", err)
+	}
+
+	if amount == 0 {
+		amount = escrowed.Amount
+	}
+	if amount <= 0 || amount > escrowed.Amount {
+		return nil, fmt.Errorf("%w: %d, in escrow %s", ErrEscrowExceedsFunds, amount, escrowed)
+	}
+	m.Amount.Amount = amount
+	m.PayeeAmount.Amount = escrowPayeeShare(amount, escrowed, payeeEscrowed)
+	m.Escrowed.Amount -= amount
+
+	entry := JournalEntry{ReferenceID: referenceID, EntryType: EntryEscrowRelease,
+		Postings: escrowReleasePostings(payeeID, m.Amount, m.PayeeAmount)}
+	refunded := money.Money{Currency: m.Amount.Currency}
+	payeeRefunded := money.Money{Currency: m.PayeeAmount.Currency}
+	if kind == EscrowRefund {
+		entry = JournalEntry{ReferenceID: referenceID, EntryType: EntryEscrowRefund, Postings: []Posting{
+			{AccountID: EscrowAccountID(m.Amount.Currency), Bucket: BucketAvailable, Direction: Debit, Amount: m.Amount},
+			{AccountID: payerID, Bucket: BucketAvailable, Direction: Credit, Amount: m.Amount},
+		}}
+		refunded, payeeRefunded = m.Amount, m.PayeeAmount
+	}
+	if _, err := r.postEntry(ctx, tx, entry); err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO escrow_movements (id, reference_id, kind, amount, currency, payee_amount, payee_currency)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`, movementID, referenceID, kind, m.Amount.Amount, m.Amount.Currency, m.PayeeAmount.Amount, m.PayeeAmount.Currency)
+	if err != nil {
+		return nil, fmt.Errorf("insert escrow movement: %w", err)
+	}
+	_, err = tx.Exec(ctx, `
+		UPDATE reservations SET escrow_amount = escrow_amount - $2, escrow_payee_amount = escrow_payee_amount - $3,
+			refunded_amount = refunded_amount + $4, payee_refunded_amount = payee_refunded_amount + $5, updated_at = now()
+		WHERE reference_id = $1
+	`, referenceID, m.Amount.Amount, m.PayeeAmount.Amount, refunded.Amount, payeeRefunded.Amount)
+	if err != nil {
+		return nil, fmt.Errorf("update reservation: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit tx: %w", err)
+	}
+	return &m, nil
+}
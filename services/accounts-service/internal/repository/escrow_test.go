@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/parasagrawal71/bank-settlement-system/shared/money"
+)
+
+func TestEscrowPayeeShare(t *testing.T) {
+	tests := []struct {
+		name          string
+		escrowed      money.Money
+		payeeEscrowed money.Money
+		amounts       []int64
+		want          []int64
+	}{
+		{"same currency", money.Money{Amount: 1000, Currency: "INR"}, money.Money{Amount: 1000, Currency: "INR"},
+			[]int64{400, 600}, []int64{400, 600}},
+		{"everything at once", money.Money{Amount: 300, Currency: "USD"}, money.Money{Amount: 25000, Currency: "INR"},
+			[]int64{300}, []int64{25000}},
+		{"partial releases round down, the rest takes the rest", money.Money{Amount: 300, Currency: "USD"}, money.Money{Amount: 25000, Currency: "INR"},
+			[]int64{100, 100, 100}, []int64{8333, 8333, 8334}},
+		{"to fewer decimals", money.Money{Amount: 1001, Currency: "USD"}, money.Money{Amount: 1501, Currency: "JPY"},
+			[]int64{1, 1000}, []int64{1, 1500}},
+		{"uneven payee side", money.Money{Amount: 200, Currency: "USD"}, money.Money{Amount: 16667, Currency: "INR"},
+			[]int64{50, 150}, []int64{4166, 12501}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			escrowed, payeeEscrowed := tt.escrowed, tt.payeeEscrowed
+			for i, amount := range tt.amounts {
+				got := escrowPayeeShare(amount, escrowed, payeeEscrowed)
+				if got != tt.want[i] {
+					t.Fatalf("movement %d of %d: payee share %d, want %d", i+1, amount, got, tt.want[i])
+				}
+				escrowed.Amount -= amount
+				payeeEscrowed.Amount -= got
+			}
+			if escrowed.Amount != 0 || payeeEscrowed.Amount != 0 {
+				t.Fatalf("escrow left %v and %v after taking everything out", escrowed, payeeEscrowed)
+			}
+		})
+	}
+}
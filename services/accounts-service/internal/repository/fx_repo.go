@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/parasagrawal71/bank-settlement-system/shared/money"
+)
+
+// fxAccountPrefix names the ledger-only FX position account of a currency, e.g.
+// FX:USD. A cross-currency transfer credits the source currency position and
+// debits the target currency position so that each currency balances on its own.
+const fxAccountPrefix = "FX:"
+
+func FXAccountID(currency string) string {
+	return fxAccountPrefix + currency
+}
+
+var (
+	ErrRateNotFound = errors.New("exchange rate not found")
+	ErrQuoteInvalid = errors.New("fx quote is invalid, expired or already used")
+)
+
+type Rate struct {
+	BaseCurrency  string
+	QuoteCurrency string
+	Rate          string
+	UpdatedAt     time.Time
+}
+
+type Quote struct {
+	ID        string
+	Source    money.Money
+	Target    money.Money
+	Rate      string
+	ExpiresAt time.Time
+}
+
+func (r *Repository) SetRate(ctx context.Context, base, quote string, rate string) (*Rate, error) {
+	res := Rate{BaseCurrency: base, QuoteCurrency: quote}
+	err := r.pool.QueryRow(ctx, `
+		INSERT INTO fx_rates (base_currency, quote_currency, rate, updated_at)
+		VALUES ($1, $2, $3::NUMERIC, now())
+		ON CONFLICT (base_currency, quote_currency) DO UPDATE SET rate = EXCLUDED.rate, updated_at = now()
+		RETURNING rate::TEXT, updated_at
+	`, base, quote, rate).Scan(&res.Rate, &res.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("set rate: %w", err)
+	}
+	return &res, nil
+}
+
+// GetRate returns the rate for base→quote, falling back to the inverse of a
+// stored quote→base rate.
+func (r *Repository) GetRate(ctx context.Context, base, quote string) (*big.Rat, string, error) {
+	var rate string
+	err := r.pool.QueryRow(ctx, `
+		SELECT rate::TEXT FROM fx_rates WHERE base_currency = $1 AND quote_currency = $2
+	`, base, quote).Scan(&rate)
+	if err == nil {
+		rat, err := money.ParseRate(rate)
+		return rat, rate, err
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, "", fmt.Errorf("get rate: %w", err)
+	}
+
+	err = r.pool.QueryRow(ctx, `
+		SELECT rate::TEXT FROM fx_rates WHERE base_currency = $1 AND quote_currency = $2
+	`, quote, base).Scan(&rate)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, "", fmt.Errorf("%w: %s/%s", ErrRateNotFound, base, quote)
+		}
+		return nil, "", fmt.Errorf("get rate: %w", err)
+	}
+	return inverseRate(rate)
+}
+
+// inverseRate turns a stored quote→base rate into base→quote. Conversions use
+// the exact inverse; the string, rounded to 12 decimals, is only for display.
+func inverseRate(rate string) (*big.Rat, string, error) {
+	rat, err := money.ParseRate(rate)
+	if err != nil {
+		return nil, "", err
+	}
+	inv := new(big.Rat).Inv(rat)
+	return inv, inv.FloatString(12), nil
+}
+
+// CreateQuote converts source into the target currency at the current rate and
+// stores the result so it can be locked by a reservation until expiresAt.
+func (r *Repository) CreateQuote(ctx context.Context, source money.Money, toCurrency string, expiresAt time.Time) (*Quote, error) {
+	rat, rateStr, err := r.GetRate(ctx, source.Currency, toCurrency)
+	if err != nil {
+		return nil, err
+	}
+	target, err := money.Convert(source, toCurrency, rat)
+	if err != nil {
+		return nil, err
+	}
+	q := Quote{Source: source, Target: target, Rate: rateStr, ExpiresAt: expiresAt}
+	err = r.pool.QueryRow(ctx, `
+		INSERT INTO fx_quotes (from_currency, to_currency, rate, source_amount, target_amount, status, expires_at)
+		VALUES ($1, $2, $3::NUMERIC, $4, $5, 'OPEN', $6)
+		RETURNING id
+	`, source.Currency, target.Currency, rateStr, source.Amount, target.Amount, expiresAt).Scan(&q.ID)
+	if err != nil {
+		return nil, fmt.Errorf("insert quote: %w", err)
+	}
+	return &q, nil
+}
+
+// useQuote marks an open, unexpired quote for exactly source → toCurrency as used
+// and returns the locked target amount.
+func useQuote(ctx context.Context, tx pgx.Tx, quoteID string, source money.Money, toCurrency string) (money.Money, error) {
+	target := money.Money{Currency: toCurrency}
+	err := tx.QueryRow(ctx, `
+		UPDATE fx_quotes SET status = 'USED'
+		WHERE id = $1 AND status = 'OPEN' AND expires_at > now()
+			AND from_currency = $2 AND source_amount = $3 AND to_currency = $4
+		RETURNING target_amount
+	`, quoteID, source.Currency, source.Amount, toCurrency).Scan(&target.Amount)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return money.Money{}, ErrQuoteInvalid
+		}
+		return money.Money{}, fmt.Errorf("use quote: %w", err)
+	}
+	return target, nil
+}
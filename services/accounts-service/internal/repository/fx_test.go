@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/parasagrawal71/bank-settlement-system/shared/money"
+)
+
+func TestInverseRate(t *testing.T) {
+	tests := []struct {
+		name   string
+		stored string
+		source money.Money
+		to     string
+		shown  string
+		want   money.Money
+	}{
+		{"exact inverse", "0.5", money.Money{Amount: 1000, Currency: "EUR"}, "USD", "2.000000000000", money.Money{Amount: 2000, Currency: "USD"}},
+		{"repeating decimal", "83.25", money.Money{Amount: 10000, Currency: "INR"}, "USD", "0.012012012012", money.Money{Amount: 120, Currency: "USD"}},
+		// at the 12-decimal display rate this would come out 1000 cents short
+		{"converts at the exact inverse", "3", money.Money{Amount: 3_000_000_000_000_000, Currency: "INR"}, "USD", "0.333333333333", money.Money{Amount: 1_000_000_000_000_000, Currency: "USD"}},
+		{"half rounds up", "8", money.Money{Amount: 4, Currency: "INR"}, "USD", "0.125000000000", money.Money{Amount: 1, Currency: "USD"}},
+		{"below half rounds down", "8", money.Money{Amount: 3, Currency: "INR"}, "USD", "0.125000000000", money.Money{Amount: 0, Currency: "USD"}},
+		{"from fewer decimals", "150", money.Money{Amount: 15075, Currency: "JPY"}, "USD", "0.006666666667", money.Money{Amount: 10050, Currency: "USD"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rate, shown, err := inverseRate(tt.stored)
+			if err != nil {
+				t.Fatalf("inverseRate(%s) error = %v", tt.stored, err)
+			}
+			if shown != tt.shown {
+				t.Errorf("inverseRate(%s) shows %s, want %s", tt.stored, shown, tt.shown)
+			}
+			got, err := money.Convert(tt.source, tt.to, rate)
+			if err != nil || got != tt.want {
+				t.Fatalf("Convert(%v) at 1/%s = %v, %v, want %v", tt.source, tt.stored, got, err, tt.want)
+			}
+		})
+	}
+
+	for _, stored := range []string{"0", "-1", "x"} {
+		if _, _, err := inverseRate(stored); err == nil {
+			t.Errorf("inverseRate(%q) succeeded", stored)
+		}
+	}
+}
+
+// A cross-currency transfer credits the payee exactly the amount locked by the
+// quote and balances in each currency through the FX position accounts.
+func TestTransferPostingsLockedAmount(t *testing.T) {
+	tests := []struct {
+		name        string
+		amount      money.Money
+		payeeAmount money.Money
+		postings    int
+	}{
+		{"same currency", money.Money{Amount: 500, Currency: "INR"}, money.Money{Amount: 500, Currency: "INR"}, 2},
+		{"cross currency", money.Money{Amount: 10000, Currency: "USD"}, money.Money{Amount: 832500, Currency: "INR"}, 4},
+		{"quote rounded down", money.Money{Amount: 1, Currency: "USD"}, money.Money{Amount: 83, Currency: "INR"}, 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			postings := transferPostings("payer", "payee", tt.amount, tt.payeeAmount)
+			if len(postings) != tt.postings {
+				t.Fatalf("transferPostings() = %d postings, want %d", len(postings), tt.postings)
+			}
+			if err := (JournalEntry{EntryType: EntryTransfer, Postings: postings}).Validate(); err != nil {
+				t.Fatalf("transfer entry does not balance: %v", err)
+			}
+			var credited money.Money
+			for _, p := range postings {
+				if p.AccountID == "payee" && p.Direction == Credit {
+					credited = p.Amount
+				}
+			}
+			if credited != tt.payeeAmount {
+				t.Fatalf("payee credited %v, want the locked %v", credited, tt.payeeAmount)
+			}
+		})
+	}
+}
@@ -0,0 +1,269 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/parasagrawal71/bank-settlement-system/shared/money"
+)
+
+// ExternalAccountID is the ledger-only counterparty for money entering or leaving
+// the system (deposits, withdrawals, opening balances). It has no accounts row.
+const ExternalAccountID = "EXTERNAL"
+
+type Direction string
+
+const (
+	Debit  Direction = "DEBIT"
+	Credit Direction = "CREDIT"
+)
+
+// isLedgerOnly reports whether the account exists only in postings (EXTERNAL, the
+// FX positions and the escrow and dispute hold accounts) and therefore has no
+// accounts row to project onto.
+func isLedgerOnly(accountID string) bool {
+	return accountID == ExternalAccountID || strings.HasPrefix(accountID, fxAccountPrefix) ||
+		strings.HasPrefix(accountID, escrowAccountPrefix) || strings.HasPrefix(accountID, disputeAccountPrefix)
+}
+
+// Bucket is the part of an account a posting applies to. AVAILABLE is projected
+// onto accounts.balance and RESERVED onto accounts.reserved.
+type Bucket string
+
+const (
+	BucketAvailable Bucket = "AVAILABLE"
+	BucketReserved  Bucket = "RESERVED"
+)
+
+const (
+	EntryOpeningBalance = "OPENING_BALANCE"
+	EntryAdjustment     = "ADJUSTMENT"
+	EntryReserve        = "RESERVE"
+	EntryTransfer       = "TRANSFER"
+	EntryRelease        = "RELEASE"
+)
+
+var (
+	ErrUnbalancedEntry = errors.New("journal entry postings do not sum to zero")
+	ErrLedgerMismatch  = errors.New("account balance does not match ledger postings")
+)
+
+type Posting struct {
+	AccountID string
+	Bucket    Bucket
+	Direction Direction
+	Amount    money.Money
+}
+
+type JournalEntry struct {
+	ID          string
+	ReferenceID string
+	EntryType   string
+	Postings    []Posting
+	CreatedAt   time.Time
+}
+
+// signed returns the effect of the posting on the account bucket: credits
+// increase a customer balance and debits decrease it.
+func (p Posting) signed() int64 {
+	if p.Direction == Debit {
+		return -p.Amount.Amount
+	}
+	return p.Amount.Amount
+}
+
+// Validate checks that the entry has at least one debit and one credit, that every
+// amount is positive and that debits equal credits in every currency.
+func (e JournalEntry) Validate() error {
+	if len(e.Postings) < 2 {
+		return fmt.Errorf("%w: need at least two postings", ErrUnbalancedEntry)
+	}
+	sums := map[string]int64{}
+	for _, p := range e.Postings {
+		if p.Amount.Amount <= 0 {
+			return fmt.Errorf("%w: posting amount must be positive, got %s", ErrUnbalancedEntry, p.Amount)
+		}
+		if p.Direction != Debit && p.Direction != Credit {
+			return fmt.Errorf("%w: unknown direction %q", ErrUnbalancedEntry, p.Direction)
+		}
+		sum, err := money.AddInt64(sums[p.Amount.Currency], p.signed())
+		if err != nil {
+			return err
+		}
+		sums[p.Amount.Currency] = sum
+	}
+	for cur, sum := range sums {
+		if sum != 0 {
+			return fmt.Errorf("%w: %s off by %d", ErrUnbalancedEntry, cur, sum)
+		}
+	}
+	return nil
+}
+
+// projection is the balance and reserved amount of an accounts row.
+type projection struct {
+	balance, reserved int64
+}
+
+// postEntry writes a balanced journal entry inside tx, applies its postings to the
+// accounts projection and verifies that the touched accounts changed by exactly
+// what the postings say. The full comparison with the ledger is left to
+// ReconcileLedger, which would otherwise scan every posting of an account while
+// its row is locked.
+func (r *Repository) postEntry(ctx context.Context, tx pgx.Tx, e JournalEntry) (string, error) {
+	if err := e.Validate(); err != nil {
+		return "", err
+	}
+
+	// lock customer accounts in a stable order so concurrent entries cannot deadlock
+	ids := make([]string, 0, len(e.Postings))
+	seen := map[string]bool{}
+	for _, p := range e.Postings {
+		if !isLedgerOnly(p.AccountID) && !seen[p.AccountID] {
+			seen[p.AccountID] = true
+			ids = append(ids, p.AccountID)
+		}
+	}
+	sort.Strings(ids)
+	balances := make(map[string]int64, len(ids))
+	expected := make(map[string]projection, len(ids))
+	for _, id := range ids {
+		var currency, status string
+		var balance, reserved int64
+		err := tx.QueryRow(ctx, `SELECT balance, reserved, currency, status FROM accounts WHERE id = $1 FOR UPDATE`, id).Scan(&balance, &reserved, &currency, &status)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return "", fmt.Errorf("%w: %s", ErrAccountNotFound, id)
+			}
+			return "", fmt.Errorf("lock account: %w", err)
+		}
+		if err := checkPostable(id, status, e.EntryType); err != nil {
+			return "", err
+		}
+		balances[id] = balance
+		expected[id] = projection{balance: balance, reserved: reserved}
+		for _, p := range e.Postings {
+			if p.AccountID == id && p.Amount.Currency != currency {
+				return "", fmt.Errorf("%w: account %s is %s, posting is %s", money.ErrCurrencyMismatch, id, currency, p.Amount.Currency)
+			}
+		}
+	}
+
+	var refID *string
+	if e.ReferenceID != "" {
+		refID = &e.ReferenceID
+	}
+	var entryID string
+	err := tx.QueryRow(ctx, `
+		INSERT INTO journal_entries (reference_id, entry_type) VALUES ($1, $2) RETURNING id
+	`, refID, e.EntryType).Scan(&entryID)
+	if err != nil {
+		return "", fmt.Errorf("insert journal entry: %w", err)
+	}
+
+	for _, p := range e.Postings {
+		// record the part of an available-balance debit drawn from the overdraft
+		var overdraft int64
+		if !isLedgerOnly(p.AccountID) && p.Bucket == BucketAvailable {
+			if p.Direction == Debit {
+				overdraft = overdraftDrawn(balances[p.AccountID], p.Amount.Amount)
+			}
+			balances[p.AccountID] += p.signed()
+		}
+		_, err := tx.Exec(ctx, `
+			INSERT INTO postings (entry_id, account_id, bucket, direction, amount, currency, overdraft_amount)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+		`, entryID, p.AccountID, p.Bucket, p.Direction, p.Amount.Amount, p.Amount.Currency, overdraft)
+		if err != nil {
+			return "", fmt.Errorf("insert posting: %w", err)
+		}
+		if isLedgerOnly(p.AccountID) {
+			continue
+		}
+		column := "balance"
+		want := expected[p.AccountID]
+		if p.Bucket == BucketReserved {
+			column = "reserved"
+			want.reserved += p.signed()
+		} else {
+			want.balance += p.signed()
+		}
+		expected[p.AccountID] = want
+		// any movement wakes a dormant account up
+		_, err = tx.Exec(ctx, `
+			UPDATE accounts SET `+column+` = `+column+` + $1, updated_at = now(),
+				status = CASE WHEN status = 'DORMANT' THEN 'ACTIVE' ELSE status END
+			WHERE id = $2`,
+			p.signed(), p.AccountID)
+		if err != nil {
+			return "", fmt.Errorf("apply posting: %w", err)
+		}
+	}
+
+	for _, id := range ids {
+		if err := verifyProjection(ctx, tx, id, expected[id]); err != nil {
+			return "", err
+		}
+	}
+	return entryID, nil
+}
+
+// verifyProjection compares the accounts row with what it was before the entry
+// plus the entry's postings.
+func verifyProjection(ctx context.Context, tx pgx.Tx, accountID string, want projection) error {
+	var got projection
+	err := tx.QueryRow(ctx, `SELECT balance, reserved FROM accounts WHERE id = $1`, accountID).Scan(&got.balance, &got.reserved)
+	if err != nil {
+		return fmt.Errorf("verify account: %w", err)
+	}
+	if got != want {
+		return fmt.Errorf("%w: account %s has balance %d/reserved %d, postings say %d/%d",
+			ErrLedgerMismatch, accountID, got.balance, got.reserved, want.balance, want.reserved)
+	}
+	return nil
+}
+
+// LedgerMismatch is an account whose projection differs from its postings.
+type LedgerMismatch struct {
+	AccountID      string
+	Balance        int64
+	Reserved       int64
+	LedgerBalance  int64
+	LedgerReserved int64
+}
+
+// ReconcileLedger recomputes balance and reserved of every account from its
+// postings and returns the accounts whose rows disagree. It reads one snapshot
+// and takes no locks, so it can run next to live traffic.
+func (r *Repository) ReconcileLedger(ctx context.Context) ([]LedgerMismatch, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT a.id::TEXT, a.balance, a.reserved, COALESCE(s.balance, 0), COALESCE(s.reserved, 0)
+		FROM accounts a
+		LEFT JOIN (
+			SELECT p.account_id,
+				SUM(CASE WHEN p.bucket = 'AVAILABLE' THEN `+signedPosting+` ELSE 0 END)::BIGINT AS balance,
+				SUM(CASE WHEN p.bucket = 'RESERVED' THEN `+signedPosting+` ELSE 0 END)::BIGINT AS reserved
+			FROM postings p
+			GROUP BY p.account_id
+		) s ON s.account_id = a.id::TEXT
+		WHERE a.balance <> COALESCE(s.balance, 0) OR a.reserved <> COALESCE(s.reserved, 0)
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("reconcile ledger: %w", err)
+	}
+	defer rows.Close()
+	var out []LedgerMismatch
+	for rows.Next() {
+		var m LedgerMismatch
+		if err := rows.Scan(&m.AccountID, &m.Balance, &m.Reserved, &m.LedgerBalance, &m.LedgerReserved); err != nil {
+			return nil, fmt.Errorf("scan row: %w", err)
+		}
+		out = append(out, m)
+	}
+	return out, rows.Err()
+}
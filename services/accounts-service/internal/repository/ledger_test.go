@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/parasagrawal71/bank-settlement-system/shared/money"
+)
+
+func posting(account string, dir Direction, amount int64, currency string) Posting {
+	return Posting{AccountID: account, Bucket: BucketAvailable, Direction: dir, Amount: money.Money{Amount: amount, Currency: currency}}
+}
+
+func TestJournalEntryValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		postings []Posting
+		err      error
+	}{
+		{"balanced", []Posting{
+			posting("a", Debit, 500, "INR"),
+			posting("b", Credit, 500, "INR"),
+		}, nil},
+		{"split credit", []Posting{
+			posting("a", Debit, 500, "INR"),
+			posting("b", Credit, 300, "INR"),
+			posting("c", Credit, 200, "INR"),
+		}, nil},
+		{"balanced per currency", []Posting{
+			posting("a", Debit, 100, "USD"),
+			posting("FX:USD", Credit, 100, "USD"),
+			posting("FX:INR", Debit, 8325, "INR"),
+			posting("b", Credit, 8325, "INR"),
+		}, nil},
+		{"single posting", []Posting{
+			posting("a", Debit, 500, "INR"),
+		}, ErrUnbalancedEntry},
+		{"no postings", nil, ErrUnbalancedEntry},
+		{"off by one", []Posting{
+			posting("a", Debit, 500, "INR"),
+			posting("b", Credit, 499, "INR"),
+		}, ErrUnbalancedEntry},
+		{"only debits", []Posting{
+			posting("a", Debit, 500, "INR"),
+			posting("b", Debit, 500, "INR"),
+		}, ErrUnbalancedEntry},
+		{"balanced across currencies only", []Posting{
+			posting("a", Debit, 500, "INR"),
+			posting("b", Credit, 500, "USD"),
+		}, ErrUnbalancedEntry},
+		{"zero amount", []Posting{
+			posting("a", Debit, 0, "INR"),
+			posting("b", Credit, 0, "INR"),
+		}, ErrUnbalancedEntry},
+		{"negative amount", []Posting{
+			posting("a", Debit, -500, "INR"),
+			posting("b", Credit, -500, "INR"),
+		}, ErrUnbalancedEntry},
+		{"unknown direction", []Posting{
+			posting("a", "SIDEWAYS", 500, "INR"),
+			posting("b", Credit, 500, "INR"),
+		}, ErrUnbalancedEntry},
+		{"overflowing sum", []Posting{
+			posting("a", Credit, math.MaxInt64, "INR"),
+			posting("b", Credit, 1, "INR"),
+			posting("c", Debit, math.MaxInt64, "INR"),
+		}, money.ErrOverflow},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := JournalEntry{EntryType: EntryTransfer, Postings: tt.postings}.Validate()
+			if !errors.Is(err, tt.err) || (tt.err == nil) != (err == nil) {
+				t.Fatalf("Validate() = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestIsLedgerOnly(t *testing.T) {
+	tests := []struct {
+		account string
+		want    bool
+	}{
+		{ExternalAccountID, true},
+		{"FX:USD", true},
+		{"ESCROW:INR", true},
+		{"DISPUTE:INR", true},
+		{"6f1c1c4e-3f0a-4d5e-9a43-1b2c3d4e5f60", false},
+		{"external", false},
+	}
+	for _, tt := range tests {
+		if got := isLedgerOnly(tt.account); got != tt.want {
+			t.Errorf("isLedgerOnly(%q) = %v, want %v", tt.account, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/parasagrawal71/bank-settlement-system/shared/money"
+)
+
+var ErrInsufficientFunds = errors.New("insufficient funds")
+
+// checkFunds checks that amount can be debited from the available balance
+// without going beyond the approved overdraft. accounts.balance is already net
+// of reserved funds, so the headroom is balance + credit limit.
+func checkFunds(balance money.Money, creditLimit int64, amount money.Money) error {
+	headroom, err := money.AddInt64(balance.Amount, creditLimit)
+	if err != nil {
+		return err
+	}
+	if headroom < amount.Amount {
+		return fmt.Errorf("%w: have %s with credit limit %d, need %s", ErrInsufficientFunds, balance, creditLimit, amount)
+	}
+	return nil
+}
+
+// overdraftDrawn returns the part of a debit of amount that takes the balance
+// below zero, given the balance before the debit.
+func overdraftDrawn(before, amount int64) int64 {
+	after := before - amount
+	if after >= 0 {
+		return 0
+	}
+	if before <= 0 {
+		return amount
+	}
+	return -after
+}
+
+// SetCreditLimit sets the approved overdraft of an account. Lowering the limit
+// below the current overdraft is allowed; further debits are then refused until
+// the account is back within its limit.
+func (r *Repository) SetCreditLimit(ctx context.Context, id string, limit money.Money) (*Account, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var currency, status string
+	err = tx.QueryRow(ctx, `SELECT currency, status FROM accounts WHERE id = $1 FOR UPDATE`, id).Scan(&currency, &status)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrAccountNotFound
+		}
+		return nil, fmt.Errorf("lock account: %w", err)
+	}
+	if status == StatusClosed {
+		return nil, &AccountStateError{AccountID: id, Status: status}
+	}
+	if limit.Currency != currency {
+		return nil, fmt.Errorf("%w: account is %s, limit is %s", money.ErrCurrencyMismatch, currency, limit.Currency)
+	}
+	_, err = tx.Exec(ctx, `UPDATE accounts SET credit_limit = $2, updated_at = now() WHERE id = $1`, id, limit.Amount)
+	if err != nil {
+		return nil, fmt.Errorf("update credit limit: %w", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit tx: %w", err)
+	}
+	return r.GetAccount(ctx, id)
+}
+
+// ListOverdrawnAccounts returns the accounts with a negative available balance,
+// largest overdraft first.
+func (r *Repository) ListOverdrawnAccounts(ctx context.Context) ([]*Account, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+accountColumns+` FROM accounts WHERE balance < 0 ORDER BY balance, id
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("list overdrawn accounts: %w", err)
+	}
+	defer rows.Close()
+	res := make([]*Account, 0)
+	for rows.Next() {
+		a, err := scanAccount(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan row: %w", err)
+		}
+		res = append(res, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list overdrawn accounts: %w", err)
+	}
+	return res, nil
+}
@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/parasagrawal71/bank-settlement-system/shared/money"
+)
+
+const EntryRefund = "REFUND"
+
+var ErrRefundExceedsCapture = errors.New("refund amount exceeds the captured amount not yet refunded")
+
+// Refund is the outcome of refunding part of a captured reservation.
+type Refund struct {
+	RefundID    string
+	Amount      money.Money // returned to the payer
+	PayeeAmount money.Money // taken back from the payee
+	Refunded    money.Money // refunded so far, including this refund
+	Legs        []Leg       // what was taken back from each payee of a split reservation
+}
+
+// refundPostings is the reverse of transferPostings on the available buckets:
+// payeeAmount is taken from the payee and amount credited to the payer.
+func refundPostings(payerID, payeeID string, amount, payeeAmount money.Money) []Posting {
+	if amount.Currency == payeeAmount.Currency {
+		return []Posting{
+			{AccountID: payeeID, Bucket: BucketAvailable, Direction: Debit, Amount: payeeAmount},
+			{AccountID: payerID, Bucket: BucketAvailable, Direction: Credit, Amount: amount},
+		}
+	}
+	return []Posting{
+		{AccountID: payeeID, Bucket: BucketAvailable, Direction: Debit, Amount: payeeAmount},
+		{AccountID: FXAccountID(payeeAmount.Currency), Bucket: BucketAvailable, Direction: Credit, Amount: payeeAmount},
+		{AccountID: FXAccountID(amount.Currency), Bucket: BucketAvailable, Direction: Debit, Amount: amount},
+		{AccountID: payerID, Bucket: BucketAvailable, Direction: Credit, Amount: amount},
+	}
+}
+
+// Refund moves amount (payer currency) of what was captured on a reservation back
+// from the payee to the payer. Cross-currency refunds use the rate of the
+// original quote. refundID makes the call idempotent: repeating it returns the
+// refund that was already made. The payee may use its overdraft to fund it. A
+// split reservation takes the refund back from every leg in proportion to what
+// the leg has captured and not yet refunded. Funds an escrow reservation still
+// holds in escrow are not refundable here, see RefundEscrow, and neither are
+// funds held for an open dispute.
+func (r *Repository) Refund(ctx context.Context, referenceID, refundID string, amount int64) (*Refund, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var payerID, payeeID string
+	var captured, payeeCaptured, refunded, payeeRefunded money.Money
+	// what is in escrow has not reached the payee and what is disputed is held
+	// from it, so both are left out of the captured amounts
+	err = tx.QueryRow(ctx, `
+		SELECT payer_id, payee_id, currency, COALESCE(payee_currency, currency),
+			captured_amount - escrow_amount - disputed_amount, payee_captured_amount - escrow_payee_amount - disputed_payee_amount,
+			refunded_amount, payee_refunded_amount
+		FROM reservations WHERE reference_id = $1 FOR UPDATE
+	`, referenceID).Scan(&payerID, &payeeID, &captured.Currency, &payeeCaptured.Currency,
+		&captured.Amount, &payeeCaptured.Amount, &refunded.Amount, &payeeRefunded.Amount)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrReservationNotFound
+		}
+		return nil, fmt.Errorf("lock reservation: %w", err)
+	}
+	refunded.Currency = captured.Currency
+	payeeRefunded.Currency = payeeCaptured.Currency
+
+	res := Refund{RefundID: refundID, Amount: money.Money{Currency: captured.Currency},
+		PayeeAmount: money.Money{Currency: payeeCaptured.Currency}, Refunded: refunded}
+	var legsDoc []byte
+	err = tx.QueryRow(ctx, `
+		SELECT amount, payee_amount, legs FROM refunds WHERE refund_id = $1 AND reference_id = $2
+	`, refundID, referenceID).Scan(&res.Amount.Amount, &res.PayeeAmount.Amount, &legsDoc)
+	if err == nil {
+		if res.Legs, err = parseLegsJSON(legsDoc); err != nil {
+			return nil, err
+		}
+		return &res, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("get refund: %w", err)
+	}
+
+	refundable := captured.Amount - refunded.Amount
+	if amount <= 0 || amount > refundable {
+		return nil, fmt.Errorf("%w: refund %d, refundable %d %s", ErrRefundExceedsCapture, amount, refundable, captured.Currency)
+	}
+	res.Amount.Amount = amount
+	// the refund that empties the captured amount gets the rest of the payee side
+	switch {
+	case amount == refundable:
+		res.PayeeAmount.Amount = payeeCaptured.Amount - payeeRefunded.Amount
+	case payeeCaptured.Currency == captured.Currency:
+		res.PayeeAmount.Amount = amount
+	default:
+		share := new(big.Int).Mul(big.NewInt(payeeCaptured.Amount), big.NewInt(amount))
+		res.PayeeAmount.Amount = share.Quo(share, big.NewInt(captured.Amount)).Int64()
+	}
+	res.Refunded.Amount += amount
+
+	legs, err := reservationLegs(ctx, tx, referenceID)
+	if err != nil {
+		return nil, err
+	}
+	postings := refundPostings(payerID, payeeID, res.Amount, res.PayeeAmount)
+	payees := []Leg{{PayeeID: payeeID, Amount: res.PayeeAmount.Amount}}
+	if len(legs) > 0 {
+		res.Legs = refundLegs(legs, amount)
+		postings = splitRefundPostings(payerID, res.Amount, res.Legs)
+		payees = res.Legs
+		if err := addToLegsTx(ctx, tx, referenceID, "refunded_amount", res.Legs); err != nil {
+			return nil, err
+		}
+	}
+	_, err = r.postEntry(ctx, tx, JournalEntry{
+		ReferenceID: referenceID,
+		EntryType:   EntryRefund,
+		Postings:    postings,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// the payee rows are locked by postEntry; refuse refunds beyond their overdraft
+	for _, p := range payees {
+		var payeeBalance money.Money
+		var creditLimit int64
+		err = tx.QueryRow(ctx, `SELECT balance, credit_limit, currency FROM accounts WHERE id = $1`, p.PayeeID).
+			Scan(&payeeBalance.Amount, &creditLimit, &payeeBalance.Currency)
+		if err != nil {
+			return nil, fmt.Errorf("get payee balance: %w", err)
+		}
+		if payeeBalance.Amount+creditLimit < 0 {
+			return nil, fmt.Errorf("%w: payee %s cannot fund refund of %d %s", ErrInsufficientFunds, p.PayeeID, p.Amount,
+				payeeBalance.Currency)
+		}
+	}
+
+	legsDoc, err = legsJSON(res.Legs)
+	if err != nil {
+		return nil, err
+	}
+	_, err = tx.Exec(ctx, `
+		INSERT INTO refunds (refund_id, reference_id, amount, currency, payee_amount, payee_currency, legs)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`, refundID, referenceID, res.Amount.Amount, res.Amount.Currency, res.PayeeAmount.Amount, res.PayeeAmount.Currency, legsDoc)
+	if err != nil {
+		return nil, fmt.Errorf("insert refund: %w", err)
+	}
+	_, err = tx.Exec(ctx, `
+		UPDATE reservations SET refunded_amount = refunded_amount + $2, payee_refunded_amount = payee_refunded_amount + $3,
+			updated_at = now()
+		WHERE reference_id = $1
+	`, referenceID, res.Amount.Amount, res.PayeeAmount.Amount)
+	if err != nil {
+		return nil, fmt.Errorf("update reservation: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit tx: %w", err)
+	}
+	return &res, nil
+}
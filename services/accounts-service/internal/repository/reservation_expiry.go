@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+var ErrReservationExpired = errors.New("reservation has expired")
+
+// ExpireReservations releases up to limit pending holds whose expires_at has
+// passed and marks them EXPIRED. A hold that fails to release does not stop the
+// others; all failures are returned together.
+func (r *Repository) ExpireReservations(ctx context.Context, limit int) (int, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT reference_id FROM reservations
+		WHERE status = 'PENDING' AND expires_at <= now()
+		ORDER BY expires_at
+		LIMIT $1
+	`, limit)
+	if err != nil {
+		return 0, fmt.Errorf("list expired reservations: %w", err)
+	}
+	var refs []string
+	for rows.Next() {
+		var ref string
+		if err := rows.Scan(&ref); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("scan reservation: %w", err)
+		}
+		refs = append(refs, ref)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("list expired reservations: %w", err)
+	}
+
+	released := 0
+	var errs []error
+	for _, ref := range refs {
+		err := r.release(ctx, ref, "EXPIRED")
+		if errors.Is(err, ErrReservationNotPending) {
+			// captured or released since it was listed
+			continue
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("release %s: %w", ref, err))
+			continue
+		}
+		released++
+	}
+	return released, errors.Join(errs...)
+}
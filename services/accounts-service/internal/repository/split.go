@@ -0,0 +1,206 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/parasagrawal71/bank-settlement-system/shared/money"
+)
+
+var ErrInvalidSplit = errors.New("invalid split payment")
+
+// Leg is a payee's share of a split reservation, or what one capture or refund
+// moved for that payee, in minor units of the payer currency. Split
+// reservations are single-currency.
+type Leg struct {
+	PayeeID string `json:"payee_id"`
+	Amount  int64  `json:"amount"`
+}
+
+// reservationLeg is a leg of a split reservation with its running totals.
+type reservationLeg struct {
+	No       int
+	PayeeID  string
+	Amount   int64
+	Captured int64
+	Refunded int64
+}
+
+// checkLegs validates the legs of a new split reservation against its amount.
+func checkLegs(legs []Leg, amount money.Money) error {
+	if len(legs) < 2 {
+		return fmt.Errorf("%w: need at least two legs", ErrInvalidSplit)
+	}
+	var sum int64
+	seen := make(map[string]bool)
+	for _, l := range legs {
+		if l.PayeeID == "" || l.Amount <= 0 {
+			return fmt.Errorf("%w: every leg needs a payee and a positive amount", ErrInvalidSplit)
+		}
+		if seen[l.PayeeID] {
+			return fmt.Errorf("%w: payee %s has more than one leg", ErrInvalidSplit, l.PayeeID)
+		}
+		seen[l.PayeeID] = true
+		var err error
+		if sum, err = money.AddInt64(sum, l.Amount); err != nil {
+			return err
+		}
+	}
+	if sum != amount.Amount {
+		return fmt.Errorf("%w: legs add up to %d, amount is %s", ErrInvalidSplit, sum, amount)
+	}
+	return nil
+}
+
+// insertLegsTx stores the legs of a split reservation.
+func insertLegsTx(ctx context.Context, tx pgx.Tx, referenceID string, legs []Leg) error {
+	for i, l := range legs {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO reservation_legs (reference_id, leg_no, payee_id, amount) VALUES ($1, $2, $3, $4)
+		`, referenceID, i+1, l.PayeeID, l.Amount)
+		if err != nil {
+			return fmt.Errorf("insert reservation leg: %w", err)
+		}
+	}
+	return nil
+}
+
+// reservationLegs returns the legs of a reservation in order, none for a
+// single-payee one. The caller holds the reservation row lock.
+func reservationLegs(ctx context.Context, tx pgx.Tx, referenceID string) ([]reservationLeg, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT leg_no, payee_id, amount, captured_amount, refunded_amount
+		FROM reservation_legs WHERE reference_id = $1 ORDER BY leg_no
+	`, referenceID)
+	if err != nil {
+		return nil, fmt.Errorf("get reservation legs: %w", err)
+	}
+	defer rows.Close()
+	var legs []reservationLeg
+	for rows.Next() {
+		var l reservationLeg
+		if err := rows.Scan(&l.No, &l.PayeeID, &l.Amount, &l.Captured, &l.Refunded); err != nil {
+			return nil, fmt.Errorf("scan reservation leg: %w", err)
+		}
+		legs = append(legs, l)
+	}
+	return legs, rows.Err()
+}
+
+// splitByWeight divides total over weights in proportion, rounding down and
+// handing the minor units lost to rounding out one by one in leg order. No share
+// exceeds its weight, and total equal to the sum of weights gives every leg
+// exactly its weight.
+func splitByWeight(total int64, weights []int64) []int64 {
+	var sum int64
+	for _, w := range weights {
+		sum += w
+	}
+	shares := make([]int64, len(weights))
+	if sum == 0 {
+		return shares
+	}
+	left := total
+	for i, w := range weights {
+		share := new(big.Int).Mul(big.NewInt(total), big.NewInt(w))
+		shares[i] = share.Quo(share, big.NewInt(sum)).Int64()
+		left -= shares[i]
+	}
+	for i := 0; left > 0 && i < len(shares); i++ {
+		if shares[i] < weights[i] {
+			shares[i]++
+			left--
+		}
+	}
+	return shares
+}
+
+// captureLegs splits a capture over the legs by what each has left to capture.
+func captureLegs(legs []reservationLeg, part int64) []Leg {
+	weights := make([]int64, len(legs))
+	for i, l := range legs {
+		weights[i] = l.Amount - l.Captured
+	}
+	return toLegs(legs, splitByWeight(part, weights))
+}
+
+// refundLegs splits a refund over the legs by what each has captured and not
+// yet refunded.
+func refundLegs(legs []reservationLeg, amount int64) []Leg {
+	weights := make([]int64, len(legs))
+	for i, l := range legs {
+		weights[i] = l.Captured - l.Refunded
+	}
+	return toLegs(legs, splitByWeight(amount, weights))
+}
+
+func toLegs(legs []reservationLeg, shares []int64) []Leg {
+	out := make([]Leg, len(legs))
+	for i, l := range legs {
+		out[i] = Leg{PayeeID: l.PayeeID, Amount: shares[i]}
+	}
+	return out
+}
+
+// splitTransferPostings moves part out of the payer's hold and credits every
+// leg its share. Legs with a zero share get no posting.
+func splitTransferPostings(payerID string, part money.Money, legs []Leg) []Posting {
+	postings := []Posting{{AccountID: payerID, Bucket: BucketReserved, Direction: Debit, Amount: part}}
+	for _, l := range legs {
+		if l.Amount > 0 {
+			postings = append(postings, Posting{AccountID: l.PayeeID, Bucket: BucketAvailable, Direction: Credit,
+				Amount: money.Money{Amount: l.Amount, Currency: part.Currency}})
+		}
+	}
+	return postings
+}
+
+// splitRefundPostings takes every leg's share back and credits the payer.
+func splitRefundPostings(payerID string, amount money.Money, legs []Leg) []Posting {
+	var postings []Posting
+	for _, l := range legs {
+		if l.Amount > 0 {
+			postings = append(postings, Posting{AccountID: l.PayeeID, Bucket: BucketAvailable, Direction: Debit,
+				Amount: money.Money{Amount: l.Amount, Currency: amount.Currency}})
+		}
+	}
+	return append(postings, Posting{AccountID: payerID, Bucket: BucketAvailable, Direction: Credit, Amount: amount})
+}
+
+// addToLegsTx adds the shares of a capture or refund to the running totals of
+// the legs; column is captured_amount or refunded_amount.
+func addToLegsTx(ctx context.Context, tx pgx.Tx, referenceID, column string, legs []Leg) error {
+	for i, l := range legs {
+		_, err := tx.Exec(ctx, `
+			UPDATE reservation_legs SET `+column+` = `+column+` + $3 WHERE reference_id = $1 AND leg_no = $2
+		`, referenceID, i+1, l.Amount)
+		if err != nil {
+			return fmt.Errorf("update reservation leg: %w", err)
+		}
+	}
+	return nil
+}
+
+// legsJSON encodes the legs of a capture or refund for storage, nil for a
+// single-payee one.
+func legsJSON(legs []Leg) ([]byte, error) {
+	if len(legs) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(legs)
+}
+
+func parseLegsJSON(raw []byte) ([]Leg, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	var legs []Leg
+	if err := json.Unmarshal(raw, &legs); err != nil {
+		return nil, fmt.Errorf("decode legs: %w", err)
+	}
+	return legs, nil
+}
@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/parasagrawal71/bank-settlement-system/shared/money"
+)
+
+func TestSplitByWeight(t *testing.T) {
+	tests := []struct {
+		name    string
+		total   int64
+		weights []int64
+		want    []int64
+	}{
+		{"exact", 1000, []int64{700, 300}, []int64{700, 300}},
+		{"proportional", 500, []int64{700, 300}, []int64{350, 150}},
+		{"rounding goes to the first legs", 100, []int64{100, 100, 100}, []int64{34, 33, 33}},
+		{"two units left over", 200, []int64{100, 100, 100}, []int64{67, 67, 66}},
+		{"rounding skips zero weights", 1, []int64{0, 1, 1}, []int64{0, 1, 0}},
+		{"zero weight gets nothing", 10, []int64{0, 20}, []int64{0, 10}},
+		{"all weights zero", 10, []int64{0, 0}, []int64{0, 0}},
+		{"nothing to split", 0, []int64{5, 5}, []int64{0, 0}},
+		{"no overflow", math.MaxInt64 / 4 * 2, []int64{math.MaxInt64 / 4, math.MaxInt64 / 4}, []int64{math.MaxInt64 / 4, math.MaxInt64 / 4}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := splitByWeight(tt.total, tt.weights)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("splitByWeight(%d, %v) = %v, want %v", tt.total, tt.weights, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCaptureAndRefundLegs(t *testing.T) {
+	legs := []reservationLeg{
+		{No: 1, PayeeID: "a", Amount: 600, Captured: 300, Refunded: 100},
+		{No: 2, PayeeID: "b", Amount: 300, Captured: 0},
+		{No: 3, PayeeID: "c", Amount: 100, Captured: 100, Refunded: 0},
+	}
+	tests := []struct {
+		name   string
+		split  func([]reservationLeg, int64) []Leg
+		amount int64
+		want   []Leg
+	}{
+		// left to capture: 300, 300, 0
+		{"capture the rest", captureLegs, 600, []Leg{{"a", 300}, {"b", 300}, {"c", 0}}},
+		{"capture part", captureLegs, 101, []Leg{{"a", 51}, {"b", 50}, {"c", 0}}},
+		// left to refund: 200, 0, 100
+		{"refund all", refundLegs, 300, []Leg{{"a", 200}, {"b", 0}, {"c", 100}}},
+		{"refund part", refundLegs, 100, []Leg{{"a", 67}, {"b", 0}, {"c", 33}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.split(legs, tt.amount)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("legs of %d = %v, want %v", tt.amount, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckLegs(t *testing.T) {
+	inr := func(amount int64) money.Money { return money.Money{Amount: amount, Currency: "INR"} }
+	tests := []struct {
+		name   string
+		legs   []Leg
+		amount money.Money
+		err    error
+	}{
+		{"valid", []Leg{{"a", 700}, {"b", 300}}, inr(1000), nil},
+		{"one leg", []Leg{{"a", 1000}}, inr(1000), ErrInvalidSplit},
+		{"short", []Leg{{"a", 700}, {"b", 200}}, inr(1000), ErrInvalidSplit},
+		{"repeated payee", []Leg{{"a", 700}, {"a", 300}}, inr(1000), ErrInvalidSplit},
+		{"zero leg", []Leg{{"a", 1000}, {"b", 0}}, inr(1000), ErrInvalidSplit},
+		{"no payee", []Leg{{"a", 700}, {"", 300}}, inr(1000), ErrInvalidSplit},
+		{"overflowing legs", []Leg{{"a", math.MaxInt64}, {"b", 1}}, inr(1000), money.ErrOverflow},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkLegs(tt.legs, tt.amount)
+			if !errors.Is(err, tt.err) || (tt.err == nil) != (err == nil) {
+				t.Fatalf("checkLegs() = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestSplitPostingsBalance(t *testing.T) {
+	part := money.Money{Amount: 101, Currency: "INR"}
+	legs := []Leg{{"a", 51}, {"b", 50}, {"c", 0}}
+	tests := []struct {
+		name     string
+		postings []Posting
+	}{
+		{"transfer", splitTransferPostings("payer", part, legs)},
+		{"refund", splitRefundPostings("payer", part, legs)},
+	}
+	for _, tt := range tests {
+		if len(tt.postings) != 3 {
+			t.Errorf("%s: %d postings, want one for the payer and one per leg with a share", tt.name, len(tt.postings))
+		}
+		if err := (JournalEntry{EntryType: EntryTransfer, Postings: tt.postings}).Validate(); err != nil {
+			t.Errorf("%s: entry does not balance: %v", tt.name, err)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package repository
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var ErrInvalidPageToken = errors.New("invalid page token")
+
+type StatementEntry struct {
+	EntryID         string
+	EntryType       string
+	ReferenceID     string
+	CounterpartyID  string
+	BalanceChange   int64
+	ReservedChange  int64
+	OverdraftDrawn  int64
+	RunningBalance  int64
+	RunningReserved int64
+	CreatedAt       time.Time
+}
+
+type Statement struct {
+	AccountID       string
+	Currency        string
+	OpeningBalance  int64
+	OpeningReserved int64
+	ClosingBalance  int64
+	ClosingReserved int64
+	Entries         []StatementEntry
+	NextPageToken   string
+}
+
+// statementCursor is the position of the last entry of a page. Entries are
+// ordered by (created_at, entry id).
+type statementCursor struct {
+	CreatedAt time.Time
+	EntryID   string
+}
+
+func (c statementCursor) encode() string {
+	return base64.RawURLEncoding.EncodeToString([]byte(c.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + c.EntryID))
+}
+
+func decodeStatementCursor(token string) (*statementCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+	ts, id, ok := strings.Cut(string(b), "|")
+	if !ok {
+		return nil, ErrInvalidPageToken
+	}
+	createdAt, err := time.Parse(time.RFC3339Nano, ts)
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+	return &statementCursor{CreatedAt: createdAt, EntryID: id}, nil
+}
+
+// signedPosting is the SQL expression for the effect of a posting on its bucket.
+const signedPosting = `CASE WHEN p.direction = 'CREDIT' THEN p.amount ELSE -p.amount END`
+
+// balancesBefore sums the account's postings of all entries ordered before
+// (createdAt, entryID). An empty entryID means strictly before createdAt.
+func (r *Repository) balancesBefore(ctx context.Context, accountID string, createdAt time.Time, entryID string) (balance, reserved int64, err error) {
+	err = r.pool.QueryRow(ctx, `
+		SELECT
+			COALESCE(SUM(CASE WHEN p.bucket = 'AVAILABLE' THEN `+signedPosting+` END), 0)::BIGINT,
+			COALESCE(SUM(CASE WHEN p.bucket = 'RESERVED' THEN `+signedPosting+` END), 0)::BIGINT
+		FROM postings p
+		JOIN journal_entries j ON j.id = p.entry_id
+		WHERE p.account_id = $1
+			AND (j.created_at < $2 OR ($3 <> '' AND j.created_at = $2 AND j.id::TEXT <= $3))
+	`, accountID, createdAt, entryID).Scan(&balance, &reserved)
+	if err != nil {
+		return 0, 0, fmt.Errorf("sum postings: %w", err)
+	}
+	return balance, reserved, nil
+}
+
+// GetStatement returns one page of the account's journal entries in [from, to)
+// with running balances, plus the opening and closing balances of the period.
+func (r *Repository) GetStatement(ctx context.Context, accountID string, from, to time.Time, pageToken string, pageSize int) (*Statement, error) {
+	acct, err := r.GetAccount(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if acct == nil {
+		return nil, ErrAccountNotFound
+	}
+
+	st := Statement{AccountID: accountID, Currency: acct.Balance.Currency}
+	if st.OpeningBalance, st.OpeningReserved, err = r.balancesBefore(ctx, accountID, from, ""); err != nil {
+		return nil, err
+	}
+	if st.ClosingBalance, st.ClosingReserved, err = r.balancesBefore(ctx, accountID, to, ""); err != nil {
+		return nil, err
+	}
+
+	after := statementCursor{CreatedAt: from}
+	runningBalance, runningReserved := st.OpeningBalance, st.OpeningReserved
+	if pageToken != "" {
+		cursor, err := decodeStatementCursor(pageToken)
+		if err != nil {
+			return nil, err
+		}
+		after = *cursor
+		if runningBalance, runningReserved, err = r.balancesBefore(ctx, accountID, cursor.CreatedAt, cursor.EntryID); err != nil {
+			return nil, err
+		}
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT j.id::TEXT, j.entry_type, COALESCE(j.reference_id, ''), j.created_at,
+			COALESCE(SUM(CASE WHEN p.bucket = 'AVAILABLE' THEN `+signedPosting+` END), 0)::BIGINT,
+			COALESCE(SUM(CASE WHEN p.bucket = 'RESERVED' THEN `+signedPosting+` END), 0)::BIGINT,
+			COALESCE(MAX(CASE WHEN res.payer_id = $1 THEN res.payee_id ELSE res.payer_id END), ''),
+			COALESCE(SUM(p.overdraft_amount), 0)::BIGINT
+		FROM postings p
+		JOIN journal_entries j ON j.id = p.entry_id
+		LEFT JOIN reservations res ON res.reference_id = j.reference_id
+		WHERE p.account_id = $1
+			AND j.created_at < $2
+			AND (j.created_at > $3 OR (j.created_at = $3 AND ($4 = '' OR j.id::TEXT > $4)))
+		GROUP BY j.id, j.entry_type, j.reference_id, j.created_at
+		ORDER BY j.created_at, j.id::TEXT
+		LIMIT $5
+	`, accountID, to, after.CreatedAt, after.EntryID, pageSize+1)
+	if err != nil {
+		return nil, fmt.Errorf("list statement entries: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var e StatementEntry
+		if err := rows.Scan(&e.EntryID, &e.EntryType, &e.ReferenceID, &e.CreatedAt,
+			&e.BalanceChange, &e.ReservedChange, &e.CounterpartyID, &e.OverdraftDrawn); err != nil {
+			return nil, fmt.Errorf("scan statement entry: %w", err)
+		}
+		e.EntryType = statementEntryType(e)
+		st.Entries = append(st.Entries, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list statement entries: %w", err)
+	}
+
+	if len(st.Entries) > pageSize {
+		st.Entries = st.Entries[:pageSize]
+		last := st.Entries[len(st.Entries)-1]
+		st.NextPageToken = statementCursor{CreatedAt: last.CreatedAt, EntryID: last.EntryID}.encode()
+	}
+	for i := range st.Entries {
+		runningBalance += st.Entries[i].BalanceChange
+		runningReserved += st.Entries[i].ReservedChange
+		st.Entries[i].RunningBalance = runningBalance
+		st.Entries[i].RunningReserved = runningReserved
+	}
+	return &st, nil
+}
+
+// statementEntryType tells the payer and payee side of a transfer apart.
+func statementEntryType(e StatementEntry) string {
+	if e.EntryType != EntryTransfer {
+		return e.EntryType
+	}
+	if e.BalanceChange > 0 {
+		return "TRANSFER_IN"
+	}
+	return "TRANSFER_OUT"
+}
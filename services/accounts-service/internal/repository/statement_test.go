@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestStatementCursor(t *testing.T) {
+	ist := time.FixedZone("IST", 5*3600+1800)
+	tests := []struct {
+		name   string
+		cursor statementCursor
+	}{
+		{"utc", statementCursor{CreatedAt: time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC), EntryID: "7c9e6679-7425-40de-944b-e07fc1f90ae7"}},
+		{"microseconds", statementCursor{CreatedAt: time.Date(2026, 3, 1, 9, 30, 0, 123456000, time.UTC), EntryID: "e1"}},
+		{"other zone", statementCursor{CreatedAt: time.Date(2026, 3, 1, 15, 0, 0, 0, ist), EntryID: "e2"}},
+		{"separator in id", statementCursor{CreatedAt: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), EntryID: "a|b"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeStatementCursor(tt.cursor.encode())
+			if err != nil {
+				t.Fatalf("decodeStatementCursor() error = %v", err)
+			}
+			if !got.CreatedAt.Equal(tt.cursor.CreatedAt) || got.EntryID != tt.cursor.EntryID {
+				t.Fatalf("decodeStatementCursor(encode(%+v)) = %+v", tt.cursor, got)
+			}
+		})
+	}
+}
+
+func TestDecodeStatementCursorInvalid(t *testing.T) {
+	token := func(s string) string { return base64.RawURLEncoding.EncodeToString([]byte(s)) }
+	for _, tok := range []string{
+		"not base64!",
+		token("2026-03-01T09:30:00Z"),
+		token("yesterday|e1"),
+		base64.StdEncoding.EncodeToString([]byte("2026-03-01T09:30:00Z|e1")),
+	} {
+		if _, err := decodeStatementCursor(tok); !errors.Is(err, ErrInvalidPageToken) {
+			t.Errorf("decodeStatementCursor(%q) = %v, want ErrInvalidPageToken", tok, err)
+		}
+	}
+}
+
+func TestStatementEntryType(t *testing.T) {
+	tests := []struct {
+		entry StatementEntry
+		want  string
+	}{
+		{StatementEntry{EntryType: EntryTransfer, BalanceChange: 500}, "TRANSFER_IN"},
+		{StatementEntry{EntryType: EntryTransfer, BalanceChange: -500}, "TRANSFER_OUT"},
+		{StatementEntry{EntryType: EntryTransfer, ReservedChange: -500}, "TRANSFER_OUT"},
+		{StatementEntry{EntryType: EntryReserve, ReservedChange: 500}, EntryReserve},
+	}
+	for _, tt := range tests {
+		if got := statementEntryType(tt.entry); got != tt.want {
+			t.Errorf("statementEntryType(%+v) = %s, want %s", tt.entry, got, tt.want)
+		}
+	}
+}
@@ -1,18 +1,25 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/parasagrawal71/bank-settlement-system/services/accounts-service/internal/config"
 	"github.com/parasagrawal71/bank-settlement-system/services/accounts-service/internal/handler"
+	"github.com/parasagrawal71/bank-settlement-system/services/accounts-service/internal/repository"
 	pb "github.com/parasagrawal71/bank-settlement-system/services/accounts-service/proto"
+	"github.com/parasagrawal71/bank-settlement-system/shared/auth"
 	"github.com/parasagrawal71/bank-settlement-system/shared/db"
+	"github.com/parasagrawal71/bank-settlement-system/shared/mtls"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 )
 
@@ -31,9 +38,24 @@ func main() {
 	if err != nil {
 		log.Fatalf("listen: %v", err)
 	}
-	grpcServer := grpc.NewServer()
+	// every call needs an API key or a JWT whose roles handler.Permissions allows
+	authenticator, err := auth.NewAuthenticator(auth.ConfigFromEnv())
+	if err != nil {
+		log.Fatalf("auth: %v", err)
+	}
+	guard := auth.NewGuard(authenticator, handler.Permissions, auth.Reflection, auth.Health)
+	// TLS, verifying client certificates, when a certificate is configured
+	serverOpts, err := mtls.ServerOptions(cfg.TLS, "accounts-service")
+	if err != nil {
+		log.Fatalf("tls: %v", err)
+	}
+	grpcServer := grpc.NewServer(append(serverOpts, guard.ServerOptions()...)...)
 	pb.RegisterAccountServiceServer(grpcServer,
-		handler.NewAccountHandler(pool))
+		handler.NewAccountHandler(pool, cfg))
+
+	// health checks let payments-service balance over the serving instances
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
 
 	// enable reflection
 	reflection.Register(grpcServer)
@@ -45,12 +67,66 @@ func main() {
 		}
 	}()
 
+	// hourly maintenance: mark idle accounts as dormant, take the daily balance
+	// snapshot used by GetBalanceAsOf and reconcile the projections with the
+	// ledger
+	go func() {
+		repo := repository.NewRepository(pool)
+		ticker := time.NewTicker(time.Hour)
+		defer ticker.Stop()
+		for range ticker.C {
+			ctx := context.Background()
+			if n, err := repo.MarkDormant(ctx, cfg.DormantAfter); err != nil {
+				log.Printf("dormancy sweep: %v", err)
+			} else if n > 0 {
+				log.Printf("marked %d accounts dormant", n)
+			}
+
+			// snapshot the last UTC midnight that is at least an hour old so that
+			// transactions started before it have committed
+			asOf := time.Now().UTC().Add(-time.Hour).Truncate(24 * time.Hour)
+			if n, err := repo.SnapshotBalances(ctx, asOf); err != nil {
+				log.Printf("balance snapshot: %v", err)
+			} else if n > 0 {
+				log.Printf("stored %d balance snapshots as of %s", n, asOf.Format(time.RFC3339))
+			}
+
+			if mismatches, err := repo.ReconcileLedger(ctx); err != nil {
+				log.Printf("ledger reconciliation: %v", err)
+			} else {
+				for _, m := range mismatches {
+					log.Printf("LEDGER MISMATCH: account %s has balance %d/reserved %d, ledger says %d/%d",
+						m.AccountID, m.Balance, m.Reserved, m.LedgerBalance, m.LedgerReserved)
+				}
+			}
+		}
+	}()
+
+	// release holds that were neither captured nor released before they expired
+	go func() {
+		repo := repository.NewRepository(pool)
+		ticker := time.NewTicker(cfg.ReservationSweepInterval)
+		defer ticker.Stop()
+		for range ticker.C {
+			n, err := repo.ExpireReservations(context.Background(), 100)
+			if err != nil {
+				log.Printf("reservation expiry: %v", err)
+			}
+			if n > 0 {
+				log.Printf("released %d expired reservations", n)
+			}
+		}
+	}()
+
 	// graceful shutdown
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, syscall.SIGINT, syscall.SIGTERM)
 	<-stop
 	fmt.Println("shutting down gRPC server...")
 
+	// clients move to the other instances before the connections close
+	healthServer.Shutdown()
+
 	grpcServer.GracefulStop()
 	fmt.Println("done")
 	// close DB done by defer
@@ -0,0 +1,24 @@
+module github.com/parasagrawal71/bank-settlement-system/services/gateway-service
+
+go 1.24.5
+
+require (
+	github.com/parasagrawal71/bank-settlement-system/services/payments-service v0.0.0-00010101000000-000000000000
+	github.com/parasagrawal71/bank-settlement-system/services/settlement-service v0.0.0-00010101000000-000000000000
+	github.com/parasagrawal71/bank-settlement-system/shared v0.0.0-20251010103137-85c822f3a6b7
+	google.golang.org/grpc v1.76.0
+	google.golang.org/protobuf v1.36.10
+)
+
+require (
+	golang.org/x/net v0.42.0 // indirect
+	golang.org/x/sys v0.34.0 // indirect
+	golang.org/x/text v0.27.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20250804133106-a7a43d27e69b // indirect
+)
+
+replace (
+	github.com/parasagrawal71/bank-settlement-system/services/payments-service => ../payments-service
+	github.com/parasagrawal71/bank-settlement-system/services/settlement-service => ../settlement-service
+	github.com/parasagrawal71/bank-settlement-system/shared => ../../shared
+)
@@ -0,0 +1,32 @@
+package config
+
+import (
+	"time"
+
+	"github.com/parasagrawal71/bank-settlement-system/shared/env"
+	"github.com/parasagrawal71/bank-settlement-system/shared/mtls"
+)
+
+type Config struct {
+	HTTPPort string
+	// gRPC addresses the gateway forwards requests to
+	AccountsAddr   string
+	PaymentsAddr   string
+	SettlementAddr string
+	// upper bound on one upstream call
+	RequestTimeout time.Duration
+	// certificate served over HTTPS and presented to the services, and the CA
+	// they are verified against; plain HTTP without a certificate
+	TLS mtls.Config
+}
+
+func Load() *Config {
+	return &Config{
+		HTTPPort:       env.GetEnvString("GATEWAY_HTTP_PORT", "8080"),
+		AccountsAddr:   env.GetEnvString("ACCOUNTS_GRPC_HOST", "") + ":" + env.GetEnvString("ACCOUNTS_GRPC_PORT", ""),
+		PaymentsAddr:   env.GetEnvString("PAYMENTS_GRPC_HOST", "") + ":" + env.GetEnvString("PAYMENTS_GRPC_PORT", ""),
+		SettlementAddr: env.GetEnvString("SETTLEMENT_GRPC_HOST", "") + ":" + env.GetEnvString("SETTLEMENT_GRPC_PORT", ""),
+		RequestTimeout: time.Duration(env.GetEnvInt("GATEWAY_REQUEST_TIMEOUT_SECONDS", 30)) * time.Second,
+		TLS:            mtls.ConfigFromEnv("GATEWAY"),
+	}
+}
@@ -0,0 +1,58 @@
+package gateway
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// HTTPStatusFromCode maps a gRPC status code to the HTTP status the gateway
+// answers with, following google.rpc.Code.
+func HTTPStatusFromCode(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		return 499 // client closed request
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusInternalServerError
+}
+
+// writeError answers with the HTTP status of a gRPC error and a JSON body
+// carrying the gRPC code and message.
+func writeError(w http.ResponseWriter, err error) {
+	st := status.Convert(err)
+	code := HTTPStatusFromCode(st.Code())
+	if code >= http.StatusInternalServerError {
+		log.Printf("gateway: %v", err)
+	}
+	body, _ := json.Marshal(map[string]any{
+		"code":    code,
+		"status":  st.Code().String(),
+		"message": st.Message(),
+	})
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write(body)
+}
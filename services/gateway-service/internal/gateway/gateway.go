@@ -0,0 +1,229 @@
+// Package gateway serves the gRPC APIs of accounts-, payments- and
+// settlement-service as HTTP/JSON, with an OpenAPI document generated from the
+// same route table.
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	pb "github.com/parasagrawal71/bank-settlement-system/services/payments-service/proto"
+	settlementpb "github.com/parasagrawal71/bank-settlement-system/services/settlement-service/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	headerIdempotencyKey = "Idempotency-Key"
+	headerActor          = "X-Actor"
+	headerAPIKey         = "X-Api-Key"
+	headerAuthorization  = "Authorization"
+	idempotencyKeyField  = "idempotency_key"
+	maxBodyBytes         = 1 << 20
+)
+
+var pathWildcard = regexp.MustCompile(`\{([a-z_]+)\}`)
+
+var marshalOptions = protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}
+
+type Gateway struct {
+	mux     *http.ServeMux
+	timeout time.Duration
+}
+
+func New(accounts pb.AccountServiceClient, payments pb.PaymentServiceClient, settlement settlementpb.SettlementServiceClient, timeout time.Duration) (*Gateway, error) {
+	g := &Gateway{mux: http.NewServeMux(), timeout: timeout}
+	table := routes(accounts, payments, settlement)
+	for _, rt := range table {
+		if err := checkRoute(rt); err != nil {
+			return nil, err
+		}
+		g.mux.HandleFunc(rt.method+" "+rt.path, g.serve(rt))
+	}
+
+	doc, err := json.MarshalIndent(openAPI(table), "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal openapi document: %w", err)
+	}
+	g.mux.HandleFunc("GET /openapi.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(doc)
+	})
+	g.mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, status.Errorf(codes.NotFound, "no route for %s %s", r.Method, r.URL.Path))
+	})
+	return g, nil
+}
+
+func (g *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	g.mux.ServeHTTP(w, r)
+}
+
+// checkRoute makes sure every path wildcard names a scalar request field, so a
+// typo in the route table fails at startup instead of on the first request.
+func checkRoute(rt route) error {
+	for _, m := range pathWildcard.FindAllStringSubmatch(rt.path, -1) {
+		fd := rt.request.Fields().ByName(protoreflect.Name(m[1]))
+		if fd == nil || fd.IsList() || fd.IsMap() || fd.Message() != nil {
+			return fmt.Errorf("route %s %s: %s is not a scalar field of %s", rt.method, rt.path, m[1], rt.request.FullName())
+		}
+	}
+	return nil
+}
+
+func (g *Gateway) serve(rt route) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		req, err := decodeRequest(rt, r)
+		if err != nil {
+			writeError(w, status.Error(codes.InvalidArgument, err.Error()))
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), g.timeout)
+		defer cancel()
+		if actor := r.Header.Get(headerActor); actor != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, "x-actor", actor)
+		}
+		// the services authenticate the caller, not the gateway
+		if key := r.Header.Get(headerAPIKey); key != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, "x-api-key", key)
+		}
+		if authz := r.Header.Get(headerAuthorization); authz != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, "authorization", authz)
+		}
+
+		resp, err := rt.call(ctx, req)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		body, err := marshalOptions.Marshal(resp)
+		if err != nil {
+			writeError(w, status.Errorf(codes.Internal, "marshal response: %v", err))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(body)
+	}
+}
+
+// decodeRequest builds the request message of rt from the body, the query
+// string, the path and the Idempotency-Key header, in that order, so values in
+// the path and header win over the same field in the body.
+func decodeRequest(rt route, r *http.Request) (proto.Message, error) {
+	req := rt.newRequest()
+	msg := req.ProtoReflect()
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxBodyBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("read body: %v", err)
+	}
+	if len(body) > maxBodyBytes {
+		return nil, fmt.Errorf("body larger than %d bytes", maxBodyBytes)
+	}
+	if len(strings.TrimSpace(string(body))) > 0 {
+		if err := protojson.Unmarshal(body, req); err != nil {
+			return nil, fmt.Errorf("body: %v", err)
+		}
+	}
+
+	for name, values := range r.URL.Query() {
+		fd := msg.Descriptor().Fields().ByName(protoreflect.Name(name))
+		if fd == nil {
+			fd = msg.Descriptor().Fields().ByJSONName(name)
+		}
+		if fd == nil || fd.IsMap() || fd.Message() != nil {
+			return nil, fmt.Errorf("unknown query parameter %q", name)
+		}
+		if !fd.IsList() && len(values) > 1 {
+			return nil, fmt.Errorf("query parameter %q given more than once", name)
+		}
+		for _, v := range values {
+			if err := setField(msg, fd, v); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, m := range pathWildcard.FindAllStringSubmatch(rt.path, -1) {
+		fd := msg.Descriptor().Fields().ByName(protoreflect.Name(m[1]))
+		if err := setField(msg, fd, r.PathValue(m[1])); err != nil {
+			return nil, err
+		}
+	}
+
+	if key := r.Header.Get(headerIdempotencyKey); key != "" {
+		fd := msg.Descriptor().Fields().ByName(idempotencyKeyField)
+		if fd == nil {
+			return nil, fmt.Errorf("%s is not supported by %s", headerIdempotencyKey, rt.operation)
+		}
+		msg.Set(fd, protoreflect.ValueOfString(key))
+	}
+	return req, nil
+}
+
+// setField parses a path or query string value into a scalar or repeated
+// scalar field; a repeated field gets the value appended.
+func setField(msg protoreflect.Message, fd protoreflect.FieldDescriptor, s string) error {
+	v, err := parseScalar(fd, s)
+	if err != nil {
+		return fmt.Errorf("%s: %v", fd.Name(), err)
+	}
+	if fd.IsList() {
+		msg.Mutable(fd).List().Append(v)
+		return nil
+	}
+	msg.Set(fd, v)
+	return nil
+}
+
+func parseScalar(fd protoreflect.FieldDescriptor, s string) (protoreflect.Value, error) {
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(s), nil
+	case protoreflect.BytesKind:
+		return protoreflect.ValueOfBytes([]byte(s)), nil
+	case protoreflect.BoolKind:
+		b, err := strconv.ParseBool(s)
+		return protoreflect.ValueOfBool(b), err
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		n, err := strconv.ParseInt(s, 10, 32)
+		return protoreflect.ValueOfInt32(int32(n)), err
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		n, err := strconv.ParseInt(s, 10, 64)
+		return protoreflect.ValueOfInt64(n), err
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		n, err := strconv.ParseUint(s, 10, 32)
+		return protoreflect.ValueOfUint32(uint32(n)), err
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		n, err := strconv.ParseUint(s, 10, 64)
+		return protoreflect.ValueOfUint64(n), err
+	case protoreflect.FloatKind:
+		f, err := strconv.ParseFloat(s, 32)
+		return protoreflect.ValueOfFloat32(float32(f)), err
+	case protoreflect.DoubleKind:
+		f, err := strconv.ParseFloat(s, 64)
+		return protoreflect.ValueOfFloat64(f), err
+	case protoreflect.EnumKind:
+		if ev := fd.Enum().Values().ByName(protoreflect.Name(s)); ev != nil {
+			return protoreflect.ValueOfEnum(ev.Number()), nil
+		}
+		n, err := strconv.ParseInt(s, 10, 32)
+		if err != nil {
+			return protoreflect.Value{}, fmt.Errorf("unknown value %q", s)
+		}
+		return protoreflect.ValueOfEnum(protoreflect.EnumNumber(n)), nil
+	}
+	return protoreflect.Value{}, fmt.Errorf("unsupported field kind %s", fd.Kind())
+}
@@ -0,0 +1,277 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	pb "github.com/parasagrawal71/bank-settlement-system/services/payments-service/proto"
+	settlementpb "github.com/parasagrawal71/bank-settlement-system/services/settlement-service/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+type accountsServer struct {
+	pb.UnimplementedAccountServiceServer
+}
+
+func (accountsServer) GetAccount(ctx context.Context, req *pb.GetAccountRequest) (*pb.AccountResponse, error) {
+	return &pb.AccountResponse{AccountId: req.AccountId, Name: "Asha"}, nil
+}
+
+type paymentsServer struct {
+	pb.UnimplementedPaymentServiceServer
+}
+
+type settlementServer struct {
+	settlementpb.UnimplementedSettlementServiceServer
+}
+
+// backend is a fake of the three services that records the last call it got
+// until the test takes it.
+type backend struct {
+	mu     sync.Mutex
+	method string
+	md     metadata.MD
+}
+
+func (b *backend) intercept(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	b.mu.Lock()
+	b.method, b.md = info.FullMethod, md
+	b.mu.Unlock()
+	return handler(ctx, req)
+}
+
+func (b *backend) last() (method string, md metadata.MD) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	method, md = b.method, b.md
+	b.method, b.md = "", nil
+	return method, md
+}
+
+func newTestGateway(t *testing.T) (*Gateway, *backend) {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := &backend{}
+	srv := grpc.NewServer(grpc.UnaryInterceptor(b.intercept))
+	pb.RegisterAccountServiceServer(srv, accountsServer{})
+	pb.RegisterPaymentServiceServer(srv, paymentsServer{})
+	settlementpb.RegisterSettlementServiceServer(srv, settlementServer{})
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	g, err := New(pb.NewAccountServiceClient(conn), pb.NewPaymentServiceClient(conn), settlementpb.NewSettlementServiceClient(conn), 5*time.Second)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return g, b
+}
+
+func TestHTTPStatusFromCode(t *testing.T) {
+	tests := []struct {
+		code codes.Code
+		want int
+	}{
+		{codes.OK, http.StatusOK},
+		{codes.Canceled, 499},
+		{codes.InvalidArgument, http.StatusBadRequest},
+		{codes.FailedPrecondition, http.StatusBadRequest},
+		{codes.OutOfRange, http.StatusBadRequest},
+		{codes.DeadlineExceeded, http.StatusGatewayTimeout},
+		{codes.NotFound, http.StatusNotFound},
+		{codes.AlreadyExists, http.StatusConflict},
+		{codes.Aborted, http.StatusConflict},
+		{codes.PermissionDenied, http.StatusForbidden},
+		{codes.Unauthenticated, http.StatusUnauthorized},
+		{codes.ResourceExhausted, http.StatusTooManyRequests},
+		{codes.Unimplemented, http.StatusNotImplemented},
+		{codes.Unavailable, http.StatusServiceUnavailable},
+		{codes.Internal, http.StatusInternalServerError},
+		{codes.Unknown, http.StatusInternalServerError},
+		{codes.DataLoss, http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		if got := HTTPStatusFromCode(tt.code); got != tt.want {
+			t.Errorf("HTTPStatusFromCode(%s) = %d, want %d", tt.code, got, tt.want)
+		}
+	}
+}
+
+func TestDecodeRequest(t *testing.T) {
+	// decodeRequest never calls the RPC, so the clients need no connection
+	payments, accounts := pb.NewPaymentServiceClient(nil), pb.NewAccountServiceClient(nil)
+	capture := rpc("POST", "/v1/payment_intents/{reference_id}/capture", "CapturePayment", payments.CapturePayment)
+	list := rpc("GET", "/v1/payment_intents", "ListPayments", payments.ListPayments)
+	get := rpc("GET", "/v1/accounts/{account_id}", "GetAccount", accounts.GetAccount)
+
+	tests := []struct {
+		name   string
+		rt     route
+		target string
+		body   string
+		key    string
+		want   proto.Message
+		err    string
+	}{
+		{"body only", capture, "/v1/payment_intents/ref-1/capture", `{"amount": 500, "final": true}`, "",
+			&pb.CapturePaymentRequest{ReferenceId: "ref-1", Amount: 500, Final: true}, ""},
+		{"path wins over body", capture, "/v1/payment_intents/ref-1/capture", `{"reference_id": "ref-2", "amount": 500}`, "",
+			&pb.CapturePaymentRequest{ReferenceId: "ref-1", Amount: 500}, ""},
+		{"header wins over body", capture, "/v1/payment_intents/ref-1/capture", `{"amount": 500, "idempotency_key": "body-key"}`, "header-key",
+			&pb.CapturePaymentRequest{ReferenceId: "ref-1", Amount: 500, IdempotencyKey: "header-key"}, ""},
+		{"query wins over body", capture, "/v1/payment_intents/ref-1/capture?amount=700", `{"amount": 500}`, "",
+			&pb.CapturePaymentRequest{ReferenceId: "ref-1", Amount: 700}, ""},
+		{"path wins over query", capture, "/v1/payment_intents/ref-1/capture?reference_id=ref-2", "", "",
+			&pb.CapturePaymentRequest{ReferenceId: "ref-1"}, ""},
+		{"query by proto and json name", list, "/v1/payment_intents?payer_id=acc-1&pageSize=20&status=CAPTURED", "", "",
+			&pb.ListPaymentsRequest{PayerId: "acc-1", PageSize: 20, Status: pb.PaymentStatus_CAPTURED}, ""},
+		{"enum by number", list, "/v1/payment_intents?status=2", "", "",
+			&pb.ListPaymentsRequest{Status: pb.PaymentStatus_CAPTURED}, ""},
+		{"unknown query parameter", list, "/v1/payment_intents?colour=red", "", "", nil, `unknown query parameter "colour"`},
+		{"repeated scalar query", list, "/v1/payment_intents?payer_id=a&payer_id=b", "", "", nil, `query parameter "payer_id" given more than once`},
+		{"bad number", list, "/v1/payment_intents?page_size=many", "", "", nil, "page_size"},
+		{"bad enum", list, "/v1/payment_intents?status=LOST", "", "", nil, `unknown value "LOST"`},
+		{"bad body", capture, "/v1/payment_intents/ref-1/capture", `{"amount": "lots"}`, "", nil, "body"},
+		{"key on a read", get, "/v1/accounts/acc-1", "", "k", nil, "Idempotency-Key is not supported by GetAccount"},
+		{"body too large", capture, "/v1/payment_intents/ref-1/capture", `{"amount": 1}` + strings.Repeat(" ", maxBodyBytes), "", nil, "body larger than"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got proto.Message
+			var err error
+			mux := http.NewServeMux()
+			mux.HandleFunc(tt.rt.method+" "+tt.rt.path, func(w http.ResponseWriter, r *http.Request) {
+				got, err = decodeRequest(tt.rt, r)
+			})
+			r := httptest.NewRequest(tt.rt.method, tt.target, strings.NewReader(tt.body))
+			if tt.key != "" {
+				r.Header.Set(headerIdempotencyKey, tt.key)
+			}
+			mux.ServeHTTP(httptest.NewRecorder(), r)
+
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("decodeRequest() error = %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("decodeRequest() error = %v", err)
+			}
+			if !proto.Equal(got, tt.want) {
+				t.Fatalf("decodeRequest() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRoutes(t *testing.T) {
+	g, b := newTestGateway(t)
+	tests := []struct {
+		method string
+		target string
+		rpc    string
+	}{
+		{"POST", "/v1/accounts", "/accounts.AccountService/CreateAccount"},
+		{"GET", "/v1/accounts", "/accounts.AccountService/ListAccounts"},
+		{"GET", "/v1/accounts/overdrawn", "/accounts.AccountService/ListOverdrawnAccounts"},
+		{"GET", "/v1/accounts/acc-1", "/accounts.AccountService/GetAccount"},
+		{"POST", "/v1/accounts/acc-1/freeze", "/accounts.AccountService/FreezeAccount"},
+		{"GET", "/v1/accounts/acc-1/statement", "/accounts.AccountService/GetAccountStatement"},
+		{"POST", "/v1/fx_quotes", "/accounts.AccountService/GetQuote"},
+		{"POST", "/v1/payment_intents", "/payments.PaymentService/CreatePaymentIntent"},
+		{"GET", "/v1/payment_intents", "/payments.PaymentService/ListPayments"},
+		{"GET", "/v1/payment_intents/ref-1", "/payments.PaymentService/GetPayment"},
+		{"POST", "/v1/payment_intents/ref-1/capture", "/payments.PaymentService/CapturePayment"},
+		{"POST", "/v1/payment_intents/ref-1/refund", "/payments.PaymentService/RefundPayment"},
+		{"POST", "/v1/webhook_deliveries/7/replay", "/payments.PaymentService/ReplayWebhookDelivery"},
+		{"GET", "/v1/settlements/ref-1", "/settlement.SettlementService/GetSettlementStatus"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.target, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			g.ServeHTTP(w, httptest.NewRequest(tt.method, tt.target, nil))
+			if method, _ := b.last(); method != tt.rpc {
+				t.Fatalf("%s %s called %s, want %s", tt.method, tt.target, method, tt.rpc)
+			}
+		})
+	}
+
+	w := httptest.NewRecorder()
+	g.ServeHTTP(w, httptest.NewRequest("DELETE", "/v1/accounts/acc-1", nil))
+	if w.Code != http.StatusNotFound && w.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("DELETE /v1/accounts/acc-1 = %d, want 404 or 405", w.Code)
+	}
+}
+
+func TestServeForwardsCaller(t *testing.T) {
+	g, b := newTestGateway(t)
+	r := httptest.NewRequest("GET", "/v1/accounts/acc-1", nil)
+	r.Header.Set(headerActor, "ops@example")
+	r.Header.Set(headerAPIKey, "shop-key")
+	r.Header.Set(headerAuthorization, "Bearer x.y.z")
+	w := httptest.NewRecorder()
+	g.ServeHTTP(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("GET /v1/accounts/acc-1 = %d %s", w.Code, w.Body)
+	}
+	var resp map[string]any
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil || resp["account_id"] != "acc-1" || resp["name"] != "Asha" {
+		t.Fatalf("GET /v1/accounts/acc-1 body = %s, %v", w.Body, err)
+	}
+	_, md := b.last()
+	for key, want := range map[string]string{"x-actor": "ops@example", "x-api-key": "shop-key", "authorization": "Bearer x.y.z"} {
+		if got := md.Get(key); len(got) != 1 || got[0] != want {
+			t.Errorf("metadata %s = %v, want %q", key, got, want)
+		}
+	}
+}
+
+func TestServeError(t *testing.T) {
+	g, _ := newTestGateway(t)
+	tests := []struct {
+		method string
+		target string
+		code   int
+		status string
+	}{
+		{"GET", "/v1/payment_intents/ref-1", http.StatusNotImplemented, "Unimplemented"},
+		{"GET", "/v1/payment_intents?colour=red", http.StatusBadRequest, "InvalidArgument"},
+		{"GET", "/v1/nowhere", http.StatusNotFound, "NotFound"},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		g.ServeHTTP(w, httptest.NewRequest(tt.method, tt.target, nil))
+		var body struct {
+			Code   int    `json:"code"`
+			Status string `json:"status"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+			t.Fatalf("%s %s body %s: %v", tt.method, tt.target, w.Body, err)
+		}
+		if w.Code != tt.code || body.Code != tt.code || body.Status != tt.status {
+			t.Errorf("%s %s = %d %+v, want %d %s", tt.method, tt.target, w.Code, body, tt.code, tt.status)
+		}
+	}
+}
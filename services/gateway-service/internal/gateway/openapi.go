@@ -0,0 +1,181 @@
+package gateway
+
+import (
+	"net/http"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// openAPI builds an OpenAPI 3 document of the route table. Schemas come from
+// the proto descriptors and follow the protojson encoding the gateway uses, so
+// 64-bit integers are strings and enums are their value names.
+func openAPI(table []route) map[string]any {
+	schemas := map[string]any{
+		"Error": map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"code":    map[string]any{"type": "integer", "description": "HTTP status"},
+				"status":  map[string]any{"type": "string", "description": "gRPC status code name"},
+				"message": map[string]any{"type": "string"},
+			},
+		},
+	}
+	paths := map[string]map[string]any{}
+
+	for _, rt := range table {
+		addMessageSchema(schemas, rt.request)
+		addMessageSchema(schemas, rt.response)
+
+		var params []any
+		inPath := map[string]bool{}
+		for _, m := range pathWildcard.FindAllStringSubmatch(rt.path, -1) {
+			inPath[m[1]] = true
+			params = append(params, map[string]any{
+				"name":     m[1],
+				"in":       "path",
+				"required": true,
+				"schema":   fieldSchema(rt.request.Fields().ByName(protoreflect.Name(m[1]))),
+			})
+		}
+		hasIdempotencyKey := rt.request.Fields().ByName(idempotencyKeyField) != nil
+		if hasIdempotencyKey {
+			params = append(params, map[string]any{
+				"name":        headerIdempotencyKey,
+				"in":          "header",
+				"description": "makes the request safe to retry; sets idempotency_key",
+				"schema":      map[string]any{"type": "string"},
+			})
+		}
+
+		op := map[string]any{
+			"operationId": rt.operation,
+			"tags":        []string{string(rt.request.ParentFile().Package())},
+			"responses": map[string]any{
+				"200": map[string]any{
+					"description": "OK",
+					"content":     jsonContent(rt.response),
+				},
+				"default": map[string]any{
+					"description": "gRPC error",
+					"content": map[string]any{
+						"application/json": map[string]any{"schema": ref("Error")},
+					},
+				},
+			},
+		}
+		if rt.method == http.MethodGet {
+			fields := rt.request.Fields()
+			for i := 0; i < fields.Len(); i++ {
+				fd := fields.Get(i)
+				if inPath[string(fd.Name())] || fd.IsMap() || fd.Message() != nil {
+					continue
+				}
+				params = append(params, map[string]any{
+					"name":   string(fd.Name()),
+					"in":     "query",
+					"schema": fieldSchema(fd),
+				})
+			}
+		} else {
+			op["requestBody"] = map[string]any{"content": jsonContent(rt.request)}
+		}
+		if len(params) > 0 {
+			op["parameters"] = params
+		}
+
+		if paths[rt.path] == nil {
+			paths[rt.path] = map[string]any{}
+		}
+		paths[rt.path][strings.ToLower(rt.method)] = op
+	}
+
+	return map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":   "Bank Settlement System API",
+			"version": "v1",
+		},
+		"paths": paths,
+		"components": map[string]any{
+			"schemas": schemas,
+			"securitySchemes": map[string]any{
+				"apiKey": map[string]any{"type": "apiKey", "in": "header", "name": headerAPIKey},
+				"bearer": map[string]any{"type": "http", "scheme": "bearer", "bearerFormat": "JWT"},
+			},
+		},
+		"security": []any{
+			map[string]any{"apiKey": []string{}},
+			map[string]any{"bearer": []string{}},
+		},
+	}
+}
+
+func ref(name string) map[string]any {
+	return map[string]any{"$ref": "#/components/schemas/" + name}
+}
+
+func jsonContent(md protoreflect.MessageDescriptor) map[string]any {
+	return map[string]any{"application/json": map[string]any{"schema": ref(string(md.FullName()))}}
+}
+
+func addMessageSchema(schemas map[string]any, md protoreflect.MessageDescriptor) {
+	name := string(md.FullName())
+	if _, ok := schemas[name]; ok {
+		return
+	}
+	props := map[string]any{}
+	schemas[name] = map[string]any{"type": "object", "properties": props}
+
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		props[string(fd.Name())] = fieldSchema(fd)
+		if fd.Message() != nil {
+			addMessageSchema(schemas, fd.Message())
+		}
+		if fd.Enum() != nil {
+			addEnumSchema(schemas, fd.Enum())
+		}
+	}
+}
+
+func addEnumSchema(schemas map[string]any, ed protoreflect.EnumDescriptor) {
+	var names []string
+	values := ed.Values()
+	for i := 0; i < values.Len(); i++ {
+		names = append(names, string(values.Get(i).Name()))
+	}
+	schemas[string(ed.FullName())] = map[string]any{"type": "string", "enum": names}
+}
+
+func fieldSchema(fd protoreflect.FieldDescriptor) map[string]any {
+	var s map[string]any
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		s = map[string]any{"type": "boolean"}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		s = map[string]any{"type": "integer", "format": "int32"}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		s = map[string]any{"type": "integer", "format": "int64", "minimum": 0}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		s = map[string]any{"type": "string", "format": "int64"}
+	case protoreflect.FloatKind:
+		s = map[string]any{"type": "number", "format": "float"}
+	case protoreflect.DoubleKind:
+		s = map[string]any{"type": "number", "format": "double"}
+	case protoreflect.BytesKind:
+		s = map[string]any{"type": "string", "format": "byte"}
+	case protoreflect.EnumKind:
+		s = ref(string(fd.Enum().FullName()))
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		s = ref(string(fd.Message().FullName()))
+	default:
+		s = map[string]any{"type": "string"}
+	}
+	if fd.IsList() {
+		return map[string]any{"type": "array", "items": s}
+	}
+	return s
+}
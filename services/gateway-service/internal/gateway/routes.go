@@ -0,0 +1,88 @@
+package gateway
+
+import (
+	"context"
+
+	pb "github.com/parasagrawal71/bank-settlement-system/services/payments-service/proto"
+	settlementpb "github.com/parasagrawal71/bank-settlement-system/services/settlement-service/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// A route maps an HTTP method and path onto one RPC. Path wildcards are named
+// after the request fields they fill; the other fields come from the JSON body,
+// or from the query string of a GET.
+type route struct {
+	method     string
+	path       string
+	operation  string // RPC name, also the OpenAPI operationId
+	request    protoreflect.MessageDescriptor
+	response   protoreflect.MessageDescriptor
+	newRequest func() proto.Message
+	call       func(ctx context.Context, req proto.Message) (proto.Message, error)
+}
+
+func rpc[Req, Resp proto.Message](method, path, operation string, call func(context.Context, Req, ...grpc.CallOption) (Resp, error)) route {
+	var req Req
+	var resp Resp
+	return route{
+		method:    method,
+		path:      path,
+		operation: operation,
+		request:   req.ProtoReflect().Descriptor(),
+		response:  resp.ProtoReflect().Descriptor(),
+		newRequest: func() proto.Message {
+			return req.ProtoReflect().New().Interface()
+		},
+		call: func(ctx context.Context, m proto.Message) (proto.Message, error) {
+			return call(ctx, m.(Req))
+		},
+	}
+}
+
+// routes lists the public API. The accounts-service RPCs that only
+// payments-service calls (ReserveFunds, Transfer, ReleaseFunds, Refund,
+// ReleaseEscrow, RefundEscrow, HoldDispute, CloseDisputeHold) are left out.
+func routes(accounts pb.AccountServiceClient, payments pb.PaymentServiceClient, settlement settlementpb.SettlementServiceClient) []route {
+	return []route{
+		rpc("POST", "/v1/accounts", "CreateAccount", accounts.CreateAccount),
+		rpc("GET", "/v1/accounts", "ListAccounts", accounts.ListAccounts),
+		rpc("GET", "/v1/accounts/overdrawn", "ListOverdrawnAccounts", accounts.ListOverdrawnAccounts),
+		rpc("GET", "/v1/accounts/{account_id}", "GetAccount", accounts.GetAccount),
+		rpc("POST", "/v1/accounts/{account_id}/balance", "UpdateBalance", accounts.UpdateBalance),
+		rpc("POST", "/v1/accounts/{account_id}/freeze", "FreezeAccount", accounts.FreezeAccount),
+		rpc("POST", "/v1/accounts/{account_id}/unfreeze", "UnfreezeAccount", accounts.UnfreezeAccount),
+		rpc("POST", "/v1/accounts/{account_id}/close", "CloseAccount", accounts.CloseAccount),
+		rpc("POST", "/v1/accounts/{account_id}/credit_limit", "SetCreditLimit", accounts.SetCreditLimit),
+		rpc("GET", "/v1/accounts/{account_id}/statement", "GetAccountStatement", accounts.GetAccountStatement),
+		rpc("GET", "/v1/accounts/{account_id}/balance_as_of", "GetBalanceAsOf", accounts.GetBalanceAsOf),
+		rpc("POST", "/v1/fx_rates", "SetRate", accounts.SetRate),
+		rpc("POST", "/v1/fx_quotes", "GetQuote", accounts.GetQuote),
+
+		rpc("POST", "/v1/payment_intents", "CreatePaymentIntent", payments.CreatePaymentIntent),
+		rpc("GET", "/v1/payment_intents", "ListPayments", payments.ListPayments),
+		rpc("GET", "/v1/payment_intents/{reference_id}", "GetPayment", payments.GetPayment),
+		rpc("POST", "/v1/payment_intents/{reference_id}/capture", "CapturePayment", payments.CapturePayment),
+		rpc("POST", "/v1/payment_intents/{reference_id}/refund", "RefundPayment", payments.RefundPayment),
+		rpc("POST", "/v1/payment_intents/{reference_id}/cancel", "CancelPaymentIntent", payments.CancelPaymentIntent),
+		rpc("POST", "/v1/payment_intents/{reference_id}/review", "ReviewPaymentIntent", payments.ReviewPaymentIntent),
+		rpc("GET", "/v1/risk_evaluations", "ListRiskEvaluations", payments.ListRiskEvaluations),
+		rpc("POST", "/v1/payment_intents/{reference_id}/escrow/release", "ReleaseEscrow", payments.ReleaseEscrow),
+		rpc("POST", "/v1/payment_intents/{reference_id}/escrow/refund", "RefundEscrow", payments.RefundEscrow),
+		rpc("POST", "/v1/payment_intents/{reference_id}/disputes", "OpenDispute", payments.OpenDispute),
+		rpc("GET", "/v1/disputes/{dispute_id}", "GetDispute", payments.GetDispute),
+		rpc("POST", "/v1/disputes/{dispute_id}/evidence", "SubmitDisputeEvidence", payments.SubmitDisputeEvidence),
+		rpc("POST", "/v1/disputes/{dispute_id}/resolve", "ResolveDispute", payments.ResolveDispute),
+		rpc("POST", "/v1/webhook_endpoints", "RegisterWebhookEndpoint", payments.RegisterWebhookEndpoint),
+		rpc("GET", "/v1/webhook_endpoints", "ListWebhookEndpoints", payments.ListWebhookEndpoints),
+		rpc("POST", "/v1/webhook_endpoints/{endpoint_id}/disable", "DisableWebhookEndpoint", payments.DisableWebhookEndpoint),
+		rpc("GET", "/v1/webhook_deliveries", "ListWebhookDeliveries", payments.ListWebhookDeliveries),
+		rpc("GET", "/v1/webhook_deliveries/{delivery_id}", "GetWebhookDelivery", payments.GetWebhookDelivery),
+		rpc("POST", "/v1/webhook_deliveries/{delivery_id}/replay", "ReplayWebhookDelivery", payments.ReplayWebhookDelivery),
+		rpc("POST", "/v1/payout_batches", "SubmitPayoutBatch", payments.SubmitPayoutBatch),
+		rpc("GET", "/v1/payout_batches/{batch_id}", "GetPayoutBatch", payments.GetPayoutBatch),
+
+		rpc("GET", "/v1/settlements/{reference_id}", "GetSettlementStatus", settlement.GetSettlementStatus),
+	}
+}
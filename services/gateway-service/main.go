@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/parasagrawal71/bank-settlement-system/services/gateway-service/internal/config"
+	"github.com/parasagrawal71/bank-settlement-system/services/gateway-service/internal/gateway"
+	pb "github.com/parasagrawal71/bank-settlement-system/services/payments-service/proto"
+	settlementpb "github.com/parasagrawal71/bank-settlement-system/services/settlement-service/proto"
+	"github.com/parasagrawal71/bank-settlement-system/shared/mtls"
+	"google.golang.org/grpc"
+)
+
+func dial(name, addr string, tlsConfig mtls.Config) *grpc.ClientConn {
+	transport, err := mtls.DialOption(tlsConfig, addr)
+	if err != nil {
+		log.Fatalf("tls: %v", err)
+	}
+	conn, err := grpc.NewClient(addr, transport)
+	if err != nil {
+		log.Fatalf("failed to connect to %s: %v", name, err)
+	}
+	return conn
+}
+
+func main() {
+	// Load config
+	cfg := config.Load()
+
+	accountsConn := dial("accounts-service", cfg.AccountsAddr, cfg.TLS)
+	defer accountsConn.Close()
+	paymentsConn := dial("payments-service", cfg.PaymentsAddr, cfg.TLS)
+	defer paymentsConn.Close()
+	settlementConn := dial("settlement-service", cfg.SettlementAddr, cfg.TLS)
+	defer settlementConn.Close()
+
+	gw, err := gateway.New(
+		pb.NewAccountServiceClient(accountsConn),
+		pb.NewPaymentServiceClient(paymentsConn),
+		settlementpb.NewSettlementServiceClient(settlementConn),
+		cfg.RequestTimeout,
+	)
+	if err != nil {
+		log.Fatalf("gateway: %v", err)
+	}
+
+	srv := &http.Server{
+		Addr:              "0.0.0.0:" + cfg.HTTPPort,
+		Handler:           gw,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	// HTTPS when a certificate is configured, verifying client certificates
+	// as GATEWAY_TLS_CLIENT_AUTH asks
+	if cfg.TLS.CertFile != "" {
+		src, err := mtls.NewSource(cfg.TLS)
+		if err != nil {
+			log.Fatalf("tls: %v", err)
+		}
+		if srv.TLSConfig, err = src.ServerConfig("h2", "http/1.1"); err != nil {
+			log.Fatalf("tls: %v", err)
+		}
+	}
+	go func() {
+		var err error
+		if srv.TLSConfig != nil {
+			fmt.Printf("gateway HTTPS listening on %s\n", cfg.HTTPPort)
+			err = srv.ListenAndServeTLS("", "")
+		} else {
+			fmt.Printf("gateway HTTP listening on %s\n", cfg.HTTPPort)
+			err = srv.ListenAndServe()
+		}
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalf("http serve: %v", err)
+		}
+	}()
+
+	// graceful shutdown
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, syscall.SIGINT, syscall.SIGTERM)
+	<-stop
+	fmt.Println("shutting down HTTP server...")
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.RequestTimeout)
+	defer cancel()
+	if err := srv.Shutdown(ctx); err != nil {
+		log.Printf("http shutdown: %v", err)
+	}
+	fmt.Println("done")
+}
@@ -1,45 +1,208 @@
+// Package client is payments-service's client of accounts-service. Every call
+// gets a deadline, calls that are safe to repeat are retried with jittered
+// backoff, a circuit breaker fails calls fast while accounts-service is down,
+// and calls are spread over the healthy accounts-service instances. Outcomes
+// are exported as expvar metrics.
 package client
 
 import (
 	"context"
-	"log"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"time"
 
 	accountpb "github.com/parasagrawal71/bank-settlement-system/services/payments-service/proto"
+	"github.com/parasagrawal71/bank-settlement-system/shared/auth"
+	"github.com/parasagrawal71/bank-settlement-system/shared/mtls"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	_ "google.golang.org/grpc/health" // client-side health checking
+	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/resolver/manual"
+	"google.golang.org/grpc/status"
 )
 
+type Config struct {
+	// host:port of every accounts-service instance. A single address is
+	// resolved through DNS, so a name with several records is balanced too.
+	Addrs  []string
+	APIKey string
+	TLS    mtls.Config
+	// deadline of one attempt, unless Timeouts has one for the method; 0 for
+	// none besides the caller's
+	Timeout  time.Duration
+	Timeouts map[string]time.Duration // by method name, e.g. "Transfer"
+	// attempts of a call that is safe to repeat, the first one included;
+	// retries wait BackoffBase doubling up to BackoffMax, jittered
+	MaxAttempts int
+	BackoffBase time.Duration
+	BackoffMax  time.Duration
+	// the breaker opens after BreakerFailures failed calls in a row and lets a
+	// trial call through after BreakerCooldown
+	BreakerFailures int
+	BreakerCooldown time.Duration
+}
+
+// serviceName is the authority of the connection, which TLS checks the
+// certificates of accounts-service against when several addresses are given.
+const serviceName = "accounts-service"
+
+// round robin over the instances whose health service reports SERVING
+const serviceConfig = `{"loadBalancingConfig": [{"round_robin": {}}], "healthCheckConfig": {"serviceName": ""}}`
+
+// AccountsClient is an accounts-service client whose calls go through the
+// deadlines, retries and circuit breaker of its Config.
 type AccountsClient struct {
-	conn   *grpc.ClientConn
-	Client accountpb.AccountServiceClient
+	accountpb.AccountServiceClient
+	cfg     Config
+	conn    *grpc.ClientConn
+	breaker *breaker
 }
 
-func NewAccountsClient(addr string) *AccountsClient {
-	conn, err := grpc.Dial(addr, grpc.WithInsecure())
+func NewAccountsClient(cfg Config) (*AccountsClient, error) {
+	if len(cfg.Addrs) == 0 {
+		return nil, errors.New("no accounts-service address")
+	}
+	if cfg.MaxAttempts < 1 {
+		cfg.MaxAttempts = 1
+	}
+	c := &AccountsClient{cfg: cfg, breaker: newBreaker(cfg.BreakerFailures, cfg.BreakerCooldown)}
+
+	transport, err := mtls.DialOption(cfg.TLS, strings.Join(cfg.Addrs, ","))
 	if err != nil {
-		log.Fatalf("Failed to connect to accounts-service: %v", err)
+		return nil, err
 	}
+	opts := []grpc.DialOption{
+		transport,
+		grpc.WithPerRPCCredentials(auth.APIKey(cfg.APIKey)),
+		grpc.WithDefaultServiceConfig(serviceConfig),
+		grpc.WithChainUnaryInterceptor(c.intercept),
+	}
+	target := "dns:///" + cfg.Addrs[0]
+	if len(cfg.Addrs) > 1 {
+		r := manual.NewBuilderWithScheme("accounts")
+		var addrs []resolver.Address
+		for _, a := range cfg.Addrs {
+			addrs = append(addrs, resolver.Address{Addr: a})
+		}
+		r.InitialState(resolver.State{Addresses: addrs})
+		target = "accounts:///" + serviceName
+		opts = append(opts, grpc.WithResolvers(r))
+	}
+	c.conn, err = grpc.NewClient(target, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("connect to accounts-service: %w", err)
+	}
+	c.AccountServiceClient = accountpb.NewAccountServiceClient(c.conn)
+	publishBreaker(c.breaker)
+	return c, nil
+}
 
-	client := accountpb.NewAccountServiceClient(conn)
-	log.Println("Connected to accounts-service at", addr)
+func (c *AccountsClient) Close() error {
+	return c.conn.Close()
+}
 
-	return &AccountsClient{
-		conn:   conn,
-		Client: client,
+func (c *AccountsClient) intercept(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	name := method[strings.LastIndex(method, "/")+1:]
+	timeout := c.cfg.Timeout
+	if t, ok := c.cfg.Timeouts[name]; ok {
+		timeout = t
 	}
+	attempts := 1
+	if safeToRetry(req) {
+		attempts = c.cfg.MaxAttempts
+	}
+
+	start := time.Now()
+	var err error
+	for attempt := 1; ; attempt++ {
+		if !c.breaker.allow() {
+			err = errCircuitOpen
+			break
+		}
+		attemptCtx, cancel := withTimeout(ctx, timeout)
+		err = invoker(attemptCtx, method, req, reply, cc, opts...)
+		cancel()
+		c.breaker.record(breakerResult(ctx, err))
+
+		if err == nil || attempt >= attempts || !retryable(ctx, err) {
+			break
+		}
+		recordRetry(name)
+		if !sleep(ctx, c.backoff(attempt)) {
+			break
+		}
+	}
+	recordCall(name, err, time.Since(start))
+	return err
+}
+
+func withTimeout(ctx context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	if d <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, d)
 }
 
-func (c *AccountsClient) ReserveFunds(ctx context.Context, reference_id string, payer_id string, payee_id string, amount float64) (*accountpb.ReserveResponse, error) {
-	return c.Client.ReserveFunds(ctx, &accountpb.ReserveRequest{ReferenceId: reference_id, PayerId: payer_id, PayeeId: payee_id, Amount: amount})
+// backoff is the wait before retry n, between half and all of
+// BackoffBase*2^(n-1), capped at BackoffMax.
+func (c *AccountsClient) backoff(n int) time.Duration {
+	d := c.cfg.BackoffBase << (n - 1)
+	if d <= 0 || d > c.cfg.BackoffMax {
+		d = c.cfg.BackoffMax
+	}
+	if d <= 0 {
+		return 0
+	}
+	return d/2 + rand.N(d/2+1)
 }
 
-func (c *AccountsClient) Transfer(ctx context.Context, reference_id string) (*accountpb.TransferResponse, error) {
-	return c.Client.Transfer(ctx, &accountpb.TransferRequest{ReferenceId: reference_id})
+func sleep(ctx context.Context, d time.Duration) bool {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
 }
 
-func (c *AccountsClient) ReleaseFunds(ctx context.Context, reference_id string) (*accountpb.ReleaseResponse, error) {
-	return c.Client.ReleaseFunds(ctx, &accountpb.ReleaseRequest{ReferenceId: reference_id})
+// safeToRetry reports whether repeating a request cannot move money twice or
+// turn a success into a failure: reads, and writes keyed by an id that
+// accounts-service deduplicates. A repeated ReserveFunds gets back the hold
+// the first one made.
+func safeToRetry(req any) bool {
+	switch r := req.(type) {
+	case *accountpb.GetAccountRequest, *accountpb.GetQuoteRequest, *accountpb.ListAccountsRequest,
+		*accountpb.GetAccountStatementRequest, *accountpb.GetBalanceAsOfRequest, *accountpb.ListOverdrawnAccountsRequest,
+		*accountpb.ReleaseRequest, *accountpb.CloseDisputeHoldRequest:
+		return true
+	case *accountpb.ReserveRequest:
+		return r.ReferenceId != ""
+	case *accountpb.TransferRequest:
+		return r.CaptureId != ""
+	case *accountpb.RefundRequest:
+		return r.RefundId != ""
+	case *accountpb.EscrowMovementRequest:
+		return r.MovementId != ""
+	case *accountpb.DisputeHoldRequest:
+		return r.DisputeId != ""
+	}
+	return false
 }
 
-func (c *AccountsClient) Close() {
-	c.conn.Close()
+// retryable reports whether a failed attempt may have been lost on the way:
+// accounts-service was unreachable, or the attempt ran out of time while the
+// caller still has some.
+func retryable(ctx context.Context, err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable:
+		return true
+	case codes.DeadlineExceeded:
+		return ctx.Err() == nil
+	}
+	return false
 }
@@ -0,0 +1,129 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var errCircuitOpen = status.Error(codes.Unavailable, "accounts-service circuit breaker is open")
+
+type breakerState int
+
+const (
+	breakerClosed breakerState = iota
+	breakerOpen
+	breakerHalfOpen // one trial call decides whether to close or open again
+)
+
+func (s breakerState) String() string {
+	switch s {
+	case breakerOpen:
+		return "OPEN"
+	case breakerHalfOpen:
+		return "HALF_OPEN"
+	}
+	return "CLOSED"
+}
+
+type result int
+
+const (
+	resultSuccess result = iota
+	resultFailure
+	resultIgnored // the caller gave up; says nothing about accounts-service
+)
+
+// breakerResult classifies a call for the breaker. Only errors that say
+// accounts-service is unreachable or broken count as failures; business errors
+// such as NotFound come from a working service, and so does Unknown, which is
+// what a handler returning a plain error produces.
+func breakerResult(ctx context.Context, err error) result {
+	switch status.Code(err) {
+	case codes.OK:
+		return resultSuccess
+	case codes.Canceled:
+		return resultIgnored
+	case codes.DeadlineExceeded:
+		if ctx.Err() != nil {
+			return resultIgnored
+		}
+		return resultFailure
+	case codes.Unavailable, codes.Internal:
+		return resultFailure
+	}
+	return resultSuccess
+}
+
+// breaker fails calls fast once accounts-service has failed failures calls in
+// a row, until cooldown has passed and a trial call succeeds. A threshold of 0
+// never opens it.
+type breaker struct {
+	failures int
+	cooldown time.Duration
+
+	mu       sync.Mutex
+	state    breakerState
+	failed   int // in a row
+	openedAt time.Time
+	probing  bool // a trial call is in flight
+	opened   int64
+}
+
+func newBreaker(failures int, cooldown time.Duration) *breaker {
+	return &breaker{failures: failures, cooldown: cooldown}
+}
+
+// allow reports whether a call may go ahead.
+func (b *breaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.state {
+	case breakerOpen:
+		if time.Since(b.openedAt) < b.cooldown {
+			return false
+		}
+		b.state = breakerHalfOpen
+		b.probing = true
+		return true
+	case breakerHalfOpen:
+		if b.probing {
+			return false
+		}
+		b.probing = true
+		return true
+	}
+	return true
+}
+
+func (b *breaker) record(r result) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch r {
+	case resultSuccess:
+		b.state = breakerClosed
+		b.failed = 0
+		b.probing = false
+	case resultFailure:
+		b.failed++
+		if b.state == breakerHalfOpen || (b.failures > 0 && b.failed >= b.failures) {
+			if b.state != breakerOpen {
+				b.opened++
+			}
+			b.state = breakerOpen
+			b.openedAt = time.Now()
+		}
+		b.probing = false
+	case resultIgnored:
+		b.probing = false
+	}
+}
+
+func (b *breaker) snapshot() (breakerState, int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state, b.opened
+}
@@ -0,0 +1,107 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestBreakerResult(t *testing.T) {
+	live := context.Background()
+	done, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		err  error
+		want result
+	}{
+		{"ok", live, nil, resultSuccess},
+		{"unavailable", live, status.Error(codes.Unavailable, "down"), resultFailure},
+		{"internal", live, status.Error(codes.Internal, "broken"), resultFailure},
+		{"attempt timed out", live, status.Error(codes.DeadlineExceeded, "slow"), resultFailure},
+		{"caller timed out", done, status.Error(codes.DeadlineExceeded, "slow"), resultIgnored},
+		{"canceled", live, status.Error(codes.Canceled, "gone"), resultIgnored},
+		{"unknown", live, errors.New("plain handler error"), resultSuccess},
+		{"not found", live, status.Error(codes.NotFound, "no account"), resultSuccess},
+		{"invalid argument", live, status.Error(codes.InvalidArgument, "bad amount"), resultSuccess},
+		{"failed precondition", live, status.Error(codes.FailedPrecondition, "frozen"), resultSuccess},
+		{"permission denied", live, status.Error(codes.PermissionDenied, "no"), resultSuccess},
+	}
+	for _, tt := range tests {
+		if got := breakerResult(tt.ctx, tt.err); got != tt.want {
+			t.Errorf("%s: breakerResult() = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBreakerOpensAfterFailures(t *testing.T) {
+	b := newBreaker(3, time.Hour)
+	for i := 0; i < 2; i++ {
+		b.record(resultFailure)
+	}
+	b.record(resultSuccess)
+	for i := 0; i < 2; i++ {
+		b.record(resultFailure)
+	}
+	if state, _ := b.snapshot(); state != breakerClosed || !b.allow() {
+		t.Fatalf("breaker %s after failures broken by a success, want CLOSED", state)
+	}
+	b.record(resultIgnored)
+	b.record(resultFailure)
+	if state, opened := b.snapshot(); state != breakerOpen || opened != 1 {
+		t.Fatalf("breaker %s opened %d times after 3 failures in a row, want OPEN once", state, opened)
+	}
+	if b.allow() {
+		t.Fatal("open breaker allowed a call before its cooldown")
+	}
+}
+
+func TestBreakerHalfOpen(t *testing.T) {
+	tests := []struct {
+		name   string
+		trial  result
+		state  breakerState
+		opened int64
+	}{
+		{"trial succeeds", resultSuccess, breakerClosed, 1},
+		{"trial fails", resultFailure, breakerOpen, 2},
+		{"trial abandoned", resultIgnored, breakerHalfOpen, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newBreaker(1, time.Millisecond)
+			b.record(resultFailure)
+			time.Sleep(2 * time.Millisecond)
+
+			if !b.allow() {
+				t.Fatal("breaker refused the trial call after its cooldown")
+			}
+			if b.allow() {
+				t.Fatal("breaker allowed a second call while the trial is in flight")
+			}
+			b.record(tt.trial)
+			if state, opened := b.snapshot(); state != tt.state || opened != tt.opened {
+				t.Fatalf("breaker %s opened %d times, want %s opened %d times", state, opened, tt.state, tt.opened)
+			}
+			if tt.state == breakerHalfOpen && !b.allow() {
+				t.Fatal("breaker refused a new trial after an abandoned one")
+			}
+		})
+	}
+}
+
+func TestBreakerDisabled(t *testing.T) {
+	b := newBreaker(0, time.Hour)
+	for i := 0; i < 100; i++ {
+		b.record(resultFailure)
+	}
+	if state, _ := b.snapshot(); state != breakerClosed || !b.allow() {
+		t.Fatalf("breaker without a threshold is %s, want CLOSED", state)
+	}
+}
@@ -0,0 +1,45 @@
+package client
+
+import (
+	"expvar"
+	"time"
+
+	"google.golang.org/grpc/status"
+)
+
+// stats is served with the other expvar variables at /debug/vars:
+//
+//	"accounts_client": {
+//	  "calls.Transfer.OK": 120, "calls.Transfer.Unavailable": 2,
+//	  "calls.GetQuote.CircuitOpen": 5, "retries.Transfer": 3,
+//	  "latency_ms.Transfer": 5310, "breaker_state": "CLOSED", "breaker_opened": 1
+//	}
+//
+// calls.<method>.<outcome> counts calls by their final outcome, the gRPC code
+// or CircuitOpen when the breaker refused them, and latency_ms.<method> adds
+// up their duration, retries included.
+var stats = expvar.NewMap("accounts_client")
+
+func recordCall(method string, err error, took time.Duration) {
+	outcome := status.Code(err).String()
+	if err == errCircuitOpen {
+		outcome = "CircuitOpen"
+	}
+	stats.Add("calls."+method+"."+outcome, 1)
+	stats.Add("latency_ms."+method, took.Milliseconds())
+}
+
+func recordRetry(method string) {
+	stats.Add("retries."+method, 1)
+}
+
+func publishBreaker(b *breaker) {
+	stats.Set("breaker_state", expvar.Func(func() any {
+		state, _ := b.snapshot()
+		return state.String()
+	}))
+	stats.Set("breaker_opened", expvar.Func(func() any {
+		_, opened := b.snapshot()
+		return opened
+	}))
+}
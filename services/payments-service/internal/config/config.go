@@ -2,13 +2,58 @@ package config
 
 import (
 	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/parasagrawal71/bank-settlement-system/services/payments-service/internal/client"
 	"github.com/parasagrawal71/bank-settlement-system/shared/env"
+	"github.com/parasagrawal71/bank-settlement-system/shared/mtls"
 )
 
 type Config struct {
 	DBUrl    string
 	GRPCPort string
+	// how often authorized intents past their hold expiry are marked EXPIRED
+	IntentExpirySweepInterval time.Duration
+	// responses of requests with an idempotency key are kept for IdempotencyKeyTTL
+	IdempotencyKeyTTL time.Duration
+	// a key claimed by a request that has not finished is freed after IdempotencyLockTimeout
+	IdempotencyLockTimeout time.Duration
+	// how long a duplicate request waits for the one holding its key
+	IdempotencyWait            time.Duration
+	IdempotencyCleanupInterval time.Duration
+	// captures that have not moved for CaptureRecoveryAfter are completed or
+	// failed by the recovery worker, which runs every CaptureRecoveryInterval
+	CaptureRecoveryAfter    time.Duration
+	CaptureRecoveryInterval time.Duration
+	// webhook deliveries are retried with exponential backoff from
+	// WebhookBackoffBase up to WebhookBackoffMax until WebhookMaxAge has passed
+	WebhookPollInterval time.Duration
+	WebhookTimeout      time.Duration
+	WebhookBackoffBase  time.Duration
+	WebhookBackoffMax   time.Duration
+	WebhookMaxAge       time.Duration
+	// rows of submitted payout files are paid every PayoutPollInterval,
+	// PayoutConcurrency at a time
+	PayoutMaxRows      int
+	PayoutConcurrency  int
+	PayoutPollInterval time.Duration
+	// how often escrows past their scheduled release are released
+	EscrowReleaseInterval time.Duration
+	// risk rules evaluated before funds are reserved, from a YAML or JSON file
+	// that is reloaded when it changes; no file allows every payment
+	RiskRulesFile           string
+	RiskRulesReloadInterval time.Duration
+	// how payments-service reaches accounts-service
+	Accounts client.Config
+	// expvar metrics, including those of the accounts-service client, are
+	// served at /debug/vars on MetricsPort; empty to not serve them
+	MetricsPort string
+	// certificate served to clients and presented to accounts-service, the CA
+	// both sides are verified against and the peer allow-list
+	TLS mtls.Config
 }
 
 type DBConfig struct {
@@ -36,5 +81,90 @@ func Load() *Config {
 	db := fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=%s", dbConfig.DBUser, dbConfig.DBPassword, dbConfig.DBHost, dbConfig.DBPort, dbConfig.DBName, dbConfig.SSLMode)
 
 	port := env.GetEnvString("PAYMENTS_GRPC_PORT", "")
-	return &Config{DBUrl: db, GRPCPort: port}
+	sweepInterval := time.Duration(env.GetEnvInt("INTENT_EXPIRY_SWEEP_INTERVAL_SECONDS", 30)) * time.Second
+	keyTTL := time.Duration(env.GetEnvInt("IDEMPOTENCY_KEY_TTL_SECONDS", 24*3600)) * time.Second
+	lockTimeout := time.Duration(env.GetEnvInt("IDEMPOTENCY_LOCK_TIMEOUT_SECONDS", 60)) * time.Second
+	wait := time.Duration(env.GetEnvInt("IDEMPOTENCY_WAIT_MS", 2000)) * time.Millisecond
+	cleanupInterval := time.Duration(env.GetEnvInt("IDEMPOTENCY_CLEANUP_INTERVAL_SECONDS", 3600)) * time.Second
+	recoveryAfter := time.Duration(env.GetEnvInt("CAPTURE_RECOVERY_AFTER_SECONDS", 60)) * time.Second
+	recoveryInterval := time.Duration(env.GetEnvInt("CAPTURE_RECOVERY_INTERVAL_SECONDS", 30)) * time.Second
+	webhookPoll := time.Duration(env.GetEnvInt("WEBHOOK_POLL_INTERVAL_SECONDS", 5)) * time.Second
+	webhookTimeout := time.Duration(env.GetEnvInt("WEBHOOK_TIMEOUT_SECONDS", 10)) * time.Second
+	webhookBackoffBase := time.Duration(env.GetEnvInt("WEBHOOK_BACKOFF_BASE_SECONDS", 30)) * time.Second
+	webhookBackoffMax := time.Duration(env.GetEnvInt("WEBHOOK_BACKOFF_MAX_SECONDS", 3600)) * time.Second
+	webhookMaxAge := time.Duration(env.GetEnvInt("WEBHOOK_MAX_AGE_SECONDS", 3*24*3600)) * time.Second
+	payoutPoll := time.Duration(env.GetEnvInt("PAYOUT_POLL_INTERVAL_SECONDS", 2)) * time.Second
+	escrowRelease := time.Duration(env.GetEnvInt("ESCROW_RELEASE_INTERVAL_SECONDS", 30)) * time.Second
+	riskReload := time.Duration(env.GetEnvInt("RISK_RULES_RELOAD_INTERVAL_SECONDS", 10)) * time.Second
+	cfg := &Config{
+		DBUrl:                      db,
+		GRPCPort:                   port,
+		IntentExpirySweepInterval:  sweepInterval,
+		IdempotencyKeyTTL:          keyTTL,
+		IdempotencyLockTimeout:     lockTimeout,
+		IdempotencyWait:            wait,
+		IdempotencyCleanupInterval: cleanupInterval,
+		CaptureRecoveryAfter:       recoveryAfter,
+		CaptureRecoveryInterval:    recoveryInterval,
+		WebhookPollInterval:        webhookPoll,
+		WebhookTimeout:             webhookTimeout,
+		WebhookBackoffBase:         webhookBackoffBase,
+		WebhookBackoffMax:          webhookBackoffMax,
+		WebhookMaxAge:              webhookMaxAge,
+		PayoutMaxRows:              env.GetEnvInt("PAYOUT_MAX_ROWS", 10000),
+		PayoutConcurrency:          env.GetEnvInt("PAYOUT_CONCURRENCY", 8),
+		PayoutPollInterval:         payoutPoll,
+		EscrowReleaseInterval:      escrowRelease,
+		RiskRulesFile:              env.GetEnvString("RISK_RULES_FILE", ""),
+		RiskRulesReloadInterval:    riskReload,
+		TLS:                        mtls.ConfigFromEnv("PAYMENTS"),
+		MetricsPort:                env.GetEnvString("PAYMENTS_METRICS_PORT", ""),
+	}
+	cfg.Accounts = client.Config{
+		Addrs:           accountsAddrs(),
+		APIKey:          env.GetEnvString("ACCOUNTS_API_KEY", ""),
+		TLS:             cfg.TLS,
+		Timeout:         time.Duration(env.GetEnvInt("ACCOUNTS_RPC_TIMEOUT_MS", 5000)) * time.Millisecond,
+		Timeouts:        methodTimeouts(env.GetEnvString("ACCOUNTS_RPC_METHOD_TIMEOUTS_MS", "")),
+		MaxAttempts:     env.GetEnvInt("ACCOUNTS_RPC_MAX_ATTEMPTS", 3),
+		BackoffBase:     time.Duration(env.GetEnvInt("ACCOUNTS_RPC_BACKOFF_BASE_MS", 100)) * time.Millisecond,
+		BackoffMax:      time.Duration(env.GetEnvInt("ACCOUNTS_RPC_BACKOFF_MAX_MS", 2000)) * time.Millisecond,
+		BreakerFailures: env.GetEnvInt("ACCOUNTS_BREAKER_FAILURES", 5),
+		BreakerCooldown: time.Duration(env.GetEnvInt("ACCOUNTS_BREAKER_COOLDOWN_SECONDS", 10)) * time.Second,
+	}
+	return cfg
+}
+
+// accountsAddrs returns the comma-separated ACCOUNTS_GRPC_ADDRS, or
+// ACCOUNTS_GRPC_HOST:ACCOUNTS_GRPC_PORT.
+func accountsAddrs() []string {
+	var addrs []string
+	for _, a := range strings.Split(env.GetEnvString("ACCOUNTS_GRPC_ADDRS", ""), ",") {
+		if a = strings.TrimSpace(a); a != "" {
+			addrs = append(addrs, a)
+		}
+	}
+	if len(addrs) == 0 {
+		addrs = []string{env.GetEnvString("ACCOUNTS_GRPC_HOST", "") + ":" + env.GetEnvString("ACCOUNTS_GRPC_PORT", "")}
+	}
+	return addrs
+}
+
+// methodTimeouts parses "Transfer=10000,GetQuote=1000", deadlines in
+// milliseconds by method. Malformed entries are logged and skipped.
+func methodTimeouts(s string) map[string]time.Duration {
+	timeouts := map[string]time.Duration{}
+	for _, entry := range strings.Split(s, ",") {
+		if entry = strings.TrimSpace(entry); entry == "" {
+			continue
+		}
+		method, ms, ok := strings.Cut(entry, "=")
+		n, err := strconv.Atoi(ms)
+		if !ok || err != nil || n < 0 {
+			log.Printf("config: ignoring ACCOUNTS_RPC_METHOD_TIMEOUTS_MS entry %q", entry)
+			continue
+		}
+		timeouts[method] = time.Duration(n) * time.Millisecond
+	}
+	return timeouts
 }
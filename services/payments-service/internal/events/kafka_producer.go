@@ -7,6 +7,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/parasagrawal71/bank-settlement-system/shared/money"
 	"github.com/segmentio/kafka-go"
 )
 
@@ -16,11 +17,19 @@ type Producer struct {
 }
 
 type PaymentEvent struct {
-	ReferenceID string  `json:"reference_id"`
-	PayeeId     string  `json:"payer_id"`
-	PayerId     string  `json:"payee_id"`
-	Amount      float64 `json:"amount"`
-	Timestamp   int64   `json:"timestamp"`
+	EventType   string      `json:"event_type"` // PAYMENT_AUTHORIZED, PAYMENT_CAPTURED, PAYMENT_ESCROWED, ESCROW_RELEASED, ESCROW_REFUNDED, PAYMENT_REFUNDED, PAYMENT_CANCELED, DISPUTE_OPENED, DISPUTE_EVIDENCE_SUBMITTED, DISPUTE_WON, DISPUTE_LOST or PAYMENT_SETTLED
+	ReferenceID string      `json:"reference_id"`
+	CaptureID   string      `json:"capture_id,omitempty"` // one event per capture of the intent
+	RefundID    string      `json:"refund_id,omitempty"`
+	EscrowID    string      `json:"escrow_id,omitempty"` // release or refund of funds held in escrow
+	DisputeID   string      `json:"dispute_id,omitempty"`
+	Leg         int         `json:"leg,omitempty"` // leg of a split payment; its events carry the leg's payee and share
+	Reason      string      `json:"reason,omitempty"`
+	PayerId     string      `json:"payer_id"`
+	PayeeId     string      `json:"payee_id"`
+	Amount      money.Money `json:"amount"`
+	PayeeAmount money.Money `json:"payee_amount"` // differs from Amount for cross-currency payments
+	Timestamp   int64       `json:"timestamp"`
 }
 
 func NewProducer(brokers []string, topic string) *Producer {
@@ -64,6 +64,8 @@ func (p *OutboxPublisher) processBatch(ctx context.Context) {
 			_ = p.repo.MarkAsFailed(ctx, tx, e.ID)
 			continue
 		}
+		// consumers tell captures and refunds apart by the type of the outbox row
+		ev.EventType = e.EventType
 
 		// check retry count
 		if e.RetryCount >= 3 {
@@ -0,0 +1,93 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/parasagrawal71/bank-settlement-system/services/payments-service/internal/repository"
+	"github.com/parasagrawal71/bank-settlement-system/services/payments-service/internal/webhooks"
+	"github.com/segmentio/kafka-go"
+)
+
+// SettlementConsumer turns PAYMENT_SETTLED events from the settlements topic
+// into webhooks to the payee.
+type SettlementConsumer struct {
+	reader        *kafka.Reader
+	pool          *pgxpool.Pool
+	webhookRepo   *repository.WebhookRepo
+	webhookMaxAge time.Duration
+}
+
+func NewSettlementConsumer(brokers []string, topic, groupID string, pool *pgxpool.Pool, webhookMaxAge time.Duration) *SettlementConsumer {
+	r := kafka.NewReader(kafka.ReaderConfig{
+		Brokers: brokers,
+		GroupID: groupID,
+		Topic:   topic,
+	})
+	return &SettlementConsumer{reader: r, pool: pool, webhookRepo: repository.NewWebhookRepository(pool), webhookMaxAge: webhookMaxAge}
+}
+
+func (c *SettlementConsumer) Start(ctx context.Context) {
+	log.Println("Settlement event consumer started")
+
+	for {
+		msg, err := c.reader.FetchMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			log.Printf("settlement consumer fetch error: %v", err)
+			continue
+		}
+
+		var ev PaymentEvent
+		if err := json.Unmarshal(msg.Value, &ev); err != nil {
+			log.Printf("invalid settlement event payload: %v", err)
+		} else if ev.EventType == "PAYMENT_SETTLED" {
+			if err := c.enqueue(ctx, ev); err != nil {
+				// left uncommitted, so the event is read again after a restart
+				log.Printf("enqueue settlement webhooks: %v", err)
+				continue
+			}
+		}
+
+		if err := c.reader.CommitMessages(ctx, msg); err != nil {
+			log.Printf("failed to commit message: %v", err)
+		}
+	}
+}
+
+func (c *SettlementConsumer) enqueue(ctx context.Context, ev PaymentEvent) error {
+	// a redelivered event gets the same id, so its webhooks are not sent twice
+	settledID := ev.CaptureID
+	if ev.EscrowID != "" {
+		settledID = ev.EscrowID
+	}
+	if ev.DisputeID != "" {
+		settledID = ev.DisputeID
+	}
+	if settledID == "" {
+		settledID = ev.ReferenceID
+	}
+	if ev.Leg > 0 {
+		settledID += "_" + strconv.Itoa(ev.Leg)
+	}
+	eventID := "evt_settled_" + settledID
+	body, err := webhooks.NewEnvelope(eventID, ev.EventType, ev)
+	if err != nil {
+		return err
+	}
+	tx, err := c.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+	if err := c.webhookRepo.EnqueueTx(ctx, tx, ev.PayeeId, ev.EventType, ev.ReferenceID, eventID, body, c.webhookMaxAge); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,216 @@
+package handler
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/parasagrawal71/bank-settlement-system/services/payments-service/internal/events"
+	"github.com/parasagrawal71/bank-settlement-system/services/payments-service/internal/repository"
+	pb "github.com/parasagrawal71/bank-settlement-system/services/payments-service/proto"
+	"github.com/parasagrawal71/bank-settlement-system/shared/money"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// captureIDFor derives the capture id of a request with an idempotency key, so
+// a retry resumes the capture the first attempt started.
+func captureIDFor(referenceID, idempotencyKey string) string {
+	sum := sha256.Sum256([]byte(referenceID + "|" + idempotencyKey))
+	return hex.EncodeToString(sum[:8])
+}
+
+func captureResponse(c *repository.CaptureSaga) *pb.CapturePaymentResponse {
+	paymentStatus := pb.PaymentStatus_CAPTURED
+	if c.Remaining > 0 {
+		paymentStatus = pb.PaymentStatus_PARTIALLY_CAPTURED
+	}
+	return &pb.CapturePaymentResponse{
+		ReferenceId:     c.ReferenceID,
+		Status:          paymentStatus,
+		Message:         "Payment processed successfully",
+		CaptureId:       c.ID,
+		Amount:          c.Amount.Amount,
+		CapturedAmount:  c.Captured,
+		RemainingAmount: c.Remaining,
+	}
+}
+
+// rejected reports whether a FAILED accounts-service response is a business
+// rejection, such as insufficient funds or a frozen account, after which
+// nothing has moved. accounts-service gives every rejection a reason and
+// returns an error status when the outcome is unknown.
+func rejected(reason string) bool {
+	return reason != ""
+}
+
+// driveCapture moves a capture saga forward from whatever step it is at. A
+// STARTED capture asks accounts-service for the transfer, which is idempotent
+// on the capture id, so it is safe to repeat after a crash or an unknown
+// outcome; only a rejection fails it. A TRANSFERRED capture only needs its
+// local records written.
+func (h *PaymentHandler) driveCapture(ctx context.Context, pi *repository.PaymentIntent, c *repository.CaptureSaga, actor string) (*pb.CapturePaymentResponse, error) {
+	switch c.Step {
+	case repository.CaptureCompleted:
+		return captureResponse(c), nil
+	case repository.CaptureFailed:
+		return &pb.CapturePaymentResponse{ReferenceId: c.ReferenceID, Status: pb.PaymentStatus_FAILED, Message: c.Message, CaptureId: c.ID}, nil
+	case repository.CaptureStarted:
+		transferResp, err := h.accountsClient.Transfer(ctx, &pb.TransferRequest{
+			ReferenceId: c.ReferenceID, Amount: c.Requested, Final: c.Final, CaptureId: c.ID})
+		if err != nil {
+			// the transfer may or may not have happened; the recovery worker finds out
+			return nil, status.Errorf(codes.Unavailable, "capture %s is pending: %v", c.ID, err)
+		}
+		if transferResp.Status != "SUCCESS" && !rejected(transferResp.Reason) {
+			// not a definite answer either; the recovery worker repeats it
+			return nil, status.Errorf(codes.Unavailable, "capture %s is pending: %s", c.ID, transferResp.Message)
+		}
+		if transferResp.Status != "SUCCESS" {
+			// rejected, nothing moved, so failing the saga is all the compensation needed
+			if err := h.repo.FailCapture(ctx, c.ID, transferResp.Message); err != nil {
+				return nil, err
+			}
+			if transferResp.Reason == "RESERVATION_EXPIRED" {
+				if err := h.repo.MarkIntentExpired(ctx, c.ReferenceID); err != nil {
+					return nil, err
+				}
+				return &pb.CapturePaymentResponse{ReferenceId: c.ReferenceID, Status: pb.PaymentStatus_EXPIRED, Message: transferResp.Message, CaptureId: c.ID}, nil
+			}
+			return &pb.CapturePaymentResponse{ReferenceId: c.ReferenceID, Status: pb.PaymentStatus_FAILED, Message: transferResp.Message, CaptureId: c.ID}, nil
+		}
+		c.Amount.Amount = transferResp.Amount
+		c.PayeeAmount.Amount = transferResp.PayeeAmount
+		c.Captured = transferResp.CapturedAmount
+		c.Remaining = transferResp.RemainingAmount
+		c.Legs = fromPayeeLegs(transferResp.Legs)
+		if err := h.repo.MarkCaptureTransferred(ctx, c); err != nil {
+			return nil, err
+		}
+		c.Step = repository.CaptureTransferred
+		fallthrough
+	case repository.CaptureTransferred:
+		if err := h.completeCapture(ctx, pi, c, actor); err != nil {
+			return nil, err
+		}
+		c.Step = repository.CaptureCompleted
+		return captureResponse(c), nil
+	}
+	return nil, fmt.Errorf("capture %s has unknown step %s", c.ID, c.Step)
+}
+
+// completeCapture writes the payments rows, the PAYMENT_CAPTURED event and the
+// intent status of a TRANSFERRED capture in one transaction. A capture of a
+// split intent credits, and emits an event for, every leg with a share in it. A
+// capture of an escrow intent credits the escrow account and is reported as
+// PAYMENT_ESCROWED; the payee is paid when it is released.
+func (h *PaymentHandler) completeCapture(ctx context.Context, pi *repository.PaymentIntent, c *repository.CaptureSaga, actor string) error {
+	tx, err := h.repo.BeginTx(ctx)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	ok, err := h.repo.CompleteCaptureTx(ctx, tx, c.ID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		// completed concurrently
+		return nil
+	}
+
+	if err := h.repo.InsertPaymentTx(ctx, tx, c.ReferenceID, c.ID, pi.PayerID, "DEBIT", c.Amount); err != nil {
+		return err
+	}
+	switch {
+	case pi.Escrow:
+		if err := h.repo.InsertPaymentTx(ctx, tx, c.ReferenceID, c.ID, repository.EscrowAccountID, "CREDIT", c.Amount); err != nil {
+			return err
+		}
+	case len(c.Legs) == 0:
+		if err := h.repo.InsertPaymentTx(ctx, tx, c.ReferenceID, c.ID, pi.PayeeID, "CREDIT", c.PayeeAmount); err != nil {
+			return err
+		}
+	}
+	for _, l := range c.Legs {
+		if l.Amount == 0 {
+			continue
+		}
+		if err := h.repo.InsertPaymentTx(ctx, tx, c.ReferenceID, c.ID, l.PayeeID, "CREDIT", money.Money{Amount: l.Amount, Currency: c.Amount.Currency}); err != nil {
+			return err
+		}
+	}
+
+	eventType := "PAYMENT_CAPTURED"
+	if pi.Escrow {
+		eventType = "PAYMENT_ESCROWED"
+	}
+	paymentEvent := events.PaymentEvent{
+		EventType:   eventType,
+		ReferenceID: c.ReferenceID,
+		CaptureID:   c.ID,
+		PayerId:     pi.PayerID,
+		PayeeId:     pi.PayeeID,
+		Amount:      c.Amount,
+		PayeeAmount: c.PayeeAmount,
+		Timestamp:   time.Now().Unix(),
+	}
+	if err := h.emitLegs(ctx, tx, paymentEvent, c.Legs); err != nil {
+		return err
+	}
+
+	intentStatus := repository.StatusCaptured
+	if c.Remaining > 0 {
+		intentStatus = repository.StatusPartiallyCaptured
+	}
+	if err := h.repo.RecordCaptureTx(ctx, tx, c.ReferenceID, c.Amount.Amount, intentStatus, actor); err != nil {
+		return transitionError(err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
+	return nil
+}
+
+// RecoverCaptures drives captures left half-finished by a crash or a lost
+// accounts-service response to COMPLETED or FAILED. Only captures that have not
+// moved for olderThan are picked up, so requests still in flight are left alone.
+// A capture that cannot be recovered yet does not stop the others; all failures
+// are returned together.
+func (h *PaymentHandler) RecoverCaptures(ctx context.Context, olderThan time.Duration, limit int) (int, error) {
+	stuck, err := h.repo.ListStuckCaptures(ctx, olderThan, limit)
+	if err != nil {
+		return 0, err
+	}
+	recovered := 0
+	var errs []error
+	for _, c := range stuck {
+		err := h.recoverCapture(ctx, c)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("capture %s: %w", c.ID, err))
+			if err := h.repo.TouchCapture(ctx, c.ID, err.Error()); err != nil {
+				errs = append(errs, fmt.Errorf("capture %s: %w", c.ID, err))
+			}
+			continue
+		}
+		recovered++
+	}
+	return recovered, errors.Join(errs...)
+}
+
+func (h *PaymentHandler) recoverCapture(ctx context.Context, c *repository.CaptureSaga) error {
+	pi, err := h.repo.GetIntent(ctx, c.ReferenceID)
+	if err != nil {
+		return err
+	}
+	if pi == nil {
+		return fmt.Errorf("intent %s does not exist", c.ReferenceID)
+	}
+	_, err = h.driveCapture(ctx, pi, c, "system")
+	return err
+}
@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/parasagrawal71/bank-settlement-system/services/payments-service/internal/repository"
+	pb "github.com/parasagrawal71/bank-settlement-system/services/payments-service/proto"
+	"github.com/parasagrawal71/bank-settlement-system/shared/money"
+)
+
+func TestCaptureIDFor(t *testing.T) {
+	id := captureIDFor("ref-1", "key-1")
+	if len(id) != 16 {
+		t.Fatalf("captureIDFor() = %q, want 16 hex characters", id)
+	}
+	tests := []struct {
+		referenceID string
+		key         string
+		same        bool
+	}{
+		{"ref-1", "key-1", true},
+		{"ref-1", "key-2", false},
+		{"ref-2", "key-1", false},
+		{"ref-1key-1", "", false},
+		{"", "ref-1key-1", false},
+	}
+	for _, tt := range tests {
+		if got := captureIDFor(tt.referenceID, tt.key); (got == id) != tt.same {
+			t.Errorf("captureIDFor(%q, %q) = %s, same as captureIDFor(ref-1, key-1) = %s: %v, want %v",
+				tt.referenceID, tt.key, got, id, got == id, tt.same)
+		}
+	}
+}
+
+func TestCaptureResponse(t *testing.T) {
+	tests := []struct {
+		name      string
+		captured  int64
+		remaining int64
+		want      pb.PaymentStatus
+	}{
+		{"first of several", 300, 700, pb.PaymentStatus_PARTIALLY_CAPTURED},
+		{"last one", 1000, 0, pb.PaymentStatus_CAPTURED},
+		{"everything at once", 1000, 0, pb.PaymentStatus_CAPTURED},
+		{"one minor unit left", 999, 1, pb.PaymentStatus_PARTIALLY_CAPTURED},
+	}
+	for _, tt := range tests {
+		c := &repository.CaptureSaga{ID: "cap-1", ReferenceID: "ref-1", Amount: money.Money{Amount: 300, Currency: "INR"},
+			Captured: tt.captured, Remaining: tt.remaining}
+		resp := captureResponse(c)
+		if resp.Status != tt.want || resp.CapturedAmount != tt.captured || resp.RemainingAmount != tt.remaining ||
+			resp.CaptureId != "cap-1" || resp.Amount != 300 {
+			t.Errorf("%s: captureResponse() = %v, want %s with %d captured and %d remaining", tt.name, resp, tt.want, tt.captured, tt.remaining)
+		}
+	}
+}
@@ -0,0 +1,316 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/parasagrawal71/bank-settlement-system/services/payments-service/internal/events"
+	"github.com/parasagrawal71/bank-settlement-system/services/payments-service/internal/repository"
+	pb "github.com/parasagrawal71/bank-settlement-system/services/payments-service/proto"
+	"github.com/parasagrawal71/bank-settlement-system/shared/money"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func toDispute(d *repository.Dispute) *pb.Dispute {
+	resp := &pb.Dispute{
+		DisputeId:     d.ID,
+		ReferenceId:   d.ReferenceID,
+		Status:        d.Status,
+		Amount:        d.Amount.Amount,
+		Currency:      d.Amount.Currency,
+		PayeeAmount:   d.PayeeAmount.Amount,
+		PayeeCurrency: d.PayeeAmount.Currency,
+		Reason:        d.Reason,
+		Outcome:       d.Outcome,
+		Message:       d.Message,
+		CreatedAt:     d.CreatedAt.Unix(),
+	}
+	if !d.ResolvedAt.IsZero() {
+		resp.ResolvedAt = d.ResolvedAt.Unix()
+	}
+	for _, e := range d.Evidence {
+		resp.Evidence = append(resp.Evidence, &pb.DisputeEvidence{
+			Id:          e.ID,
+			Description: e.Description,
+			Content:     e.Content,
+			SubmittedBy: e.SubmittedBy,
+			CreatedAt:   e.CreatedAt.Unix(),
+		})
+	}
+	return resp
+}
+
+// getDispute returns a dispute with its evidence, or NotFound.
+func (h *PaymentHandler) getDispute(ctx context.Context, id string) (*pb.Dispute, error) {
+	d, err := h.repo.GetDispute(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if d == nil {
+		return nil, status.Errorf(codes.NotFound, "dispute %s does not exist", id)
+	}
+	return toDispute(d), nil
+}
+
+func (h *PaymentHandler) GetDispute(ctx context.Context, req *pb.GetDisputeRequest) (*pb.Dispute, error) {
+	if req.DisputeId == "" {
+		return nil, status.Error(codes.InvalidArgument, "dispute_id required")
+	}
+	return h.getDispute(ctx, req.DisputeId)
+}
+
+func (h *PaymentHandler) OpenDispute(ctx context.Context, req *pb.OpenDisputeRequest) (*pb.Dispute, error) {
+	return idempotent(ctx, h, "OpenDispute", req.IdempotencyKey, req, h.openDispute)
+}
+
+// openDispute holds the disputed amount from the payee. Like a refund, a
+// repeated idempotency key returns the dispute it opened, and a dispute left
+// OPENING by a failed call is driven again; accounts-service holds it once.
+func (h *PaymentHandler) openDispute(ctx context.Context, req *pb.OpenDisputeRequest) (*pb.Dispute, error) {
+	refID := req.ReferenceId
+	if refID == "" || req.Amount < 0 {
+		return nil, status.Error(codes.InvalidArgument, "reference_id required and amount must not be negative")
+	}
+	paymentIntent, err := h.repo.GetIntent(ctx, refID)
+	if err != nil {
+		return nil, err
+	}
+	if paymentIntent == nil {
+		return nil, status.Error(codes.NotFound, "intent does not exist")
+	}
+
+	key := req.IdempotencyKey
+	if key == "" {
+		key = genRef()
+	}
+	dispute, err := h.repo.GetDisputeByKey(ctx, refID, key)
+	if err != nil {
+		return nil, err
+	}
+	if dispute == nil {
+		if len(paymentIntent.Legs) > 0 {
+			return nil, status.Error(codes.FailedPrecondition, "split payments cannot be disputed")
+		}
+		// only what reached the payee and was not refunded or disputed already
+		disputable := paymentIntent.Captured.Amount - paymentIntent.Refunded.Amount -
+			paymentIntent.Escrowed.Amount - paymentIntent.Disputed.Amount
+		amount := req.Amount
+		if amount == 0 {
+			amount = disputable
+		}
+		if amount <= 0 || amount > disputable {
+			return nil, status.Errorf(codes.FailedPrecondition, "amount exceeds the captured amount that can be disputed (%d)", disputable)
+		}
+		dispute, err = h.repo.CreateDispute(ctx, repository.Dispute{
+			ID:             genRef(),
+			ReferenceID:    refID,
+			IdempotencyKey: key,
+			Amount:         money.Money{Amount: amount, Currency: paymentIntent.Amount.Currency},
+			Reason:         req.Reason,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	if req.Amount != 0 && req.Amount != dispute.Amount.Amount {
+		return nil, status.Error(codes.InvalidArgument, "idempotency_key was used for a dispute of a different amount")
+	}
+	if dispute.Status != repository.DisputeOpening {
+		return h.getDispute(ctx, dispute.ID)
+	}
+
+	holdResp, err := h.accountsClient.HoldDispute(ctx, &pb.DisputeHoldRequest{
+		ReferenceId: refID, DisputeId: dispute.ID, Amount: dispute.Amount.Amount})
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "dispute %s is opening: %v", dispute.ID, err)
+	}
+	if holdResp.Status != "SUCCESS" {
+		if err := h.repo.MarkDisputeFailed(ctx, dispute.ID, holdResp.Message); err != nil {
+			return nil, err
+		}
+		return h.getDispute(ctx, dispute.ID)
+	}
+	dispute.PayeeAmount = money.Money{Amount: holdResp.PayeeAmount, Currency: paymentIntent.PayeeAmount.Currency}
+
+	// the payee is debited and the dispute hold credited
+	tx, err := h.repo.BeginTx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	ok, err := h.repo.OpenDisputeTx(ctx, tx, dispute)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		if err := h.repo.InsertPaymentTx(ctx, tx, refID, dispute.ID, paymentIntent.PayeeID, "DEBIT", dispute.PayeeAmount); err != nil {
+			return nil, err
+		}
+		if err := h.repo.InsertPaymentTx(ctx, tx, refID, dispute.ID, repository.DisputeAccountID, "CREDIT", dispute.PayeeAmount); err != nil {
+			return nil, err
+		}
+		if err := h.emitDispute(ctx, tx, "DISPUTE_OPENED", paymentIntent, dispute); err != nil {
+			return nil, err
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit tx: %w", err)
+	}
+	return h.getDispute(ctx, dispute.ID)
+}
+
+func (h *PaymentHandler) SubmitDisputeEvidence(ctx context.Context, req *pb.SubmitDisputeEvidenceRequest) (*pb.Dispute, error) {
+	return idempotent(ctx, h, "SubmitDisputeEvidence", req.IdempotencyKey, req, h.submitDisputeEvidence)
+}
+
+func (h *PaymentHandler) submitDisputeEvidence(ctx context.Context, req *pb.SubmitDisputeEvidenceRequest) (*pb.Dispute, error) {
+	if req.DisputeId == "" || req.Description == "" {
+		return nil, status.Error(codes.InvalidArgument, "dispute_id and description required")
+	}
+	dispute, paymentIntent, err := h.disputeWithIntent(ctx, req.DisputeId)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := h.repo.BeginTx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	err = h.repo.AddDisputeEvidenceTx(ctx, tx, dispute.ID, repository.DisputeEvidence{
+		Description: req.Description,
+		Content:     req.Content,
+		SubmittedBy: actorFromContext(ctx),
+	})
+	if errors.Is(err, repository.ErrDisputeNotOpen) {
+		return nil, status.Errorf(codes.FailedPrecondition, "dispute %s is %s and takes no more evidence", dispute.ID, dispute.Status)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := h.emitDispute(ctx, tx, "DISPUTE_EVIDENCE_SUBMITTED", paymentIntent, dispute); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit tx: %w", err)
+	}
+	return h.getDispute(ctx, dispute.ID)
+}
+
+func (h *PaymentHandler) ResolveDispute(ctx context.Context, req *pb.ResolveDisputeRequest) (*pb.Dispute, error) {
+	return idempotent(ctx, h, "ResolveDispute", req.IdempotencyKey, req, h.resolveDispute)
+}
+
+// resolveDispute records the outcome first, so a resolution interrupted after
+// accounts-service closed the hold is finished by repeating the request, and a
+// different outcome can no longer be applied.
+func (h *PaymentHandler) resolveDispute(ctx context.Context, req *pb.ResolveDisputeRequest) (*pb.Dispute, error) {
+	if req.DisputeId == "" || (req.Outcome != repository.DisputeWon && req.Outcome != repository.DisputeLost) {
+		return nil, status.Error(codes.InvalidArgument, "dispute_id required and outcome must be WON or LOST")
+	}
+	dispute, paymentIntent, err := h.disputeWithIntent(ctx, req.DisputeId)
+	if err != nil {
+		return nil, err
+	}
+	if dispute.Status == req.Outcome {
+		return toDispute(dispute), nil
+	}
+	if req.Outcome == repository.DisputeLost {
+		next := repository.RefundStatus(paymentIntent.Status, paymentIntent.Refunded.Amount+dispute.Amount.Amount >= paymentIntent.Captured.Amount)
+		if err := repository.CheckTransition(paymentIntent.Status, next); err != nil {
+			return nil, transitionError(err)
+		}
+	}
+	if err := h.repo.SetDisputeOutcome(ctx, dispute.ID, req.Outcome); err != nil {
+		if errors.Is(err, repository.ErrDisputeNotOpen) {
+			msg := fmt.Sprintf("dispute %s is %s", dispute.ID, dispute.Status)
+			if dispute.Outcome != "" {
+				msg += ", being resolved as " + dispute.Outcome
+			}
+			return nil, status.Error(codes.FailedPrecondition, msg)
+		}
+		return nil, err
+	}
+	dispute.Outcome = req.Outcome
+
+	closeResp, err := h.accountsClient.CloseDisputeHold(ctx, &pb.CloseDisputeHoldRequest{
+		ReferenceId: dispute.ReferenceID, DisputeId: dispute.ID, Won: dispute.Outcome == repository.DisputeWon})
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "dispute %s is being resolved: %v", dispute.ID, err)
+	}
+	if closeResp.Status != "SUCCESS" {
+		return nil, status.Error(codes.FailedPrecondition, closeResp.Message)
+	}
+
+	tx, err := h.repo.BeginTx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	ok, err := h.repo.ResolveDisputeTx(ctx, tx, dispute, actorFromContext(ctx))
+	if err != nil {
+		return nil, transitionError(err)
+	}
+	if ok {
+		// the dispute hold is debited and the payee of a won dispute, or the
+		// payer of a lost one, credited
+		resolutionID := dispute.ResolutionID()
+		if err := h.repo.InsertPaymentTx(ctx, tx, dispute.ReferenceID, resolutionID, repository.DisputeAccountID, "DEBIT", dispute.PayeeAmount); err != nil {
+			return nil, err
+		}
+		creditID, credit := paymentIntent.PayeeID, dispute.PayeeAmount
+		if dispute.Outcome == repository.DisputeLost {
+			creditID, credit = paymentIntent.PayerID, dispute.Amount
+		}
+		if err := h.repo.InsertPaymentTx(ctx, tx, dispute.ReferenceID, resolutionID, creditID, "CREDIT", credit); err != nil {
+			return nil, err
+		}
+		if err := h.emitDispute(ctx, tx, "DISPUTE_"+dispute.Outcome, paymentIntent, dispute); err != nil {
+			return nil, err
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit tx: %w", err)
+	}
+	return h.getDispute(ctx, dispute.ID)
+}
+
+// disputeWithIntent returns a dispute and the intent it disputes, or NotFound.
+func (h *PaymentHandler) disputeWithIntent(ctx context.Context, id string) (*repository.Dispute, *repository.PaymentIntent, error) {
+	dispute, err := h.repo.GetDispute(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if dispute == nil {
+		return nil, nil, status.Errorf(codes.NotFound, "dispute %s does not exist", id)
+	}
+	paymentIntent, err := h.repo.GetIntent(ctx, dispute.ReferenceID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if paymentIntent == nil {
+		return nil, nil, fmt.Errorf("intent %s of dispute %s does not exist", dispute.ReferenceID, id)
+	}
+	return dispute, paymentIntent, nil
+}
+
+func (h *PaymentHandler) emitDispute(ctx context.Context, tx pgx.Tx, eventType string, pi *repository.PaymentIntent, d *repository.Dispute) error {
+	return h.emit(ctx, tx, events.PaymentEvent{
+		EventType:   eventType,
+		ReferenceID: d.ReferenceID,
+		DisputeID:   d.ID,
+		Reason:      d.Reason,
+		PayerId:     pi.PayerID,
+		PayeeId:     pi.PayeeID,
+		Amount:      d.Amount,
+		PayeeAmount: d.PayeeAmount,
+		Timestamp:   time.Now().Unix(),
+	})
+}
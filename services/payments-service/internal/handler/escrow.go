@@ -0,0 +1,235 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/parasagrawal71/bank-settlement-system/services/payments-service/internal/events"
+	"github.com/parasagrawal71/bank-settlement-system/services/payments-service/internal/repository"
+	pb "github.com/parasagrawal71/bank-settlement-system/services/payments-service/proto"
+	"github.com/parasagrawal71/bank-settlement-system/shared/money"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func escrowResponse(pi *repository.PaymentIntent, m *repository.EscrowMovement, msg string) *pb.EscrowResponse {
+	return &pb.EscrowResponse{
+		ReferenceId:  pi.ReferenceID,
+		EscrowId:     m.ID,
+		Status:       pb.PaymentStatus(pb.PaymentStatus_value[pi.Status]),
+		Message:      msg,
+		Amount:       m.Amount.Amount,
+		EscrowAmount: pi.Escrowed.Amount,
+	}
+}
+
+func (h *PaymentHandler) ReleaseEscrow(ctx context.Context, req *pb.ReleaseEscrowRequest) (*pb.EscrowResponse, error) {
+	return idempotent(ctx, h, "ReleaseEscrow", req.IdempotencyKey, req, h.releaseEscrow)
+}
+
+func (h *PaymentHandler) releaseEscrow(ctx context.Context, req *pb.ReleaseEscrowRequest) (*pb.EscrowResponse, error) {
+	return h.moveEscrow(ctx, req.ReferenceId, repository.EscrowRelease, req.Amount, "", req.IdempotencyKey, actorFromContext(ctx))
+}
+
+func (h *PaymentHandler) RefundEscrow(ctx context.Context, req *pb.RefundEscrowRequest) (*pb.EscrowResponse, error) {
+	return idempotent(ctx, h, "RefundEscrow", req.IdempotencyKey, req, h.refundEscrow)
+}
+
+func (h *PaymentHandler) refundEscrow(ctx context.Context, req *pb.RefundEscrowRequest) (*pb.EscrowResponse, error) {
+	return h.moveEscrow(ctx, req.ReferenceId, repository.EscrowRefund, req.Amount, req.Reason, req.IdempotencyKey, actorFromContext(ctx))
+}
+
+// moveEscrow releases or refunds amount (0 for everything) of what an escrow
+// intent holds in escrow. Like a refund, a repeated idempotency key returns the
+// movement it created, and a movement left PENDING by a failed call is driven
+// again.
+func (h *PaymentHandler) moveEscrow(ctx context.Context, refID, kind string, amount int64, reason, key, actor string) (*pb.EscrowResponse, error) {
+	if refID == "" || amount < 0 {
+		return nil, status.Error(codes.InvalidArgument, "reference_id required and amount must not be negative")
+	}
+	failed := func(escrowID, msg string) *pb.EscrowResponse {
+		return &pb.EscrowResponse{ReferenceId: refID, EscrowId: escrowID, Status: pb.PaymentStatus_FAILED, Message: msg}
+	}
+
+	paymentIntent, err := h.repo.GetIntent(ctx, refID)
+	if err != nil {
+		return nil, err
+	}
+	if paymentIntent == nil {
+		return failed("", "intent does not exist"), nil
+	}
+	if !paymentIntent.Escrow {
+		return nil, status.Errorf(codes.FailedPrecondition, "payment %s is not an escrow payment", refID)
+	}
+
+	if key == "" {
+		key = genRef()
+	}
+	movement, err := h.repo.GetEscrowMovementByKey(ctx, refID, kind, key)
+	if err != nil {
+		return nil, err
+	}
+	if movement == nil {
+		escrowed := paymentIntent.Escrowed.Amount
+		want := amount
+		if want == 0 {
+			want = escrowed
+		}
+		if want <= 0 || want > escrowed {
+			return failed("", fmt.Sprintf("amount exceeds the funds held in escrow (%d)", escrowed)), nil
+		}
+		if kind == repository.EscrowRefund {
+			next := repository.RefundStatus(paymentIntent.Status, paymentIntent.Refunded.Amount+want >= paymentIntent.Captured.Amount)
+			if err := repository.CheckTransition(paymentIntent.Status, next); err != nil {
+				return nil, transitionError(err)
+			}
+		}
+		movement, err = h.repo.CreateEscrowMovement(ctx, repository.EscrowMovement{
+			ID:             genRef(),
+			ReferenceID:    refID,
+			Kind:           kind,
+			IdempotencyKey: key,
+			Amount:         money.Money{Amount: want, Currency: paymentIntent.Amount.Currency},
+			Reason:         reason,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	if amount != 0 && amount != movement.Amount.Amount {
+		return failed(movement.ID, "idempotency_key was used for an escrow movement of a different amount"), nil
+	}
+	return h.driveEscrowMovement(ctx, paymentIntent, movement, actor)
+}
+
+// driveEscrowMovement asks accounts-service to move a PENDING movement's funds
+// out of escrow, which is idempotent on the movement id, then writes its
+// payments rows, event and intent totals in one transaction.
+func (h *PaymentHandler) driveEscrowMovement(ctx context.Context, pi *repository.PaymentIntent, m *repository.EscrowMovement, actor string) (*pb.EscrowResponse, error) {
+	switch m.Status {
+	case "SUCCEEDED":
+		return escrowResponse(pi, m, "escrow movement already processed"), nil
+	case "FAILED":
+		return &pb.EscrowResponse{ReferenceId: m.ReferenceID, EscrowId: m.ID, Status: pb.PaymentStatus_FAILED, Message: m.Message}, nil
+	}
+
+	move := h.accountsClient.ReleaseEscrow
+	eventType, done := "ESCROW_RELEASED", "Escrow released successfully"
+	if m.Kind == repository.EscrowRefund {
+		move = h.accountsClient.RefundEscrow
+		eventType, done = "ESCROW_REFUNDED", "Escrow refunded successfully"
+	}
+	moveResp, err := move(ctx, &pb.EscrowMovementRequest{ReferenceId: m.ReferenceID, MovementId: m.ID, Amount: m.Amount.Amount})
+	if err != nil {
+		// the funds may or may not have moved; the escrow worker finds out
+		return nil, status.Errorf(codes.Unavailable, "escrow movement %s is pending: %v", m.ID, err)
+	}
+	if moveResp.Status != "SUCCESS" {
+		if err := h.repo.MarkEscrowMovementFailed(ctx, m.ID, moveResp.Message); err != nil {
+			return nil, err
+		}
+		return &pb.EscrowResponse{ReferenceId: m.ReferenceID, EscrowId: m.ID, Status: pb.PaymentStatus_FAILED, Message: moveResp.Message}, nil
+	}
+	m.PayeeAmount = money.Money{Amount: moveResp.PayeeAmount, Currency: pi.PayeeAmount.Currency}
+
+	tx, err := h.repo.BeginTx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	ok, err := h.repo.CompleteEscrowMovementTx(ctx, tx, m, actor)
+	if err != nil {
+		return nil, transitionError(err)
+	}
+	if ok {
+		// the escrow account is debited and the payee, or for a refund the payer, credited
+		if err := h.repo.InsertPaymentTx(ctx, tx, m.ReferenceID, m.ID, repository.EscrowAccountID, "DEBIT", m.Amount); err != nil {
+			return nil, err
+		}
+		creditID, credit := pi.PayeeID, m.PayeeAmount
+		if m.Kind == repository.EscrowRefund {
+			creditID, credit = pi.PayerID, m.Amount
+		}
+		if err := h.repo.InsertPaymentTx(ctx, tx, m.ReferenceID, m.ID, creditID, "CREDIT", credit); err != nil {
+			return nil, err
+		}
+		paymentEvent := events.PaymentEvent{
+			EventType:   eventType,
+			ReferenceID: m.ReferenceID,
+			EscrowID:    m.ID,
+			Reason:      m.Reason,
+			PayerId:     pi.PayerID,
+			PayeeId:     pi.PayeeID,
+			Amount:      m.Amount,
+			PayeeAmount: m.PayeeAmount,
+			Timestamp:   time.Now().Unix(),
+		}
+		if err := h.emit(ctx, tx, paymentEvent); err != nil {
+			return nil, err
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit tx: %w", err)
+	}
+
+	updated, err := h.repo.GetIntent(ctx, m.ReferenceID)
+	if err != nil {
+		return nil, err
+	}
+	return escrowResponse(updated, m, done), nil
+}
+
+// ReleaseDueEscrows first drives escrow movements left PENDING for stuckAfter
+// by a crash or a lost accounts-service response, then releases everything
+// still held in escrow by up to limit intents whose scheduled release is due.
+// A scheduled release is keyed on the captured amount, so running it again
+// after a failure resumes the same release. It returns how many movements
+// completed; all failures are returned together.
+func (h *PaymentHandler) ReleaseDueEscrows(ctx context.Context, stuckAfter time.Duration, limit int) (int, error) {
+	done := 0
+	var errs []error
+
+	stuck, err := h.repo.ListStuckEscrowMovements(ctx, stuckAfter, limit)
+	if err != nil {
+		return 0, err
+	}
+	for _, m := range stuck {
+		pi, err := h.repo.GetIntent(ctx, m.ReferenceID)
+		if err == nil && pi == nil {
+			err = fmt.Errorf("intent %s does not exist", m.ReferenceID)
+		}
+		if err == nil {
+			_, err = h.driveEscrowMovement(ctx, pi, m, "system")
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("escrow movement %s: %w", m.ID, err))
+			if err := h.repo.TouchEscrowMovement(ctx, m.ID, err.Error()); err != nil {
+				errs = append(errs, fmt.Errorf("escrow movement %s: %w", m.ID, err))
+			}
+			continue
+		}
+		done++
+	}
+
+	due, err := h.repo.ListDueEscrows(ctx, limit)
+	if err != nil {
+		return done, errors.Join(append(errs, err)...)
+	}
+	for _, pi := range due {
+		key := "scheduled:" + strconv.FormatInt(pi.Captured.Amount, 10)
+		resp, err := h.moveEscrow(ctx, pi.ReferenceID, repository.EscrowRelease, 0, "", key, "system")
+		if err == nil && resp.Status == pb.PaymentStatus_FAILED {
+			err = errors.New(resp.Message)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("scheduled release of %s: %w", pi.ReferenceID, err))
+			continue
+		}
+		done++
+	}
+	return done, errors.Join(errs...)
+}
@@ -4,17 +4,26 @@ import (
 	"context"
 	"crypto/rand"
 	"encoding/hex"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
-	"os"
+	"strings"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/parasagrawal71/bank-settlement-system/services/payments-service/internal/client"
+	"github.com/parasagrawal71/bank-settlement-system/services/payments-service/internal/config"
 	"github.com/parasagrawal71/bank-settlement-system/services/payments-service/internal/events"
 	"github.com/parasagrawal71/bank-settlement-system/services/payments-service/internal/repository"
+	"github.com/parasagrawal71/bank-settlement-system/services/payments-service/internal/risk"
+	"github.com/parasagrawal71/bank-settlement-system/services/payments-service/internal/webhooks"
 	pb "github.com/parasagrawal71/bank-settlement-system/services/payments-service/proto"
-	"google.golang.org/grpc"
+	"github.com/parasagrawal71/bank-settlement-system/shared/auth"
+	"github.com/parasagrawal71/bank-settlement-system/shared/money"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 type PaymentHandler struct {
@@ -23,22 +32,101 @@ type PaymentHandler struct {
 	accountsClient pb.AccountServiceClient
 	outboxRepo     *repository.OutboxRepository
 	idempRepo      *repository.IdempotencyRepo
+	webhookRepo    *repository.WebhookRepo
+	payoutRepo     *repository.PayoutRepo
+	risk           *risk.Engine
+
+	idempotencyKeyTTL      time.Duration
+	idempotencyLockTimeout time.Duration
+	idempotencyWait        time.Duration
+	webhookMaxAge          time.Duration
+	payoutMaxRows          int
 }
 
-func NewPaymentHandler(pool *pgxpool.Pool) *PaymentHandler {
-	accountsAddr := os.Getenv("ACCOUNTS_GRPC_HOST") + ":" + os.Getenv("ACCOUNTS_GRPC_PORT")
-	conn, err := grpc.Dial(accountsAddr, grpc.WithInsecure())
+func NewPaymentHandler(pool *pgxpool.Pool, cfg *config.Config) *PaymentHandler {
+	accounts, err := client.NewAccountsClient(cfg.Accounts)
 	if err != nil {
-		log.Fatalf("failed to connect to accounts-service: %v", err)
+		log.Fatalf("accounts-service client: %v", err)
+	}
+	riskEngine, err := risk.NewEngine(cfg.RiskRulesFile)
+	if err != nil {
+		log.Fatalf("failed to load risk rules: %v", err)
 	}
-
-	client := pb.NewAccountServiceClient(conn)
 	return &PaymentHandler{
 		repo:           repository.NewRepository(pool),
-		accountsClient: client,
+		accountsClient: accounts,
 		outboxRepo:     repository.NewOutboxRepository(pool),
 		idempRepo:      repository.NewIdempotencyRepository(pool),
+		webhookRepo:    repository.NewWebhookRepository(pool),
+		payoutRepo:     repository.NewPayoutRepository(pool),
+		risk:           riskEngine,
+
+		idempotencyKeyTTL:      cfg.IdempotencyKeyTTL,
+		idempotencyLockTimeout: cfg.IdempotencyLockTimeout,
+		idempotencyWait:        cfg.IdempotencyWait,
+		webhookMaxAge:          cfg.WebhookMaxAge,
+		payoutMaxRows:          cfg.PayoutMaxRows,
+	}
+}
+
+// actorFromContext names who made a request, from the x-actor metadata key and
+// the authenticated caller, as "alice (ops-console)". It is recorded in the
+// status history of the payments the request changes.
+func actorFromContext(ctx context.Context) string {
+	actor := ""
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if v := md.Get("x-actor"); len(v) > 0 {
+			actor = v[0]
+		}
+	}
+	caller := auth.PrincipalFromContext(ctx)
+	switch {
+	case caller != nil && actor != "" && actor != caller.Name:
+		return actor + " (" + caller.Name + ")"
+	case caller != nil:
+		return caller.Name
+	case actor != "":
+		return actor
+	}
+	return "api"
+}
+
+// transitionError returns FailedPrecondition for a status change the payment
+// state machine does not allow, and err unchanged otherwise.
+func transitionError(err error) error {
+	var te *repository.TransitionError
+	if errors.As(err, &te) {
+		return status.Error(codes.FailedPrecondition, te.Error())
+	}
+	return err
+}
+
+// emit stores a payment event in the outbox for Kafka and schedules its
+// webhooks to the payee, in the transaction that makes the change it reports.
+func (h *PaymentHandler) emit(ctx context.Context, tx pgx.Tx, ev events.PaymentEvent) error {
+	if err := h.outboxRepo.AddEvent(ctx, tx, ev.EventType, ev); err != nil {
+		return fmt.Errorf("store %s event in outbox: %w", ev.EventType, err)
+	}
+	eventID := webhooks.NewEventID()
+	body, err := webhooks.NewEnvelope(eventID, ev.EventType, ev)
+	if err != nil {
+		return err
 	}
+	return h.webhookRepo.EnqueueTx(ctx, tx, ev.PayeeId, ev.EventType, ev.ReferenceID, eventID, body, h.webhookMaxAge)
+}
+
+// accountsUnavailable sorts out a failed accounts-service call. A business
+// error, such as a payer that does not exist, is the outcome of the request:
+// nil is returned and the caller answers FAILED, which an idempotency key keeps.
+// Anything else, accounts-service unreachable, timed out, broken or its circuit
+// breaker open, says nothing about the request and is returned as Unavailable,
+// which releases the key so a retry runs the request again.
+func accountsUnavailable(what string, err error) error {
+	switch status.Code(err) {
+	case codes.NotFound, codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange, codes.AlreadyExists:
+		return nil
+	}
+	return status.Errorf(codes.Unavailable, "%s: %v", what, status.Convert(err).Message())
 }
 
 func genRef() string {
@@ -48,45 +136,176 @@ func genRef() string {
 }
 
 func (h *PaymentHandler) CreatePaymentIntent(ctx context.Context, req *pb.CreatePaymentIntentRequest) (*pb.CreatePaymentIntentResponse, error) {
-	if req.PayerId == "" || req.PayeeId == "" || req.Amount <= 0 {
-		return nil, fmt.Errorf("payer_id, payee_id and amount required")
+	return idempotent(ctx, h, "CreatePaymentIntent", req.IdempotencyKey, req, h.createPaymentIntent)
+}
+
+func (h *PaymentHandler) createPaymentIntent(ctx context.Context, req *pb.CreatePaymentIntentRequest) (*pb.CreatePaymentIntentResponse, error) {
+	if req.PayerId == "" || (req.PayeeId == "") == (len(req.Legs) == 0) || req.Amount <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "payer_id, amount and either payee_id or legs required")
+	}
+	if req.Escrow && len(req.Legs) > 0 {
+		return nil, status.Error(codes.InvalidArgument, "split payments cannot be held in escrow")
+	}
+	if req.EscrowReleaseAt != 0 && !req.Escrow {
+		return nil, status.Error(codes.InvalidArgument, "escrow_release_at needs escrow")
 	}
 
 	refID := req.ReferenceId
 	if refID == "" {
 		refID = genRef()
 	}
-	// idempotency check
-	if b, err := h.idempRepo.GetResponse(ctx, refID); err == nil && b != nil {
-		var resp pb.CreatePaymentIntentResponse
-		// best-effort: ignore unmarshal error
-		_ = json.Unmarshal(b, &resp)
-		return &resp, nil
+
+	// The amount is in the payer's currency; a different payee currency needs a locked FX quote
+	payer, err := h.accountsClient.GetAccount(ctx, &pb.GetAccountRequest{AccountId: req.PayerId})
+	if err != nil {
+		if err := accountsUnavailable("payer", err); err != nil {
+			return nil, err
+		}
+		return &pb.CreatePaymentIntentResponse{ReferenceId: refID, Status: pb.PaymentStatus_FAILED, Message: "payer: " + err.Error()}, nil
+	}
+	currency := req.Currency
+	if currency == "" {
+		currency = payer.Currency
+	}
+	amount, err := money.New(req.Amount, currency)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if amount.Currency != payer.Currency {
+		return nil, status.Errorf(codes.InvalidArgument, "%v: payer account is %s, amount is %s", money.ErrCurrencyMismatch, payer.Currency, amount.Currency)
 	}
 
-	log.Printf("Processing payment intent of %.2f from %s → %s",
-		req.Amount, req.PayerId, req.PayeeId)
+	// a split payment goes to the payee of every leg, all in the payer's currency
+	payeeID := req.PayeeId
+	var legs []repository.PaymentLeg
+	if len(req.Legs) > 0 {
+		if legs, err = resolveLegs(amount, req.Legs); err != nil {
+			return nil, err
+		}
+		if err := h.checkLegPayees(ctx, legs, payer.Currency); err != nil {
+			if err := accountsUnavailable("payee", err); err != nil {
+				return nil, err
+			}
+			return &pb.CreatePaymentIntentResponse{ReferenceId: refID, Status: pb.PaymentStatus_FAILED, Message: err.Error()}, nil
+		}
+		payeeID = legs[0].PayeeID
+	}
+	payee, err := h.accountsClient.GetAccount(ctx, &pb.GetAccountRequest{AccountId: payeeID})
+	if err != nil {
+		if err := accountsUnavailable("payee", err); err != nil {
+			return nil, err
+		}
+		return &pb.CreatePaymentIntentResponse{ReferenceId: refID, Status: pb.PaymentStatus_FAILED, Message: "payee: " + err.Error()}, nil
+	}
+
+	payeeAmount := amount
+	quoteID := ""
+	if payee.Currency != payer.Currency {
+		quote, err := h.accountsClient.GetQuote(ctx, &pb.GetQuoteRequest{Amount: amount.Amount, FromCurrency: amount.Currency, ToCurrency: payee.Currency})
+		if err != nil {
+			if err := accountsUnavailable("fx quote", err); err != nil {
+				return nil, err
+			}
+			return &pb.CreatePaymentIntentResponse{ReferenceId: refID, Status: pb.PaymentStatus_FAILED, Message: "fx quote: " + err.Error()}, nil
+		}
+		quoteID = quote.QuoteId
+		payeeAmount = money.Money{Amount: quote.TargetAmount, Currency: quote.ToCurrency}
+	}
+
+	log.Printf("Processing payment intent of %s (%s to payee) from %s → %s",
+		amount, payeeAmount, req.PayerId, payeeID)
+
+	// the risk rules decide before any funds are reserved
+	assessment, err := h.assessRisk(ctx, refID, req.PayerId, payeeID, amount, legs)
+	if err != nil {
+		return nil, err
+	}
+	switch assessment.Decision {
+	case risk.Deny:
+		return &pb.CreatePaymentIntentResponse{ReferenceId: refID, Status: pb.PaymentStatus_FAILED, Message: "declined by risk rules: " + strings.Join(assessment.Reasons, ", ")}, nil
+	case risk.Review:
+		return h.holdForReview(ctx, req, refID, payeeID, amount, payeeAmount, legs, assessment)
+	}
 
 	// Reserve funds in accounts-service
-	_, err := h.accountsClient.ReserveFunds(ctx, &pb.ReserveRequest{PayerId: req.PayerId, PayeeId: req.PayeeId, Amount: req.Amount, ReferenceId: refID})
+	var reserveLegs []*pb.PayeeLeg
+	for _, l := range legs {
+		reserveLegs = append(reserveLegs, &pb.PayeeLeg{PayeeId: l.PayeeID, Amount: l.Amount})
+	}
+	reserveResp, err := h.accountsClient.ReserveFunds(ctx, &pb.ReserveRequest{PayerId: req.PayerId, PayeeId: payeeID, Amount: amount.Amount, Currency: amount.Currency, ReferenceId: refID, QuoteId: quoteID, HoldTtlSeconds: req.HoldTtlSeconds, Legs: reserveLegs, Escrow: req.Escrow})
 	if err != nil {
+		if err := accountsUnavailable("reserve funds", err); err != nil {
+			// a hold the call did make is of no use without an intent; release
+			// it when nobody else can know the reference, and leave it to the
+			// expiry sweeper otherwise
+			if req.ReferenceId == "" {
+				h.releaseOrphanedHold(ctx, refID)
+			}
+			return nil, err
+		}
 		return &pb.CreatePaymentIntentResponse{ReferenceId: refID, Status: pb.PaymentStatus_FAILED, Message: err.Error()}, nil
 	}
+	if reserveResp.Status != "SUCCESS" {
+		return &pb.CreatePaymentIntentResponse{ReferenceId: refID, Status: pb.PaymentStatus_FAILED, Message: reserveResp.Message}, nil
+	}
 
 	// insert payment_intent
-	if err := h.repo.CreateIntent(ctx, refID, req.PayerId, req.PayeeId, req.Amount); err != nil {
+	tx, err := h.repo.BeginTx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	expiresAt := time.Unix(reserveResp.ExpiresAt, 0)
+	if err := h.repo.CreateIntentTx(ctx, tx, refID, req.PayerId, payeeID, amount, payeeAmount, quoteID, expiresAt, actorFromContext(ctx)); err != nil {
 		return nil, err
 	}
+	if err := h.repo.InsertLegsTx(ctx, tx, refID, legs); err != nil {
+		return nil, err
+	}
+	if req.Escrow {
+		var releaseAt time.Time
+		if req.EscrowReleaseAt != 0 {
+			releaseAt = time.Unix(req.EscrowReleaseAt, 0)
+		}
+		if err := h.repo.SetEscrowTx(ctx, tx, refID, releaseAt); err != nil {
+			return nil, err
+		}
+	}
+	paymentEvent := events.PaymentEvent{
+		EventType:   "PAYMENT_AUTHORIZED",
+		ReferenceID: refID,
+		PayerId:     req.PayerId,
+		PayeeId:     payeeID,
+		Amount:      amount,
+		PayeeAmount: payeeAmount,
+		Timestamp:   time.Now().Unix(),
+	}
+	if err := h.emitLegs(ctx, tx, paymentEvent, legs); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit tx: %w", err)
+	}
+
+	return &pb.CreatePaymentIntentResponse{ReferenceId: refID, Status: pb.PaymentStatus_AUTHORIZED, Message: "Authorised", ExpiresAt: reserveResp.ExpiresAt}, nil
+}
 
-	resp := pb.CreatePaymentIntentResponse{ReferenceId: refID, Status: pb.PaymentStatus_AUTHORIZED, Message: "Authorised"}
-	// store idempotency response
-	if jb, err := json.Marshal(resp); err == nil {
-		_ = h.idempRepo.SaveResponse(ctx, refID, jb)
+// releaseOrphanedHold releases, best effort, a hold that may have been made by
+// a ReserveFunds call whose outcome is unknown.
+func (h *PaymentHandler) releaseOrphanedHold(ctx context.Context, refID string) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 10*time.Second)
+	defer cancel()
+	if _, err := h.accountsClient.ReleaseFunds(ctx, &pb.ReleaseRequest{ReferenceId: refID}); err != nil {
+		log.Printf("release hold %s after a failed reservation: %v; the expiry sweeper releases it", refID, err)
 	}
-	return &resp, nil
 }
 
 func (h *PaymentHandler) CapturePayment(ctx context.Context, req *pb.CapturePaymentRequest) (*pb.CapturePaymentResponse, error) {
+	return idempotent(ctx, h, "CapturePayment", req.IdempotencyKey, req, h.capturePayment)
+}
+
+func (h *PaymentHandler) capturePayment(ctx context.Context, req *pb.CapturePaymentRequest) (*pb.CapturePaymentResponse, error) {
 	refID := req.ReferenceId
 
 	// Check intent exists and check its status
@@ -97,55 +316,386 @@ func (h *PaymentHandler) CapturePayment(ctx context.Context, req *pb.CapturePaym
 	if paymentIntent == nil {
 		return &pb.CapturePaymentResponse{ReferenceId: refID, Status: pb.PaymentStatus_FAILED, Message: "intent does not exist"}, nil
 	}
-	if paymentIntent.Status != "AUTHORIZED" {
-		return &pb.CapturePaymentResponse{ReferenceId: refID, Status: pb.PaymentStatus_FAILED, Message: "intent not authorized"}, nil
+
+	// every capture is a saga of its own with its own payments rows and event;
+	// a retry with the same idempotency key resumes the capture it started
+	captureID := genRef()
+	var capture *repository.CaptureSaga
+	if req.IdempotencyKey != "" {
+		captureID = captureIDFor(refID, req.IdempotencyKey)
+		if capture, err = h.repo.GetCapture(ctx, captureID); err != nil {
+			return nil, err
+		}
+	}
+	if capture == nil {
+		if paymentIntent.Status == repository.StatusExpired {
+			return &pb.CapturePaymentResponse{ReferenceId: refID, Status: pb.PaymentStatus_EXPIRED, Message: "intent expired"}, nil
+		}
+		if err := repository.CheckTransition(paymentIntent.Status, repository.StatusCaptured); err != nil {
+			return nil, transitionError(err)
+		}
+		if req.Amount < 0 || req.Amount > paymentIntent.Amount.Amount-paymentIntent.Captured.Amount {
+			return &pb.CapturePaymentResponse{ReferenceId: refID, Status: pb.PaymentStatus_FAILED, Message: "amount exceeds the authorized amount left to capture"}, nil
+		}
+		capture, err = h.repo.StartCapture(ctx, repository.CaptureSaga{
+			ID:          captureID,
+			ReferenceID: refID,
+			Requested:   req.Amount,
+			Final:       req.Final,
+			Amount:      money.Money{Currency: paymentIntent.Amount.Currency},
+			PayeeAmount: money.Money{Currency: paymentIntent.PayeeAmount.Currency},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("start capture: %w", err)
+		}
 	}
+	return h.driveCapture(ctx, paymentIntent, capture, actorFromContext(ctx))
+}
 
-	// Call Transfer funds
-	_, err = h.accountsClient.Transfer(ctx, &pb.TransferRequest{ReferenceId: refID})
+func (h *PaymentHandler) RefundPayment(ctx context.Context, req *pb.RefundPaymentRequest) (*pb.RefundPaymentResponse, error) {
+	return idempotent(ctx, h, "RefundPayment", req.IdempotencyKey, req, h.refundPayment)
+}
+
+func (h *PaymentHandler) refundPayment(ctx context.Context, req *pb.RefundPaymentRequest) (*pb.RefundPaymentResponse, error) {
+	refID := req.ReferenceId
+	if refID == "" || req.Amount < 0 {
+		return nil, status.Error(codes.InvalidArgument, "reference_id required and amount must not be negative")
+	}
+	failed := func(refundID, msg string) *pb.RefundPaymentResponse {
+		return &pb.RefundPaymentResponse{ReferenceId: refID, RefundId: refundID, Status: pb.PaymentStatus_FAILED, Message: msg}
+	}
+
+	paymentIntent, err := h.repo.GetIntent(ctx, refID)
 	if err != nil {
-		return &pb.CapturePaymentResponse{ReferenceId: refID, Status: pb.PaymentStatus_FAILED, Message: err.Error()}, nil
+		return nil, err
+	}
+	if paymentIntent == nil {
+		return failed("", "intent does not exist"), nil
+	}
+
+	// a repeated idempotency key returns the refund it created; a refund left
+	// PENDING by a failed call is driven again, accounts-service refunds it once
+	key := req.IdempotencyKey
+	if key == "" {
+		key = genRef()
+	}
+	refund, err := h.repo.GetRefundByKey(ctx, refID, key)
+	if err != nil {
+		return nil, err
+	}
+	if refund == nil {
+		// funds still held in escrow are returned with RefundEscrow, and disputed
+		// funds are held until the dispute is resolved
+		refundable := paymentIntent.Captured.Amount - paymentIntent.Refunded.Amount - paymentIntent.Escrowed.Amount -
+			paymentIntent.Disputed.Amount
+		amount := req.Amount
+		if amount == 0 {
+			amount = refundable
+		}
+		if amount <= 0 || amount > refundable {
+			return failed("", fmt.Sprintf("refund exceeds the captured amount not yet refunded (%d)", refundable)), nil
+		}
+		next := repository.RefundStatus(paymentIntent.Status, amount == refundable)
+		if err := repository.CheckTransition(paymentIntent.Status, next); err != nil {
+			return nil, transitionError(err)
+		}
+		refund, err = h.repo.CreateRefund(ctx, repository.Refund{
+			ID:             genRef(),
+			ReferenceID:    refID,
+			IdempotencyKey: key,
+			Amount:         money.Money{Amount: amount, Currency: paymentIntent.Amount.Currency},
+			Reason:         req.Reason,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	if req.Amount != 0 && req.Amount != refund.Amount.Amount {
+		return failed(refund.ID, "idempotency_key was used for a refund of a different amount"), nil
+	}
+	switch refund.Status {
+	case "SUCCEEDED":
+		return &pb.RefundPaymentResponse{ReferenceId: refID, RefundId: refund.ID, Status: pb.PaymentStatus_REFUNDED,
+			Message: "refund already processed", Amount: refund.Amount.Amount, RefundedAmount: paymentIntent.Refunded.Amount}, nil
+	case "FAILED":
+		return failed(refund.ID, refund.Message), nil
 	}
 
-	// Now insert payment transactions
+	refundResp, err := h.accountsClient.Refund(ctx, &pb.RefundRequest{ReferenceId: refID, RefundId: refund.ID, Amount: refund.Amount.Amount})
+	if err != nil {
+		// the refund stays PENDING and a retry repeats it under the same refund id
+		if err := accountsUnavailable("refund "+refund.ID, err); err != nil {
+			return nil, err
+		}
+		return failed(refund.ID, err.Error()), nil
+	}
+	if refundResp.Status != "SUCCESS" {
+		if err := h.repo.MarkRefundFailed(ctx, refund.ID, refundResp.Message); err != nil {
+			return nil, err
+		}
+		return failed(refund.ID, refundResp.Message), nil
+	}
+	refund.PayeeAmount = money.Money{Amount: refundResp.PayeeAmount, Currency: paymentIntent.PayeeAmount.Currency}
+	refundLegs := fromPayeeLegs(refundResp.Legs)
+
+	// reverse payments rows: the payer is credited and the payee debited
 	tx, err := h.repo.BeginTx(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("begin tx: %w", err)
 	}
 	defer tx.Rollback(ctx)
 
-	if err := h.repo.InsertPaymentTx(ctx, tx, refID, paymentIntent.PayerID, "DEBIT", paymentIntent.Amount); err != nil {
+	if err := h.repo.InsertPaymentTx(ctx, tx, refID, refund.ID, paymentIntent.PayerID, "CREDIT", refund.Amount); err != nil {
 		return nil, err
 	}
-	if err := h.repo.InsertPaymentTx(ctx, tx, refID, paymentIntent.PayeeID, "CREDIT", paymentIntent.Amount); err != nil {
-		return nil, err
+	if len(refundLegs) == 0 {
+		if err := h.repo.InsertPaymentTx(ctx, tx, refID, refund.ID, paymentIntent.PayeeID, "DEBIT", refund.PayeeAmount); err != nil {
+			return nil, err
+		}
+	}
+	for _, l := range refundLegs {
+		if l.Amount == 0 {
+			continue
+		}
+		if err := h.repo.InsertPaymentTx(ctx, tx, refID, refund.ID, l.PayeeID, "DEBIT", money.Money{Amount: l.Amount, Currency: refund.Amount.Currency}); err != nil {
+			return nil, err
+		}
 	}
 
-	now := time.Now().Unix()
 	paymentEvent := events.PaymentEvent{
+		EventType:   "PAYMENT_REFUNDED",
 		ReferenceID: refID,
+		RefundID:    refund.ID,
+		Reason:      refund.Reason,
 		PayerId:     paymentIntent.PayerID,
 		PayeeId:     paymentIntent.PayeeID,
-		Amount:      paymentIntent.Amount,
-		Timestamp:   now,
+		Amount:      refund.Amount,
+		PayeeAmount: refund.PayeeAmount,
+		Timestamp:   time.Now().Unix(),
+	}
+	if err := h.emitLegs(ctx, tx, paymentEvent, refundLegs); err != nil {
+		return nil, err
 	}
 
-	if err := h.outboxRepo.AddEvent(ctx, tx, "PAYMENT_CAPTURED", paymentEvent); err != nil {
-		return nil, fmt.Errorf("store payment event in outbox: %w", err)
+	if err := h.repo.CompleteRefundTx(ctx, tx, refund, actorFromContext(ctx)); err != nil {
+		return nil, transitionError(err)
 	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit tx: %w", err)
+	}
+
+	return &pb.RefundPaymentResponse{
+		ReferenceId:    refID,
+		RefundId:       refund.ID,
+		Status:         pb.PaymentStatus_REFUNDED,
+		Message:        "Refund processed successfully",
+		Amount:         refund.Amount.Amount,
+		RefundedAmount: refundResp.RefundedAmount,
+	}, nil
+}
+
+func (h *PaymentHandler) CancelPaymentIntent(ctx context.Context, req *pb.CancelPaymentIntentRequest) (*pb.CancelPaymentIntentResponse, error) {
+	return idempotent(ctx, h, "CancelPaymentIntent", req.IdempotencyKey, req, h.cancelPaymentIntent)
+}
 
-	// Update intent status
-	if err := h.repo.UpdateIntentStatusTx(ctx, tx, refID, "CAPTURED"); err != nil {
+func (h *PaymentHandler) cancelPaymentIntent(ctx context.Context, req *pb.CancelPaymentIntentRequest) (*pb.CancelPaymentIntentResponse, error) {
+	refID := req.ReferenceId
+	if refID == "" {
+		return nil, status.Error(codes.InvalidArgument, "reference_id required")
+	}
+	canceled := &pb.CancelPaymentIntentResponse{ReferenceId: refID, Status: pb.PaymentStatus_CANCELED, Message: "Payment intent canceled"}
+
+	paymentIntent, err := h.repo.GetIntent(ctx, refID)
+	if err != nil {
 		return nil, err
 	}
+	if paymentIntent == nil {
+		return &pb.CancelPaymentIntentResponse{ReferenceId: refID, Status: pb.PaymentStatus_FAILED, Message: "intent does not exist"}, nil
+	}
+	if paymentIntent.Status == repository.StatusCanceled {
+		return canceled, nil
+	}
+	if err := repository.CheckTransition(paymentIntent.Status, repository.StatusCanceled); err != nil {
+		return nil, transitionError(err)
+	}
 
+	// releasing an already released hold succeeds, so a retry after a failure
+	// below gets here again and completes the cancel
+	releaseResp, err := h.accountsClient.ReleaseFunds(ctx, &pb.ReleaseRequest{ReferenceId: refID})
+	if err != nil {
+		if err := accountsUnavailable("release funds", err); err != nil {
+			return nil, err
+		}
+		return &pb.CancelPaymentIntentResponse{ReferenceId: refID, Status: pb.PaymentStatus_FAILED, Message: err.Error()}, nil
+	}
+	if releaseResp.Status != "SUCCESS" {
+		return &pb.CancelPaymentIntentResponse{ReferenceId: refID, Status: pb.PaymentStatus_FAILED, Message: releaseResp.Message}, nil
+	}
+
+	tx, err := h.repo.BeginTx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	ok, err := h.repo.CancelIntentTx(ctx, tx, refID, req.ReasonCode, actorFromContext(ctx))
+	if err != nil {
+		return nil, transitionError(err)
+	}
+	if !ok {
+		// a concurrent cancel won the race
+		return canceled, nil
+	}
+	paymentEvent := events.PaymentEvent{
+		EventType:   "PAYMENT_CANCELED",
+		ReferenceID: refID,
+		Reason:      req.ReasonCode,
+		PayerId:     paymentIntent.PayerID,
+		PayeeId:     paymentIntent.PayeeID,
+		Amount:      paymentIntent.Amount,
+		PayeeAmount: paymentIntent.PayeeAmount,
+		Timestamp:   time.Now().Unix(),
+	}
+	if err := h.emitLegs(ctx, tx, paymentEvent, paymentIntent.Legs); err != nil {
+		return nil, err
+	}
 	if err := tx.Commit(ctx); err != nil {
 		return nil, fmt.Errorf("commit tx: %w", err)
 	}
+	return canceled, nil
+}
+
+func toPayment(pi *repository.PaymentIntent) *pb.Payment {
+	p := &pb.Payment{
+		ReferenceId:    pi.ReferenceID,
+		PayerId:        pi.PayerID,
+		PayeeId:        pi.PayeeID,
+		Amount:         pi.Amount.Amount,
+		Currency:       pi.Amount.Currency,
+		PayeeAmount:    pi.PayeeAmount.Amount,
+		PayeeCurrency:  pi.PayeeAmount.Currency,
+		QuoteId:        pi.QuoteID,
+		Status:         pb.PaymentStatus(pb.PaymentStatus_value[pi.Status]),
+		CapturedAmount: pi.Captured.Amount,
+		RefundedAmount: pi.Refunded.Amount,
+		CancelReason:   pi.CancelReason,
+		CreatedAt:      pi.CreatedAt.Unix(),
+		UpdatedAt:      pi.UpdatedAt.Unix(),
+		Legs:           toPbLegs(pi.Legs),
+		DisputedAmount: pi.Disputed.Amount,
+	}
+	if !pi.ExpiresAt.IsZero() {
+		p.ExpiresAt = pi.ExpiresAt.Unix()
+	}
+	if pi.Escrow {
+		p.Escrow = true
+		p.EscrowAmount = pi.Escrowed.Amount
+		if !pi.EscrowReleaseAt.IsZero() {
+			p.EscrowReleaseAt = pi.EscrowReleaseAt.Unix()
+		}
+	}
+	return p
+}
+
+// GetPayment returns an intent with its payments rows, its status history and
+// the state of its outbox events.
+func (h *PaymentHandler) GetPayment(ctx context.Context, req *pb.GetPaymentRequest) (*pb.GetPaymentResponse, error) {
+	if req.ReferenceId == "" {
+		return nil, status.Error(codes.InvalidArgument, "reference_id required")
+	}
+	paymentIntent, err := h.repo.GetIntent(ctx, req.ReferenceId)
+	if err != nil {
+		return nil, err
+	}
+	if paymentIntent == nil {
+		return nil, status.Error(codes.NotFound, "intent does not exist")
+	}
+	txs, err := h.repo.ListPaymentTxs(ctx, req.ReferenceId)
+	if err != nil {
+		return nil, err
+	}
+	history, err := h.repo.ListStatusHistory(ctx, req.ReferenceId)
+	if err != nil {
+		return nil, err
+	}
+	outboxEvents, err := h.outboxRepo.ListByReference(ctx, req.ReferenceId)
+	if err != nil {
+		return nil, fmt.Errorf("list outbox events: %w", err)
+	}
 
-	resp := pb.CapturePaymentResponse{ReferenceId: refID, Status: pb.PaymentStatus_CAPTURED, Message: "Payment processed successfully"}
-	if jb, err := json.Marshal(resp); err == nil {
-		_ = h.idempRepo.SaveResponse(ctx, refID, jb)
+	resp := &pb.GetPaymentResponse{Payment: toPayment(paymentIntent)}
+	for _, p := range txs {
+		resp.Transactions = append(resp.Transactions, &pb.PaymentTransaction{
+			Id:        p.ID,
+			CaptureId: p.CaptureID,
+			AccountId: p.AccountID,
+			TxnType:   p.TxnType,
+			Amount:    p.Amount.Amount,
+			Currency:  p.Amount.Currency,
+			Status:    p.Status,
+			CreatedAt: p.CreatedAt.Unix(),
+		})
+	}
+	for _, t := range history {
+		resp.History = append(resp.History, &pb.StatusTransition{
+			FromStatus: t.From,
+			ToStatus:   t.To,
+			Actor:      t.Actor,
+			Reason:     t.Reason,
+			CreatedAt:  t.CreatedAt.Unix(),
+		})
+	}
+	for _, e := range outboxEvents {
+		resp.Events = append(resp.Events, &pb.OutboxEventStatus{
+			Id:         int64(e.ID),
+			EventType:  e.EventType,
+			Status:     e.Status,
+			RetryCount: int32(e.RetryCount),
+			CreatedAt:  e.CreatedAt.Unix(),
+			UpdatedAt:  e.UpdatedAt.Unix(),
+		})
+	}
+	return resp, nil
+}
+
+// ListPayments lists intents newest first, filtered and paginated by cursor.
+func (h *PaymentHandler) ListPayments(ctx context.Context, req *pb.ListPaymentsRequest) (*pb.ListPaymentsResponse, error) {
+	if req.MinAmount < 0 || req.MaxAmount < 0 || (req.MaxAmount > 0 && req.MinAmount > req.MaxAmount) {
+		return nil, status.Error(codes.InvalidArgument, "invalid amount range")
+	}
+	filter := repository.PaymentFilter{
+		PayerID:   req.PayerId,
+		PayeeID:   req.PayeeId,
+		MinAmount: req.MinAmount,
+		MaxAmount: req.MaxAmount,
+	}
+	if req.Status != pb.PaymentStatus_UNKNOWN {
+		filter.Status = req.Status.String()
+	}
+	if req.CreatedFrom > 0 {
+		filter.CreatedFrom = time.Unix(req.CreatedFrom, 0).UTC()
+	}
+	if req.CreatedTo > 0 {
+		filter.CreatedTo = time.Unix(req.CreatedTo, 0).UTC()
+	}
+	pageSize := int(req.PageSize)
+	if pageSize <= 0 {
+		pageSize = 50
+	}
+	if pageSize > 500 {
+		pageSize = 500
+	}
+
+	list, next, err := h.repo.ListIntents(ctx, filter, req.PageToken, pageSize)
+	if err != nil {
+		if errors.Is(err, repository.ErrInvalidPageToken) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, err
+	}
+	resp := &pb.ListPaymentsResponse{NextPageToken: next}
+	for _, pi := range list {
+		resp.Payments = append(resp.Payments, toPayment(pi))
 	}
-	return &resp, nil
+	return resp, nil
 }
@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/parasagrawal71/bank-settlement-system/services/payments-service/internal/repository"
+	"github.com/parasagrawal71/bank-settlement-system/shared/auth"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// idempotencyPollInterval is how often a duplicate request checks whether the
+// request holding its key has finished.
+const idempotencyPollInterval = 100 * time.Millisecond
+
+// requestHash fingerprints a request body so a reused key can be told apart
+// from a retry.
+func requestHash(req proto.Message) (string, error) {
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(req)
+	if err != nil {
+		return "", fmt.Errorf("hash request: %w", err)
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// idempotencyPrincipal is whom the keys of a request belong to: the
+// authenticated caller, or no one when authentication is disabled.
+func idempotencyPrincipal(ctx context.Context) string {
+	if p := auth.PrincipalFromContext(ctx); p != nil {
+		return p.Name
+	}
+	return ""
+}
+
+// idempotent runs fn once per caller, operation and idempotency key. Repeating the
+// request with the same key and body returns the stored response; reusing the
+// key with another body fails with InvalidArgument. A duplicate that arrives
+// while the first request is still running waits up to idempotencyWait for it
+// and then fails with Aborted. Requests without a key always run fn.
+//
+// Only responses are stored: when fn fails with an error the key is released
+// and a retry runs fn again, so fn returns an error, not a FAILED response, for
+// failures a retry can get past, such as accounts-service being unavailable.
+func idempotent[Req, Resp proto.Message](ctx context.Context, h *PaymentHandler, operation, key string, req Req,
+	fn func(context.Context, Req) (Resp, error)) (Resp, error) {
+	var zero Resp
+	if key == "" {
+		return fn(ctx, req)
+	}
+	hash, err := requestHash(req)
+	if err != nil {
+		return zero, err
+	}
+
+	principal := idempotencyPrincipal(ctx)
+	deadline := time.Now().Add(h.idempotencyWait)
+	for {
+		stored, err := h.idempRepo.Acquire(ctx, principal, operation, key, hash, h.idempotencyLockTimeout, h.idempotencyKeyTTL)
+		switch {
+		case errors.Is(err, repository.ErrIdempotencyKeyReused):
+			return zero, status.Error(codes.InvalidArgument, err.Error())
+		case errors.Is(err, repository.ErrIdempotencyInProgress):
+			if time.Now().After(deadline) {
+				return zero, status.Error(codes.Aborted, err.Error())
+			}
+			select {
+			case <-ctx.Done():
+				return zero, ctx.Err()
+			case <-time.After(idempotencyPollInterval):
+			}
+			continue
+		case err != nil:
+			return zero, fmt.Errorf("acquire idempotency key: %w", err)
+		case stored != nil:
+			resp := zero.ProtoReflect().New().Interface().(Resp)
+			if err := protojson.Unmarshal(stored, resp); err != nil {
+				return zero, fmt.Errorf("decode stored response: %w", err)
+			}
+			return resp, nil
+		}
+		break
+	}
+
+	resp, err := fn(ctx, req)
+	if err != nil {
+		// let a retry run the request again
+		if relErr := h.idempRepo.Release(context.WithoutCancel(ctx), principal, operation, key); relErr != nil {
+			log.Printf("release idempotency key %s/%s: %v", operation, key, relErr)
+		}
+		return resp, err
+	}
+	b, err := protojson.Marshal(resp)
+	if err != nil {
+		return zero, fmt.Errorf("encode response: %w", err)
+	}
+	if err := h.idempRepo.Complete(context.WithoutCancel(ctx), principal, operation, key, b); err != nil {
+		// the request did run; a retry after the lock lapses runs it again
+		log.Printf("store response for idempotency key %s/%s: %v", operation, key, err)
+	}
+	return resp, nil
+}
@@ -0,0 +1,421 @@
+package handler
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/parasagrawal71/bank-settlement-system/services/payments-service/internal/repository"
+	pb "github.com/parasagrawal71/bank-settlement-system/services/payments-service/proto"
+	"github.com/parasagrawal71/bank-settlement-system/shared/money"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// a claimed row is tried again after payoutRowLease if its attempt ended
+	// without a result, up to payoutMaxAttempts times
+	payoutRowLease    = time.Minute
+	payoutMaxAttempts = 5
+	// a row whose payment the risk rules held is looked at again after
+	// payoutReviewRecheck, for as long as the review takes
+	payoutReviewRecheck = time.Minute
+	// rejections listed in the error of an invalid file
+	maxListedPayoutErrors = 20
+)
+
+var payoutColumns = []string{"payer_id", "payee_id", "amount", "currency", "reference"}
+
+type payoutLine struct {
+	PayerID   string `json:"payer_id"`
+	PayeeID   string `json:"payee_id"`
+	Amount    int64  `json:"amount"`
+	Currency  string `json:"currency"`
+	Reference string `json:"reference"`
+}
+
+// parsePayoutFile reads the rows of a CSV or JSONL payout file. Rows that cannot
+// be read are returned as rejections along with the rows that can; a file that
+// cannot be read at all is an error.
+func parsePayoutFile(format, file string) ([]repository.PayoutRow, []string, error) {
+	var payouts []repository.PayoutRow
+	var rejected []string
+	add := func(line int, p payoutLine) {
+		payouts = append(payouts, repository.PayoutRow{
+			Line: line, PayerID: p.PayerID, PayeeID: p.PayeeID, Amount: p.Amount, Currency: p.Currency, Reference: p.Reference})
+	}
+
+	switch format {
+	case "CSV":
+		r := csv.NewReader(strings.NewReader(file))
+		r.TrimLeadingSpace = true
+		header, err := r.Read()
+		if err != nil {
+			return nil, nil, fmt.Errorf("read CSV header: %w", err)
+		}
+		col := make(map[string]int)
+		for i, name := range header {
+			name = strings.ToLower(strings.TrimSpace(name))
+			if !slices.Contains(payoutColumns, name) {
+				return nil, nil, fmt.Errorf("unknown CSV column %q", name)
+			}
+			col[name] = i
+		}
+		for _, name := range []string{"payer_id", "payee_id", "amount", "reference"} {
+			if _, ok := col[name]; !ok {
+				return nil, nil, fmt.Errorf("CSV column %s missing", name)
+			}
+		}
+		field := func(record []string, name string) string {
+			if i, ok := col[name]; ok {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		for {
+			record, err := r.Read()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, nil, fmt.Errorf("read CSV: %w", err)
+			}
+			line, _ := r.FieldPos(0)
+			amount, err := strconv.ParseInt(field(record, "amount"), 10, 64)
+			if err != nil {
+				rejected = append(rejected, fmt.Sprintf("line %d: amount must be an integer in minor units", line))
+				continue
+			}
+			add(line, payoutLine{
+				PayerID: field(record, "payer_id"), PayeeID: field(record, "payee_id"), Amount: amount,
+				Currency: field(record, "currency"), Reference: field(record, "reference")})
+		}
+	case "JSONL":
+		sc := bufio.NewScanner(strings.NewReader(file))
+		sc.Buffer(make([]byte, 64<<10), 1<<20)
+		for line := 1; sc.Scan(); line++ {
+			if strings.TrimSpace(sc.Text()) == "" {
+				continue
+			}
+			var p payoutLine
+			dec := json.NewDecoder(bytes.NewReader(sc.Bytes()))
+			dec.DisallowUnknownFields()
+			if err := dec.Decode(&p); err != nil {
+				rejected = append(rejected, fmt.Sprintf("line %d: %v", line, err))
+				continue
+			}
+			add(line, p)
+		}
+		if err := sc.Err(); err != nil {
+			return nil, nil, fmt.Errorf("read JSONL: %w", err)
+		}
+	default:
+		return nil, nil, fmt.Errorf("format must be CSV or JSONL")
+	}
+	return payouts, rejected, nil
+}
+
+func (h *PaymentHandler) SubmitPayoutBatch(ctx context.Context, req *pb.SubmitPayoutBatchRequest) (*pb.PayoutBatch, error) {
+	return idempotent(ctx, h, "SubmitPayoutBatch", req.IdempotencyKey, req, h.submitPayoutBatch)
+}
+
+// submitPayoutBatch checks every row of a payout file and stores the batch only
+// when all of them are valid, so a file is never half paid because of a typo.
+// The rows are paid by ProcessPayouts.
+func (h *PaymentHandler) submitPayoutBatch(ctx context.Context, req *pb.SubmitPayoutBatchRequest) (*pb.PayoutBatch, error) {
+	format := strings.ToUpper(req.Format)
+	payouts, rejected, err := parsePayoutFile(format, req.File)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if len(payouts)+len(rejected) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "payout file has no rows")
+	}
+	if len(payouts)+len(rejected) > h.payoutMaxRows {
+		return nil, status.Errorf(codes.InvalidArgument, "payout file has more than %d rows", h.payoutMaxRows)
+	}
+	invalid, err := h.validatePayouts(ctx, payouts)
+	if err != nil {
+		return nil, err
+	}
+	rejected = append(rejected, invalid...)
+	if len(rejected) > 0 {
+		msg := strings.Join(rejected[:min(len(rejected), maxListedPayoutErrors)], "; ")
+		if len(rejected) > maxListedPayoutErrors {
+			msg += fmt.Sprintf("; and %d more", len(rejected)-maxListedPayoutErrors)
+		}
+		return nil, status.Errorf(codes.InvalidArgument, "payout file has %d invalid rows: %s", len(rejected), msg)
+	}
+
+	batch := repository.PayoutBatch{ID: "pb_" + genRef(), Format: format, Actor: actorFromContext(ctx)}
+	if err := h.payoutRepo.CreateBatch(ctx, batch, payouts); err != nil {
+		return nil, err
+	}
+	stored, err := h.payoutRepo.GetBatch(ctx, batch.ID)
+	if err != nil {
+		return nil, err
+	}
+	return toPayoutBatch(stored), nil
+}
+
+// validatePayouts returns the rejections of rows that could be read: missing
+// fields, unknown or mismatched accounts and references used before. An account
+// that cannot be looked up because accounts-service is unavailable fails the
+// whole check rather than the row.
+func (h *PaymentHandler) validatePayouts(ctx context.Context, payouts []repository.PayoutRow) ([]string, error) {
+	var rejected []string
+	reject := func(line int, format string, args ...any) {
+		rejected = append(rejected, fmt.Sprintf("line %d: ", line)+fmt.Sprintf(format, args...))
+	}
+
+	// each account is looked up once, however many rows name it
+	type lookup struct {
+		account *pb.AccountResponse
+		err     error
+	}
+	accounts := make(map[string]lookup)
+	account := func(id string) (*pb.AccountResponse, error) {
+		l, ok := accounts[id]
+		if !ok {
+			l.account, l.err = h.accountsClient.GetAccount(ctx, &pb.GetAccountRequest{AccountId: id})
+			accounts[id] = l
+		}
+		return l.account, l.err
+	}
+
+	seen := make(map[string]int)
+	refs := make([]string, 0, len(payouts))
+	for i := range payouts {
+		p := &payouts[i]
+		switch {
+		case p.PayerID == "" || p.PayeeID == "" || p.Reference == "":
+			reject(p.Line, "payer_id, payee_id and reference required")
+			continue
+		case p.PayerID == p.PayeeID:
+			reject(p.Line, "payer and payee are the same account")
+			continue
+		case p.Amount <= 0:
+			reject(p.Line, "amount must be positive")
+			continue
+		case len(p.Reference) > 100:
+			reject(p.Line, "reference longer than 100 characters")
+			continue
+		}
+		if first, ok := seen[p.Reference]; ok {
+			reject(p.Line, "reference %s repeats line %d", p.Reference, first)
+			continue
+		}
+		seen[p.Reference] = p.Line
+		refs = append(refs, p.Reference)
+
+		payer, err := account(p.PayerID)
+		if err != nil {
+			if err := accountsUnavailable("payer "+p.PayerID, err); err != nil {
+				return nil, err
+			}
+			reject(p.Line, "payer %s: %v", p.PayerID, status.Convert(err).Message())
+			continue
+		}
+		if _, err := account(p.PayeeID); err != nil {
+			if err := accountsUnavailable("payee "+p.PayeeID, err); err != nil {
+				return nil, err
+			}
+			reject(p.Line, "payee %s: %v", p.PayeeID, status.Convert(err).Message())
+			continue
+		}
+		if p.Currency != "" {
+			currency, err := money.NormalizeCurrency(p.Currency)
+			if err != nil {
+				reject(p.Line, "%v", err)
+				continue
+			}
+			if currency != payer.Currency {
+				reject(p.Line, "payer account is %s, amount is %s", payer.Currency, currency)
+				continue
+			}
+			p.Currency = currency
+		}
+	}
+
+	used, err := h.payoutRepo.UsedReferences(ctx, refs)
+	if err != nil {
+		return nil, err
+	}
+	for _, ref := range used {
+		reject(seen[ref], "reference %s is already used", ref)
+	}
+	return rejected, nil
+}
+
+func toPayoutBatch(b *repository.PayoutBatch) *pb.PayoutBatch {
+	resp := &pb.PayoutBatch{
+		BatchId:       b.ID,
+		Format:        b.Format,
+		Status:        b.Status,
+		TotalRows:     int32(b.TotalRows),
+		PendingRows:   int32(b.Pending),
+		SucceededRows: int32(b.Succeeded),
+		FailedRows:    int32(b.Failed),
+		CreatedAt:     b.CreatedAt.Unix(),
+	}
+	if !b.CompletedAt.IsZero() {
+		resp.CompletedAt = b.CompletedAt.Unix()
+	}
+	return resp
+}
+
+// GetPayoutBatch returns the progress of a batch and, on request, a CSV with the
+// outcome of every row.
+func (h *PaymentHandler) GetPayoutBatch(ctx context.Context, req *pb.GetPayoutBatchRequest) (*pb.PayoutBatch, error) {
+	b, err := h.payoutRepo.GetBatch(ctx, req.BatchId)
+	if errors.Is(err, repository.ErrPayoutBatchNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	resp := toPayoutBatch(b)
+	if !req.IncludeResultFile {
+		return resp, nil
+	}
+
+	payouts, err := h.payoutRepo.ListRows(ctx, b.ID)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.Write([]string{"line", "reference", "payer_id", "payee_id", "amount", "currency", "status", "capture_id", "message"})
+	for _, p := range payouts {
+		w.Write([]string{strconv.Itoa(p.Line), p.Reference, p.PayerID, p.PayeeID, strconv.FormatInt(p.Amount, 10),
+			p.Currency, p.Status, p.CaptureID, p.Message})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, fmt.Errorf("write payout results: %w", err)
+	}
+	resp.ResultFile = buf.String()
+	return resp, nil
+}
+
+// ProcessPayouts pays pending payout rows, at most concurrency at a time, until
+// none is left to claim, and returns how many got a final result. A row whose
+// attempt fails stays pending for a later call; all such failures are returned
+// together.
+func (h *PaymentHandler) ProcessPayouts(ctx context.Context, concurrency int) (int, error) {
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		finished int
+		errs     []error
+	)
+	sem := make(chan struct{}, concurrency)
+	for {
+		payouts, err := h.payoutRepo.ClaimRows(ctx, concurrency*4, payoutRowLease)
+		if err != nil {
+			errs = append(errs, err)
+			break
+		}
+		if len(payouts) == 0 {
+			break
+		}
+		for _, p := range payouts {
+			sem <- struct{}{}
+			wg.Add(1)
+			go func() {
+				defer func() {
+					<-sem
+					wg.Done()
+				}()
+				done, err := h.processPayout(ctx, p)
+				mu.Lock()
+				defer mu.Unlock()
+				if err != nil {
+					errs = append(errs, fmt.Errorf("payout %s: %w", p.Reference, err))
+				}
+				if done {
+					finished++
+				}
+			}()
+		}
+		wg.Wait()
+	}
+	return finished, errors.Join(errs...)
+}
+
+// processPayout authorizes and captures one row through the public RPCs. The
+// idempotency keys are derived from the reference, so a retried row gets the
+// results of the calls that already went through and resumes a pending capture.
+// The RPCs answer FAILED only for a rejection, which fails the row; a failure a
+// retry can get past, such as accounts-service being unavailable, is an error
+// that keeps the row pending for another attempt and leaves its key free. A
+// payment held for risk review keeps the row pending until it is approved,
+// when it is captured, or declined, when the row fails.
+func (h *PaymentHandler) processPayout(ctx context.Context, p *repository.PayoutRow) (bool, error) {
+	ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("x-actor", "payout:"+p.BatchID))
+	key := "payout:" + p.Reference
+
+	intent, err := h.CreatePaymentIntent(ctx, &pb.CreatePaymentIntentRequest{
+		PayerId: p.PayerID, PayeeId: p.PayeeID, ReferenceId: p.Reference, Amount: p.Amount, Currency: p.Currency,
+		IdempotencyKey: key})
+	if err != nil {
+		return h.payoutAttemptFailed(ctx, p, fmt.Errorf("authorize: %w", err))
+	}
+	if intent.Status == pb.PaymentStatus_PENDING_REVIEW {
+		// the stored response stays PENDING_REVIEW after the review; the
+		// intent tells how it ended
+		pi, err := h.repo.GetIntent(ctx, p.Reference)
+		if err != nil {
+			return h.payoutAttemptFailed(ctx, p, fmt.Errorf("authorize: %w", err))
+		}
+		switch {
+		case pi == nil || pi.Status == repository.StatusPendingReview:
+			return false, h.payoutRepo.DeferRow(ctx, p, "pending risk review", payoutReviewRecheck)
+		case pi.Status == repository.StatusFailed:
+			p.Status, p.Message = repository.PayoutFailed, "declined in risk review"
+			return true, h.payoutRepo.FinishRow(ctx, p)
+		}
+	} else if intent.Status != pb.PaymentStatus_AUTHORIZED {
+		p.Status, p.Message = repository.PayoutFailed, intent.Message
+		return true, h.payoutRepo.FinishRow(ctx, p)
+	}
+
+	capture, err := h.CapturePayment(ctx, &pb.CapturePaymentRequest{ReferenceId: p.Reference, Final: true, IdempotencyKey: key})
+	if err != nil {
+		return h.payoutAttemptFailed(ctx, p, fmt.Errorf("capture: %w", err))
+	}
+	if capture.Status != pb.PaymentStatus_CAPTURED {
+		if capture.Status == pb.PaymentStatus_FAILED {
+			// best effort: otherwise the hold lasts until it expires
+			h.CancelPaymentIntent(ctx, &pb.CancelPaymentIntentRequest{ReferenceId: p.Reference, ReasonCode: "PAYOUT_FAILED", IdempotencyKey: key})
+		}
+		p.Status, p.Message = repository.PayoutFailed, capture.Message
+		return true, h.payoutRepo.FinishRow(ctx, p)
+	}
+	p.Status, p.CaptureID, p.Message = repository.PayoutSucceeded, capture.CaptureId, ""
+	return true, h.payoutRepo.FinishRow(ctx, p)
+}
+
+// payoutAttemptFailed fails a row for good when retrying cannot help or it has
+// been tried payoutMaxAttempts times, and keeps it pending otherwise.
+func (h *PaymentHandler) payoutAttemptFailed(ctx context.Context, p *repository.PayoutRow, err error) (bool, error) {
+	if status.Code(err) == codes.InvalidArgument || p.Attempts >= payoutMaxAttempts {
+		p.Status, p.Message = repository.PayoutFailed, err.Error()
+		return true, h.payoutRepo.FinishRow(ctx, p)
+	}
+	if noteErr := h.payoutRepo.NoteRowError(ctx, p, err.Error()); noteErr != nil {
+		return false, errors.Join(err, noteErr)
+	}
+	return false, err
+}
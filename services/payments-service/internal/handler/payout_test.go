@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/parasagrawal71/bank-settlement-system/services/payments-service/internal/repository"
+)
+
+func TestParsePayoutFile(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   string
+		file     string
+		rows     []repository.PayoutRow
+		rejected []string
+		err      string
+	}{
+		{
+			name:   "csv",
+			format: "CSV",
+			file:   "payer_id,payee_id,amount,currency,reference\np1,e1,1000,USD,r1\n p2 , e2 , 250 ,,r2\n",
+			rows: []repository.PayoutRow{
+				{Line: 2, PayerID: "p1", PayeeID: "e1", Amount: 1000, Currency: "USD", Reference: "r1"},
+				{Line: 3, PayerID: "p2", PayeeID: "e2", Amount: 250, Reference: "r2"},
+			},
+		},
+		{
+			name:   "csv columns in any order and case, currency optional",
+			format: "CSV",
+			file:   "Reference,AMOUNT,payee_id,payer_id\nr1,500,e1,p1\n",
+			rows:   []repository.PayoutRow{{Line: 2, PayerID: "p1", PayeeID: "e1", Amount: 500, Reference: "r1"}},
+		},
+		{
+			name:     "csv amount not in minor units",
+			format:   "CSV",
+			file:     "payer_id,payee_id,amount,reference\np1,e1,10.50,r1\np1,e1,x,r2\np1,e1,7,r3\n",
+			rows:     []repository.PayoutRow{{Line: 4, PayerID: "p1", PayeeID: "e1", Amount: 7, Reference: "r3"}},
+			rejected: []string{"line 2: amount must be an integer in minor units", "line 3: amount must be an integer in minor units"},
+		},
+		{
+			name:   "csv unknown column",
+			format: "CSV",
+			file:   "payer_id,payee_id,amount,reference,note\n",
+			err:    `unknown CSV column "note"`,
+		},
+		{
+			name:   "csv missing column",
+			format: "CSV",
+			file:   "payer_id,payee_id,amount\n",
+			err:    "CSV column reference missing",
+		},
+		{
+			name:   "csv short record",
+			format: "CSV",
+			file:   "payer_id,payee_id,amount,reference\np1,e1,100\n",
+			err:    "read CSV",
+		},
+		{
+			name:   "csv empty",
+			format: "CSV",
+			file:   "",
+			err:    "read CSV header",
+		},
+		{
+			name:   "jsonl",
+			format: "JSONL",
+			file:   "{\"payer_id\":\"p1\",\"payee_id\":\"e1\",\"amount\":1000,\"currency\":\"USD\",\"reference\":\"r1\"}\n\n{\"payer_id\":\"p2\",\"payee_id\":\"e2\",\"amount\":5,\"reference\":\"r2\"}\n",
+			rows: []repository.PayoutRow{
+				{Line: 1, PayerID: "p1", PayeeID: "e1", Amount: 1000, Currency: "USD", Reference: "r1"},
+				{Line: 3, PayerID: "p2", PayeeID: "e2", Amount: 5, Reference: "r2"},
+			},
+		},
+		{
+			name:     "jsonl bad lines",
+			format:   "JSONL",
+			file:     "{\"payer_id\":\"p1\",\"amount\":\"10\"}\n{\"payer_id\":\"p1\",\"memo\":\"x\"}\nnot json\n",
+			rejected: []string{"line 1:", "line 2:", "line 3:"},
+		},
+		{
+			name:   "unknown format",
+			format: "XML",
+			file:   "<payouts/>",
+			err:    "format must be CSV or JSONL",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, rejected, err := parsePayoutFile(tt.format, tt.file)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("parsePayoutFile() error = %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parsePayoutFile() error = %v", err)
+			}
+			if !reflect.DeepEqual(rows, tt.rows) {
+				t.Errorf("rows = %+v, want %+v", rows, tt.rows)
+			}
+			if len(rejected) != len(tt.rejected) {
+				t.Fatalf("rejected = %q, want %q", rejected, tt.rejected)
+			}
+			for i, prefix := range tt.rejected {
+				if !strings.HasPrefix(rejected[i], prefix) {
+					t.Errorf("rejected[%d] = %q, want prefix %q", i, rejected[i], prefix)
+				}
+			}
+		})
+	}
+}
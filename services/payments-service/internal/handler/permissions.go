@@ -0,0 +1,41 @@
+package handler
+
+import (
+	pb "github.com/parasagrawal71/bank-settlement-system/services/payments-service/proto"
+	"github.com/parasagrawal71/bank-settlement-system/shared/auth"
+)
+
+var (
+	backOffice = []string{auth.RoleAdmin, auth.RoleOperator}
+	clients    = []string{auth.RoleAdmin, auth.RoleOperator, auth.RoleClient}
+)
+
+// Permissions lists who may call each RPC. Risk reviews and dispute outcomes
+// are decided by the back office. A principal is not tied to an account, so
+// the RPCs that act for one side of a payment or on an account's events
+// (escrow release and refund, webhook endpoints and deliveries) are kept to
+// the back office too.
+var Permissions = auth.Permissions{
+	pb.PaymentService_CreatePaymentIntent_FullMethodName:     clients,
+	pb.PaymentService_CapturePayment_FullMethodName:          clients,
+	pb.PaymentService_RefundPayment_FullMethodName:           clients,
+	pb.PaymentService_CancelPaymentIntent_FullMethodName:     clients,
+	pb.PaymentService_GetPayment_FullMethodName:              clients,
+	pb.PaymentService_ListPayments_FullMethodName:            clients,
+	pb.PaymentService_RegisterWebhookEndpoint_FullMethodName: backOffice,
+	pb.PaymentService_ListWebhookEndpoints_FullMethodName:    backOffice,
+	pb.PaymentService_DisableWebhookEndpoint_FullMethodName:  backOffice,
+	pb.PaymentService_ListWebhookDeliveries_FullMethodName:   backOffice,
+	pb.PaymentService_GetWebhookDelivery_FullMethodName:      backOffice,
+	pb.PaymentService_ReplayWebhookDelivery_FullMethodName:   backOffice,
+	pb.PaymentService_SubmitPayoutBatch_FullMethodName:       clients,
+	pb.PaymentService_GetPayoutBatch_FullMethodName:          clients,
+	pb.PaymentService_ReleaseEscrow_FullMethodName:           backOffice,
+	pb.PaymentService_RefundEscrow_FullMethodName:            backOffice,
+	pb.PaymentService_OpenDispute_FullMethodName:             backOffice,
+	pb.PaymentService_SubmitDisputeEvidence_FullMethodName:   clients,
+	pb.PaymentService_ResolveDispute_FullMethodName:          backOffice,
+	pb.PaymentService_GetDispute_FullMethodName:              clients,
+	pb.PaymentService_ReviewPaymentIntent_FullMethodName:     backOffice,
+	pb.PaymentService_ListRiskEvaluations_FullMethodName:     backOffice,
+}
@@ -0,0 +1,251 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/parasagrawal71/bank-settlement-system/services/payments-service/internal/events"
+	"github.com/parasagrawal71/bank-settlement-system/services/payments-service/internal/repository"
+	"github.com/parasagrawal71/bank-settlement-system/services/payments-service/internal/risk"
+	pb "github.com/parasagrawal71/bank-settlement-system/services/payments-service/proto"
+	"github.com/parasagrawal71/bank-settlement-system/shared/money"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// assessRisk runs the risk rules on an intent about to reserve its funds and
+// records their decision.
+func (h *PaymentHandler) assessRisk(ctx context.Context, refID, payerID, payeeID string, amount money.Money, legs []repository.PaymentLeg) (*risk.Result, error) {
+	p := risk.Payment{PayerID: payerID, Amount: amount}
+	for _, l := range legs {
+		p.Payees = append(p.Payees, risk.Payee{ID: l.PayeeID, Amount: l.Amount})
+	}
+	if len(legs) == 0 {
+		p.Payees = []risk.Payee{{ID: payeeID, Amount: amount.Amount}}
+	}
+	res, err := h.risk.Evaluate(ctx, p, h.repo)
+	if err != nil {
+		return nil, fmt.Errorf("risk rules: %w", err)
+	}
+	err = h.repo.InsertRiskEvaluation(ctx, repository.RiskEvaluation{
+		ReferenceID:  refID,
+		PayerID:      payerID,
+		PayeeID:      payeeID,
+		Amount:       amount,
+		Decision:     string(res.Decision),
+		Reasons:      res.Reasons,
+		RulesVersion: res.Version,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if res.Decision != risk.Allow {
+		log.Printf("risk rules: %s payment intent %s (%s)", res.Decision, refID, strings.Join(res.Reasons, ", "))
+	}
+	return res, nil
+}
+
+// holdForReview stores an intent the risk rules want reviewed in PENDING_REVIEW.
+// Its funds are only reserved once an operator approves it.
+func (h *PaymentHandler) holdForReview(ctx context.Context, req *pb.CreatePaymentIntentRequest, refID, payeeID string, amount, payeeAmount money.Money, legs []repository.PaymentLeg, assessment *risk.Result) (*pb.CreatePaymentIntentResponse, error) {
+	reasons := strings.Join(assessment.Reasons, ", ")
+
+	tx, err := h.repo.BeginTx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := h.repo.CreateReviewIntentTx(ctx, tx, refID, req.PayerId, payeeID, amount, payeeAmount, req.HoldTtlSeconds, actorFromContext(ctx), "risk review: "+reasons); err != nil {
+		return nil, err
+	}
+	if err := h.repo.InsertLegsTx(ctx, tx, refID, legs); err != nil {
+		return nil, err
+	}
+	if req.Escrow {
+		var releaseAt time.Time
+		if req.EscrowReleaseAt != 0 {
+			releaseAt = time.Unix(req.EscrowReleaseAt, 0)
+		}
+		if err := h.repo.SetEscrowTx(ctx, tx, refID, releaseAt); err != nil {
+			return nil, err
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit tx: %w", err)
+	}
+	return &pb.CreatePaymentIntentResponse{ReferenceId: refID, Status: pb.PaymentStatus_PENDING_REVIEW, Message: "held for risk review: " + reasons}, nil
+}
+
+func (h *PaymentHandler) ReviewPaymentIntent(ctx context.Context, req *pb.ReviewPaymentIntentRequest) (*pb.ReviewPaymentIntentResponse, error) {
+	return idempotent(ctx, h, "ReviewPaymentIntent", req.IdempotencyKey, req, h.reviewPaymentIntent)
+}
+
+func (h *PaymentHandler) reviewPaymentIntent(ctx context.Context, req *pb.ReviewPaymentIntentRequest) (*pb.ReviewPaymentIntentResponse, error) {
+	if req.ReferenceId == "" || (req.Decision != "APPROVE" && req.Decision != "DECLINE") {
+		return nil, status.Error(codes.InvalidArgument, "reference_id and a decision of APPROVE or DECLINE required")
+	}
+	paymentIntent, err := h.repo.GetIntent(ctx, req.ReferenceId)
+	if err != nil {
+		return nil, err
+	}
+	if paymentIntent == nil {
+		return nil, status.Errorf(codes.NotFound, "payment %s not found", req.ReferenceId)
+	}
+	if req.Decision == "DECLINE" {
+		return h.declineReview(ctx, paymentIntent, req.Reason)
+	}
+	return h.approveReview(ctx, paymentIntent, req.Reason)
+}
+
+func (h *PaymentHandler) declineReview(ctx context.Context, pi *repository.PaymentIntent, reason string) (*pb.ReviewPaymentIntentResponse, error) {
+	declined := &pb.ReviewPaymentIntentResponse{ReferenceId: pi.ReferenceID, Status: pb.PaymentStatus_FAILED, Message: "declined in review"}
+	if pi.Status == repository.StatusFailed {
+		return declined, nil
+	}
+	if pi.Status != repository.StatusPendingReview {
+		return nil, status.Errorf(codes.FailedPrecondition, "payment %s is %s, not pending review", pi.ReferenceID, pi.Status)
+	}
+
+	tx, err := h.repo.BeginTx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+	if _, err := h.repo.DeclineIntentTx(ctx, tx, pi.ReferenceID, actorFromContext(ctx), reviewReason("declined in review", reason)); err != nil {
+		return nil, transitionError(err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit tx: %w", err)
+	}
+	return declined, nil
+}
+
+// approveReview reserves the funds of an intent held for review, quoting a
+// cross-currency intent again since its quote has long expired, and authorizes
+// it. An intent whose funds cannot be reserved fails.
+func (h *PaymentHandler) approveReview(ctx context.Context, pi *repository.PaymentIntent, reason string) (*pb.ReviewPaymentIntentResponse, error) {
+	refID := pi.ReferenceID
+	if pi.Status == repository.StatusAuthorized {
+		return &pb.ReviewPaymentIntentResponse{ReferenceId: refID, Status: pb.PaymentStatus_AUTHORIZED, Message: "Authorised", ExpiresAt: pi.ExpiresAt.Unix()}, nil
+	}
+	if pi.Status != repository.StatusPendingReview {
+		return nil, status.Errorf(codes.FailedPrecondition, "payment %s is %s, not pending review", refID, pi.Status)
+	}
+	actor := actorFromContext(ctx)
+
+	payeeAmount := pi.PayeeAmount
+	quoteID := ""
+	if pi.PayeeAmount.Currency != pi.Amount.Currency {
+		quote, err := h.accountsClient.GetQuote(ctx, &pb.GetQuoteRequest{Amount: pi.Amount.Amount, FromCurrency: pi.Amount.Currency, ToCurrency: pi.PayeeAmount.Currency})
+		if err != nil {
+			return nil, status.Errorf(codes.Unavailable, "fx quote: %v", err)
+		}
+		quoteID = quote.QuoteId
+		payeeAmount = money.Money{Amount: quote.TargetAmount, Currency: quote.ToCurrency}
+	}
+
+	var reserveLegs []*pb.PayeeLeg
+	for _, l := range pi.Legs {
+		reserveLegs = append(reserveLegs, &pb.PayeeLeg{PayeeId: l.PayeeID, Amount: l.Amount})
+	}
+	reserveResp, err := h.accountsClient.ReserveFunds(ctx, &pb.ReserveRequest{PayerId: pi.PayerID, PayeeId: pi.PayeeID, Amount: pi.Amount.Amount, Currency: pi.Amount.Currency, ReferenceId: refID, QuoteId: quoteID, HoldTtlSeconds: pi.HoldTTLSeconds, Legs: reserveLegs, Escrow: pi.Escrow})
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "reserve funds: %v", err)
+	}
+	if reserveResp.Status != "SUCCESS" && !rejected(reserveResp.Reason) {
+		// the intent stays in review; approving it again reserves again, and a
+		// hold that went through is handed back rather than made twice
+		return nil, status.Errorf(codes.Unavailable, "reserve funds: %s", reserveResp.Message)
+	}
+
+	tx, err := h.repo.BeginTx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if reserveResp.Status != "SUCCESS" {
+		if _, err := h.repo.DeclineIntentTx(ctx, tx, refID, actor, reserveResp.Message); err != nil {
+			return nil, transitionError(err)
+		}
+		if err := tx.Commit(ctx); err != nil {
+			return nil, fmt.Errorf("commit tx: %w", err)
+		}
+		return &pb.ReviewPaymentIntentResponse{ReferenceId: refID, Status: pb.PaymentStatus_FAILED, Message: reserveResp.Message}, nil
+	}
+
+	expiresAt := time.Unix(reserveResp.ExpiresAt, 0)
+	ok, err := h.repo.ApproveIntentTx(ctx, tx, refID, payeeAmount, quoteID, expiresAt, actor, reviewReason("approved in review", reason))
+	if err != nil {
+		// declined while the funds were being reserved; best effort, otherwise
+		// the hold lasts until it expires
+		h.accountsClient.ReleaseFunds(ctx, &pb.ReleaseRequest{ReferenceId: refID})
+		return nil, transitionError(err)
+	}
+	if ok {
+		paymentEvent := events.PaymentEvent{
+			EventType:   "PAYMENT_AUTHORIZED",
+			ReferenceID: refID,
+			PayerId:     pi.PayerID,
+			PayeeId:     pi.PayeeID,
+			Amount:      pi.Amount,
+			PayeeAmount: payeeAmount,
+			Timestamp:   time.Now().Unix(),
+		}
+		if err := h.emitLegs(ctx, tx, paymentEvent, pi.Legs); err != nil {
+			return nil, err
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit tx: %w", err)
+	}
+	return &pb.ReviewPaymentIntentResponse{ReferenceId: refID, Status: pb.PaymentStatus_AUTHORIZED, Message: "Authorised", ExpiresAt: reserveResp.ExpiresAt}, nil
+}
+
+func reviewReason(what, reason string) string {
+	if reason == "" {
+		return what
+	}
+	return what + ": " + reason
+}
+
+func (h *PaymentHandler) ListRiskEvaluations(ctx context.Context, req *pb.ListRiskEvaluationsRequest) (*pb.ListRiskEvaluationsResponse, error) {
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = 50
+	}
+	if limit > 500 {
+		limit = 500
+	}
+	list, err := h.repo.ListRiskEvaluations(ctx, req.ReferenceId, req.PayerId, req.Decision, limit)
+	if err != nil {
+		return nil, err
+	}
+	resp := &pb.ListRiskEvaluationsResponse{}
+	for _, e := range list {
+		resp.Evaluations = append(resp.Evaluations, &pb.RiskEvaluation{
+			Id:           e.ID,
+			ReferenceId:  e.ReferenceID,
+			PayerId:      e.PayerID,
+			PayeeId:      e.PayeeID,
+			Amount:       e.Amount.Amount,
+			Currency:     e.Amount.Currency,
+			Decision:     e.Decision,
+			Reasons:      e.Reasons,
+			RulesVersion: e.RulesVersion,
+			CreatedAt:    e.CreatedAt.Unix(),
+		})
+	}
+	return resp, nil
+}
+
+// ReloadRiskRules reads the risk rules file again if it has changed. It
+// reports whether the rules changed; on error the rules in use are kept.
+func (h *PaymentHandler) ReloadRiskRules() (bool, string, error) {
+	changed, err := h.risk.Reload()
+	return changed, h.risk.Version(), err
+}
@@ -0,0 +1,120 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/parasagrawal71/bank-settlement-system/services/payments-service/internal/events"
+	"github.com/parasagrawal71/bank-settlement-system/services/payments-service/internal/repository"
+	pb "github.com/parasagrawal71/bank-settlement-system/services/payments-service/proto"
+	"github.com/parasagrawal71/bank-settlement-system/shared/money"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// resolveLegs turns the legs of a split intent into amounts. A leg has either a
+// fixed amount or basis points of amount; basis-point shares are rounded down
+// and what rounding loses goes to the first of them. The legs must add up to
+// amount exactly.
+func resolveLegs(amount money.Money, legs []*pb.PaymentLeg) ([]repository.PaymentLeg, error) {
+	if len(legs) < 2 {
+		return nil, status.Error(codes.InvalidArgument, "a split payment needs at least two legs")
+	}
+	res := make([]repository.PaymentLeg, len(legs))
+	firstBP := -1
+	var sum, bpSum, bpShares int64
+	for i, l := range legs {
+		if l.PayeeId == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "leg %d: payee_id required", i+1)
+		}
+		switch {
+		case l.Amount > 0 && l.BasisPoints == 0:
+			res[i] = repository.PaymentLeg{PayeeID: l.PayeeId, Amount: l.Amount}
+		case l.Amount == 0 && l.BasisPoints > 0 && l.BasisPoints <= 10000:
+			share := new(big.Int).Mul(big.NewInt(amount.Amount), big.NewInt(int64(l.BasisPoints)))
+			res[i] = repository.PaymentLeg{PayeeID: l.PayeeId, Amount: share.Quo(share, big.NewInt(10000)).Int64()}
+			bpSum += int64(l.BasisPoints)
+			bpShares += res[i].Amount
+			if firstBP < 0 {
+				firstBP = i
+			}
+		default:
+			return nil, status.Errorf(codes.InvalidArgument, "leg %d: give either a positive amount or basis_points between 1 and 10000", i+1)
+		}
+		var err error
+		if sum, err = money.AddInt64(sum, res[i].Amount); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "legs: %v", err)
+		}
+	}
+	if firstBP >= 0 {
+		exact := new(big.Int).Mul(big.NewInt(amount.Amount), big.NewInt(bpSum))
+		lost := exact.Quo(exact, big.NewInt(10000)).Int64() - bpShares
+		res[firstBP].Amount += lost
+		sum += lost
+	}
+	if sum != amount.Amount {
+		return nil, status.Errorf(codes.InvalidArgument, "legs add up to %d, amount is %d", sum, amount.Amount)
+	}
+	for i, l := range res {
+		if l.Amount <= 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "leg %d: share of the amount is zero", i+1)
+		}
+	}
+	return res, nil
+}
+
+func toPbLegs(legs []repository.PaymentLeg) []*pb.PaymentLeg {
+	var out []*pb.PaymentLeg
+	for _, l := range legs {
+		out = append(out, &pb.PaymentLeg{PayeeId: l.PayeeID, Amount: l.Amount})
+	}
+	return out
+}
+
+func fromPayeeLegs(legs []*pb.PayeeLeg) []repository.PaymentLeg {
+	var out []repository.PaymentLeg
+	for _, l := range legs {
+		out = append(out, repository.PaymentLeg{PayeeID: l.PayeeId, Amount: l.Amount})
+	}
+	return out
+}
+
+// emitLegs emits ev once per leg of a split payment, to that leg's payee and
+// for that leg's share, or ev itself when there are no legs. Legs without a
+// share in this capture or refund get no event.
+func (h *PaymentHandler) emitLegs(ctx context.Context, tx pgx.Tx, ev events.PaymentEvent, legs []repository.PaymentLeg) error {
+	if len(legs) == 0 {
+		return h.emit(ctx, tx, ev)
+	}
+	for i, l := range legs {
+		if l.Amount == 0 {
+			continue
+		}
+		legEv := ev
+		legEv.Leg = i + 1
+		legEv.PayeeId = l.PayeeID
+		legEv.Amount = money.Money{Amount: l.Amount, Currency: ev.Amount.Currency}
+		legEv.PayeeAmount = legEv.Amount
+		if err := h.emit(ctx, tx, legEv); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkLegPayees looks up the payee of every leg; a split payment does not
+// convert currencies, so each must hold the payer's currency.
+func (h *PaymentHandler) checkLegPayees(ctx context.Context, legs []repository.PaymentLeg, currency string) error {
+	for _, l := range legs {
+		payee, err := h.accountsClient.GetAccount(ctx, &pb.GetAccountRequest{AccountId: l.PayeeID})
+		if err != nil {
+			return fmt.Errorf("payee %s: %w", l.PayeeID, err)
+		}
+		if payee.Currency != currency {
+			return fmt.Errorf("payee %s: %w: payee account is %s, payer is %s", l.PayeeID, money.ErrCurrencyMismatch, payee.Currency, currency)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/url"
+	"slices"
+
+	"github.com/parasagrawal71/bank-settlement-system/services/payments-service/internal/repository"
+	"github.com/parasagrawal71/bank-settlement-system/services/payments-service/internal/webhooks"
+	pb "github.com/parasagrawal71/bank-settlement-system/services/payments-service/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func toWebhookEndpoint(e *repository.WebhookEndpoint) *pb.WebhookEndpoint {
+	return &pb.WebhookEndpoint{
+		Id:         e.ID,
+		AccountId:  e.AccountID,
+		Url:        e.URL,
+		EventTypes: e.EventTypes,
+		Active:     e.Active,
+		CreatedAt:  e.CreatedAt.Unix(),
+	}
+}
+
+func toWebhookDelivery(d *repository.WebhookDelivery) *pb.WebhookDelivery {
+	return &pb.WebhookDelivery{
+		Id:             d.ID,
+		EndpointId:     d.EndpointID,
+		EventId:        d.EventID,
+		EventType:      d.EventType,
+		ReferenceId:    d.ReferenceID,
+		Status:         d.Status,
+		Attempts:       int32(d.Attempts),
+		LastStatusCode: int32(d.LastStatusCode),
+		LastError:      d.LastError,
+		NextAttemptAt:  d.NextAttemptAt.Unix(),
+		ExpiresAt:      d.ExpiresAt.Unix(),
+		CreatedAt:      d.CreatedAt.Unix(),
+		Payload:        string(d.Payload),
+	}
+}
+
+func webhookError(err error) error {
+	if errors.Is(err, repository.ErrWebhookNotFound) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	return err
+}
+
+func (h *PaymentHandler) RegisterWebhookEndpoint(ctx context.Context, req *pb.RegisterWebhookEndpointRequest) (*pb.WebhookEndpoint, error) {
+	return idempotent(ctx, h, "RegisterWebhookEndpoint", req.IdempotencyKey, req, h.registerWebhookEndpoint)
+}
+
+// registerWebhookEndpoint stores an endpoint with a new signing secret, which
+// is returned only here. The URL's host must resolve to public addresses only,
+// so that webhooks cannot be aimed at services inside the network.
+func (h *PaymentHandler) registerWebhookEndpoint(ctx context.Context, req *pb.RegisterWebhookEndpointRequest) (*pb.WebhookEndpoint, error) {
+	if req.AccountId == "" {
+		return nil, status.Error(codes.InvalidArgument, "account_id required")
+	}
+	u, err := url.Parse(req.Url)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return nil, status.Error(codes.InvalidArgument, "url must be an absolute http(s) URL")
+	}
+	if err := webhooks.CheckHost(ctx, net.DefaultResolver, u.Hostname()); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "url: %v", err)
+	}
+	for _, t := range req.EventTypes {
+		if !slices.Contains(webhooks.EventTypes, t) {
+			return nil, status.Errorf(codes.InvalidArgument, "unknown event type %q", t)
+		}
+	}
+	if _, err := h.accountsClient.GetAccount(ctx, &pb.GetAccountRequest{AccountId: req.AccountId}); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "account: %v", err)
+	}
+
+	e, err := h.webhookRepo.CreateEndpoint(ctx, repository.WebhookEndpoint{
+		ID:         "we_" + genRef(),
+		AccountID:  req.AccountId,
+		URL:        u.String(),
+		Secret:     webhooks.NewSecret(),
+		EventTypes: req.EventTypes,
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp := toWebhookEndpoint(e)
+	resp.Secret = e.Secret
+	return resp, nil
+}
+
+func (h *PaymentHandler) ListWebhookEndpoints(ctx context.Context, req *pb.ListWebhookEndpointsRequest) (*pb.ListWebhookEndpointsResponse, error) {
+	list, err := h.webhookRepo.ListEndpoints(ctx, req.AccountId)
+	if err != nil {
+		return nil, err
+	}
+	resp := &pb.ListWebhookEndpointsResponse{}
+	for _, e := range list {
+		resp.Endpoints = append(resp.Endpoints, toWebhookEndpoint(e))
+	}
+	return resp, nil
+}
+
+func (h *PaymentHandler) DisableWebhookEndpoint(ctx context.Context, req *pb.DisableWebhookEndpointRequest) (*pb.WebhookEndpoint, error) {
+	e, err := h.webhookRepo.DisableEndpoint(ctx, req.EndpointId)
+	if err != nil {
+		return nil, webhookError(err)
+	}
+	return toWebhookEndpoint(e), nil
+}
+
+func (h *PaymentHandler) ListWebhookDeliveries(ctx context.Context, req *pb.ListWebhookDeliveriesRequest) (*pb.ListWebhookDeliveriesResponse, error) {
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = 50
+	}
+	if limit > 500 {
+		limit = 500
+	}
+	list, err := h.webhookRepo.ListDeliveries(ctx, req.EndpointId, req.ReferenceId, req.Status, limit)
+	if err != nil {
+		return nil, err
+	}
+	resp := &pb.ListWebhookDeliveriesResponse{}
+	for _, d := range list {
+		resp.Deliveries = append(resp.Deliveries, toWebhookDelivery(d))
+	}
+	return resp, nil
+}
+
+// GetWebhookDelivery returns a delivery with the log of its attempts.
+func (h *PaymentHandler) GetWebhookDelivery(ctx context.Context, req *pb.GetWebhookDeliveryRequest) (*pb.WebhookDelivery, error) {
+	d, err := h.webhookRepo.GetDelivery(ctx, req.DeliveryId)
+	if err != nil {
+		return nil, webhookError(err)
+	}
+	attempts, err := h.webhookRepo.ListAttempts(ctx, d.ID)
+	if err != nil {
+		return nil, err
+	}
+	resp := toWebhookDelivery(d)
+	for _, a := range attempts {
+		resp.AttemptLog = append(resp.AttemptLog, &pb.WebhookAttempt{
+			Attempt:    int32(a.Attempt),
+			StatusCode: int32(a.StatusCode),
+			Error:      a.Error,
+			DurationMs: a.Duration.Milliseconds(),
+			CreatedAt:  a.CreatedAt.Unix(),
+		})
+	}
+	return resp, nil
+}
+
+func (h *PaymentHandler) ReplayWebhookDelivery(ctx context.Context, req *pb.ReplayWebhookDeliveryRequest) (*pb.WebhookDelivery, error) {
+	d, err := h.webhookRepo.ReplayDelivery(ctx, req.DeliveryId, h.webhookMaxAge)
+	if err != nil {
+		return nil, webhookError(err)
+	}
+	return toWebhookDelivery(d), nil
+}
@@ -0,0 +1,159 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/parasagrawal71/bank-settlement-system/shared/money"
+)
+
+// Steps of a capture saga. A capture is STARTED before accounts-service is
+// asked to move the money, TRANSFERRED once it has, and COMPLETED when the
+// payments rows, outbox event and intent status are written. It is FAILED when
+// accounts-service refused the transfer, so nothing moved.
+const (
+	CaptureStarted     = "STARTED"
+	CaptureTransferred = "TRANSFERRED"
+	CaptureCompleted   = "COMPLETED"
+	CaptureFailed      = "FAILED"
+)
+
+type CaptureSaga struct {
+	ID          string
+	ReferenceID string
+	Requested   int64 // amount asked for, 0 for everything left
+	Final       bool
+	Step        string
+	// set from the accounts-service result once TRANSFERRED
+	Amount      money.Money
+	PayeeAmount money.Money
+	Captured    int64        // captured on the intent so far, including this capture
+	Remaining   int64        // still authorized after this capture
+	Legs        []PaymentLeg // credited to each payee of a split intent
+	Message     string
+	Attempts    int
+}
+
+const captureColumns = `id, reference_id, requested_amount, final, step, COALESCE(amount, 0), currency,
+	COALESCE(payee_amount, 0), payee_currency, COALESCE(captured_total, 0), COALESCE(remaining_amount, 0),
+	COALESCE(message, ''), attempts, legs`
+
+func scanCapture(row pgx.Row) (*CaptureSaga, error) {
+	var c CaptureSaga
+	var legs []byte
+	err := row.Scan(&c.ID, &c.ReferenceID, &c.Requested, &c.Final, &c.Step, &c.Amount.Amount, &c.Amount.Currency,
+		&c.PayeeAmount.Amount, &c.PayeeAmount.Currency, &c.Captured, &c.Remaining, &c.Message, &c.Attempts, &legs)
+	if err != nil {
+		return nil, err
+	}
+	if c.Legs, err = parseLegs(legs); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// StartCapture stores a STARTED capture saga. When a capture with the same id
+// already exists, that capture is returned instead so it can be resumed.
+func (r *Repository) StartCapture(ctx context.Context, c CaptureSaga) (*CaptureSaga, error) {
+	started, err := scanCapture(r.pool.QueryRow(ctx, `
+	INSERT INTO captures (id, reference_id, requested_amount, final, step, currency, payee_currency)
+	VALUES ($1, $2, $3, $4, 'STARTED', $5, $6)
+	ON CONFLICT (id) DO NOTHING
+	RETURNING `+captureColumns,
+		c.ID, c.ReferenceID, c.Requested, c.Final, c.Amount.Currency, c.PayeeAmount.Currency))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return r.GetCapture(ctx, c.ID)
+	}
+	return started, err
+}
+
+func (r *Repository) GetCapture(ctx context.Context, id string) (*CaptureSaga, error) {
+	c, err := scanCapture(r.pool.QueryRow(ctx, `SELECT `+captureColumns+` FROM captures WHERE id=$1`, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	return c, err
+}
+
+// MarkCaptureTransferred records the result of the accounts-service transfer.
+func (r *Repository) MarkCaptureTransferred(ctx context.Context, c *CaptureSaga) error {
+	legs, err := legsValue(c.Legs)
+	if err != nil {
+		return err
+	}
+	_, err = r.pool.Exec(ctx, `
+	UPDATE captures SET step='TRANSFERRED', amount=$2, payee_amount=$3, captured_total=$4, remaining_amount=$5,
+		legs=$6, updated_at=now()
+	WHERE id=$1 AND step='STARTED'
+	`, c.ID, c.Amount.Amount, c.PayeeAmount.Amount, c.Captured, c.Remaining, legs)
+	return err
+}
+
+// FailCapture marks a capture that accounts-service refused as FAILED.
+func (r *Repository) FailCapture(ctx context.Context, id, message string) error {
+	_, err := r.pool.Exec(ctx, `
+	UPDATE captures SET step='FAILED', message=$2, updated_at=now() WHERE id=$1 AND step='STARTED'
+	`, id, message)
+	return err
+}
+
+// TouchCapture counts a recovery attempt, which also keeps the capture out of
+// the next recovery pass until it is stale again.
+func (r *Repository) TouchCapture(ctx context.Context, id, message string) error {
+	_, err := r.pool.Exec(ctx, `
+	UPDATE captures SET attempts=attempts+1, message=NULLIF($2,''), updated_at=now() WHERE id=$1
+	`, id, message)
+	return err
+}
+
+// CompleteCaptureTx locks a TRANSFERRED capture and marks it COMPLETED. It
+// reports false when another worker already completed it.
+func (r *Repository) CompleteCaptureTx(ctx context.Context, tx pgx.Tx, id string) (bool, error) {
+	var step string
+	err := tx.QueryRow(ctx, `SELECT step FROM captures WHERE id=$1 FOR UPDATE`, id).Scan(&step)
+	if err != nil {
+		return false, fmt.Errorf("lock capture: %w", err)
+	}
+	switch step {
+	case CaptureCompleted:
+		return false, nil
+	case CaptureTransferred:
+	default:
+		return false, fmt.Errorf("capture %s is %s, not TRANSFERRED", id, step)
+	}
+	_, err = tx.Exec(ctx, `UPDATE captures SET step='COMPLETED', message=NULL, updated_at=now() WHERE id=$1`, id)
+	if err != nil {
+		return false, fmt.Errorf("complete capture: %w", err)
+	}
+	return true, nil
+}
+
+// ListStuckCaptures returns up to limit captures that are neither COMPLETED nor
+// FAILED and have not moved for olderThan, oldest first.
+func (r *Repository) ListStuckCaptures(ctx context.Context, olderThan time.Duration, limit int) ([]*CaptureSaga, error) {
+	rows, err := r.pool.Query(ctx, `
+	SELECT `+captureColumns+` FROM captures
+	WHERE step IN ('STARTED', 'TRANSFERRED') AND updated_at <= now() - make_interval(secs => $1)
+	ORDER BY updated_at
+	LIMIT $2
+	`, olderThan.Seconds(), limit)
+	if err != nil {
+		return nil, fmt.Errorf("list stuck captures: %w", err)
+	}
+	defer rows.Close()
+	var res []*CaptureSaga
+	for rows.Next() {
+		c, err := scanCapture(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan capture: %w", err)
+		}
+		res = append(res, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list stuck captures: %w", err)
+	}
+	return res, nil
+}
@@ -0,0 +1,244 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/parasagrawal71/bank-settlement-system/shared/money"
+)
+
+// DisputeAccountID is the account_id of the payments rows that move a disputed
+// amount into or out of the dispute hold. The funds sit in the accounts-service
+// dispute hold ledger account.
+const DisputeAccountID = "DISPUTE"
+
+// Dispute statuses. A dispute is OPENING until accounts-service has held the
+// amount from the payee, FAILED if it refused, then OPEN, UNDER_REVIEW once
+// evidence is submitted, and finally WON or LOST.
+const (
+	DisputeOpening     = "OPENING"
+	DisputeOpen        = "OPEN"
+	DisputeUnderReview = "UNDER_REVIEW"
+	DisputeWon         = "WON"
+	DisputeLost        = "LOST"
+	DisputeFailed      = "FAILED"
+)
+
+var ErrDisputeNotOpen = errors.New("dispute is not open")
+
+type Dispute struct {
+	ID             string
+	ReferenceID    string
+	IdempotencyKey string
+	Amount         money.Money // payer currency
+	PayeeAmount    money.Money // held from the payee, set once OPEN
+	Reason         string
+	Status         string
+	Outcome        string // WON or LOST while the resolution is in progress
+	Message        string
+	CreatedAt      time.Time
+	ResolvedAt     time.Time // zero until WON or LOST
+	Evidence       []DisputeEvidence
+}
+
+type DisputeEvidence struct {
+	ID          int64
+	Description string
+	Content     string
+	SubmittedBy string
+	CreatedAt   time.Time
+}
+
+// ResolutionID is the capture_id of the payments rows that close the dispute
+// hold; the rows that open it use the dispute id.
+func (d *Dispute) ResolutionID() string {
+	return d.ID + "_resolution"
+}
+
+const disputeColumns = `id, reference_id, idempotency_key, amount, currency, COALESCE(payee_amount, 0),
+	COALESCE(payee_currency, currency), COALESCE(reason, ''), status, COALESCE(outcome, ''), COALESCE(message, ''),
+	created_at, resolved_at`
+
+func scanDispute(row pgx.Row) (*Dispute, error) {
+	var d Dispute
+	var resolvedAt *time.Time
+	err := row.Scan(&d.ID, &d.ReferenceID, &d.IdempotencyKey, &d.Amount.Amount, &d.Amount.Currency,
+		&d.PayeeAmount.Amount, &d.PayeeAmount.Currency, &d.Reason, &d.Status, &d.Outcome, &d.Message,
+		&d.CreatedAt, &resolvedAt)
+	if err != nil {
+		return nil, err
+	}
+	if resolvedAt != nil {
+		d.ResolvedAt = *resolvedAt
+	}
+	return &d, nil
+}
+
+// GetDispute returns a dispute with its evidence, or nil.
+func (r *Repository) GetDispute(ctx context.Context, id string) (*Dispute, error) {
+	d, err := scanDispute(r.pool.QueryRow(ctx, `SELECT `+disputeColumns+` FROM disputes WHERE id=$1`, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.pool.Query(ctx, `
+	SELECT id, description, COALESCE(content, ''), submitted_by, created_at
+	FROM dispute_evidence WHERE dispute_id=$1 ORDER BY id
+	`, id)
+	if err != nil {
+		return nil, fmt.Errorf("list dispute evidence: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var e DisputeEvidence
+		if err := rows.Scan(&e.ID, &e.Description, &e.Content, &e.SubmittedBy, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan dispute evidence: %w", err)
+		}
+		d.Evidence = append(d.Evidence, e)
+	}
+	return d, rows.Err()
+}
+
+// GetDisputeByKey returns the dispute opened with an idempotency key on a
+// payment, or nil.
+func (r *Repository) GetDisputeByKey(ctx context.Context, referenceID, key string) (*Dispute, error) {
+	d, err := scanDispute(r.pool.QueryRow(ctx, `
+	SELECT `+disputeColumns+` FROM disputes WHERE reference_id=$1 AND idempotency_key=$2
+	`, referenceID, key))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	return d, err
+}
+
+// CreateDispute stores an OPENING dispute. When one with the same idempotency
+// key was created concurrently, that dispute is returned instead.
+func (r *Repository) CreateDispute(ctx context.Context, d Dispute) (*Dispute, error) {
+	created, err := scanDispute(r.pool.QueryRow(ctx, `
+	INSERT INTO disputes (id, reference_id, idempotency_key, amount, currency, reason, status)
+	VALUES ($1,$2,$3,$4,$5,NULLIF($6,''),'OPENING')
+	ON CONFLICT (reference_id, idempotency_key) DO NOTHING
+	RETURNING `+disputeColumns,
+		d.ID, d.ReferenceID, d.IdempotencyKey, d.Amount.Amount, d.Amount.Currency, d.Reason))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return r.GetDisputeByKey(ctx, d.ReferenceID, d.IdempotencyKey)
+	}
+	return created, err
+}
+
+func (r *Repository) MarkDisputeFailed(ctx context.Context, id, message string) error {
+	_, err := r.pool.Exec(ctx, `
+	UPDATE disputes SET status='FAILED', message=$2, updated_at=now() WHERE id=$1 AND status='OPENING'
+	`, id, message)
+	return err
+}
+
+// OpenDisputeTx moves an OPENING dispute to OPEN once its amount is held and adds
+// it to the disputed amount of the intent. It reports false when the dispute was
+// already open.
+func (r *Repository) OpenDisputeTx(ctx context.Context, tx pgx.Tx, d *Dispute) (bool, error) {
+	tag, err := tx.Exec(ctx, `
+	UPDATE disputes SET status='OPEN', payee_amount=$2, payee_currency=$3, message=NULL, updated_at=now()
+	WHERE id=$1 AND status='OPENING'
+	`, d.ID, d.PayeeAmount.Amount, d.PayeeAmount.Currency)
+	if err != nil {
+		return false, fmt.Errorf("open dispute: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return false, nil
+	}
+	_, err = tx.Exec(ctx, `
+	UPDATE payment_intents SET disputed_amount=disputed_amount+$2, updated_at=now() WHERE reference_id=$1
+	`, d.ReferenceID, d.Amount.Amount)
+	if err != nil {
+		return false, fmt.Errorf("update intent disputed amount: %w", err)
+	}
+	return true, nil
+}
+
+// AddDisputeEvidenceTx records evidence for an OPEN or UNDER_REVIEW dispute and
+// moves it to UNDER_REVIEW. It fails with ErrDisputeNotOpen otherwise.
+func (r *Repository) AddDisputeEvidenceTx(ctx context.Context, tx pgx.Tx, disputeID string, e DisputeEvidence) error {
+	tag, err := tx.Exec(ctx, `
+	UPDATE disputes SET status='UNDER_REVIEW', updated_at=now()
+	WHERE id=$1 AND status IN ('OPEN', 'UNDER_REVIEW') AND outcome IS NULL
+	`, disputeID)
+	if err != nil {
+		return fmt.Errorf("update dispute: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrDisputeNotOpen
+	}
+	_, err = tx.Exec(ctx, `
+	INSERT INTO dispute_evidence (dispute_id, description, content, submitted_by) VALUES ($1,$2,NULLIF($3,''),$4)
+	`, disputeID, e.Description, e.Content, e.SubmittedBy)
+	if err != nil {
+		return fmt.Errorf("insert dispute evidence: %w", err)
+	}
+	return nil
+}
+
+// SetDisputeOutcome records the outcome a dispute is being resolved with, before
+// accounts-service closes its hold. Repeating the same outcome is allowed; a
+// different one, or a dispute that is not open, fails with ErrDisputeNotOpen.
+func (r *Repository) SetDisputeOutcome(ctx context.Context, id, outcome string) error {
+	tag, err := r.pool.Exec(ctx, `
+	UPDATE disputes SET outcome=$2, updated_at=now()
+	WHERE id=$1 AND status IN ('OPEN', 'UNDER_REVIEW') AND (outcome IS NULL OR outcome=$2)
+	`, id, outcome)
+	if err != nil {
+		return fmt.Errorf("set dispute outcome: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrDisputeNotOpen
+	}
+	return nil
+}
+
+// ResolveDisputeTx moves a dispute to its outcome once accounts-service has
+// closed the hold, and takes it out of the disputed amount of the intent. A lost
+// dispute is added to the refunded amount of the intent, which moves to
+// PARTIALLY_REFUNDED or REFUNDED like for any other refund. It reports false
+// when the dispute was already resolved.
+func (r *Repository) ResolveDisputeTx(ctx context.Context, tx pgx.Tx, d *Dispute, actor string) (bool, error) {
+	tag, err := tx.Exec(ctx, `
+	UPDATE disputes SET status=outcome, resolved_at=now(), updated_at=now()
+	WHERE id=$1 AND status IN ('OPEN', 'UNDER_REVIEW') AND outcome=$2
+	`, d.ID, d.Outcome)
+	if err != nil {
+		return false, fmt.Errorf("resolve dispute: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return false, nil
+	}
+	from, err := lockStatusTx(ctx, tx, d.ReferenceID)
+	if err != nil {
+		return false, err
+	}
+	refunded := int64(0)
+	if d.Outcome == DisputeLost {
+		refunded = d.Amount.Amount
+	}
+	var fullyRefunded bool
+	err = tx.QueryRow(ctx, `
+	UPDATE payment_intents SET disputed_amount=disputed_amount-$2, refunded_amount=refunded_amount+$3, updated_at=now()
+	WHERE reference_id=$1
+	RETURNING refunded_amount >= captured_amount
+	`, d.ReferenceID, d.Amount.Amount, refunded).Scan(&fullyRefunded)
+	if err != nil {
+		return false, fmt.Errorf("update intent disputed amount: %w", err)
+	}
+	if d.Outcome != DisputeLost {
+		return true, nil
+	}
+	reason := "dispute lost " + d.ID
+	if d.Reason != "" {
+		reason += ": " + d.Reason
+	}
+	return true, setStatusTx(ctx, tx, d.ReferenceID, from, RefundStatus(from, fullyRefunded), actor, reason)
+}
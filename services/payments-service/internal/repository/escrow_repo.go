@@ -0,0 +1,197 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/parasagrawal71/bank-settlement-system/shared/money"
+)
+
+// EscrowAccountID is the account_id of the payments rows that move money into or
+// out of escrow. The funds sit in the accounts-service escrow ledger account.
+const EscrowAccountID = "ESCROW"
+
+// Kinds of escrow movements: a release pays escrowed funds out to the payee, a
+// refund returns them to the payer.
+const (
+	EscrowRelease = "RELEASE"
+	EscrowRefund  = "REFUND"
+)
+
+// EscrowMovement is a release or refund of funds an escrow intent holds in
+// escrow. Like a refund it is PENDING until accounts-service has moved the
+// money, then SUCCEEDED or FAILED.
+type EscrowMovement struct {
+	ID             string
+	ReferenceID    string
+	Kind           string
+	IdempotencyKey string
+	Amount         money.Money // taken out of escrow
+	PayeeAmount    money.Money // credited to the payee by a release, set once SUCCEEDED
+	Reason         string
+	Status         string // PENDING, SUCCEEDED or FAILED
+	Message        string
+}
+
+const escrowMovementColumns = `id, reference_id, kind, idempotency_key, amount, currency, COALESCE(payee_amount, 0),
+	COALESCE(payee_currency, currency), COALESCE(reason, ''), status, COALESCE(message, '')`
+
+func scanEscrowMovement(row pgx.Row) (*EscrowMovement, error) {
+	var m EscrowMovement
+	err := row.Scan(&m.ID, &m.ReferenceID, &m.Kind, &m.IdempotencyKey, &m.Amount.Amount, &m.Amount.Currency,
+		&m.PayeeAmount.Amount, &m.PayeeAmount.Currency, &m.Reason, &m.Status, &m.Message)
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// GetEscrowMovementByKey returns the movement of a kind made for an idempotency
+// key of a payment, or nil.
+func (r *Repository) GetEscrowMovementByKey(ctx context.Context, referenceID, kind, key string) (*EscrowMovement, error) {
+	m, err := scanEscrowMovement(r.pool.QueryRow(ctx, `
+	SELECT `+escrowMovementColumns+` FROM escrow_movements WHERE reference_id=$1 AND kind=$2 AND idempotency_key=$3
+	`, referenceID, kind, key))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	return m, err
+}
+
+// CreateEscrowMovement stores a PENDING movement. When one with the same
+// idempotency key was created concurrently, that movement is returned instead.
+func (r *Repository) CreateEscrowMovement(ctx context.Context, m EscrowMovement) (*EscrowMovement, error) {
+	created, err := scanEscrowMovement(r.pool.QueryRow(ctx, `
+	INSERT INTO escrow_movements (id, reference_id, kind, idempotency_key, amount, currency, reason, status)
+	VALUES ($1,$2,$3,$4,$5,$6,NULLIF($7,''),'PENDING')
+	ON CONFLICT (reference_id, kind, idempotency_key) DO NOTHING
+	RETURNING `+escrowMovementColumns,
+		m.ID, m.ReferenceID, m.Kind, m.IdempotencyKey, m.Amount.Amount, m.Amount.Currency, m.Reason))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return r.GetEscrowMovementByKey(ctx, m.ReferenceID, m.Kind, m.IdempotencyKey)
+	}
+	return created, err
+}
+
+func (r *Repository) MarkEscrowMovementFailed(ctx context.Context, id, message string) error {
+	_, err := r.pool.Exec(ctx, `
+	UPDATE escrow_movements SET status='FAILED', message=$2, updated_at=now() WHERE id=$1 AND status='PENDING'
+	`, id, message)
+	return err
+}
+
+// CompleteEscrowMovementTx marks a PENDING movement SUCCEEDED and takes it out of
+// the intent's escrow. A refund is added to the refunded amount of the intent,
+// which moves to PARTIALLY_REFUNDED or REFUNDED like for any other refund. It
+// reports false when the movement was already completed.
+func (r *Repository) CompleteEscrowMovementTx(ctx context.Context, tx pgx.Tx, m *EscrowMovement, actor string) (bool, error) {
+	tag, err := tx.Exec(ctx, `
+	UPDATE escrow_movements SET status='SUCCEEDED', payee_amount=$2, payee_currency=$3, message=NULL, updated_at=now()
+	WHERE id=$1 AND status='PENDING'
+	`, m.ID, m.PayeeAmount.Amount, m.PayeeAmount.Currency)
+	if err != nil {
+		return false, fmt.Errorf("complete escrow movement: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return false, nil
+	}
+	from, err := lockStatusTx(ctx, tx, m.ReferenceID)
+	if err != nil {
+		return false, err
+	}
+	refunded := int64(0)
+	if m.Kind == EscrowRefund {
+		refunded = m.Amount.Amount
+	}
+	var fullyRefunded bool
+	err = tx.QueryRow(ctx, `
+	UPDATE payment_intents SET escrow_amount=escrow_amount-$2, refunded_amount=refunded_amount+$3, updated_at=now()
+	WHERE reference_id=$1
+	RETURNING refunded_amount >= captured_amount
+	`, m.ReferenceID, m.Amount.Amount, refunded).Scan(&fullyRefunded)
+	if err != nil {
+		return false, fmt.Errorf("update intent escrow: %w", err)
+	}
+	if m.Kind != EscrowRefund {
+		return true, nil
+	}
+	reason := "escrow refund " + m.ID
+	if m.Reason != "" {
+		reason += ": " + m.Reason
+	}
+	return true, setStatusTx(ctx, tx, m.ReferenceID, from, RefundStatus(from, fullyRefunded), actor, reason)
+}
+
+// ListDueEscrows returns up to limit escrow intents that hold funds in escrow
+// past their scheduled release, longest due first.
+func (r *Repository) ListDueEscrows(ctx context.Context, limit int) ([]*PaymentIntent, error) {
+	rows, err := r.pool.Query(ctx, `
+	SELECT `+intentColumns+` FROM payment_intents
+	WHERE escrow AND escrow_amount > 0 AND escrow_release_at <= now()
+	ORDER BY escrow_release_at
+	LIMIT $1
+	`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list due escrows: %w", err)
+	}
+	defer rows.Close()
+	var res []*PaymentIntent
+	for rows.Next() {
+		pi, err := scanIntent(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan intent: %w", err)
+		}
+		res = append(res, pi)
+	}
+	return res, rows.Err()
+}
+
+// ListStuckEscrowMovements returns up to limit PENDING movements that have not
+// moved for olderThan, oldest first.
+func (r *Repository) ListStuckEscrowMovements(ctx context.Context, olderThan time.Duration, limit int) ([]*EscrowMovement, error) {
+	rows, err := r.pool.Query(ctx, `
+	SELECT `+escrowMovementColumns+` FROM escrow_movements
+	WHERE status='PENDING' AND updated_at <= now() - make_interval(secs => $1)
+	ORDER BY updated_at
+	LIMIT $2
+	`, olderThan.Seconds(), limit)
+	if err != nil {
+		return nil, fmt.Errorf("list stuck escrow movements: %w", err)
+	}
+	defer rows.Close()
+	var res []*EscrowMovement
+	for rows.Next() {
+		m, err := scanEscrowMovement(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan escrow movement: %w", err)
+		}
+		res = append(res, m)
+	}
+	return res, rows.Err()
+}
+
+// TouchEscrowMovement records why a PENDING movement could not be completed,
+// which also keeps it out of the next recovery pass until it is stale again.
+func (r *Repository) TouchEscrowMovement(ctx context.Context, id, message string) error {
+	_, err := r.pool.Exec(ctx, `
+	UPDATE escrow_movements SET message=NULLIF($2,''), updated_at=now() WHERE id=$1 AND status='PENDING'
+	`, id, message)
+	return err
+}
+
+// SetEscrowTx makes a new intent an escrow intent, released automatically at
+// releaseAt unless it is zero.
+func (r *Repository) SetEscrowTx(ctx context.Context, tx pgx.Tx, referenceID string, releaseAt time.Time) error {
+	var at *time.Time
+	if !releaseAt.IsZero() {
+		at = &releaseAt
+	}
+	_, err := tx.Exec(ctx, `UPDATE payment_intents SET escrow=true, escrow_release_at=$2 WHERE reference_id=$1`, referenceID, at)
+	if err != nil {
+		return fmt.Errorf("set escrow: %w", err)
+	}
+	return nil
+}
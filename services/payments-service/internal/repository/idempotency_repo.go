@@ -3,11 +3,17 @@ package repository
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+var (
+	ErrIdempotencyKeyReused  = errors.New("idempotency key was already used with a different request")
+	ErrIdempotencyInProgress = errors.New("a request with this idempotency key is still in progress")
+)
+
 type IdempotencyRepo struct {
 	pool *pgxpool.Pool
 }
@@ -16,19 +22,85 @@ func NewIdempotencyRepository(pool *pgxpool.Pool) *IdempotencyRepo {
 	return &IdempotencyRepo{pool: pool}
 }
 
-func (i *IdempotencyRepo) GetResponse(ctx context.Context, key string) ([]byte, error) {
-	var b []byte
-	err := i.pool.QueryRow(ctx, `SELECT response FROM idempotency_keys WHERE key=$1`, key).Scan(&b)
+// Keys belong to the principal that sent them: callers cannot see or block each
+// other's requests by picking the same key. Without authentication the
+// principal is empty.
+
+// Acquire claims key for an operation with the hash of the request body. It
+// returns the stored response when a request with the key already completed,
+// or nil when the caller now holds the key and must Complete or Release it.
+// The claim lapses after lockTimeout, so a request that died while holding it
+// can be retried; a completed key is kept for ttl.
+func (i *IdempotencyRepo) Acquire(ctx context.Context, principal, operation, key, requestHash string, lockTimeout, ttl time.Duration) ([]byte, error) {
+	var claimed bool
+	err := i.pool.QueryRow(ctx, `
+	INSERT INTO idempotency_keys (principal, operation, key, request_hash, status, locked_until, created_at, expires_at)
+	VALUES ($1, $2, $3, $4, 'IN_PROGRESS', now() + make_interval(secs => $5), now(), now() + make_interval(secs => $6))
+	ON CONFLICT (principal, operation, key) DO UPDATE
+		SET request_hash=EXCLUDED.request_hash, status='IN_PROGRESS', response=NULL,
+			locked_until=EXCLUDED.locked_until, created_at=now(), expires_at=EXCLUDED.expires_at
+		WHERE idempotency_keys.expires_at <= now()
+			OR (idempotency_keys.status='IN_PROGRESS' AND idempotency_keys.locked_until <= now()
+				AND idempotency_keys.request_hash = EXCLUDED.request_hash)
+	RETURNING true
+	`, principal, operation, key, requestHash, lockTimeout.Seconds(), ttl.Seconds()).Scan(&claimed)
+	if err == nil {
+		return nil, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, err
+	}
+
+	// someone else holds or completed the key
+	var storedHash *string
+	var status string
+	var response []byte
+	err = i.pool.QueryRow(ctx, `
+	SELECT request_hash, status, response FROM idempotency_keys WHERE principal=$1 AND operation=$2 AND key=$3
+	`, principal, operation, key).Scan(&storedHash, &status, &response)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, nil
+			// released since the insert; the caller may try again
+			return nil, ErrIdempotencyInProgress
 		}
 		return nil, err
 	}
-	return b, nil
+	// keys stored before requests were hashed have no hash to compare
+	if storedHash != nil && *storedHash != requestHash {
+		return nil, ErrIdempotencyKeyReused
+	}
+	if status != "COMPLETED" {
+		return nil, ErrIdempotencyInProgress
+	}
+	return response, nil
+}
+
+// Complete stores the response of a request holding key.
+func (i *IdempotencyRepo) Complete(ctx context.Context, principal, operation, key string, response []byte) error {
+	_, err := i.pool.Exec(ctx, `
+	UPDATE idempotency_keys SET status='COMPLETED', response=$4, locked_until=NULL
+	WHERE principal=$1 AND operation=$2 AND key=$3
+	`, principal, operation, key, response)
+	return err
 }
 
-func (i *IdempotencyRepo) SaveResponse(ctx context.Context, key string, resp []byte) error {
-	_, err := i.pool.Exec(ctx, `INSERT INTO idempotency_keys (key, response, created_at) VALUES ($1,$2,now())`, key, resp)
+// Release drops the claim of a request that failed without a response, so the
+// key can be used again.
+func (i *IdempotencyRepo) Release(ctx context.Context, principal, operation, key string) error {
+	_, err := i.pool.Exec(ctx, `
+	DELETE FROM idempotency_keys WHERE principal=$1 AND operation=$2 AND key=$3 AND status='IN_PROGRESS'
+	`, principal, operation, key)
 	return err
 }
+
+// DeleteExpired removes completed keys past their TTL and expired claims whose lock has lapsed.
+func (i *IdempotencyRepo) DeleteExpired(ctx context.Context) (int64, error) {
+	tag, err := i.pool.Exec(ctx, `
+	DELETE FROM idempotency_keys
+	WHERE expires_at <= now() AND (status='COMPLETED' OR locked_until <= now())
+	`)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
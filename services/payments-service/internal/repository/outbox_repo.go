@@ -90,3 +90,27 @@ func (r *OutboxRepository) MarkAsFailed(ctx context.Context, conn pgx.Tx, id int
 	`, id)
 	return err
 }
+
+// ListByReference returns the outbox events of a payment, oldest first.
+func (r *OutboxRepository) ListByReference(ctx context.Context, referenceID string) ([]OutboxEvent, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT id, event_type, payload, status, retry_count, created_at, updated_at
+		FROM outbox_events
+		WHERE payload->>'reference_id' = $1
+		ORDER BY id
+	`, referenceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []OutboxEvent
+	for rows.Next() {
+		var e OutboxEvent
+		if err := rows.Scan(&e.ID, &e.EventType, &e.Payload, &e.Status, &e.RetryCount, &e.CreatedAt, &e.UpdatedAt); err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+	return events, rows.Err()
+}
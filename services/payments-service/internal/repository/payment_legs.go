@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// PaymentLeg is a payee's share of a split intent, or of one capture or refund
+// of it, in minor units of the intent currency.
+type PaymentLeg struct {
+	PayeeID string `json:"payee_id"`
+	Amount  int64  `json:"amount"`
+}
+
+// InsertLegsTx stores the legs of a split intent, numbered from 1 in order.
+func (r *Repository) InsertLegsTx(ctx context.Context, tx pgx.Tx, referenceID string, legs []PaymentLeg) error {
+	for i, l := range legs {
+		_, err := tx.Exec(ctx, `
+		INSERT INTO payment_legs (reference_id, leg_no, payee_id, amount) VALUES ($1, $2, $3, $4)
+		`, referenceID, i+1, l.PayeeID, l.Amount)
+		if err != nil {
+			return fmt.Errorf("insert payment leg: %w", err)
+		}
+	}
+	return nil
+}
+
+// parseLegs decodes a JSON array of legs; NULL means a single payee.
+func parseLegs(raw []byte) ([]PaymentLeg, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	var legs []PaymentLeg
+	if err := json.Unmarshal(raw, &legs); err != nil {
+		return nil, fmt.Errorf("decode payment legs: %w", err)
+	}
+	return legs, nil
+}
+
+// legsValue encodes legs for a JSONB column, NULL for a single payee.
+func legsValue(legs []PaymentLeg) ([]byte, error) {
+	if len(legs) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(legs)
+}
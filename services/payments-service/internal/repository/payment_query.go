@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/parasagrawal71/bank-settlement-system/shared/money"
+)
+
+var ErrInvalidPageToken = errors.New("invalid page token")
+
+// PaymentFilter narrows ListIntents. Zero values do not filter.
+type PaymentFilter struct {
+	PayerID     string
+	PayeeID     string
+	Status      string
+	MinAmount   int64
+	MaxAmount   int64
+	CreatedFrom time.Time
+	CreatedTo   time.Time // exclusive
+}
+
+// intentCursor is the position of the last intent of a page. Intents are
+// listed newest first by (created_at, id).
+type intentCursor struct {
+	CreatedAt time.Time
+	ID        int64
+}
+
+func (c intentCursor) encode() string {
+	return base64.RawURLEncoding.EncodeToString([]byte(c.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + strconv.FormatInt(c.ID, 10)))
+}
+
+func decodeIntentCursor(token string) (*intentCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+	ts, id, ok := strings.Cut(string(b), "|")
+	if !ok {
+		return nil, ErrInvalidPageToken
+	}
+	createdAt, err := time.Parse(time.RFC3339Nano, ts)
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+	n, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+	return &intentCursor{CreatedAt: createdAt, ID: n}, nil
+}
+
+// ListIntents returns one page of payment intents matching f, newest first, and
+// the token of the next page ("" on the last page).
+func (r *Repository) ListIntents(ctx context.Context, f PaymentFilter, pageToken string, pageSize int) ([]*PaymentIntent, string, error) {
+	var where []string
+	var args []any
+	add := func(cond string, arg any) {
+		args = append(args, arg)
+		where = append(where, fmt.Sprintf(cond, len(args)))
+	}
+	if f.PayerID != "" {
+		add("payer_id = $%d", f.PayerID)
+	}
+	if f.PayeeID != "" {
+		// a payee of any leg of a split intent matches too
+		add("(payee_id = $%[1]d OR reference_id IN (SELECT reference_id FROM payment_legs WHERE payee_id = $%[1]d))", f.PayeeID)
+	}
+	if f.Status != "" {
+		add("status = $%d", f.Status)
+	}
+	if f.MinAmount > 0 {
+		add("amount >= $%d", f.MinAmount)
+	}
+	if f.MaxAmount > 0 {
+		add("amount <= $%d", f.MaxAmount)
+	}
+	if !f.CreatedFrom.IsZero() {
+		add("created_at >= $%d", f.CreatedFrom)
+	}
+	if !f.CreatedTo.IsZero() {
+		add("created_at < $%d", f.CreatedTo)
+	}
+	if pageToken != "" {
+		cursor, err := decodeIntentCursor(pageToken)
+		if err != nil {
+			return nil, "", err
+		}
+		args = append(args, cursor.CreatedAt, cursor.ID)
+		where = append(where, fmt.Sprintf("(created_at, id) < ($%d, $%d)", len(args)-1, len(args)))
+	}
+
+	sql := `SELECT ` + intentColumns + ` FROM payment_intents`
+	if len(where) > 0 {
+		sql += ` WHERE ` + strings.Join(where, " AND ")
+	}
+	args = append(args, pageSize+1)
+	sql += fmt.Sprintf(` ORDER BY created_at DESC, id DESC LIMIT $%d`, len(args))
+
+	rows, err := r.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, "", fmt.Errorf("list intents: %w", err)
+	}
+	defer rows.Close()
+	res := make([]*PaymentIntent, 0)
+	for rows.Next() {
+		pi, err := scanIntent(rows)
+		if err != nil {
+			return nil, "", fmt.Errorf("scan intent: %w", err)
+		}
+		res = append(res, pi)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", fmt.Errorf("list intents: %w", err)
+	}
+
+	next := ""
+	if len(res) > pageSize {
+		res = res[:pageSize]
+		last := res[len(res)-1]
+		id, err := strconv.ParseInt(last.ID, 10, 64)
+		if err != nil {
+			return nil, "", fmt.Errorf("intent id: %w", err)
+		}
+		next = intentCursor{CreatedAt: last.CreatedAt, ID: id}.encode()
+	}
+	return res, next, nil
+}
+
+type PaymentTx struct {
+	ID        int64
+	CaptureID string
+	AccountID string
+	TxnType   string
+	Amount    money.Money
+	Status    string
+	CreatedAt time.Time
+}
+
+// ListPaymentTxs returns the DEBIT/CREDIT rows of all captures and refunds of a payment.
+func (r *Repository) ListPaymentTxs(ctx context.Context, referenceID string) ([]PaymentTx, error) {
+	rows, err := r.pool.Query(ctx, `
+	SELECT id, capture_id, account_id, txn_type, amount, currency, COALESCE(status, ''), created_at
+	FROM payments WHERE reference_id=$1 ORDER BY id
+	`, referenceID)
+	if err != nil {
+		return nil, fmt.Errorf("list payments: %w", err)
+	}
+	defer rows.Close()
+	var res []PaymentTx
+	for rows.Next() {
+		var p PaymentTx
+		if err := rows.Scan(&p.ID, &p.CaptureID, &p.AccountID, &p.TxnType, &p.Amount.Amount, &p.Amount.Currency,
+			&p.Status, &p.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan payment: %w", err)
+		}
+		res = append(res, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list payments: %w", err)
+	}
+	return res, nil
+}
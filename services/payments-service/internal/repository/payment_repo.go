@@ -2,9 +2,14 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/parasagrawal71/bank-settlement-system/shared/money"
 )
 
 type Repository struct {
@@ -12,12 +17,63 @@ type Repository struct {
 }
 
 type PaymentIntent struct {
-	ID          string
-	ReferenceID string
-	PayerID     string
-	PayeeID     string
-	Amount      float64
-	Status      string
+	ID           string
+	ReferenceID  string
+	PayerID      string
+	PayeeID      string
+	Amount       money.Money
+	PayeeAmount  money.Money
+	QuoteID      string
+	Captured     money.Money // sum of the captures so far, payer currency
+	Refunded     money.Money // sum of the refunds so far, payer currency
+	Status       string
+	CancelReason string
+	Legs         []PaymentLeg // payees of a split intent; PayeeID is the payee of the first
+	// captures of an escrow intent are held in escrow until released or refunded
+	Escrow          bool
+	Escrowed        money.Money // held in escrow now, payer currency
+	EscrowReleaseAt time.Time   // zero when only released on request
+	Disputed        money.Money // held from the payee for open disputes, payer currency
+	HoldTTLSeconds  int64       // hold requested for an intent held for review, 0 for the default
+	ExpiresAt       time.Time   // zero for intents created before holds expired
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+const intentColumns = `id::TEXT, reference_id, payer_id, payee_id, amount, currency,
+	COALESCE(payee_amount, amount), COALESCE(payee_currency, currency), COALESCE(quote_id, ''),
+	captured_amount, refunded_amount, status, COALESCE(cancel_reason, ''), expires_at, created_at, updated_at,
+	(SELECT jsonb_agg(jsonb_build_object('payee_id', l.payee_id, 'amount', l.amount) ORDER BY l.leg_no)
+		FROM payment_legs l WHERE l.reference_id = payment_intents.reference_id),
+	escrow, escrow_amount, escrow_release_at, disputed_amount, COALESCE(hold_ttl_seconds, 0)`
+
+// scanIntent reads a row selected with intentColumns.
+func scanIntent(row pgx.Row) (*PaymentIntent, error) {
+	var pi PaymentIntent
+	var expiresAt *time.Time
+	var legs []byte
+	var escrowReleaseAt *time.Time
+	err := row.Scan(&pi.ID, &pi.ReferenceID, &pi.PayerID, &pi.PayeeID, &pi.Amount.Amount, &pi.Amount.Currency,
+		&pi.PayeeAmount.Amount, &pi.PayeeAmount.Currency, &pi.QuoteID, &pi.Captured.Amount, &pi.Refunded.Amount,
+		&pi.Status, &pi.CancelReason, &expiresAt, &pi.CreatedAt, &pi.UpdatedAt, &legs,
+		&pi.Escrow, &pi.Escrowed.Amount, &escrowReleaseAt, &pi.Disputed.Amount, &pi.HoldTTLSeconds)
+	if err != nil {
+		return nil, err
+	}
+	pi.Escrowed.Currency = pi.Amount.Currency
+	pi.Disputed.Currency = pi.Amount.Currency
+	if escrowReleaseAt != nil {
+		pi.EscrowReleaseAt = *escrowReleaseAt
+	}
+	if pi.Legs, err = parseLegs(legs); err != nil {
+		return nil, err
+	}
+	pi.Captured.Currency = pi.Amount.Currency
+	pi.Refunded.Currency = pi.Amount.Currency
+	if expiresAt != nil {
+		pi.ExpiresAt = *expiresAt
+	}
+	return &pi, nil
 }
 
 func NewRepository(pool *pgxpool.Pool) *Repository {
@@ -28,33 +84,104 @@ func (r *Repository) BeginTx(ctx context.Context) (pgx.Tx, error) {
 	return r.pool.Begin(ctx)
 }
 
-func (r *Repository) CreateIntent(ctx context.Context, referenceID string, payerID string, payeeID string, amount float64) error {
-	_, err := r.pool.Exec(ctx, `
-    INSERT INTO payment_intents (reference_id, payer_id, payee_id, amount, status, created_at)
-    VALUES ($1,$2,$3,$4,'AUTHORIZED', now())
-    `, referenceID, payerID, payeeID, amount)
-	return err
+// CreateIntentTx stores an AUTHORIZED intent and the first entry of its status history.
+func (r *Repository) CreateIntentTx(ctx context.Context, tx pgx.Tx, referenceID string, payerID string, payeeID string, amount money.Money, payeeAmount money.Money, quoteID string, expiresAt time.Time, actor string) error {
+	_, err := tx.Exec(ctx, `
+    INSERT INTO payment_intents (reference_id, payer_id, payee_id, amount, currency, payee_amount, payee_currency, quote_id, status, expires_at, created_at)
+    VALUES ($1,$2,$3,$4,$5,$6,$7,NULLIF($8,''),'AUTHORIZED',$9, now())
+    `, referenceID, payerID, payeeID, amount.Amount, amount.Currency, payeeAmount.Amount, payeeAmount.Currency, quoteID, expiresAt)
+	if err != nil {
+		return err
+	}
+	return insertHistoryTx(ctx, tx, referenceID, StatusTransition{To: StatusAuthorized, Actor: actor, Reason: "funds reserved"})
 }
 
 func (r *Repository) GetIntent(ctx context.Context, referenceID string) (*PaymentIntent, error) {
-	var pi PaymentIntent
-	err := r.pool.QueryRow(ctx, `
-	SELECT id, reference_id, payer_id, payee_id, amount, status FROM payment_intents WHERE reference_id=$1
-	`, referenceID).Scan(&pi.ID, &pi.ReferenceID, &pi.PayerID, &pi.PayeeID, &pi.Amount, &pi.Status)
-	return &pi, err
+	pi, err := scanIntent(r.pool.QueryRow(ctx, `
+	SELECT `+intentColumns+` FROM payment_intents WHERE reference_id=$1
+	`, referenceID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return pi, nil
 }
 
-func (r *Repository) UpdateIntentStatusTx(ctx context.Context, tx pgx.Tx, referenceID string, status string) error {
+// CancelIntentTx moves an AUTHORIZED intent to CANCELED. It reports false when
+// the intent was already CANCELED.
+func (r *Repository) CancelIntentTx(ctx context.Context, tx pgx.Tx, referenceID string, reasonCode string, actor string) (bool, error) {
+	from, err := lockStatusTx(ctx, tx, referenceID)
+	if err != nil {
+		return false, err
+	}
+	if from == StatusCanceled {
+		return false, nil
+	}
+	if err := setStatusTx(ctx, tx, referenceID, from, StatusCanceled, actor, reasonCode); err != nil {
+		return false, err
+	}
+	_, err = tx.Exec(ctx, `UPDATE payment_intents SET cancel_reason=NULLIF($2,'') WHERE reference_id=$1`, referenceID, reasonCode)
+	return err == nil, err
+}
+
+// RecordCaptureTx adds a capture of amount to the intent, and to its escrow for
+// an escrow intent, and moves it to status.
+func (r *Repository) RecordCaptureTx(ctx context.Context, tx pgx.Tx, referenceID string, amount int64, status string, actor string) error {
+	if _, err := r.TransitionTx(ctx, tx, referenceID, status, actor, "captured "+strconv.FormatInt(amount, 10)); err != nil {
+		return err
+	}
 	_, err := tx.Exec(ctx, `
-	UPDATE payment_intents SET status=$1 WHERE reference_id=$2
-	`, status, referenceID)
+	UPDATE payment_intents SET captured_amount=captured_amount+$2,
+		escrow_amount=escrow_amount + CASE WHEN escrow THEN $2 ELSE 0 END
+	WHERE reference_id=$1
+	`, referenceID, amount)
+	return err
+}
+
+// MarkIntentExpired expires an intent whose hold accounts-service reported as expired.
+func (r *Repository) MarkIntentExpired(ctx context.Context, referenceID string) error {
+	_, err := r.expireIntent(ctx, referenceID)
 	return err
 }
 
-func (r *Repository) InsertPaymentTx(ctx context.Context, tx pgx.Tx, referenceID string, accountID string, txnType string, amount float64) error {
+// ExpireIntents expires authorized intents whose hold has expired. The hold
+// itself is released by accounts-service. An intent that fails to expire does
+// not stop the others; all failures are returned together.
+func (r *Repository) ExpireIntents(ctx context.Context) (int64, error) {
+	rows, err := r.pool.Query(ctx, `
+	SELECT reference_id FROM payment_intents
+	WHERE status IN ('AUTHORIZED', 'PARTIALLY_CAPTURED') AND expires_at <= now()
+	ORDER BY expires_at
+	`)
+	if err != nil {
+		return 0, fmt.Errorf("list expired intents: %w", err)
+	}
+	refs, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return 0, fmt.Errorf("list expired intents: %w", err)
+	}
+
+	var expired int64
+	var errs []error
+	for _, ref := range refs {
+		ok, err := r.expireIntent(ctx, ref)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("expire %s: %w", ref, err))
+			continue
+		}
+		if ok {
+			expired++
+		}
+	}
+	return expired, errors.Join(errs...)
+}
+
+func (r *Repository) InsertPaymentTx(ctx context.Context, tx pgx.Tx, referenceID string, captureID string, accountID string, txnType string, amount money.Money) error {
 	_, err := tx.Exec(ctx, `
-    INSERT INTO payments (reference_id, account_id, amount, txn_type, created_at)
-    VALUES ($1,$2,$3,$4, now())
-    `, referenceID, accountID, amount, txnType)
+    INSERT INTO payments (reference_id, capture_id, account_id, amount, currency, txn_type, created_at)
+    VALUES ($1,$2,$3,$4,$5,$6, now())
+    `, referenceID, captureID, accountID, amount.Amount, amount.Currency, txnType)
 	return err
 }
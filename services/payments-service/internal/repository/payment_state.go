@@ -0,0 +1,190 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	StatusAuthorized        = "AUTHORIZED"
+	StatusPartiallyCaptured = "PARTIALLY_CAPTURED"
+	StatusCaptured          = "CAPTURED"
+	StatusPartiallyRefunded = "PARTIALLY_REFUNDED"
+	StatusRefunded          = "REFUNDED"
+	StatusFailed            = "FAILED"
+	StatusExpired           = "EXPIRED"
+	StatusCanceled          = "CANCELED"
+	StatusPendingReview     = "PENDING_REVIEW" // held by the risk rules, no funds reserved yet
+)
+
+// transitions lists the statuses an intent may move to from each status. A
+// status that allows itself can be re-entered, e.g. a second partial capture.
+// Statuses without an entry are final.
+var transitions = map[string][]string{
+	"":                      {StatusAuthorized, StatusPendingReview},
+	StatusPendingReview:     {StatusAuthorized, StatusFailed},
+	StatusAuthorized:        {StatusPartiallyCaptured, StatusCaptured, StatusCanceled, StatusExpired, StatusFailed},
+	StatusPartiallyCaptured: {StatusPartiallyCaptured, StatusCaptured},
+	StatusCaptured:          {StatusPartiallyRefunded, StatusRefunded},
+	StatusPartiallyRefunded: {StatusPartiallyRefunded, StatusRefunded},
+}
+
+// TransitionError is returned for a status change the state machine does not allow.
+type TransitionError struct {
+	From string
+	To   string
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("payment cannot move from %s to %s", e.From, e.To)
+}
+
+// CheckTransition returns a *TransitionError unless from → to is allowed.
+func CheckTransition(from, to string) error {
+	if !slices.Contains(transitions[from], to) {
+		return &TransitionError{From: from, To: to}
+	}
+	return nil
+}
+
+type StatusTransition struct {
+	From      string
+	To        string
+	Actor     string
+	Reason    string
+	CreatedAt time.Time
+}
+
+// insertHistoryTx records a status change of an intent.
+func insertHistoryTx(ctx context.Context, tx pgx.Tx, referenceID string, t StatusTransition) error {
+	_, err := tx.Exec(ctx, `
+	INSERT INTO payment_status_history (reference_id, from_status, to_status, actor, reason)
+	VALUES ($1, NULLIF($2,''), $3, $4, NULLIF($5,''))
+	`, referenceID, t.From, t.To, t.Actor, t.Reason)
+	if err != nil {
+		return fmt.Errorf("insert status history: %w", err)
+	}
+	return nil
+}
+
+// lockStatusTx locks the intent row and returns its status.
+func lockStatusTx(ctx context.Context, tx pgx.Tx, referenceID string) (string, error) {
+	var status string
+	err := tx.QueryRow(ctx, `SELECT status FROM payment_intents WHERE reference_id=$1 FOR UPDATE`, referenceID).Scan(&status)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", fmt.Errorf("intent %s not found", referenceID)
+		}
+		return "", fmt.Errorf("lock intent: %w", err)
+	}
+	return status, nil
+}
+
+// setStatusTx moves a locked intent from → to and records the transition.
+func setStatusTx(ctx context.Context, tx pgx.Tx, referenceID, from, to, actor, reason string) error {
+	if err := CheckTransition(from, to); err != nil {
+		return err
+	}
+	_, err := tx.Exec(ctx, `UPDATE payment_intents SET status=$2, updated_at=now() WHERE reference_id=$1`, referenceID, to)
+	if err != nil {
+		return fmt.Errorf("update intent status: %w", err)
+	}
+	return insertHistoryTx(ctx, tx, referenceID, StatusTransition{From: from, To: to, Actor: actor, Reason: reason})
+}
+
+// TransitionTx locks the intent, checks that its current status may move to
+// `to`, writes the new status and records the transition. It returns the status
+// the intent had before.
+func (r *Repository) TransitionTx(ctx context.Context, tx pgx.Tx, referenceID, to, actor, reason string) (string, error) {
+	from, err := lockStatusTx(ctx, tx, referenceID)
+	if err != nil {
+		return "", err
+	}
+	return from, setStatusTx(ctx, tx, referenceID, from, to, actor, reason)
+}
+
+// RefundStatus is the status an intent moves to when a refund is recorded. A
+// partially captured intent stays PARTIALLY_CAPTURED until its hold is settled.
+func RefundStatus(from string, fullyRefunded bool) string {
+	switch {
+	case from == StatusPartiallyCaptured:
+		return StatusPartiallyCaptured
+	case fullyRefunded:
+		return StatusRefunded
+	default:
+		return StatusPartiallyRefunded
+	}
+}
+
+// expireIntent moves an intent whose hold has expired to EXPIRED, or to CAPTURED
+// when part of it was captured: the uncaptured rest is simply released. It
+// reports false when the intent was no longer waiting for a capture or a
+// capture of it is still in progress.
+func (r *Repository) expireIntent(ctx context.Context, referenceID string) (bool, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return false, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	from, err := lockStatusTx(ctx, tx, referenceID)
+	if err != nil {
+		return false, err
+	}
+	// a capture in flight may already have moved the money; wait for it to finish
+	var capturing bool
+	err = tx.QueryRow(ctx, `
+	SELECT EXISTS (SELECT 1 FROM captures WHERE reference_id=$1 AND step IN ('STARTED', 'TRANSFERRED'))
+	`, referenceID).Scan(&capturing)
+	if err != nil {
+		return false, fmt.Errorf("check captures: %w", err)
+	}
+	if capturing {
+		return false, nil
+	}
+
+	to := StatusExpired
+	switch from {
+	case StatusAuthorized:
+	case StatusPartiallyCaptured:
+		to = StatusCaptured
+	default:
+		return false, nil
+	}
+	if err := setStatusTx(ctx, tx, referenceID, from, to, "system", "hold expired"); err != nil {
+		return false, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return false, fmt.Errorf("commit tx: %w", err)
+	}
+	return true, nil
+}
+
+// ListStatusHistory returns the status changes of an intent, oldest first.
+func (r *Repository) ListStatusHistory(ctx context.Context, referenceID string) ([]StatusTransition, error) {
+	rows, err := r.pool.Query(ctx, `
+	SELECT COALESCE(from_status, ''), to_status, actor, COALESCE(reason, ''), created_at
+	FROM payment_status_history WHERE reference_id=$1 ORDER BY id
+	`, referenceID)
+	if err != nil {
+		return nil, fmt.Errorf("list status history: %w", err)
+	}
+	defer rows.Close()
+	var res []StatusTransition
+	for rows.Next() {
+		var t StatusTransition
+		if err := rows.Scan(&t.From, &t.To, &t.Actor, &t.Reason, &t.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan status history: %w", err)
+		}
+		res = append(res, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list status history: %w", err)
+	}
+	return res, nil
+}
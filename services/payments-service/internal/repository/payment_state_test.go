@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCheckTransition(t *testing.T) {
+	tests := []struct {
+		from, to string
+		ok       bool
+	}{
+		{"", StatusAuthorized, true},
+		{"", StatusPendingReview, true},
+		{"", StatusCaptured, false},
+		{StatusPendingReview, StatusAuthorized, true},
+		{StatusPendingReview, StatusFailed, true},
+		{StatusPendingReview, StatusCaptured, false},
+		{StatusPendingReview, StatusCanceled, false},
+		{StatusAuthorized, StatusPartiallyCaptured, true},
+		{StatusAuthorized, StatusCaptured, true},
+		{StatusAuthorized, StatusCanceled, true},
+		{StatusAuthorized, StatusExpired, true},
+		{StatusAuthorized, StatusFailed, true},
+		{StatusAuthorized, StatusRefunded, false},
+		{StatusAuthorized, StatusAuthorized, false},
+		{StatusPartiallyCaptured, StatusPartiallyCaptured, true},
+		{StatusPartiallyCaptured, StatusCaptured, true},
+		{StatusPartiallyCaptured, StatusCanceled, false},
+		{StatusCaptured, StatusPartiallyRefunded, true},
+		{StatusCaptured, StatusRefunded, true},
+		{StatusCaptured, StatusCanceled, false},
+		{StatusCaptured, StatusCaptured, false},
+		{StatusPartiallyRefunded, StatusPartiallyRefunded, true},
+		{StatusPartiallyRefunded, StatusRefunded, true},
+		{StatusPartiallyRefunded, StatusCaptured, false},
+		{StatusRefunded, StatusPartiallyRefunded, false},
+		{StatusFailed, StatusAuthorized, false},
+		{StatusExpired, StatusCaptured, false},
+		{StatusCanceled, StatusAuthorized, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.from+"->"+tt.to, func(t *testing.T) {
+			err := CheckTransition(tt.from, tt.to)
+			if tt.ok {
+				if err != nil {
+					t.Fatalf("CheckTransition() = %v, want allowed", err)
+				}
+				return
+			}
+			var te *TransitionError
+			if !errors.As(err, &te) || te.From != tt.from || te.To != tt.to {
+				t.Fatalf("CheckTransition() = %v, want a *TransitionError from %q to %q", err, tt.from, tt.to)
+			}
+		})
+	}
+}
+
+func TestRefundStatus(t *testing.T) {
+	tests := []struct {
+		from  string
+		fully bool
+		want  string
+	}{
+		{StatusCaptured, false, StatusPartiallyRefunded},
+		{StatusCaptured, true, StatusRefunded},
+		{StatusPartiallyRefunded, true, StatusRefunded},
+		{StatusPartiallyCaptured, false, StatusPartiallyCaptured},
+		{StatusPartiallyCaptured, true, StatusPartiallyCaptured},
+	}
+	for _, tt := range tests {
+		if got := RefundStatus(tt.from, tt.fully); got != tt.want {
+			t.Errorf("RefundStatus(%s, %v) = %s, want %s", tt.from, tt.fully, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,230 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Statuses of a payout row. A batch is PROCESSING until none of its rows is
+// PENDING, then COMPLETED.
+const (
+	PayoutPending   = "PENDING"
+	PayoutSucceeded = "SUCCEEDED"
+	PayoutFailed    = "FAILED"
+)
+
+var ErrPayoutBatchNotFound = errors.New("payout batch not found")
+
+type PayoutBatch struct {
+	ID          string
+	Format      string // CSV or JSONL
+	Status      string // PROCESSING or COMPLETED
+	Actor       string // who submitted the file
+	TotalRows   int
+	Pending     int
+	Succeeded   int
+	Failed      int
+	CreatedAt   time.Time
+	CompletedAt time.Time // zero while PROCESSING
+}
+
+// PayoutRow is one payout of a batch. Reference is the reference_id of the
+// intent created for it.
+type PayoutRow struct {
+	BatchID   string
+	Line      int // line of the row in the submitted file
+	Reference string
+	PayerID   string
+	PayeeID   string
+	Amount    int64  // minor units
+	Currency  string // empty for the payer's currency
+	Status    string
+	CaptureID string
+	Message   string
+	Attempts  int
+}
+
+type PayoutRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewPayoutRepository(pool *pgxpool.Pool) *PayoutRepo {
+	return &PayoutRepo{pool: pool}
+}
+
+// UsedReferences returns the references that an earlier payout or payment
+// intent already has.
+func (p *PayoutRepo) UsedReferences(ctx context.Context, refs []string) ([]string, error) {
+	rows, err := p.pool.Query(ctx, `
+	SELECT reference FROM payout_rows WHERE reference = ANY($1)
+	UNION
+	SELECT reference_id FROM payment_intents WHERE reference_id = ANY($1)
+	`, refs)
+	if err != nil {
+		return nil, fmt.Errorf("query used references: %w", err)
+	}
+	used, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("scan used references: %w", err)
+	}
+	return used, nil
+}
+
+// CreateBatch stores a PROCESSING batch and its rows, all PENDING.
+func (p *PayoutRepo) CreateBatch(ctx context.Context, b PayoutBatch, payouts []PayoutRow) error {
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `
+	INSERT INTO payout_batches (id, format, status, actor, total_rows)
+	VALUES ($1, $2, 'PROCESSING', $3, $4)
+	`, b.ID, b.Format, b.Actor, len(payouts))
+	if err != nil {
+		return fmt.Errorf("insert payout batch: %w", err)
+	}
+	_, err = tx.CopyFrom(ctx, pgx.Identifier{"payout_rows"},
+		[]string{"batch_id", "line", "reference", "payer_id", "payee_id", "amount", "currency", "status"},
+		pgx.CopyFromSlice(len(payouts), func(i int) ([]any, error) {
+			r := payouts[i]
+			var currency any
+			if r.Currency != "" {
+				currency = r.Currency
+			}
+			return []any{b.ID, r.Line, r.Reference, r.PayerID, r.PayeeID, r.Amount, currency, PayoutPending}, nil
+		}))
+	if err != nil {
+		return fmt.Errorf("insert payout rows: %w", err)
+	}
+	return tx.Commit(ctx)
+}
+
+// GetBatch returns a batch with the number of its rows in each status.
+func (p *PayoutRepo) GetBatch(ctx context.Context, id string) (*PayoutBatch, error) {
+	var b PayoutBatch
+	var completedAt *time.Time
+	err := p.pool.QueryRow(ctx, `
+	SELECT b.id, b.format, b.status, b.actor, b.total_rows, b.created_at, b.completed_at,
+		count(*) FILTER (WHERE r.status = 'PENDING'),
+		count(*) FILTER (WHERE r.status = 'SUCCEEDED'),
+		count(*) FILTER (WHERE r.status = 'FAILED')
+	FROM payout_batches b LEFT JOIN payout_rows r ON r.batch_id = b.id
+	WHERE b.id = $1
+	GROUP BY b.id
+	`, id).Scan(&b.ID, &b.Format, &b.Status, &b.Actor, &b.TotalRows, &b.CreatedAt, &completedAt,
+		&b.Pending, &b.Succeeded, &b.Failed)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrPayoutBatchNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get payout batch: %w", err)
+	}
+	if completedAt != nil {
+		b.CompletedAt = *completedAt
+	}
+	return &b, nil
+}
+
+const payoutRowColumns = `batch_id, line, reference, payer_id, payee_id, amount, COALESCE(currency, ''), status,
+	COALESCE(capture_id, ''), COALESCE(message, ''), attempts`
+
+func scanPayoutRows(rows pgx.Rows) ([]*PayoutRow, error) {
+	defer rows.Close()
+	var list []*PayoutRow
+	for rows.Next() {
+		var r PayoutRow
+		if err := rows.Scan(&r.BatchID, &r.Line, &r.Reference, &r.PayerID, &r.PayeeID, &r.Amount, &r.Currency,
+			&r.Status, &r.CaptureID, &r.Message, &r.Attempts); err != nil {
+			return nil, fmt.Errorf("scan payout row: %w", err)
+		}
+		list = append(list, &r)
+	}
+	return list, rows.Err()
+}
+
+// ListRows returns the rows of a batch in file order.
+func (p *PayoutRepo) ListRows(ctx context.Context, batchID string) ([]*PayoutRow, error) {
+	rows, err := p.pool.Query(ctx, `SELECT `+payoutRowColumns+` FROM payout_rows WHERE batch_id=$1 ORDER BY line`, batchID)
+	if err != nil {
+		return nil, fmt.Errorf("list payout rows: %w", err)
+	}
+	return scanPayoutRows(rows)
+}
+
+// ClaimRows returns up to limit PENDING rows, oldest batches first, and locks
+// them for lease so that no other processor picks them up meanwhile. A row whose
+// processing stops without a result is claimed again once the lease is over.
+func (p *PayoutRepo) ClaimRows(ctx context.Context, limit int, lease time.Duration) ([]*PayoutRow, error) {
+	rows, err := p.pool.Query(ctx, `
+	UPDATE payout_rows SET locked_until = now() + make_interval(secs => $2), attempts = attempts + 1, updated_at = now()
+	WHERE (batch_id, line) IN (
+		SELECT batch_id, line FROM payout_rows
+		WHERE status = 'PENDING' AND locked_until <= now()
+		ORDER BY created_at, line
+		LIMIT $1
+		FOR UPDATE SKIP LOCKED
+	)
+	RETURNING `+payoutRowColumns, limit, lease.Seconds())
+	if err != nil {
+		return nil, fmt.Errorf("claim payout rows: %w", err)
+	}
+	return scanPayoutRows(rows)
+}
+
+// FinishRow records the outcome of a row and completes its batch when it was
+// the last one pending.
+func (p *PayoutRepo) FinishRow(ctx context.Context, r *PayoutRow) error {
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `
+	UPDATE payout_rows SET status=$3, capture_id=NULLIF($4, ''), message=NULLIF($5, ''), updated_at=now()
+	WHERE batch_id=$1 AND line=$2
+	`, r.BatchID, r.Line, r.Status, r.CaptureID, r.Message)
+	if err != nil {
+		return fmt.Errorf("update payout row: %w", err)
+	}
+	_, err = tx.Exec(ctx, `
+	UPDATE payout_batches SET status='COMPLETED', completed_at=now(), updated_at=now()
+	WHERE id=$1 AND status='PROCESSING'
+		AND NOT EXISTS (SELECT 1 FROM payout_rows WHERE batch_id=$1 AND status='PENDING')
+	`, r.BatchID)
+	if err != nil {
+		return fmt.Errorf("complete payout batch: %w", err)
+	}
+	return tx.Commit(ctx)
+}
+
+// DeferRow keeps a row PENDING with message and leaves it alone for wait,
+// without counting the attempt that found it not ready.
+func (p *PayoutRepo) DeferRow(ctx context.Context, r *PayoutRow, message string, wait time.Duration) error {
+	_, err := p.pool.Exec(ctx, `
+	UPDATE payout_rows SET message=$3, locked_until = now() + make_interval(secs => $4), attempts = attempts - 1, updated_at=now()
+	WHERE batch_id=$1 AND line=$2
+	`, r.BatchID, r.Line, message, wait.Seconds())
+	if err != nil {
+		return fmt.Errorf("update payout row: %w", err)
+	}
+	return nil
+}
+
+// NoteRowError keeps a row PENDING with the error of its last attempt; it is
+// tried again when its lease runs out.
+func (p *PayoutRepo) NoteRowError(ctx context.Context, r *PayoutRow, message string) error {
+	_, err := p.pool.Exec(ctx, `UPDATE payout_rows SET message=$3, updated_at=now() WHERE batch_id=$1 AND line=$2`,
+		r.BatchID, r.Line, message)
+	if err != nil {
+		return fmt.Errorf("update payout row: %w", err)
+	}
+	return nil
+}
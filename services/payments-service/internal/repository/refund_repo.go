@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/parasagrawal71/bank-settlement-system/shared/money"
+)
+
+type Refund struct {
+	ID             string
+	ReferenceID    string
+	IdempotencyKey string
+	Amount         money.Money // returned to the payer
+	PayeeAmount    money.Money // taken back from the payee, set once SUCCEEDED
+	Reason         string
+	Status         string // PENDING, SUCCEEDED or FAILED
+	Message        string
+}
+
+const refundColumns = `id, reference_id, idempotency_key, amount, currency, COALESCE(payee_amount, 0),
+	COALESCE(payee_currency, currency), COALESCE(reason, ''), status, COALESCE(message, '')`
+
+func scanRefund(row pgx.Row) (*Refund, error) {
+	var rf Refund
+	err := row.Scan(&rf.ID, &rf.ReferenceID, &rf.IdempotencyKey, &rf.Amount.Amount, &rf.Amount.Currency,
+		&rf.PayeeAmount.Amount, &rf.PayeeAmount.Currency, &rf.Reason, &rf.Status, &rf.Message)
+	if err != nil {
+		return nil, err
+	}
+	return &rf, nil
+}
+
+// GetRefundByKey returns the refund made for an idempotency key of a payment, or nil.
+func (r *Repository) GetRefundByKey(ctx context.Context, referenceID, key string) (*Refund, error) {
+	rf, err := scanRefund(r.pool.QueryRow(ctx, `
+	SELECT `+refundColumns+` FROM refunds WHERE reference_id=$1 AND idempotency_key=$2
+	`, referenceID, key))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	return rf, err
+}
+
+// CreateRefund stores a PENDING refund. When a refund with the same idempotency
+// key was created concurrently, that refund is returned instead.
+func (r *Repository) CreateRefund(ctx context.Context, rf Refund) (*Refund, error) {
+	created, err := scanRefund(r.pool.QueryRow(ctx, `
+	INSERT INTO refunds (id, reference_id, idempotency_key, amount, currency, reason, status)
+	VALUES ($1,$2,$3,$4,$5,NULLIF($6,''),'PENDING')
+	ON CONFLICT (reference_id, idempotency_key) DO NOTHING
+	RETURNING `+refundColumns,
+		rf.ID, rf.ReferenceID, rf.IdempotencyKey, rf.Amount.Amount, rf.Amount.Currency, rf.Reason))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return r.GetRefundByKey(ctx, rf.ReferenceID, rf.IdempotencyKey)
+	}
+	return created, err
+}
+
+func (r *Repository) MarkRefundFailed(ctx context.Context, id, message string) error {
+	_, err := r.pool.Exec(ctx, `
+	UPDATE refunds SET status='FAILED', message=$2, updated_at=now() WHERE id=$1 AND status='PENDING'
+	`, id, message)
+	return err
+}
+
+// CompleteRefundTx marks the refund SUCCEEDED, adds it to the intent and moves
+// the intent to PARTIALLY_REFUNDED, or REFUNDED once everything captured has
+// been refunded.
+func (r *Repository) CompleteRefundTx(ctx context.Context, tx pgx.Tx, rf *Refund, actor string) error {
+	_, err := tx.Exec(ctx, `
+	UPDATE refunds SET status='SUCCEEDED', payee_amount=$2, payee_currency=$3, updated_at=now() WHERE id=$1
+	`, rf.ID, rf.PayeeAmount.Amount, rf.PayeeAmount.Currency)
+	if err != nil {
+		return err
+	}
+	from, err := lockStatusTx(ctx, tx, rf.ReferenceID)
+	if err != nil {
+		return err
+	}
+	var fullyRefunded bool
+	err = tx.QueryRow(ctx, `
+	UPDATE payment_intents SET refunded_amount=refunded_amount+$2, updated_at=now()
+	WHERE reference_id=$1
+	RETURNING refunded_amount >= captured_amount
+	`, rf.ReferenceID, rf.Amount.Amount).Scan(&fullyRefunded)
+	if err != nil {
+		return err
+	}
+	reason := "refund " + rf.ID
+	if rf.Reason != "" {
+		reason += ": " + rf.Reason
+	}
+	return setStatusTx(ctx, tx, rf.ReferenceID, from, RefundStatus(from, fullyRefunded), actor, reason)
+}
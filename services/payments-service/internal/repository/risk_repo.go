@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/parasagrawal71/bank-settlement-system/shared/money"
+)
+
+// RiskEvaluation records the decision the risk rules made on a payment intent
+// before its funds were reserved, including intents that were denied and so
+// never stored.
+type RiskEvaluation struct {
+	ID           int64
+	ReferenceID  string
+	PayerID      string
+	PayeeID      string // the payee, or the payee of the first leg
+	Amount       money.Money
+	Decision     string // ALLOW, REVIEW or DENY
+	Reasons      []string
+	RulesVersion string // of the rules file, empty when no rules were loaded
+	CreatedAt    time.Time
+}
+
+func (r *Repository) InsertRiskEvaluation(ctx context.Context, e RiskEvaluation) error {
+	if e.Reasons == nil {
+		e.Reasons = []string{}
+	}
+	_, err := r.pool.Exec(ctx, `
+	INSERT INTO risk_evaluations (reference_id, payer_id, payee_id, amount, currency, decision, reasons, rules_version)
+	VALUES ($1,$2,$3,$4,$5,$6,$7,NULLIF($8,''))
+	`, e.ReferenceID, e.PayerID, e.PayeeID, e.Amount.Amount, e.Amount.Currency, e.Decision, e.Reasons, e.RulesVersion)
+	if err != nil {
+		return fmt.Errorf("insert risk evaluation: %w", err)
+	}
+	return nil
+}
+
+// ListRiskEvaluations returns the newest evaluations matching the non-empty filters.
+func (r *Repository) ListRiskEvaluations(ctx context.Context, referenceID, payerID, decision string, limit int) ([]RiskEvaluation, error) {
+	rows, err := r.pool.Query(ctx, `
+	SELECT id, reference_id, payer_id, payee_id, amount, currency, decision, reasons, COALESCE(rules_version, ''), created_at
+	FROM risk_evaluations
+	WHERE ($1 = '' OR reference_id = $1) AND ($2 = '' OR payer_id = $2) AND ($3 = '' OR decision = $3)
+	ORDER BY id DESC
+	LIMIT $4
+	`, referenceID, payerID, decision, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list risk evaluations: %w", err)
+	}
+	defer rows.Close()
+	var res []RiskEvaluation
+	for rows.Next() {
+		var e RiskEvaluation
+		if err := rows.Scan(&e.ID, &e.ReferenceID, &e.PayerID, &e.PayeeID, &e.Amount.Amount, &e.Amount.Currency,
+			&e.Decision, &e.Reasons, &e.RulesVersion, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan risk evaluation: %w", err)
+		}
+		res = append(res, e)
+	}
+	return res, rows.Err()
+}
+
+// PayerVelocity returns how many intents a payer has created in currency since
+// a time, and their total amount. Failed intents do not count.
+func (r *Repository) PayerVelocity(ctx context.Context, payerID, currency string, since time.Time) (int, int64, error) {
+	var count int
+	var total int64
+	err := r.pool.QueryRow(ctx, `
+	SELECT COUNT(*), COALESCE(SUM(amount), 0)::BIGINT FROM payment_intents
+	WHERE payer_id=$1 AND currency=$2 AND created_at >= $3 AND status <> 'FAILED'
+	`, payerID, currency, since).Scan(&count, &total)
+	if err != nil {
+		return 0, 0, fmt.Errorf("payer velocity: %w", err)
+	}
+	return count, total, nil
+}
+
+// HasPaid reports whether a payer has captured anything to a payee before,
+// directly or as a leg of a split payment.
+func (r *Repository) HasPaid(ctx context.Context, payerID, payeeID string) (bool, error) {
+	var paid bool
+	err := r.pool.QueryRow(ctx, `
+	SELECT EXISTS (
+		SELECT 1 FROM payment_intents i
+		WHERE i.payer_id=$1 AND i.captured_amount > 0
+			AND (i.payee_id=$2 OR EXISTS (SELECT 1 FROM payment_legs l WHERE l.reference_id=i.reference_id AND l.payee_id=$2))
+	)
+	`, payerID, payeeID).Scan(&paid)
+	if err != nil {
+		return false, fmt.Errorf("check payee history: %w", err)
+	}
+	return paid, nil
+}
+
+// CreateReviewIntentTx stores an intent the risk rules held for review, in
+// PENDING_REVIEW and without reserved funds, and the first entry of its status
+// history. holdTTLSeconds is the hold requested for when it is approved.
+func (r *Repository) CreateReviewIntentTx(ctx context.Context, tx pgx.Tx, referenceID string, payerID string, payeeID string, amount money.Money, payeeAmount money.Money, holdTTLSeconds int64, actor string, reason string) error {
+	_, err := tx.Exec(ctx, `
+    INSERT INTO payment_intents (reference_id, payer_id, payee_id, amount, currency, payee_amount, payee_currency, status, hold_ttl_seconds, created_at)
+    VALUES ($1,$2,$3,$4,$5,$6,$7,'PENDING_REVIEW',NULLIF($8,0), now())
+    `, referenceID, payerID, payeeID, amount.Amount, amount.Currency, payeeAmount.Amount, payeeAmount.Currency, holdTTLSeconds)
+	if err != nil {
+		return err
+	}
+	return insertHistoryTx(ctx, tx, referenceID, StatusTransition{To: StatusPendingReview, Actor: actor, Reason: reason})
+}
+
+// ApproveIntentTx moves an intent out of PENDING_REVIEW to AUTHORIZED once its
+// funds are reserved, with the payee amount and quote of the reservation. It
+// reports false when the intent was already AUTHORIZED.
+func (r *Repository) ApproveIntentTx(ctx context.Context, tx pgx.Tx, referenceID string, payeeAmount money.Money, quoteID string, expiresAt time.Time, actor string, reason string) (bool, error) {
+	from, err := lockStatusTx(ctx, tx, referenceID)
+	if err != nil {
+		return false, err
+	}
+	if from == StatusAuthorized {
+		return false, nil
+	}
+	if err := setStatusTx(ctx, tx, referenceID, from, StatusAuthorized, actor, reason); err != nil {
+		return false, err
+	}
+	_, err = tx.Exec(ctx, `
+	UPDATE payment_intents SET payee_amount=$2, payee_currency=$3, quote_id=NULLIF($4,''), expires_at=$5 WHERE reference_id=$1
+	`, referenceID, payeeAmount.Amount, payeeAmount.Currency, quoteID, expiresAt)
+	if err != nil {
+		return false, fmt.Errorf("update approved intent: %w", err)
+	}
+	return true, nil
+}
+
+// DeclineIntentTx moves an intent out of PENDING_REVIEW to FAILED. It reports
+// false when the intent was already FAILED.
+func (r *Repository) DeclineIntentTx(ctx context.Context, tx pgx.Tx, referenceID string, actor string, reason string) (bool, error) {
+	from, err := lockStatusTx(ctx, tx, referenceID)
+	if err != nil {
+		return false, err
+	}
+	if from == StatusFailed {
+		return false, nil
+	}
+	return true, setStatusTx(ctx, tx, referenceID, from, StatusFailed, actor, reason)
+}
@@ -0,0 +1,270 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var ErrWebhookNotFound = errors.New("webhook not found")
+
+type WebhookEndpoint struct {
+	ID         string
+	AccountID  string
+	URL        string
+	Secret     string
+	EventTypes []string // empty receives every event type
+	Active     bool
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// WebhookDelivery is one notification for one endpoint. Retries and replays
+// reuse the delivery, so receivers see the same event id every time.
+type WebhookDelivery struct {
+	ID             int64
+	EndpointID     string
+	EventID        string
+	EventType      string
+	ReferenceID    string
+	Payload        []byte
+	Status         string // PENDING, DELIVERED or FAILED
+	Attempts       int
+	LastStatusCode int
+	LastError      string
+	NextAttemptAt  time.Time
+	ExpiresAt      time.Time // retries stop after this
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+type WebhookAttempt struct {
+	Attempt    int
+	StatusCode int // 0 when no response was received
+	Error      string
+	Duration   time.Duration
+	CreatedAt  time.Time
+}
+
+type WebhookRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewWebhookRepository(pool *pgxpool.Pool) *WebhookRepo {
+	return &WebhookRepo{pool: pool}
+}
+
+const endpointColumns = `id, account_id, url, secret, event_types, active, created_at, updated_at`
+
+func scanEndpoint(row pgx.Row) (*WebhookEndpoint, error) {
+	var e WebhookEndpoint
+	if err := row.Scan(&e.ID, &e.AccountID, &e.URL, &e.Secret, &e.EventTypes, &e.Active, &e.CreatedAt, &e.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+func (w *WebhookRepo) CreateEndpoint(ctx context.Context, e WebhookEndpoint) (*WebhookEndpoint, error) {
+	if e.EventTypes == nil {
+		e.EventTypes = []string{}
+	}
+	return scanEndpoint(w.pool.QueryRow(ctx, `
+	INSERT INTO webhook_endpoints (id, account_id, url, secret, event_types)
+	VALUES ($1, $2, $3, $4, $5)
+	RETURNING `+endpointColumns,
+		e.ID, e.AccountID, e.URL, e.Secret, e.EventTypes))
+}
+
+func (w *WebhookRepo) GetEndpoint(ctx context.Context, id string) (*WebhookEndpoint, error) {
+	e, err := scanEndpoint(w.pool.QueryRow(ctx, `SELECT `+endpointColumns+` FROM webhook_endpoints WHERE id=$1`, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrWebhookNotFound
+	}
+	return e, err
+}
+
+// ListEndpoints returns the endpoints of an account, or of every account when accountID is empty.
+func (w *WebhookRepo) ListEndpoints(ctx context.Context, accountID string) ([]*WebhookEndpoint, error) {
+	rows, err := w.pool.Query(ctx, `
+	SELECT `+endpointColumns+` FROM webhook_endpoints
+	WHERE $1 = '' OR account_id = $1
+	ORDER BY created_at
+	`, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("list webhook endpoints: %w", err)
+	}
+	defer rows.Close()
+	var res []*WebhookEndpoint
+	for rows.Next() {
+		e, err := scanEndpoint(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan webhook endpoint: %w", err)
+		}
+		res = append(res, e)
+	}
+	return res, rows.Err()
+}
+
+// DisableEndpoint stops new deliveries to an endpoint. Pending deliveries are still attempted.
+func (w *WebhookRepo) DisableEndpoint(ctx context.Context, id string) (*WebhookEndpoint, error) {
+	e, err := scanEndpoint(w.pool.QueryRow(ctx, `
+	UPDATE webhook_endpoints SET active=false, updated_at=now() WHERE id=$1
+	RETURNING `+endpointColumns, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrWebhookNotFound
+	}
+	return e, err
+}
+
+// EnqueueTx schedules a delivery of payload to every active endpoint of the
+// account that subscribes to eventType. Deliveries are retried until maxAge
+// has passed.
+func (w *WebhookRepo) EnqueueTx(ctx context.Context, tx pgx.Tx, accountID, eventType, referenceID, eventID string, payload []byte, maxAge time.Duration) error {
+	_, err := tx.Exec(ctx, `
+	INSERT INTO webhook_deliveries (endpoint_id, event_id, event_type, reference_id, payload, status, next_attempt_at, expires_at)
+	SELECT id, $2, $3, $4, $5, 'PENDING', now(), now() + make_interval(secs => $6)
+	FROM webhook_endpoints
+	WHERE account_id = $1 AND active AND (cardinality(event_types) = 0 OR $3 = ANY(event_types))
+	ON CONFLICT (endpoint_id, event_id) DO NOTHING
+	`, accountID, eventID, eventType, referenceID, payload, maxAge.Seconds())
+	if err != nil {
+		return fmt.Errorf("enqueue webhooks: %w", err)
+	}
+	return nil
+}
+
+const deliveryColumns = `id, endpoint_id, event_id, event_type, COALESCE(reference_id, ''), payload, status, attempts,
+	COALESCE(last_status_code, 0), COALESCE(last_error, ''), next_attempt_at, expires_at, created_at, updated_at`
+
+func scanDelivery(row pgx.Row) (*WebhookDelivery, error) {
+	var d WebhookDelivery
+	err := row.Scan(&d.ID, &d.EndpointID, &d.EventID, &d.EventType, &d.ReferenceID, &d.Payload, &d.Status, &d.Attempts,
+		&d.LastStatusCode, &d.LastError, &d.NextAttemptAt, &d.ExpiresAt, &d.CreatedAt, &d.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+func scanDeliveries(rows pgx.Rows) ([]*WebhookDelivery, error) {
+	defer rows.Close()
+	var res []*WebhookDelivery
+	for rows.Next() {
+		d, err := scanDelivery(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan webhook delivery: %w", err)
+		}
+		res = append(res, d)
+	}
+	return res, rows.Err()
+}
+
+// ClaimDue returns up to limit deliveries that are due and pushes their next
+// attempt back by lease, so another dispatcher does not pick them up while
+// they are being sent.
+func (w *WebhookRepo) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*WebhookDelivery, error) {
+	rows, err := w.pool.Query(ctx, `
+	UPDATE webhook_deliveries SET next_attempt_at = now() + make_interval(secs => $2)
+	WHERE id IN (
+		SELECT id FROM webhook_deliveries
+		WHERE status = 'PENDING' AND next_attempt_at <= now()
+		ORDER BY next_attempt_at
+		LIMIT $1
+		FOR UPDATE SKIP LOCKED
+	)
+	RETURNING `+deliveryColumns, limit, lease.Seconds())
+	if err != nil {
+		return nil, fmt.Errorf("claim webhook deliveries: %w", err)
+	}
+	return scanDeliveries(rows)
+}
+
+// RecordAttempt logs an attempt and moves the delivery to status. A PENDING
+// delivery is attempted again at nextAttemptAt.
+func (w *WebhookRepo) RecordAttempt(ctx context.Context, deliveryID int64, a WebhookAttempt, status string, nextAttemptAt time.Time) error {
+	tx, err := w.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `
+	INSERT INTO webhook_delivery_attempts (delivery_id, attempt, status_code, error, duration_ms)
+	VALUES ($1, $2, NULLIF($3, 0), NULLIF($4, ''), $5)
+	`, deliveryID, a.Attempt, a.StatusCode, a.Error, a.Duration.Milliseconds())
+	if err != nil {
+		return fmt.Errorf("insert webhook attempt: %w", err)
+	}
+	_, err = tx.Exec(ctx, `
+	UPDATE webhook_deliveries SET status=$2, attempts=$3, last_status_code=NULLIF($4, 0), last_error=NULLIF($5, ''),
+		next_attempt_at=$6, updated_at=now()
+	WHERE id=$1
+	`, deliveryID, status, a.Attempt, a.StatusCode, a.Error, nextAttemptAt)
+	if err != nil {
+		return fmt.Errorf("update webhook delivery: %w", err)
+	}
+	return tx.Commit(ctx)
+}
+
+func (w *WebhookRepo) GetDelivery(ctx context.Context, id int64) (*WebhookDelivery, error) {
+	d, err := scanDelivery(w.pool.QueryRow(ctx, `SELECT `+deliveryColumns+` FROM webhook_deliveries WHERE id=$1`, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrWebhookNotFound
+	}
+	return d, err
+}
+
+// ListDeliveries returns the newest deliveries matching the non-empty filters.
+func (w *WebhookRepo) ListDeliveries(ctx context.Context, endpointID, referenceID, status string, limit int) ([]*WebhookDelivery, error) {
+	rows, err := w.pool.Query(ctx, `
+	SELECT `+deliveryColumns+` FROM webhook_deliveries
+	WHERE ($1 = '' OR endpoint_id = $1) AND ($2 = '' OR reference_id = $2) AND ($3 = '' OR status = $3)
+	ORDER BY id DESC
+	LIMIT $4
+	`, endpointID, referenceID, status, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list webhook deliveries: %w", err)
+	}
+	return scanDeliveries(rows)
+}
+
+// ListAttempts returns the attempts of a delivery, oldest first.
+func (w *WebhookRepo) ListAttempts(ctx context.Context, deliveryID int64) ([]WebhookAttempt, error) {
+	rows, err := w.pool.Query(ctx, `
+	SELECT attempt, COALESCE(status_code, 0), COALESCE(error, ''), duration_ms, created_at
+	FROM webhook_delivery_attempts WHERE delivery_id=$1 ORDER BY id
+	`, deliveryID)
+	if err != nil {
+		return nil, fmt.Errorf("list webhook attempts: %w", err)
+	}
+	defer rows.Close()
+	var res []WebhookAttempt
+	for rows.Next() {
+		var a WebhookAttempt
+		var ms int64
+		if err := rows.Scan(&a.Attempt, &a.StatusCode, &a.Error, &ms, &a.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan webhook attempt: %w", err)
+		}
+		a.Duration = time.Duration(ms) * time.Millisecond
+		res = append(res, a)
+	}
+	return res, rows.Err()
+}
+
+// ReplayDelivery schedules a delivery to be sent again now, whatever its
+// status, and gives it a fresh maxAge of retries.
+func (w *WebhookRepo) ReplayDelivery(ctx context.Context, id int64, maxAge time.Duration) (*WebhookDelivery, error) {
+	d, err := scanDelivery(w.pool.QueryRow(ctx, `
+	UPDATE webhook_deliveries SET status='PENDING', next_attempt_at=now(),
+		expires_at=now() + make_interval(secs => $2), updated_at=now()
+	WHERE id=$1
+	RETURNING `+deliveryColumns, id, maxAge.Seconds()))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrWebhookNotFound
+	}
+	return d, err
+}
@@ -0,0 +1,164 @@
+// Package risk decides whether a payment may be authorized before its funds
+// are reserved. An Engine runs a set of rules, loaded from a YAML or JSON file
+// that can be reloaded while the service runs, and combines their decisions:
+// the strictest one wins and the reason codes of every rule that fired are
+// kept.
+package risk
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/parasagrawal71/bank-settlement-system/shared/money"
+)
+
+type Decision string
+
+const (
+	Allow  Decision = "ALLOW"
+	Review Decision = "REVIEW" // held in PENDING_REVIEW until an operator approves or declines it
+	Deny   Decision = "DENY"
+)
+
+func (d Decision) severity() int {
+	switch d {
+	case Review:
+		return 1
+	case Deny:
+		return 2
+	}
+	return 0
+}
+
+// Payment is what the rules see of a payment intent.
+type Payment struct {
+	PayerID string
+	Amount  money.Money // payer currency
+	Payees  []Payee     // the payee, or the payees of every leg of a split payment
+}
+
+type Payee struct {
+	ID     string
+	Amount int64 // share of the amount, payer currency
+}
+
+// History answers the questions rules ask about earlier payments.
+type History interface {
+	// PayerVelocity returns how many payments a payer has made in currency
+	// since a time, and their total amount. Failed payments do not count.
+	PayerVelocity(ctx context.Context, payerID, currency string, since time.Time) (int, int64, error)
+	// HasPaid reports whether a payer has ever completed a capture to a payee.
+	HasPaid(ctx context.Context, payerID, payeeID string) (bool, error)
+}
+
+// A Rule decides on a payment. It returns Allow with no reasons when it does
+// not apply.
+type Rule interface {
+	Evaluate(ctx context.Context, p Payment, h History) (Decision, []string, error)
+}
+
+type Result struct {
+	Decision Decision
+	Reasons  []string
+	Version  string // of the rules file the decision was made with
+}
+
+type ruleSet struct {
+	rules   []Rule
+	version string
+}
+
+// Engine evaluates payments against the rules of a file. Without a file every
+// payment is allowed.
+type Engine struct {
+	path string
+
+	mu      sync.RWMutex
+	set     ruleSet
+	modTime time.Time
+}
+
+// NewEngine loads the rules of path, or none when path is empty.
+func NewEngine(path string) (*Engine, error) {
+	e := &Engine{path: path}
+	if path == "" {
+		return e, nil
+	}
+	if _, err := e.Reload(); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// Reload reads the rules file again when it has changed since it was last
+// loaded and reports whether the rules changed. An invalid file leaves the
+// rules in use unchanged.
+func (e *Engine) Reload() (bool, error) {
+	if e.path == "" {
+		return false, nil
+	}
+	info, err := os.Stat(e.path)
+	if err != nil {
+		return false, fmt.Errorf("risk rules: %w", err)
+	}
+	e.mu.RLock()
+	unchanged := info.ModTime().Equal(e.modTime)
+	current := e.set.version
+	e.mu.RUnlock()
+	if unchanged {
+		return false, nil
+	}
+
+	data, err := os.ReadFile(e.path)
+	if err != nil {
+		return false, fmt.Errorf("risk rules: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	version := hex.EncodeToString(sum[:6])
+	rules, err := ParseRules(data)
+	if err != nil {
+		return false, fmt.Errorf("risk rules %s: %w", e.path, err)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.set = ruleSet{rules: rules, version: version}
+	e.modTime = info.ModTime()
+	return version != current, nil
+}
+
+// Version identifies the rules in use, empty without a rules file.
+func (e *Engine) Version() string {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.set.version
+}
+
+// Evaluate runs every rule on a payment. The strictest decision wins, and the
+// reasons of all rules that did not allow the payment are returned.
+func (e *Engine) Evaluate(ctx context.Context, p Payment, h History) (*Result, error) {
+	e.mu.RLock()
+	set := e.set
+	e.mu.RUnlock()
+
+	res := &Result{Decision: Allow, Version: set.version}
+	for _, rule := range set.rules {
+		d, reasons, err := rule.Evaluate(ctx, p, h)
+		if err != nil {
+			return nil, err
+		}
+		if d == Allow {
+			continue
+		}
+		res.Reasons = append(res.Reasons, reasons...)
+		if d.severity() > res.Decision.severity() {
+			res.Decision = d
+		}
+	}
+	return res, nil
+}
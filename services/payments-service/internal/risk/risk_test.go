@@ -0,0 +1,108 @@
+package risk
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestEngineEvaluate(t *testing.T) {
+	tests := []struct {
+		name     string
+		rules    []Rule
+		payment  Payment
+		decision Decision
+		reasons  []string
+	}{
+		{"no rules", nil, payment(100, "INR"), Allow, nil},
+		{"all allow", []Rule{
+			&AmountThreshold{ReviewAbove: 1000},
+			&Blocklist{Accounts: []string{"x"}, Action: Deny},
+		}, payment(100, "INR"), Allow, nil},
+		{"review", []Rule{
+			&AmountThreshold{ReviewAbove: 50},
+			&Blocklist{Accounts: []string{"x"}, Action: Deny},
+		}, payment(100, "INR"), Review, []string{ReasonAmountReview}},
+		{"deny beats review, reasons of both", []Rule{
+			&Blocklist{Accounts: []string{"payer"}, Action: Deny},
+			&AmountThreshold{ReviewAbove: 50},
+		}, payment(100, "INR"), Deny, []string{ReasonBlocklistedPayer, ReasonAmountReview}},
+		{"review after deny stays deny", []Rule{
+			&AmountThreshold{DenyAbove: 50},
+			&NewPayeeLimit{MaxAmount: 10, Action: Review},
+		}, payment(100, "INR"), Deny, []string{ReasonAmountDeny, ReasonNewPayeeLimit}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &Engine{set: ruleSet{rules: tt.rules, version: "v1"}}
+			res, err := e.Evaluate(context.Background(), tt.payment, &fakeHistory{})
+			if err != nil {
+				t.Fatalf("Evaluate() error = %v", err)
+			}
+			if res.Decision != tt.decision || !reflect.DeepEqual(res.Reasons, tt.reasons) || res.Version != "v1" {
+				t.Fatalf("Evaluate() = %+v, want %s %v", res, tt.decision, tt.reasons)
+			}
+		})
+	}
+}
+
+func TestEngineReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.yaml")
+	write := func(data string, mod time.Time) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, mod, mod); err != nil {
+			t.Fatal(err)
+		}
+	}
+	start := time.Now().Add(-time.Hour)
+	write("rules:\n  - type: amount_threshold\n    review_above: 100\n", start)
+
+	e, err := NewEngine(path)
+	if err != nil {
+		t.Fatalf("NewEngine() error = %v", err)
+	}
+	first := e.Version()
+	if first == "" {
+		t.Fatal("Version() is empty with a rules file")
+	}
+	if changed, err := e.Reload(); changed || err != nil {
+		t.Fatalf("Reload() of an unchanged file = %v, %v", changed, err)
+	}
+
+	write("rules:\n  - type: nope\n", start.Add(time.Minute))
+	if _, err := e.Reload(); err == nil {
+		t.Fatal("Reload() of an invalid file succeeded")
+	}
+	if e.Version() != first {
+		t.Fatalf("invalid file replaced the rules: version %s, want %s", e.Version(), first)
+	}
+
+	write("rules:\n  - type: amount_threshold\n    deny_above: 100\n", start.Add(2*time.Minute))
+	if changed, err := e.Reload(); !changed || err != nil {
+		t.Fatalf("Reload() of a new file = %v, %v", changed, err)
+	}
+	res, err := e.Evaluate(context.Background(), payment(101, "INR"), &fakeHistory{})
+	if err != nil || res.Decision != Deny {
+		t.Fatalf("Evaluate() with reloaded rules = %+v, %v, want DENY", res, err)
+	}
+}
+
+func TestEngineWithoutFile(t *testing.T) {
+	e, err := NewEngine("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := e.Evaluate(context.Background(), payment(1<<40, "INR"), &fakeHistory{})
+	if err != nil || res.Decision != Allow || res.Version != "" {
+		t.Fatalf("Evaluate() without rules = %+v, %v", res, err)
+	}
+	if _, err := NewEngine(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Fatal("NewEngine() with a missing file succeeded")
+	}
+}
@@ -0,0 +1,241 @@
+package risk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Reason codes of the built-in rules.
+const (
+	ReasonAmountReview     = "AMOUNT_OVER_REVIEW_THRESHOLD"
+	ReasonAmountDeny       = "AMOUNT_OVER_DENY_THRESHOLD"
+	ReasonVelocityCount    = "PAYER_VELOCITY_COUNT"
+	ReasonVelocityAmount   = "PAYER_VELOCITY_AMOUNT"
+	ReasonNewPayeeLimit    = "NEW_PAYEE_LIMIT"
+	ReasonBlocklistedPayer = "BLOCKLISTED_PAYER"
+	ReasonBlocklistedPayee = "BLOCKLISTED_PAYEE"
+)
+
+// A RuleFactory builds a rule from its entry in the rules file.
+type RuleFactory func(node *yaml.Node) (Rule, error)
+
+var ruleTypes = map[string]RuleFactory{
+	"amount_threshold": func(n *yaml.Node) (Rule, error) { return decodeRule(n, &AmountThreshold{}) },
+	"velocity":         func(n *yaml.Node) (Rule, error) { return decodeRule(n, &Velocity{}) },
+	"new_payee":        func(n *yaml.Node) (Rule, error) { return decodeRule(n, &NewPayeeLimit{}) },
+	"blocklist":        func(n *yaml.Node) (Rule, error) { return decodeRule(n, &Blocklist{}) },
+}
+
+// RegisterRuleType makes a rule type available to rules files under name. It
+// is meant to be called from init functions.
+func RegisterRuleType(name string, factory RuleFactory) {
+	ruleTypes[name] = factory
+}
+
+// configuredRule is a built-in rule, which checks its own settings.
+type configuredRule interface {
+	Rule
+	validate() error
+}
+
+func decodeRule(node *yaml.Node, r configuredRule) (Rule, error) {
+	if err := node.Decode(r); err != nil {
+		return nil, err
+	}
+	return r, r.validate()
+}
+
+// ParseRules reads a rules file, YAML or JSON:
+//
+//	rules:
+//	  - type: amount_threshold
+//	    review_above: 500000
+//	    deny_above: 5000000
+//
+// Every rule has a type; the other keys depend on it.
+func ParseRules(data []byte) ([]Rule, error) {
+	var file struct {
+		Rules []yaml.Node `yaml:"rules"`
+	}
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+	var rules []Rule
+	for i := range file.Rules {
+		node := &file.Rules[i]
+		var head struct {
+			Type string `yaml:"type"`
+		}
+		if err := node.Decode(&head); err != nil {
+			return nil, fmt.Errorf("rule %d: %w", i+1, err)
+		}
+		factory, ok := ruleTypes[head.Type]
+		if !ok {
+			return nil, fmt.Errorf("rule %d: unknown type %q (known: %v)", i+1, head.Type, knownTypes())
+		}
+		rule, err := factory(node)
+		if err != nil {
+			return nil, fmt.Errorf("rule %d (%s): %w", i+1, head.Type, err)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+func knownTypes() []string {
+	var names []string
+	for name := range ruleTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// checkAction accepts REVIEW or DENY, and defaults to def.
+func checkAction(a *Decision, def Decision) error {
+	if *a == "" {
+		*a = def
+	}
+	if *a != Review && *a != Deny {
+		return fmt.Errorf("action must be REVIEW or DENY, got %q", *a)
+	}
+	return nil
+}
+
+// AmountThreshold reviews or denies payments above an amount. With a currency
+// it only applies to payments in that currency.
+type AmountThreshold struct {
+	Currency    string `yaml:"currency"`
+	ReviewAbove int64  `yaml:"review_above"` // 0 for no review threshold
+	DenyAbove   int64  `yaml:"deny_above"`   // 0 for no deny threshold
+}
+
+func (r *AmountThreshold) validate() error {
+	if r.ReviewAbove < 0 || r.DenyAbove < 0 || (r.ReviewAbove == 0 && r.DenyAbove == 0) {
+		return errors.New("review_above or deny_above must be positive")
+	}
+	return nil
+}
+
+func (r *AmountThreshold) Evaluate(_ context.Context, p Payment, _ History) (Decision, []string, error) {
+	if r.Currency != "" && r.Currency != p.Amount.Currency {
+		return Allow, nil, nil
+	}
+	switch {
+	case r.DenyAbove > 0 && p.Amount.Amount > r.DenyAbove:
+		return Deny, []string{ReasonAmountDeny}, nil
+	case r.ReviewAbove > 0 && p.Amount.Amount > r.ReviewAbove:
+		return Review, []string{ReasonAmountReview}, nil
+	}
+	return Allow, nil, nil
+}
+
+// Velocity limits how many payments, and how much, a payer can make over a
+// sliding window, this payment included. Only payments in the payment's
+// currency count, and with a currency the rule only applies to that one.
+type Velocity struct {
+	Currency      string   `yaml:"currency"`
+	WindowSeconds int64    `yaml:"window_seconds"`
+	MaxCount      int      `yaml:"max_count"`  // 0 for no count limit
+	MaxAmount     int64    `yaml:"max_amount"` // 0 for no amount limit
+	Action        Decision `yaml:"action"`     // REVIEW (default) or DENY
+}
+
+func (r *Velocity) validate() error {
+	if r.WindowSeconds <= 0 {
+		return errors.New("window_seconds must be positive")
+	}
+	if r.MaxCount < 0 || r.MaxAmount < 0 || (r.MaxCount == 0 && r.MaxAmount == 0) {
+		return errors.New("max_count or max_amount must be positive")
+	}
+	return checkAction(&r.Action, Review)
+}
+
+func (r *Velocity) Evaluate(ctx context.Context, p Payment, h History) (Decision, []string, error) {
+	if r.Currency != "" && r.Currency != p.Amount.Currency {
+		return Allow, nil, nil
+	}
+	since := time.Now().Add(-time.Duration(r.WindowSeconds) * time.Second)
+	count, total, err := h.PayerVelocity(ctx, p.PayerID, p.Amount.Currency, since)
+	if err != nil {
+		return "", nil, err
+	}
+	var reasons []string
+	if r.MaxCount > 0 && count+1 > r.MaxCount {
+		reasons = append(reasons, ReasonVelocityCount)
+	}
+	if r.MaxAmount > 0 && total+p.Amount.Amount > r.MaxAmount {
+		reasons = append(reasons, ReasonVelocityAmount)
+	}
+	if len(reasons) == 0 {
+		return Allow, nil, nil
+	}
+	return r.Action, reasons, nil
+}
+
+// NewPayeeLimit limits what a payer can send to a payee it has never paid
+// before. Each payee of a split payment is checked with its own share.
+type NewPayeeLimit struct {
+	Currency  string   `yaml:"currency"`
+	MaxAmount int64    `yaml:"max_amount"`
+	Action    Decision `yaml:"action"` // REVIEW (default) or DENY
+}
+
+func (r *NewPayeeLimit) validate() error {
+	if r.MaxAmount <= 0 {
+		return errors.New("max_amount must be positive")
+	}
+	return checkAction(&r.Action, Review)
+}
+
+func (r *NewPayeeLimit) Evaluate(ctx context.Context, p Payment, h History) (Decision, []string, error) {
+	if r.Currency != "" && r.Currency != p.Amount.Currency {
+		return Allow, nil, nil
+	}
+	for _, payee := range p.Payees {
+		if payee.Amount <= r.MaxAmount {
+			continue
+		}
+		paid, err := h.HasPaid(ctx, p.PayerID, payee.ID)
+		if err != nil {
+			return "", nil, err
+		}
+		if !paid {
+			return r.Action, []string{ReasonNewPayeeLimit}, nil
+		}
+	}
+	return Allow, nil, nil
+}
+
+// Blocklist denies, or reviews, payments from or to any of its accounts.
+type Blocklist struct {
+	Accounts []string `yaml:"accounts"`
+	Action   Decision `yaml:"action"` // DENY (default) or REVIEW
+}
+
+func (r *Blocklist) validate() error {
+	return checkAction(&r.Action, Deny)
+}
+
+func (r *Blocklist) Evaluate(_ context.Context, p Payment, _ History) (Decision, []string, error) {
+	var reasons []string
+	if slices.Contains(r.Accounts, p.PayerID) {
+		reasons = append(reasons, ReasonBlocklistedPayer)
+	}
+	for _, payee := range p.Payees {
+		if slices.Contains(r.Accounts, payee.ID) {
+			reasons = append(reasons, ReasonBlocklistedPayee)
+			break
+		}
+	}
+	if len(reasons) == 0 {
+		return Allow, nil, nil
+	}
+	return r.Action, reasons, nil
+}
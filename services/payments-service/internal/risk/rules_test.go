@@ -0,0 +1,171 @@
+package risk
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/parasagrawal71/bank-settlement-system/shared/money"
+)
+
+// fakeHistory answers from fixed values and records the window it was asked about.
+type fakeHistory struct {
+	count int
+	total int64
+	paid  map[string]bool
+	err   error
+	since time.Time
+}
+
+func (h *fakeHistory) PayerVelocity(_ context.Context, _, _ string, since time.Time) (int, int64, error) {
+	h.since = since
+	return h.count, h.total, h.err
+}
+
+func (h *fakeHistory) HasPaid(_ context.Context, _, payeeID string) (bool, error) {
+	return h.paid[payeeID], h.err
+}
+
+func payment(amount int64, currency string, payees ...Payee) Payment {
+	if len(payees) == 0 {
+		payees = []Payee{{ID: "payee", Amount: amount}}
+	}
+	return Payment{PayerID: "payer", Amount: money.Money{Amount: amount, Currency: currency}, Payees: payees}
+}
+
+func TestRules(t *testing.T) {
+	tests := []struct {
+		name     string
+		rule     Rule
+		payment  Payment
+		history  *fakeHistory
+		decision Decision
+		reasons  []string
+	}{
+		{"amount below review", &AmountThreshold{ReviewAbove: 1000, DenyAbove: 5000}, payment(1000, "INR"), &fakeHistory{}, Allow, nil},
+		{"amount over review", &AmountThreshold{ReviewAbove: 1000, DenyAbove: 5000}, payment(1001, "INR"), &fakeHistory{}, Review, []string{ReasonAmountReview}},
+		{"amount over deny", &AmountThreshold{ReviewAbove: 1000, DenyAbove: 5000}, payment(5001, "INR"), &fakeHistory{}, Deny, []string{ReasonAmountDeny}},
+		{"amount deny only", &AmountThreshold{DenyAbove: 5000}, payment(4000, "INR"), &fakeHistory{}, Allow, nil},
+		{"amount other currency", &AmountThreshold{Currency: "USD", ReviewAbove: 1000}, payment(9999, "INR"), &fakeHistory{}, Allow, nil},
+
+		{"velocity under limits", &Velocity{WindowSeconds: 3600, MaxCount: 3, MaxAmount: 1000, Action: Review}, payment(100, "INR"), &fakeHistory{count: 2, total: 900}, Allow, nil},
+		{"velocity count", &Velocity{WindowSeconds: 3600, MaxCount: 3, Action: Review}, payment(100, "INR"), &fakeHistory{count: 3}, Review, []string{ReasonVelocityCount}},
+		{"velocity amount", &Velocity{WindowSeconds: 3600, MaxAmount: 1000, Action: Deny}, payment(101, "INR"), &fakeHistory{total: 900}, Deny, []string{ReasonVelocityAmount}},
+		{"velocity both", &Velocity{WindowSeconds: 3600, MaxCount: 1, MaxAmount: 1000, Action: Review}, payment(2000, "INR"), &fakeHistory{count: 1}, Review, []string{ReasonVelocityCount, ReasonVelocityAmount}},
+		{"velocity other currency", &Velocity{Currency: "USD", WindowSeconds: 3600, MaxCount: 1, Action: Review}, payment(100, "INR"), &fakeHistory{count: 5}, Allow, nil},
+
+		{"new payee under limit", &NewPayeeLimit{MaxAmount: 1000, Action: Review}, payment(1000, "INR"), &fakeHistory{}, Allow, nil},
+		{"new payee over limit", &NewPayeeLimit{MaxAmount: 1000, Action: Review}, payment(1001, "INR"), &fakeHistory{}, Review, []string{ReasonNewPayeeLimit}},
+		{"known payee over limit", &NewPayeeLimit{MaxAmount: 1000, Action: Review}, payment(1001, "INR"), &fakeHistory{paid: map[string]bool{"payee": true}}, Allow, nil},
+		{"split shares under limit", &NewPayeeLimit{MaxAmount: 1000, Action: Deny},
+			payment(1500, "INR", Payee{"a", 750}, Payee{"b", 750}), &fakeHistory{}, Allow, nil},
+		{"split share over limit", &NewPayeeLimit{MaxAmount: 1000, Action: Deny},
+			payment(1500, "INR", Payee{"a", 300}, Payee{"b", 1200}), &fakeHistory{paid: map[string]bool{"a": true}}, Deny, []string{ReasonNewPayeeLimit}},
+
+		{"blocklist clear", &Blocklist{Accounts: []string{"x"}, Action: Deny}, payment(100, "INR"), &fakeHistory{}, Allow, nil},
+		{"blocklisted payer", &Blocklist{Accounts: []string{"payer"}, Action: Deny}, payment(100, "INR"), &fakeHistory{}, Deny, []string{ReasonBlocklistedPayer}},
+		{"blocklisted leg payee", &Blocklist{Accounts: []string{"b"}, Action: Review},
+			payment(100, "INR", Payee{"a", 50}, Payee{"b", 50}), &fakeHistory{}, Review, []string{ReasonBlocklistedPayee}},
+		{"blocklisted both", &Blocklist{Accounts: []string{"payer", "payee"}, Action: Deny}, payment(100, "INR"), &fakeHistory{}, Deny, []string{ReasonBlocklistedPayer, ReasonBlocklistedPayee}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, reasons, err := tt.rule.Evaluate(context.Background(), tt.payment, tt.history)
+			if err != nil {
+				t.Fatalf("Evaluate() error = %v", err)
+			}
+			if d != tt.decision || !reflect.DeepEqual(reasons, tt.reasons) {
+				t.Fatalf("Evaluate() = %s %v, want %s %v", d, reasons, tt.decision, tt.reasons)
+			}
+		})
+	}
+}
+
+func TestVelocityWindow(t *testing.T) {
+	h := &fakeHistory{}
+	before := time.Now()
+	if _, _, err := (&Velocity{WindowSeconds: 600, MaxCount: 1, Action: Review}).Evaluate(context.Background(), payment(1, "INR"), h); err != nil {
+		t.Fatal(err)
+	}
+	if want := before.Add(-10 * time.Minute); h.since.Before(want.Add(-time.Second)) || h.since.After(time.Now().Add(-10*time.Minute)) {
+		t.Fatalf("asked for payments since %v, want about %v", h.since, want)
+	}
+}
+
+func TestRuleHistoryError(t *testing.T) {
+	boom := errors.New("db down")
+	for _, rule := range []Rule{
+		&Velocity{WindowSeconds: 60, MaxCount: 1, Action: Review},
+		&NewPayeeLimit{MaxAmount: 1, Action: Review},
+	} {
+		if _, _, err := rule.Evaluate(context.Background(), payment(100, "INR"), &fakeHistory{err: boom}); !errors.Is(err, boom) {
+			t.Errorf("%T.Evaluate() error = %v, want %v", rule, err, boom)
+		}
+	}
+}
+
+func TestParseRules(t *testing.T) {
+	tests := []struct {
+		name  string
+		data  string
+		rules []Rule
+		err   string
+	}{
+		{
+			name: "yaml with defaults",
+			data: `
+rules:
+  - type: amount_threshold
+    currency: INR
+    review_above: 500000
+  - type: velocity
+    window_seconds: 3600
+    max_count: 10
+  - type: new_payee
+    max_amount: 100000
+    action: DENY
+  - type: blocklist
+    accounts: [a, b]
+`,
+			rules: []Rule{
+				&AmountThreshold{Currency: "INR", ReviewAbove: 500000},
+				&Velocity{WindowSeconds: 3600, MaxCount: 10, Action: Review},
+				&NewPayeeLimit{MaxAmount: 100000, Action: Deny},
+				&Blocklist{Accounts: []string{"a", "b"}, Action: Deny},
+			},
+		},
+		{
+			name:  "json",
+			data:  `{"rules": [{"type": "amount_threshold", "deny_above": 10}]}`,
+			rules: []Rule{&AmountThreshold{DenyAbove: 10}},
+		},
+		{name: "empty", data: ``},
+		{name: "unknown type", data: "rules:\n  - type: geo\n", err: `rule 1: unknown type "geo"`},
+		{name: "no thresholds", data: "rules:\n  - type: amount_threshold\n", err: "rule 1 (amount_threshold): review_above or deny_above must be positive"},
+		{name: "no window", data: "rules:\n  - type: velocity\n    max_count: 1\n", err: "window_seconds must be positive"},
+		{name: "no velocity limit", data: "rules:\n  - type: velocity\n    window_seconds: 60\n", err: "max_count or max_amount must be positive"},
+		{name: "new payee without limit", data: "rules:\n  - type: new_payee\n", err: "max_amount must be positive"},
+		{name: "bad action", data: "rules:\n  - type: blocklist\n    action: ALLOW\n", err: `action must be REVIEW or DENY, got "ALLOW"`},
+		{name: "not yaml", data: "rules: [", err: "yaml"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules, err := ParseRules([]byte(tt.data))
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("ParseRules() error = %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseRules() error = %v", err)
+			}
+			if !reflect.DeepEqual(rules, tt.rules) {
+				t.Fatalf("ParseRules() = %#v, want %#v", rules, tt.rules)
+			}
+		})
+	}
+}
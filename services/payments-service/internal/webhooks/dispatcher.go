@@ -0,0 +1,154 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/parasagrawal71/bank-settlement-system/services/payments-service/internal/repository"
+)
+
+type DispatcherConfig struct {
+	PollInterval time.Duration
+	Timeout      time.Duration // of one HTTP request
+	BackoffBase  time.Duration // wait after the first failed attempt, doubled after each further one
+	BackoffMax   time.Duration
+	BatchSize    int
+}
+
+// Dispatcher sends due webhook deliveries and schedules retries of the ones
+// that fail, until they expire.
+type Dispatcher struct {
+	repo   *repository.WebhookRepo
+	client *http.Client
+	cfg    DispatcherConfig
+}
+
+// NewDispatcher returns a dispatcher whose requests only connect to public
+// addresses, whatever an endpoint's host resolves to by the time it is sent.
+func NewDispatcher(repo *repository.WebhookRepo, cfg DispatcherConfig) *Dispatcher {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// no proxy, so that the address checked is the receiver's
+	transport.Proxy = nil
+	transport.DialContext = (&net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second, Control: dialPublic}).DialContext
+	return &Dispatcher{repo: repo, client: &http.Client{Timeout: cfg.Timeout, Transport: transport}, cfg: cfg}
+}
+
+func (d *Dispatcher) Start(ctx context.Context) {
+	log.Println("Webhook dispatcher started")
+	ticker := time.NewTicker(d.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("Webhook dispatcher stopped")
+			return
+		case <-ticker.C:
+			if err := d.DeliverDue(ctx); err != nil {
+				log.Printf("webhook delivery: %v", err)
+			}
+		}
+	}
+}
+
+// DeliverDue sends one batch of due deliveries.
+func (d *Dispatcher) DeliverDue(ctx context.Context) error {
+	// the lease outlasts a request, so a delivery is not sent twice at once
+	deliveries, err := d.repo.ClaimDue(ctx, d.cfg.BatchSize, d.cfg.Timeout+time.Minute)
+	if err != nil {
+		return err
+	}
+	endpoints := make(map[string]*repository.WebhookEndpoint)
+	for _, del := range deliveries {
+		ep, ok := endpoints[del.EndpointID]
+		if !ok {
+			ep, err = d.repo.GetEndpoint(ctx, del.EndpointID)
+			if err != nil {
+				// the rest of the batch is still sent; this one is tried again later
+				err = fmt.Errorf("get endpoint %s: %w", del.EndpointID, err)
+				if recErr := d.fail(ctx, del, err); recErr != nil {
+					log.Printf("webhook delivery %d: %v", del.ID, errors.Join(err, recErr))
+				}
+				continue
+			}
+			endpoints[del.EndpointID] = ep
+		}
+		if err := d.deliver(ctx, ep, del); err != nil {
+			log.Printf("webhook delivery %d: %v", del.ID, err)
+		}
+	}
+	return nil
+}
+
+// fail records an attempt at a delivery that could not be sent and schedules
+// the next one.
+func (d *Dispatcher) fail(ctx context.Context, del *repository.WebhookDelivery, err error) error {
+	attempt := repository.WebhookAttempt{Attempt: del.Attempts + 1, Error: err.Error()}
+	status, next := d.retry(attempt.Attempt, time.Now(), del.ExpiresAt)
+	return d.repo.RecordAttempt(ctx, del.ID, attempt, status, next)
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, ep *repository.WebhookEndpoint, del *repository.WebhookDelivery) error {
+	attempt := repository.WebhookAttempt{Attempt: del.Attempts + 1}
+	start := time.Now()
+	attempt.StatusCode, attempt.Error = d.send(ctx, ep, del)
+	attempt.Duration = time.Since(start)
+
+	status, next := "DELIVERED", time.Now()
+	if attempt.Error != "" {
+		status, next = d.retry(attempt.Attempt, next, del.ExpiresAt)
+	}
+	return d.repo.RecordAttempt(ctx, del.ID, attempt, status, next)
+}
+
+// retry returns the status of a delivery whose attempt failed at now and when
+// it is tried next. It is FAILED when the next try would be after expiresAt.
+func (d *Dispatcher) retry(attempt int, now, expiresAt time.Time) (string, time.Time) {
+	next := now.Add(d.backoff(attempt))
+	if next.After(expiresAt) {
+		return "FAILED", next
+	}
+	return "PENDING", next
+}
+
+// send posts a delivery and returns the response status code and, unless the
+// endpoint answered 2xx, what went wrong.
+func (d *Dispatcher) send(ctx context.Context, ep *repository.WebhookEndpoint, del *repository.WebhookDelivery) (int, string) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, ep.URL, bytes.NewReader(del.Payload))
+	if err != nil {
+		return 0, err.Error()
+	}
+	ts := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEventID, del.EventID)
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(ts, 10))
+	req.Header.Set(HeaderSignature, Sign(ep.Secret, ts, del.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err.Error()
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, "endpoint responded " + resp.Status
+	}
+	return resp.StatusCode, ""
+}
+
+// backoff is the wait after the given failed attempt.
+func (d *Dispatcher) backoff(attempt int) time.Duration {
+	wait := d.cfg.BackoffBase
+	for i := 1; i < attempt && wait < d.cfg.BackoffMax; i++ {
+		wait *= 2
+	}
+	return min(wait, d.cfg.BackoffMax)
+}
@@ -0,0 +1,112 @@
+package webhooks
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/parasagrawal71/bank-settlement-system/services/payments-service/internal/repository"
+)
+
+func TestBackoff(t *testing.T) {
+	d := &Dispatcher{cfg: DispatcherConfig{BackoffBase: 10 * time.Second, BackoffMax: time.Minute}}
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{1, 10 * time.Second},
+		{2, 20 * time.Second},
+		{3, 40 * time.Second},
+		{4, time.Minute},
+		{50, time.Minute},
+	}
+	for _, tt := range tests {
+		if got := d.backoff(tt.attempt); got != tt.want {
+			t.Errorf("backoff(%d) = %v, want %v", tt.attempt, got, tt.want)
+		}
+	}
+}
+
+func TestRetry(t *testing.T) {
+	d := &Dispatcher{cfg: DispatcherConfig{BackoffBase: 10 * time.Second, BackoffMax: time.Minute}}
+	now := time.Unix(1_700_000_000, 0)
+	tests := []struct {
+		name      string
+		attempt   int
+		expiresAt time.Time
+		status    string
+		next      time.Time
+	}{
+		{"first failure", 1, now.Add(time.Hour), "PENDING", now.Add(10 * time.Second)},
+		{"capped wait", 6, now.Add(time.Hour), "PENDING", now.Add(time.Minute)},
+		{"next try at expiry", 2, now.Add(20 * time.Second), "PENDING", now.Add(20 * time.Second)},
+		{"next try after expiry", 2, now.Add(19 * time.Second), "FAILED", now.Add(20 * time.Second)},
+		{"already expired", 1, now.Add(-time.Second), "FAILED", now.Add(10 * time.Second)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, next := d.retry(tt.attempt, now, tt.expiresAt)
+			if status != tt.status || !next.Equal(tt.next) {
+				t.Fatalf("retry() = %s, %v, want %s, %v", status, next, tt.status, tt.next)
+			}
+		})
+	}
+}
+
+func TestSend(t *testing.T) {
+	const secret = "whsec_test"
+	payload := []byte(`{"id":"evt_1","type":"PAYMENT_CAPTURED","data":{}}`)
+
+	var got *http.Request
+	var gotBody []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		gotBody, _ = io.ReadAll(r.Body)
+		if r.URL.Path == "/down" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+
+	d := &Dispatcher{client: srv.Client(), cfg: DispatcherConfig{Timeout: 5 * time.Second}}
+	ep := &repository.WebhookEndpoint{URL: srv.URL + "/hooks", Secret: secret}
+	del := &repository.WebhookDelivery{EventID: "evt_1", Payload: payload}
+
+	code, errMsg := d.send(context.Background(), ep, del)
+	if code != http.StatusOK || errMsg != "" {
+		t.Fatalf("send() = %d, %q, want 200 and no error", code, errMsg)
+	}
+	if got.Method != http.MethodPost || got.Header.Get(HeaderEventID) != "evt_1" || string(gotBody) != string(payload) {
+		t.Fatalf("endpoint got %s with id %q and body %s", got.Method, got.Header.Get(HeaderEventID), gotBody)
+	}
+	ts, err := strconv.ParseInt(got.Header.Get(HeaderTimestamp), 10, 64)
+	if err != nil {
+		t.Fatalf("timestamp header: %v", err)
+	}
+	if err := Verify(secret, got.Header.Get(HeaderSignature), ts, gotBody, time.Minute, time.Now()); err != nil {
+		t.Fatalf("signature does not verify: %v", err)
+	}
+
+	ep.URL = srv.URL + "/down"
+	if code, errMsg := d.send(context.Background(), ep, del); code != http.StatusServiceUnavailable || errMsg == "" {
+		t.Fatalf("send() to a failing endpoint = %d, %q, want 503 and an error", code, errMsg)
+	}
+}
+
+func TestSendRefusesPrivateAddress(t *testing.T) {
+	called := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { called = true }))
+	defer srv.Close()
+
+	d := NewDispatcher(nil, DispatcherConfig{Timeout: 5 * time.Second})
+	ep := &repository.WebhookEndpoint{URL: srv.URL, Secret: "whsec_test"}
+	code, errMsg := d.send(context.Background(), ep, &repository.WebhookDelivery{EventID: "evt_1", Payload: []byte(`{}`)})
+	if called || code != 0 || !strings.Contains(errMsg, ErrPrivateAddress.Error()) {
+		t.Fatalf("send() to loopback = %d, %q, called %v, want it refused", code, errMsg, called)
+	}
+}
@@ -0,0 +1,131 @@
+// Package webhooks delivers payment notifications to the HTTP endpoints that
+// payees register. Every request is signed so receivers can check that it came
+// from payments-service and is recent.
+package webhooks
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// Headers of a webhook request. The signature is "v1=" followed by the hex
+// HMAC-SHA256, keyed with the endpoint secret, of "<timestamp>.<body>".
+const (
+	HeaderEventID   = "X-Webhook-Id"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+// ErrPrivateAddress is returned for an endpoint whose host is, or resolves to,
+// an address webhooks are not sent to.
+var ErrPrivateAddress = errors.New("not a public address")
+
+// EventTypes are the notifications an endpoint can subscribe to.
+var EventTypes = []string{"PAYMENT_AUTHORIZED", "PAYMENT_CAPTURED", "PAYMENT_ESCROWED", "ESCROW_RELEASED", "ESCROW_REFUNDED",
+	"PAYMENT_REFUNDED", "PAYMENT_CANCELED", "DISPUTE_OPENED", "DISPUTE_EVIDENCE_SUBMITTED", "DISPUTE_WON", "DISPUTE_LOST",
+	"PAYMENT_SETTLED"}
+
+// Envelope is the JSON body of a webhook request.
+type Envelope struct {
+	ID        string          `json:"id"`
+	Type      string          `json:"type"`
+	CreatedAt int64           `json:"created_at"` // unix seconds
+	Data      json.RawMessage `json:"data"`
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// NewSecret returns a signing secret for a new endpoint.
+func NewSecret() string {
+	return "whsec_" + randomHex(24)
+}
+
+// NewEventID returns a random event id.
+func NewEventID() string {
+	return "evt_" + randomHex(12)
+}
+
+// NewEnvelope returns the body of a webhook request carrying data.
+func NewEnvelope(eventID, eventType string, data any) ([]byte, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("marshal webhook data: %w", err)
+	}
+	body, err := json.Marshal(Envelope{ID: eventID, Type: eventType, CreatedAt: time.Now().Unix(), Data: raw})
+	if err != nil {
+		return nil, fmt.Errorf("marshal webhook envelope: %w", err)
+	}
+	return body, nil
+}
+
+// Sign returns the signature header value of body sent at timestamp.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "v1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks a signature header and that timestamp is within tolerance of
+// now. Receivers written in Go can use it as is.
+func Verify(secret, signature string, timestamp int64, body []byte, tolerance time.Duration, now time.Time) error {
+	if d := now.Sub(time.Unix(timestamp, 0)); d > tolerance || d < -tolerance {
+		return fmt.Errorf("webhook timestamp outside tolerance")
+	}
+	if !hmac.Equal([]byte(signature), []byte(Sign(secret, timestamp, body))) {
+		return fmt.Errorf("webhook signature mismatch")
+	}
+	return nil
+}
+
+// publicAddr reports whether webhooks may be sent to ip: it is not loopback,
+// private, link-local, multicast or unspecified.
+func publicAddr(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	return ip.IsValid() && !ip.IsLoopback() && !ip.IsPrivate() && !ip.IsLinkLocalUnicast() &&
+		!ip.IsLinkLocalMulticast() && !ip.IsMulticast() && !ip.IsUnspecified()
+}
+
+// CheckHost resolves the host of an endpoint URL and fails with
+// ErrPrivateAddress unless all of its addresses are public.
+func CheckHost(ctx context.Context, resolver *net.Resolver, host string) error {
+	addrs, err := resolver.LookupNetIP(ctx, "ip", host)
+	if err != nil {
+		return fmt.Errorf("resolve %s: %w", host, err)
+	}
+	for _, a := range addrs {
+		if !publicAddr(a) {
+			return fmt.Errorf("%s is %s: %w", host, a, ErrPrivateAddress)
+		}
+	}
+	return nil
+}
+
+// dialPublic is the net.Dialer Control of the dispatcher: it refuses to
+// connect to an address that is not public.
+func dialPublic(network, address string, _ syscall.RawConn) error {
+	ap, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return err
+	}
+	if !publicAddr(ap.Addr()) {
+		return fmt.Errorf("%s: %w", ap.Addr(), ErrPrivateAddress)
+	}
+	return nil
+}
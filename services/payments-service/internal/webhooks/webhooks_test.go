@@ -0,0 +1,127 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/netip"
+	"testing"
+	"time"
+)
+
+func TestSignVerify(t *testing.T) {
+	body := []byte(`{"id":"evt_1","type":"PAYMENT_CAPTURED"}`)
+	now := time.Unix(1_700_000_000, 0)
+	sig := Sign("whsec_test", now.Unix(), body)
+
+	tests := []struct {
+		name      string
+		secret    string
+		signature string
+		timestamp int64
+		body      []byte
+		ok        bool
+	}{
+		{"valid", "whsec_test", sig, now.Unix(), body, true},
+		{"wrong secret", "whsec_other", sig, now.Unix(), body, false},
+		{"altered body", "whsec_test", sig, now.Unix(), []byte(`{"id":"evt_2"}`), false},
+		{"other timestamp", "whsec_test", sig, now.Unix() - 1, body, false},
+		{"missing version", "whsec_test", sig[len("v1="):], now.Unix(), body, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Verify(tt.secret, tt.signature, tt.timestamp, tt.body, 5*time.Minute, now)
+			if (err == nil) != tt.ok {
+				t.Fatalf("Verify() = %v, want ok %v", err, tt.ok)
+			}
+		})
+	}
+}
+
+func TestVerifyTimestamp(t *testing.T) {
+	body := []byte(`{}`)
+	sent := time.Unix(1_700_000_000, 0)
+	sig := Sign("whsec_test", sent.Unix(), body)
+
+	tests := []struct {
+		name string
+		now  time.Time
+		ok   bool
+	}{
+		{"at once", sent, true},
+		{"at the tolerance", sent.Add(5 * time.Minute), true},
+		{"too late", sent.Add(5*time.Minute + time.Second), false},
+		{"clock behind", sent.Add(-5 * time.Minute), true},
+		{"too early", sent.Add(-5*time.Minute - time.Second), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Verify("whsec_test", sig, sent.Unix(), body, 5*time.Minute, tt.now)
+			if (err == nil) != tt.ok {
+				t.Fatalf("Verify() = %v, want ok %v", err, tt.ok)
+			}
+		})
+	}
+}
+
+func TestPublicAddr(t *testing.T) {
+	tests := []struct {
+		addr string
+		ok   bool
+	}{
+		{"93.184.216.34", true},
+		{"2606:2800:220:1:248:1893:25c8:1946", true},
+		{"127.0.0.1", false},
+		{"::1", false},
+		{"10.1.2.3", false},
+		{"172.16.0.1", false},
+		{"192.168.1.1", false},
+		{"fd00::1", false},
+		{"169.254.169.254", false},
+		{"fe80::1", false},
+		{"0.0.0.0", false},
+		{"::", false},
+		{"224.0.0.1", false},
+		{"::ffff:127.0.0.1", false},
+		{"::ffff:10.0.0.1", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			if got := publicAddr(netip.MustParseAddr(tt.addr)); got != tt.ok {
+				t.Fatalf("publicAddr(%s) = %v, want %v", tt.addr, got, tt.ok)
+			}
+		})
+	}
+}
+
+func TestCheckHost(t *testing.T) {
+	tests := []struct {
+		host    string
+		private bool
+	}{
+		{"93.184.216.34", false},
+		{"127.0.0.1", true},
+		{"169.254.169.254", true},
+		{"::1", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.host, func(t *testing.T) {
+			err := CheckHost(context.Background(), net.DefaultResolver, tt.host)
+			if got := errors.Is(err, ErrPrivateAddress); got != tt.private {
+				t.Fatalf("CheckHost(%s) = %v, want private %v", tt.host, err, tt.private)
+			}
+			if !tt.private && err != nil {
+				t.Fatalf("CheckHost(%s) = %v", tt.host, err)
+			}
+		})
+	}
+}
+
+func TestDialPublic(t *testing.T) {
+	if err := dialPublic("tcp", "127.0.0.1:443", nil); !errors.Is(err, ErrPrivateAddress) {
+		t.Fatalf("dialPublic(loopback) = %v, want ErrPrivateAddress", err)
+	}
+	if err := dialPublic("tcp", "93.184.216.34:443", nil); err != nil {
+		t.Fatalf("dialPublic(public) = %v", err)
+	}
+}
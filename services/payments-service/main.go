@@ -2,20 +2,26 @@ package main
 
 import (
 	"context"
+	"expvar"
 	"fmt"
 	"log"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/parasagrawal71/bank-settlement-system/services/payments-service/internal/config"
 	"github.com/parasagrawal71/bank-settlement-system/services/payments-service/internal/events"
 	"github.com/parasagrawal71/bank-settlement-system/services/payments-service/internal/handler"
 	"github.com/parasagrawal71/bank-settlement-system/services/payments-service/internal/repository"
+	"github.com/parasagrawal71/bank-settlement-system/services/payments-service/internal/webhooks"
 	pb "github.com/parasagrawal71/bank-settlement-system/services/payments-service/proto"
+	"github.com/parasagrawal71/bank-settlement-system/shared/auth"
 	"github.com/parasagrawal71/bank-settlement-system/shared/db"
+	"github.com/parasagrawal71/bank-settlement-system/shared/mtls"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
 )
@@ -35,9 +41,20 @@ func main() {
 	if err != nil {
 		log.Fatalf("listen: %v", err)
 	}
-	grpcServer := grpc.NewServer()
-	pb.RegisterPaymentServiceServer(grpcServer,
-		handler.NewPaymentHandler(pool))
+	// every call needs an API key or a JWT whose roles handler.Permissions allows
+	authenticator, err := auth.NewAuthenticator(auth.ConfigFromEnv())
+	if err != nil {
+		log.Fatalf("auth: %v", err)
+	}
+	guard := auth.NewGuard(authenticator, handler.Permissions, auth.Reflection)
+	// TLS, verifying client certificates, when a certificate is configured
+	serverOpts, err := mtls.ServerOptions(cfg.TLS, "payments-service")
+	if err != nil {
+		log.Fatalf("tls: %v", err)
+	}
+	grpcServer := grpc.NewServer(append(serverOpts, guard.ServerOptions()...)...)
+	paymentHandler := handler.NewPaymentHandler(pool, cfg)
+	pb.RegisterPaymentServiceServer(grpcServer, paymentHandler)
 
 	// enable reflection
 	reflection.Register(grpcServer)
@@ -49,6 +66,18 @@ func main() {
 		}
 	}()
 
+	// expvar metrics, among them the outcomes of accounts-service calls
+	if cfg.MetricsPort != "" {
+		mux := http.NewServeMux()
+		mux.Handle("/debug/vars", expvar.Handler())
+		go func() {
+			fmt.Printf("payments metrics listening on %s\n", cfg.MetricsPort)
+			if err := http.ListenAndServe("0.0.0.0:"+cfg.MetricsPort, mux); err != nil {
+				log.Printf("metrics serve: %v", err)
+			}
+		}()
+	}
+
 	// a simple background goroutine polling every 5s.
 	brokersStr := os.Getenv("KAFKA_BROKERS")
 	brokers := strings.Split(brokersStr, ",")
@@ -59,6 +88,110 @@ func main() {
 	publisher := events.NewOutboxPublisher(pool, outboxRepo, producer)
 	go publisher.Start(context.Background())
 
+	// webhooks to payees; settlement-service reports settled captures on its own topic
+	dispatcher := webhooks.NewDispatcher(repository.NewWebhookRepository(pool), webhooks.DispatcherConfig{
+		PollInterval: cfg.WebhookPollInterval,
+		Timeout:      cfg.WebhookTimeout,
+		BackoffBase:  cfg.WebhookBackoffBase,
+		BackoffMax:   cfg.WebhookBackoffMax,
+		BatchSize:    50,
+	})
+	go dispatcher.Start(context.Background())
+	if settlementsTopic := os.Getenv("SETTLEMENTS_TOPIC"); settlementsTopic != "" {
+		settlementConsumer := events.NewSettlementConsumer(brokers, settlementsTopic, "payments-service-webhooks", pool, cfg.WebhookMaxAge)
+		go settlementConsumer.Start(context.Background())
+	}
+
+	// expire intents whose funds hold has run out
+	go func() {
+		repo := repository.NewRepository(pool)
+		ticker := time.NewTicker(cfg.IntentExpirySweepInterval)
+		defer ticker.Stop()
+		for range ticker.C {
+			n, err := repo.ExpireIntents(context.Background())
+			if err != nil {
+				log.Printf("intent expiry: %v", err)
+			} else if n > 0 {
+				log.Printf("marked %d payment intents expired", n)
+			}
+		}
+	}()
+
+	// finish captures left half-done by a crash or a lost accounts-service response
+	go func() {
+		ticker := time.NewTicker(cfg.CaptureRecoveryInterval)
+		defer ticker.Stop()
+		for range ticker.C {
+			n, err := paymentHandler.RecoverCaptures(context.Background(), cfg.CaptureRecoveryAfter, 100)
+			if err != nil {
+				log.Printf("capture recovery: %v", err)
+			}
+			if n > 0 {
+				log.Printf("recovered %d captures", n)
+			}
+		}
+	}()
+
+	// release escrows whose scheduled release is due and finish escrow
+	// releases and refunds left half-done
+	go func() {
+		ticker := time.NewTicker(cfg.EscrowReleaseInterval)
+		defer ticker.Stop()
+		for range ticker.C {
+			n, err := paymentHandler.ReleaseDueEscrows(context.Background(), cfg.CaptureRecoveryAfter, 100)
+			if err != nil {
+				log.Printf("escrow release: %v", err)
+			}
+			if n > 0 {
+				log.Printf("completed %d escrow releases and refunds", n)
+			}
+		}
+	}()
+
+	// pick up edits to the risk rules file
+	go func() {
+		ticker := time.NewTicker(cfg.RiskRulesReloadInterval)
+		defer ticker.Stop()
+		for range ticker.C {
+			changed, version, err := paymentHandler.ReloadRiskRules()
+			if err != nil {
+				log.Printf("risk rules reload: %v", err)
+			} else if changed {
+				log.Printf("loaded risk rules version %s", version)
+			}
+		}
+	}()
+
+	// pay the rows of submitted payout files
+	go func() {
+		ticker := time.NewTicker(cfg.PayoutPollInterval)
+		defer ticker.Stop()
+		for range ticker.C {
+			n, err := paymentHandler.ProcessPayouts(context.Background(), cfg.PayoutConcurrency)
+			if err != nil {
+				log.Printf("payouts: %v", err)
+			}
+			if n > 0 {
+				log.Printf("finished %d payouts", n)
+			}
+		}
+	}()
+
+	// drop idempotency keys past their TTL
+	go func() {
+		idempRepo := repository.NewIdempotencyRepository(pool)
+		ticker := time.NewTicker(cfg.IdempotencyCleanupInterval)
+		defer ticker.Stop()
+		for range ticker.C {
+			n, err := idempRepo.DeleteExpired(context.Background())
+			if err != nil {
+				log.Printf("idempotency key cleanup: %v", err)
+			} else if n > 0 {
+				log.Printf("deleted %d expired idempotency keys", n)
+			}
+		}
+	}()
+
 	// graceful shutdown
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, syscall.SIGINT, syscall.SIGTERM)
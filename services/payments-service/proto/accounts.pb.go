@@ -21,11 +21,13 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// All amounts are int64 minor units (e.g. paise) of the given currency.
 type CreateAccountRequest struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
 	Name           string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	AccountNo      string                 `protobuf:"bytes,2,opt,name=account_no,json=accountNo,proto3" json:"account_no,omitempty"`
-	InitialBalance float64                `protobuf:"fixed64,3,opt,name=initial_balance,json=initialBalance,proto3" json:"initial_balance,omitempty"`
+	InitialBalance int64                  `protobuf:"varint,4,opt,name=initial_balance,json=initialBalance,proto3" json:"initial_balance,omitempty"`
+	Currency       string                 `protobuf:"bytes,5,opt,name=currency,proto3" json:"currency,omitempty"` // ISO 4217, defaults to INR
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
@@ -74,13 +76,20 @@ func (x *CreateAccountRequest) GetAccountNo() string {
 	return ""
 }
 
-func (x *CreateAccountRequest) GetInitialBalance() float64 {
+func (x *CreateAccountRequest) GetInitialBalance() int64 {
 	if x != nil {
 		return x.InitialBalance
 	}
 	return 0
 }
 
+func (x *CreateAccountRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
 type GetAccountRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
@@ -128,8 +137,9 @@ func (x *GetAccountRequest) GetAccountId() string {
 type UpdateBalanceRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
-	Amount        float64                `protobuf:"fixed64,2,opt,name=amount,proto3" json:"amount,omitempty"`
 	IsCredit      bool                   `protobuf:"varint,3,opt,name=is_credit,json=isCredit,proto3" json:"is_credit,omitempty"`
+	Amount        int64                  `protobuf:"varint,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency      string                 `protobuf:"bytes,5,opt,name=currency,proto3" json:"currency,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -171,18 +181,25 @@ func (x *UpdateBalanceRequest) GetAccountId() string {
 	return ""
 }
 
-func (x *UpdateBalanceRequest) GetAmount() float64 {
+func (x *UpdateBalanceRequest) GetIsCredit() bool {
+	if x != nil {
+		return x.IsCredit
+	}
+	return false
+}
+
+func (x *UpdateBalanceRequest) GetAmount() int64 {
 	if x != nil {
 		return x.Amount
 	}
 	return 0
 }
 
-func (x *UpdateBalanceRequest) GetIsCredit() bool {
+func (x *UpdateBalanceRequest) GetCurrency() string {
 	if x != nil {
-		return x.IsCredit
+		return x.Currency
 	}
-	return false
+	return ""
 }
 
 type AccountResponse struct {
@@ -190,8 +207,14 @@ type AccountResponse struct {
 	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
 	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
 	AccountNo     string                 `protobuf:"bytes,3,opt,name=account_no,json=accountNo,proto3" json:"account_no,omitempty"`
-	Balance       float64                `protobuf:"fixed64,4,opt,name=balance,proto3" json:"balance,omitempty"`
-	Reserved      float64                `protobuf:"fixed64,5,opt,name=reserved,proto3" json:"reserved,omitempty"`
+	Balance       int64                  `protobuf:"varint,6,opt,name=balance,proto3" json:"balance,omitempty"`
+	Reserved      int64                  `protobuf:"varint,7,opt,name=reserved,proto3" json:"reserved,omitempty"`
+	Currency      string                 `protobuf:"bytes,8,opt,name=currency,proto3" json:"currency,omitempty"`
+	Status        string                 `protobuf:"bytes,9,opt,name=status,proto3" json:"status,omitempty"` // ACTIVE, FROZEN, DORMANT or CLOSED
+	StatusReason  string                 `protobuf:"bytes,10,opt,name=status_reason,json=statusReason,proto3" json:"status_reason,omitempty"`
+	CreditLimit   int64                  `protobuf:"varint,11,opt,name=credit_limit,json=creditLimit,proto3" json:"credit_limit,omitempty"` // approved overdraft
+	Available     int64                  `protobuf:"varint,12,opt,name=available,proto3" json:"available,omitempty"`                        // balance + credit_limit, what can still be spent
+	OverdraftUsed int64                  `protobuf:"varint,13,opt,name=overdraft_used,json=overdraftUsed,proto3" json:"overdraft_used,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -247,20 +270,62 @@ func (x *AccountResponse) GetAccountNo() string {
 	return ""
 }
 
-func (x *AccountResponse) GetBalance() float64 {
+func (x *AccountResponse) GetBalance() int64 {
 	if x != nil {
 		return x.Balance
 	}
 	return 0
 }
 
-func (x *AccountResponse) GetReserved() float64 {
+func (x *AccountResponse) GetReserved() int64 {
 	if x != nil {
 		return x.Reserved
 	}
 	return 0
 }
 
+func (x *AccountResponse) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *AccountResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *AccountResponse) GetStatusReason() string {
+	if x != nil {
+		return x.StatusReason
+	}
+	return ""
+}
+
+func (x *AccountResponse) GetCreditLimit() int64 {
+	if x != nil {
+		return x.CreditLimit
+	}
+	return 0
+}
+
+func (x *AccountResponse) GetAvailable() int64 {
+	if x != nil {
+		return x.Available
+	}
+	return 0
+}
+
+func (x *AccountResponse) GetOverdraftUsed() int64 {
+	if x != nil {
+		return x.OverdraftUsed
+	}
+	return 0
+}
+
 type ListAccountsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
@@ -342,11 +407,20 @@ func (x *ListAccountsResponse) GetAccounts() []*AccountResponse {
 }
 
 type ReserveRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	PayerId       string                 `protobuf:"bytes,1,opt,name=payer_id,json=payerId,proto3" json:"payer_id,omitempty"`
-	PayeeId       string                 `protobuf:"bytes,2,opt,name=payee_id,json=payeeId,proto3" json:"payee_id,omitempty"`
-	Amount        float64                `protobuf:"fixed64,3,opt,name=amount,proto3" json:"amount,omitempty"`
-	ReferenceId   string                 `protobuf:"bytes,4,opt,name=reference_id,json=referenceId,proto3" json:"reference_id,omitempty"`
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	PayerId        string                 `protobuf:"bytes,1,opt,name=payer_id,json=payerId,proto3" json:"payer_id,omitempty"`
+	PayeeId        string                 `protobuf:"bytes,2,opt,name=payee_id,json=payeeId,proto3" json:"payee_id,omitempty"`
+	ReferenceId    string                 `protobuf:"bytes,4,opt,name=reference_id,json=referenceId,proto3" json:"reference_id,omitempty"`
+	Amount         int64                  `protobuf:"varint,5,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency       string                 `protobuf:"bytes,6,opt,name=currency,proto3" json:"currency,omitempty"`
+	QuoteId        string                 `protobuf:"bytes,7,opt,name=quote_id,json=quoteId,proto3" json:"quote_id,omitempty"`                         // required when payer and payee currencies differ
+	HoldTtlSeconds int64                  `protobuf:"varint,8,opt,name=hold_ttl_seconds,json=holdTtlSeconds,proto3" json:"hold_ttl_seconds,omitempty"` // 0 uses the service default
+	// split payments: one hold on the payer, paid out to several payees; payee_id
+	// must be the first leg's payee and the leg amounts must add up to amount
+	Legs []*PayeeLeg `protobuf:"bytes,9,rep,name=legs,proto3" json:"legs,omitempty"`
+	// escrow: captures go to the system escrow account instead of the payee and
+	// are paid out by ReleaseEscrow or returned to the payer by RefundEscrow
+	Escrow        bool `protobuf:"varint,10,opt,name=escrow,proto3" json:"escrow,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -395,31 +469,122 @@ func (x *ReserveRequest) GetPayeeId() string {
 	return ""
 }
 
-func (x *ReserveRequest) GetAmount() float64 {
+func (x *ReserveRequest) GetReferenceId() string {
+	if x != nil {
+		return x.ReferenceId
+	}
+	return ""
+}
+
+func (x *ReserveRequest) GetAmount() int64 {
 	if x != nil {
 		return x.Amount
 	}
 	return 0
 }
 
-func (x *ReserveRequest) GetReferenceId() string {
+func (x *ReserveRequest) GetCurrency() string {
 	if x != nil {
-		return x.ReferenceId
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *ReserveRequest) GetQuoteId() string {
+	if x != nil {
+		return x.QuoteId
+	}
+	return ""
+}
+
+func (x *ReserveRequest) GetHoldTtlSeconds() int64 {
+	if x != nil {
+		return x.HoldTtlSeconds
+	}
+	return 0
+}
+
+func (x *ReserveRequest) GetLegs() []*PayeeLeg {
+	if x != nil {
+		return x.Legs
+	}
+	return nil
+}
+
+func (x *ReserveRequest) GetEscrow() bool {
+	if x != nil {
+		return x.Escrow
+	}
+	return false
+}
+
+// A payee's share of a split payment, in minor units of the payer currency.
+type PayeeLeg struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PayeeId       string                 `protobuf:"bytes,1,opt,name=payee_id,json=payeeId,proto3" json:"payee_id,omitempty"`
+	Amount        int64                  `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PayeeLeg) Reset() {
+	*x = PayeeLeg{}
+	mi := &file_services_payments_service_proto_accounts_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PayeeLeg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PayeeLeg) ProtoMessage() {}
+
+func (x *PayeeLeg) ProtoReflect() protoreflect.Message {
+	mi := &file_services_payments_service_proto_accounts_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PayeeLeg.ProtoReflect.Descriptor instead.
+func (*PayeeLeg) Descriptor() ([]byte, []int) {
+	return file_services_payments_service_proto_accounts_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *PayeeLeg) GetPayeeId() string {
+	if x != nil {
+		return x.PayeeId
 	}
 	return ""
 }
 
+func (x *PayeeLeg) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+// reason is a machine readable failure code such as ACCOUNT_FROZEN or ACCOUNT_CLOSED.
 type ReserveResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
 	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	ExpiresAt     int64                  `protobuf:"varint,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // unix seconds; the hold is released automatically after it
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ReserveResponse) Reset() {
 	*x = ReserveResponse{}
-	mi := &file_services_payments_service_proto_accounts_proto_msgTypes[7]
+	mi := &file_services_payments_service_proto_accounts_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -431,7 +596,7 @@ func (x *ReserveResponse) String() string {
 func (*ReserveResponse) ProtoMessage() {}
 
 func (x *ReserveResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_services_payments_service_proto_accounts_proto_msgTypes[7]
+	mi := &file_services_payments_service_proto_accounts_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -444,7 +609,7 @@ func (x *ReserveResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReserveResponse.ProtoReflect.Descriptor instead.
 func (*ReserveResponse) Descriptor() ([]byte, []int) {
-	return file_services_payments_service_proto_accounts_proto_rawDescGZIP(), []int{7}
+	return file_services_payments_service_proto_accounts_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *ReserveResponse) GetStatus() string {
@@ -461,16 +626,36 @@ func (x *ReserveResponse) GetMessage() string {
 	return ""
 }
 
+func (x *ReserveResponse) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *ReserveResponse) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+// amount is the part of the hold to capture, 0 captures all that is left. A
+// final capture releases the rest of the hold back to the payer.
 type TransferRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ReferenceId   string                 `protobuf:"bytes,1,opt,name=reference_id,json=referenceId,proto3" json:"reference_id,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	ReferenceId string                 `protobuf:"bytes,1,opt,name=reference_id,json=referenceId,proto3" json:"reference_id,omitempty"`
+	Amount      int64                  `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	Final       bool                   `protobuf:"varint,3,opt,name=final,proto3" json:"final,omitempty"`
+	// makes the transfer safe to retry: a repeated capture_id returns the first result
+	CaptureId     string `protobuf:"bytes,4,opt,name=capture_id,json=captureId,proto3" json:"capture_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *TransferRequest) Reset() {
 	*x = TransferRequest{}
-	mi := &file_services_payments_service_proto_accounts_proto_msgTypes[8]
+	mi := &file_services_payments_service_proto_accounts_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -482,7 +667,7 @@ func (x *TransferRequest) String() string {
 func (*TransferRequest) ProtoMessage() {}
 
 func (x *TransferRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_services_payments_service_proto_accounts_proto_msgTypes[8]
+	mi := &file_services_payments_service_proto_accounts_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -495,7 +680,7 @@ func (x *TransferRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TransferRequest.ProtoReflect.Descriptor instead.
 func (*TransferRequest) Descriptor() ([]byte, []int) {
-	return file_services_payments_service_proto_accounts_proto_rawDescGZIP(), []int{8}
+	return file_services_payments_service_proto_accounts_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *TransferRequest) GetReferenceId() string {
@@ -505,17 +690,45 @@ func (x *TransferRequest) GetReferenceId() string {
 	return ""
 }
 
+func (x *TransferRequest) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *TransferRequest) GetFinal() bool {
+	if x != nil {
+		return x.Final
+	}
+	return false
+}
+
+func (x *TransferRequest) GetCaptureId() string {
+	if x != nil {
+		return x.CaptureId
+	}
+	return ""
+}
+
 type TransferResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Status          string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Message         string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Reason          string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	Amount          int64                  `protobuf:"varint,4,opt,name=amount,proto3" json:"amount,omitempty"`                                          // captured by this transfer, payer currency
+	PayeeAmount     int64                  `protobuf:"varint,5,opt,name=payee_amount,json=payeeAmount,proto3" json:"payee_amount,omitempty"`             // credited to the payee, payee currency
+	CapturedAmount  int64                  `protobuf:"varint,6,opt,name=captured_amount,json=capturedAmount,proto3" json:"captured_amount,omitempty"`    // captured so far
+	ReleasedAmount  int64                  `protobuf:"varint,7,opt,name=released_amount,json=releasedAmount,proto3" json:"released_amount,omitempty"`    // returned to the payer by a final capture
+	RemainingAmount int64                  `protobuf:"varint,8,opt,name=remaining_amount,json=remainingAmount,proto3" json:"remaining_amount,omitempty"` // still on hold
+	Legs            []*PayeeLeg            `protobuf:"bytes,9,rep,name=legs,proto3" json:"legs,omitempty"`                                               // credited to each leg of a split payment
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *TransferResponse) Reset() {
 	*x = TransferResponse{}
-	mi := &file_services_payments_service_proto_accounts_proto_msgTypes[9]
+	mi := &file_services_payments_service_proto_accounts_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -527,7 +740,7 @@ func (x *TransferResponse) String() string {
 func (*TransferResponse) ProtoMessage() {}
 
 func (x *TransferResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_services_payments_service_proto_accounts_proto_msgTypes[9]
+	mi := &file_services_payments_service_proto_accounts_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -540,7 +753,7 @@ func (x *TransferResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TransferResponse.ProtoReflect.Descriptor instead.
 func (*TransferResponse) Descriptor() ([]byte, []int) {
-	return file_services_payments_service_proto_accounts_proto_rawDescGZIP(), []int{9}
+	return file_services_payments_service_proto_accounts_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *TransferResponse) GetStatus() string {
@@ -557,6 +770,55 @@ func (x *TransferResponse) GetMessage() string {
 	return ""
 }
 
+func (x *TransferResponse) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *TransferResponse) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *TransferResponse) GetPayeeAmount() int64 {
+	if x != nil {
+		return x.PayeeAmount
+	}
+	return 0
+}
+
+func (x *TransferResponse) GetCapturedAmount() int64 {
+	if x != nil {
+		return x.CapturedAmount
+	}
+	return 0
+}
+
+func (x *TransferResponse) GetReleasedAmount() int64 {
+	if x != nil {
+		return x.ReleasedAmount
+	}
+	return 0
+}
+
+func (x *TransferResponse) GetRemainingAmount() int64 {
+	if x != nil {
+		return x.RemainingAmount
+	}
+	return 0
+}
+
+func (x *TransferResponse) GetLegs() []*PayeeLeg {
+	if x != nil {
+		return x.Legs
+	}
+	return nil
+}
+
 type ReleaseRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ReferenceId   string                 `protobuf:"bytes,1,opt,name=reference_id,json=referenceId,proto3" json:"reference_id,omitempty"`
@@ -566,7 +828,7 @@ type ReleaseRequest struct {
 
 func (x *ReleaseRequest) Reset() {
 	*x = ReleaseRequest{}
-	mi := &file_services_payments_service_proto_accounts_proto_msgTypes[10]
+	mi := &file_services_payments_service_proto_accounts_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -578,7 +840,7 @@ func (x *ReleaseRequest) String() string {
 func (*ReleaseRequest) ProtoMessage() {}
 
 func (x *ReleaseRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_services_payments_service_proto_accounts_proto_msgTypes[10]
+	mi := &file_services_payments_service_proto_accounts_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -591,7 +853,7 @@ func (x *ReleaseRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReleaseRequest.ProtoReflect.Descriptor instead.
 func (*ReleaseRequest) Descriptor() ([]byte, []int) {
-	return file_services_payments_service_proto_accounts_proto_rawDescGZIP(), []int{10}
+	return file_services_payments_service_proto_accounts_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *ReleaseRequest) GetReferenceId() string {
@@ -605,13 +867,14 @@ type ReleaseResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
 	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ReleaseResponse) Reset() {
 	*x = ReleaseResponse{}
-	mi := &file_services_payments_service_proto_accounts_proto_msgTypes[11]
+	mi := &file_services_payments_service_proto_accounts_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -623,7 +886,7 @@ func (x *ReleaseResponse) String() string {
 func (*ReleaseResponse) ProtoMessage() {}
 
 func (x *ReleaseResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_services_payments_service_proto_accounts_proto_msgTypes[11]
+	mi := &file_services_payments_service_proto_accounts_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -636,7 +899,7 @@ func (x *ReleaseResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReleaseResponse.ProtoReflect.Descriptor instead.
 func (*ReleaseResponse) Descriptor() ([]byte, []int) {
-	return file_services_payments_service_proto_accounts_proto_rawDescGZIP(), []int{11}
+	return file_services_payments_service_proto_accounts_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *ReleaseResponse) GetStatus() string {
@@ -653,53 +916,1654 @@ func (x *ReleaseResponse) GetMessage() string {
 	return ""
 }
 
-var File_services_payments_service_proto_accounts_proto protoreflect.FileDescriptor
+func (x *ReleaseResponse) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
 
-const file_services_payments_service_proto_accounts_proto_rawDesc = "" +
-	"\n" +
-	".services/payments-service/proto/accounts.proto\x12\baccounts\"r\n" +
-	"\x14CreateAccountRequest\x12\x12\n" +
-	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1d\n" +
-	"\n" +
-	"account_no\x18\x02 \x01(\tR\taccountNo\x12'\n" +
-	"\x0finitial_balance\x18\x03 \x01(\x01R\x0einitialBalance\"2\n" +
-	"\x11GetAccountRequest\x12\x1d\n" +
-	"\n" +
-	"account_id\x18\x01 \x01(\tR\taccountId\"j\n" +
-	"\x14UpdateBalanceRequest\x12\x1d\n" +
-	"\n" +
-	"account_id\x18\x01 \x01(\tR\taccountId\x12\x16\n" +
-	"\x06amount\x18\x02 \x01(\x01R\x06amount\x12\x1b\n" +
-	"\tis_credit\x18\x03 \x01(\bR\bisCredit\"\x99\x01\n" +
-	"\x0fAccountResponse\x12\x1d\n" +
-	"\n" +
-	"account_id\x18\x01 \x01(\tR\taccountId\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1d\n" +
-	"\n" +
-	"account_no\x18\x03 \x01(\tR\taccountNo\x12\x18\n" +
-	"\abalance\x18\x04 \x01(\x01R\abalance\x12\x1a\n" +
-	"\breserved\x18\x05 \x01(\x01R\breserved\"\x15\n" +
-	"\x13ListAccountsRequest\"M\n" +
-	"\x14ListAccountsResponse\x125\n" +
-	"\baccounts\x18\x01 \x03(\v2\x19.accounts.AccountResponseR\baccounts\"\x81\x01\n" +
-	"\x0eReserveRequest\x12\x19\n" +
-	"\bpayer_id\x18\x01 \x01(\tR\apayerId\x12\x19\n" +
-	"\bpayee_id\x18\x02 \x01(\tR\apayeeId\x12\x16\n" +
-	"\x06amount\x18\x03 \x01(\x01R\x06amount\x12!\n" +
-	"\freference_id\x18\x04 \x01(\tR\vreferenceId\"C\n" +
-	"\x0fReserveResponse\x12\x16\n" +
-	"\x06status\x18\x01 \x01(\tR\x06status\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"4\n" +
-	"\x0fTransferRequest\x12!\n" +
-	"\freference_id\x18\x01 \x01(\tR\vreferenceId\"D\n" +
-	"\x10TransferResponse\x12\x16\n" +
-	"\x06status\x18\x01 \x01(\tR\x06status\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"3\n" +
-	"\x0eReleaseRequest\x12!\n" +
-	"\freference_id\x18\x01 \x01(\tR\vreferenceId\"C\n" +
-	"\x0fReleaseResponse\x12\x16\n" +
+// A rate is the number of quote_currency units per one base_currency unit, as a
+// decimal string so that it is never rounded through a float.
+type SetRateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BaseCurrency  string                 `protobuf:"bytes,1,opt,name=base_currency,json=baseCurrency,proto3" json:"base_currency,omitempty"`
+	QuoteCurrency string                 `protobuf:"bytes,2,opt,name=quote_currency,json=quoteCurrency,proto3" json:"quote_currency,omitempty"`
+	Rate          string                 `protobuf:"bytes,3,opt,name=rate,proto3" json:"rate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetRateRequest) Reset() {
+	*x = SetRateRequest{}
+	mi := &file_services_payments_service_proto_accounts_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetRateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetRateRequest) ProtoMessage() {}
+
+func (x *SetRateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_payments_service_proto_accounts_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetRateRequest.ProtoReflect.Descriptor instead.
+func (*SetRateRequest) Descriptor() ([]byte, []int) {
+	return file_services_payments_service_proto_accounts_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *SetRateRequest) GetBaseCurrency() string {
+	if x != nil {
+		return x.BaseCurrency
+	}
+	return ""
+}
+
+func (x *SetRateRequest) GetQuoteCurrency() string {
+	if x != nil {
+		return x.QuoteCurrency
+	}
+	return ""
+}
+
+func (x *SetRateRequest) GetRate() string {
+	if x != nil {
+		return x.Rate
+	}
+	return ""
+}
+
+type RateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BaseCurrency  string                 `protobuf:"bytes,1,opt,name=base_currency,json=baseCurrency,proto3" json:"base_currency,omitempty"`
+	QuoteCurrency string                 `protobuf:"bytes,2,opt,name=quote_currency,json=quoteCurrency,proto3" json:"quote_currency,omitempty"`
+	Rate          string                 `protobuf:"bytes,3,opt,name=rate,proto3" json:"rate,omitempty"`
+	UpdatedAt     int64                  `protobuf:"varint,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"` // unix seconds
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RateResponse) Reset() {
+	*x = RateResponse{}
+	mi := &file_services_payments_service_proto_accounts_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RateResponse) ProtoMessage() {}
+
+func (x *RateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_payments_service_proto_accounts_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RateResponse.ProtoReflect.Descriptor instead.
+func (*RateResponse) Descriptor() ([]byte, []int) {
+	return file_services_payments_service_proto_accounts_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *RateResponse) GetBaseCurrency() string {
+	if x != nil {
+		return x.BaseCurrency
+	}
+	return ""
+}
+
+func (x *RateResponse) GetQuoteCurrency() string {
+	if x != nil {
+		return x.QuoteCurrency
+	}
+	return ""
+}
+
+func (x *RateResponse) GetRate() string {
+	if x != nil {
+		return x.Rate
+	}
+	return ""
+}
+
+func (x *RateResponse) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+type GetQuoteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Amount        int64                  `protobuf:"varint,1,opt,name=amount,proto3" json:"amount,omitempty"` // minor units of from_currency
+	FromCurrency  string                 `protobuf:"bytes,2,opt,name=from_currency,json=fromCurrency,proto3" json:"from_currency,omitempty"`
+	ToCurrency    string                 `protobuf:"bytes,3,opt,name=to_currency,json=toCurrency,proto3" json:"to_currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetQuoteRequest) Reset() {
+	*x = GetQuoteRequest{}
+	mi := &file_services_payments_service_proto_accounts_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetQuoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetQuoteRequest) ProtoMessage() {}
+
+func (x *GetQuoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_payments_service_proto_accounts_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetQuoteRequest.ProtoReflect.Descriptor instead.
+func (*GetQuoteRequest) Descriptor() ([]byte, []int) {
+	return file_services_payments_service_proto_accounts_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetQuoteRequest) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *GetQuoteRequest) GetFromCurrency() string {
+	if x != nil {
+		return x.FromCurrency
+	}
+	return ""
+}
+
+func (x *GetQuoteRequest) GetToCurrency() string {
+	if x != nil {
+		return x.ToCurrency
+	}
+	return ""
+}
+
+type QuoteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	QuoteId       string                 `protobuf:"bytes,1,opt,name=quote_id,json=quoteId,proto3" json:"quote_id,omitempty"`
+	FromCurrency  string                 `protobuf:"bytes,2,opt,name=from_currency,json=fromCurrency,proto3" json:"from_currency,omitempty"`
+	ToCurrency    string                 `protobuf:"bytes,3,opt,name=to_currency,json=toCurrency,proto3" json:"to_currency,omitempty"`
+	Rate          string                 `protobuf:"bytes,4,opt,name=rate,proto3" json:"rate,omitempty"`
+	SourceAmount  int64                  `protobuf:"varint,5,opt,name=source_amount,json=sourceAmount,proto3" json:"source_amount,omitempty"`
+	TargetAmount  int64                  `protobuf:"varint,6,opt,name=target_amount,json=targetAmount,proto3" json:"target_amount,omitempty"`
+	ExpiresAt     int64                  `protobuf:"varint,7,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // unix seconds
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QuoteResponse) Reset() {
+	*x = QuoteResponse{}
+	mi := &file_services_payments_service_proto_accounts_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuoteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuoteResponse) ProtoMessage() {}
+
+func (x *QuoteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_payments_service_proto_accounts_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuoteResponse.ProtoReflect.Descriptor instead.
+func (*QuoteResponse) Descriptor() ([]byte, []int) {
+	return file_services_payments_service_proto_accounts_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *QuoteResponse) GetQuoteId() string {
+	if x != nil {
+		return x.QuoteId
+	}
+	return ""
+}
+
+func (x *QuoteResponse) GetFromCurrency() string {
+	if x != nil {
+		return x.FromCurrency
+	}
+	return ""
+}
+
+func (x *QuoteResponse) GetToCurrency() string {
+	if x != nil {
+		return x.ToCurrency
+	}
+	return ""
+}
+
+func (x *QuoteResponse) GetRate() string {
+	if x != nil {
+		return x.Rate
+	}
+	return ""
+}
+
+func (x *QuoteResponse) GetSourceAmount() int64 {
+	if x != nil {
+		return x.SourceAmount
+	}
+	return 0
+}
+
+func (x *QuoteResponse) GetTargetAmount() int64 {
+	if x != nil {
+		return x.TargetAmount
+	}
+	return 0
+}
+
+func (x *QuoteResponse) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+type AccountStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AccountStatusRequest) Reset() {
+	*x = AccountStatusRequest{}
+	mi := &file_services_payments_service_proto_accounts_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AccountStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccountStatusRequest) ProtoMessage() {}
+
+func (x *AccountStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_payments_service_proto_accounts_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccountStatusRequest.ProtoReflect.Descriptor instead.
+func (*AccountStatusRequest) Descriptor() ([]byte, []int) {
+	return file_services_payments_service_proto_accounts_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *AccountStatusRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *AccountStatusRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// A non-zero balance is moved to sweep_to_account_id before closing; without it
+// only an account with zero balance and no pending reservations can be closed.
+type CloseAccountRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	AccountId        string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	SweepToAccountId string                 `protobuf:"bytes,2,opt,name=sweep_to_account_id,json=sweepToAccountId,proto3" json:"sweep_to_account_id,omitempty"`
+	Reason           string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *CloseAccountRequest) Reset() {
+	*x = CloseAccountRequest{}
+	mi := &file_services_payments_service_proto_accounts_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CloseAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloseAccountRequest) ProtoMessage() {}
+
+func (x *CloseAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_payments_service_proto_accounts_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloseAccountRequest.ProtoReflect.Descriptor instead.
+func (*CloseAccountRequest) Descriptor() ([]byte, []int) {
+	return file_services_payments_service_proto_accounts_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *CloseAccountRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *CloseAccountRequest) GetSweepToAccountId() string {
+	if x != nil {
+		return x.SweepToAccountId
+	}
+	return ""
+}
+
+func (x *CloseAccountRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// Statement period is [from, to) in unix seconds; to defaults to now.
+type GetAccountStatementRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	From          int64                  `protobuf:"varint,2,opt,name=from,proto3" json:"from,omitempty"`
+	To            int64                  `protobuf:"varint,3,opt,name=to,proto3" json:"to,omitempty"`
+	PageToken     string                 `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	PageSize      int32                  `protobuf:"varint,5,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"` // defaults to 50, max 500
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAccountStatementRequest) Reset() {
+	*x = GetAccountStatementRequest{}
+	mi := &file_services_payments_service_proto_accounts_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAccountStatementRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAccountStatementRequest) ProtoMessage() {}
+
+func (x *GetAccountStatementRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_payments_service_proto_accounts_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAccountStatementRequest.ProtoReflect.Descriptor instead.
+func (*GetAccountStatementRequest) Descriptor() ([]byte, []int) {
+	return file_services_payments_service_proto_accounts_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetAccountStatementRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *GetAccountStatementRequest) GetFrom() int64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *GetAccountStatementRequest) GetTo() int64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+func (x *GetAccountStatementRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *GetAccountStatementRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+// balance is the available balance, reserved the amount on hold; changes are
+// signed minor units and running values are after the entry was applied.
+type StatementEntry struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	EntryId         string                 `protobuf:"bytes,1,opt,name=entry_id,json=entryId,proto3" json:"entry_id,omitempty"`
+	EntryType       string                 `protobuf:"bytes,2,opt,name=entry_type,json=entryType,proto3" json:"entry_type,omitempty"` // OPENING_BALANCE, RESERVE, TRANSFER_IN, TRANSFER_OUT, RELEASE, REFUND, ADJUSTMENT, CLOSING_SWEEP
+	ReferenceId     string                 `protobuf:"bytes,3,opt,name=reference_id,json=referenceId,proto3" json:"reference_id,omitempty"`
+	CounterpartyId  string                 `protobuf:"bytes,4,opt,name=counterparty_id,json=counterpartyId,proto3" json:"counterparty_id,omitempty"`
+	BalanceChange   int64                  `protobuf:"varint,5,opt,name=balance_change,json=balanceChange,proto3" json:"balance_change,omitempty"`
+	ReservedChange  int64                  `protobuf:"varint,6,opt,name=reserved_change,json=reservedChange,proto3" json:"reserved_change,omitempty"`
+	RunningBalance  int64                  `protobuf:"varint,7,opt,name=running_balance,json=runningBalance,proto3" json:"running_balance,omitempty"`
+	RunningReserved int64                  `protobuf:"varint,8,opt,name=running_reserved,json=runningReserved,proto3" json:"running_reserved,omitempty"`
+	CreatedAt       int64                  `protobuf:"varint,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	OverdraftDrawn  int64                  `protobuf:"varint,10,opt,name=overdraft_drawn,json=overdraftDrawn,proto3" json:"overdraft_drawn,omitempty"` // part of the debit taken from the overdraft
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *StatementEntry) Reset() {
+	*x = StatementEntry{}
+	mi := &file_services_payments_service_proto_accounts_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatementEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatementEntry) ProtoMessage() {}
+
+func (x *StatementEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_services_payments_service_proto_accounts_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatementEntry.ProtoReflect.Descriptor instead.
+func (*StatementEntry) Descriptor() ([]byte, []int) {
+	return file_services_payments_service_proto_accounts_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *StatementEntry) GetEntryId() string {
+	if x != nil {
+		return x.EntryId
+	}
+	return ""
+}
+
+func (x *StatementEntry) GetEntryType() string {
+	if x != nil {
+		return x.EntryType
+	}
+	return ""
+}
+
+func (x *StatementEntry) GetReferenceId() string {
+	if x != nil {
+		return x.ReferenceId
+	}
+	return ""
+}
+
+func (x *StatementEntry) GetCounterpartyId() string {
+	if x != nil {
+		return x.CounterpartyId
+	}
+	return ""
+}
+
+func (x *StatementEntry) GetBalanceChange() int64 {
+	if x != nil {
+		return x.BalanceChange
+	}
+	return 0
+}
+
+func (x *StatementEntry) GetReservedChange() int64 {
+	if x != nil {
+		return x.ReservedChange
+	}
+	return 0
+}
+
+func (x *StatementEntry) GetRunningBalance() int64 {
+	if x != nil {
+		return x.RunningBalance
+	}
+	return 0
+}
+
+func (x *StatementEntry) GetRunningReserved() int64 {
+	if x != nil {
+		return x.RunningReserved
+	}
+	return 0
+}
+
+func (x *StatementEntry) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *StatementEntry) GetOverdraftDrawn() int64 {
+	if x != nil {
+		return x.OverdraftDrawn
+	}
+	return 0
+}
+
+type AccountStatementResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	AccountId       string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Currency        string                 `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	OpeningBalance  int64                  `protobuf:"varint,3,opt,name=opening_balance,json=openingBalance,proto3" json:"opening_balance,omitempty"`
+	OpeningReserved int64                  `protobuf:"varint,4,opt,name=opening_reserved,json=openingReserved,proto3" json:"opening_reserved,omitempty"`
+	ClosingBalance  int64                  `protobuf:"varint,5,opt,name=closing_balance,json=closingBalance,proto3" json:"closing_balance,omitempty"`
+	ClosingReserved int64                  `protobuf:"varint,6,opt,name=closing_reserved,json=closingReserved,proto3" json:"closing_reserved,omitempty"`
+	Entries         []*StatementEntry      `protobuf:"bytes,7,rep,name=entries,proto3" json:"entries,omitempty"`
+	NextPageToken   string                 `protobuf:"bytes,8,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *AccountStatementResponse) Reset() {
+	*x = AccountStatementResponse{}
+	mi := &file_services_payments_service_proto_accounts_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AccountStatementResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccountStatementResponse) ProtoMessage() {}
+
+func (x *AccountStatementResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_payments_service_proto_accounts_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccountStatementResponse.ProtoReflect.Descriptor instead.
+func (*AccountStatementResponse) Descriptor() ([]byte, []int) {
+	return file_services_payments_service_proto_accounts_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *AccountStatementResponse) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *AccountStatementResponse) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *AccountStatementResponse) GetOpeningBalance() int64 {
+	if x != nil {
+		return x.OpeningBalance
+	}
+	return 0
+}
+
+func (x *AccountStatementResponse) GetOpeningReserved() int64 {
+	if x != nil {
+		return x.OpeningReserved
+	}
+	return 0
+}
+
+func (x *AccountStatementResponse) GetClosingBalance() int64 {
+	if x != nil {
+		return x.ClosingBalance
+	}
+	return 0
+}
+
+func (x *AccountStatementResponse) GetClosingReserved() int64 {
+	if x != nil {
+		return x.ClosingReserved
+	}
+	return 0
+}
+
+func (x *AccountStatementResponse) GetEntries() []*StatementEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *AccountStatementResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type GetBalanceAsOfRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Timestamp     int64                  `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"` // unix seconds, entries at or before it are included
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBalanceAsOfRequest) Reset() {
+	*x = GetBalanceAsOfRequest{}
+	mi := &file_services_payments_service_proto_accounts_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBalanceAsOfRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBalanceAsOfRequest) ProtoMessage() {}
+
+func (x *GetBalanceAsOfRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_payments_service_proto_accounts_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBalanceAsOfRequest.ProtoReflect.Descriptor instead.
+func (*GetBalanceAsOfRequest) Descriptor() ([]byte, []int) {
+	return file_services_payments_service_proto_accounts_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetBalanceAsOfRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *GetBalanceAsOfRequest) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+type BalanceAsOfResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	AccountId       string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Currency        string                 `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	Balance         int64                  `protobuf:"varint,3,opt,name=balance,proto3" json:"balance,omitempty"`
+	Reserved        int64                  `protobuf:"varint,4,opt,name=reserved,proto3" json:"reserved,omitempty"`
+	AsOf            int64                  `protobuf:"varint,5,opt,name=as_of,json=asOf,proto3" json:"as_of,omitempty"`
+	SnapshotAt      int64                  `protobuf:"varint,6,opt,name=snapshot_at,json=snapshotAt,proto3" json:"snapshot_at,omitempty"` // snapshot the replay started from, 0 if none
+	EntriesReplayed int32                  `protobuf:"varint,7,opt,name=entries_replayed,json=entriesReplayed,proto3" json:"entries_replayed,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *BalanceAsOfResponse) Reset() {
+	*x = BalanceAsOfResponse{}
+	mi := &file_services_payments_service_proto_accounts_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BalanceAsOfResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BalanceAsOfResponse) ProtoMessage() {}
+
+func (x *BalanceAsOfResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_payments_service_proto_accounts_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BalanceAsOfResponse.ProtoReflect.Descriptor instead.
+func (*BalanceAsOfResponse) Descriptor() ([]byte, []int) {
+	return file_services_payments_service_proto_accounts_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *BalanceAsOfResponse) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *BalanceAsOfResponse) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *BalanceAsOfResponse) GetBalance() int64 {
+	if x != nil {
+		return x.Balance
+	}
+	return 0
+}
+
+func (x *BalanceAsOfResponse) GetReserved() int64 {
+	if x != nil {
+		return x.Reserved
+	}
+	return 0
+}
+
+func (x *BalanceAsOfResponse) GetAsOf() int64 {
+	if x != nil {
+		return x.AsOf
+	}
+	return 0
+}
+
+func (x *BalanceAsOfResponse) GetSnapshotAt() int64 {
+	if x != nil {
+		return x.SnapshotAt
+	}
+	return 0
+}
+
+func (x *BalanceAsOfResponse) GetEntriesReplayed() int32 {
+	if x != nil {
+		return x.EntriesReplayed
+	}
+	return 0
+}
+
+type SetCreditLimitRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	CreditLimit   int64                  `protobuf:"varint,2,opt,name=credit_limit,json=creditLimit,proto3" json:"credit_limit,omitempty"` // minor units, 0 removes the overdraft
+	Currency      string                 `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetCreditLimitRequest) Reset() {
+	*x = SetCreditLimitRequest{}
+	mi := &file_services_payments_service_proto_accounts_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetCreditLimitRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetCreditLimitRequest) ProtoMessage() {}
+
+func (x *SetCreditLimitRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_payments_service_proto_accounts_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetCreditLimitRequest.ProtoReflect.Descriptor instead.
+func (*SetCreditLimitRequest) Descriptor() ([]byte, []int) {
+	return file_services_payments_service_proto_accounts_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *SetCreditLimitRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *SetCreditLimitRequest) GetCreditLimit() int64 {
+	if x != nil {
+		return x.CreditLimit
+	}
+	return 0
+}
+
+func (x *SetCreditLimitRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type ListOverdrawnAccountsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOverdrawnAccountsRequest) Reset() {
+	*x = ListOverdrawnAccountsRequest{}
+	mi := &file_services_payments_service_proto_accounts_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOverdrawnAccountsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOverdrawnAccountsRequest) ProtoMessage() {}
+
+func (x *ListOverdrawnAccountsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_payments_service_proto_accounts_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOverdrawnAccountsRequest.ProtoReflect.Descriptor instead.
+func (*ListOverdrawnAccountsRequest) Descriptor() ([]byte, []int) {
+	return file_services_payments_service_proto_accounts_proto_rawDescGZIP(), []int{25}
+}
+
+// Refund moves amount (payer currency) of what was captured on a reservation back
+// from the payee to the payer. Repeating a refund_id returns the original refund.
+type RefundRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReferenceId   string                 `protobuf:"bytes,1,opt,name=reference_id,json=referenceId,proto3" json:"reference_id,omitempty"`
+	RefundId      string                 `protobuf:"bytes,2,opt,name=refund_id,json=refundId,proto3" json:"refund_id,omitempty"`
+	Amount        int64                  `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefundRequest) Reset() {
+	*x = RefundRequest{}
+	mi := &file_services_payments_service_proto_accounts_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefundRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefundRequest) ProtoMessage() {}
+
+func (x *RefundRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_payments_service_proto_accounts_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefundRequest.ProtoReflect.Descriptor instead.
+func (*RefundRequest) Descriptor() ([]byte, []int) {
+	return file_services_payments_service_proto_accounts_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *RefundRequest) GetReferenceId() string {
+	if x != nil {
+		return x.ReferenceId
+	}
+	return ""
+}
+
+func (x *RefundRequest) GetRefundId() string {
+	if x != nil {
+		return x.RefundId
+	}
+	return ""
+}
+
+func (x *RefundRequest) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+type RefundResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Status         string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Message        string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Reason         string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	Amount         int64                  `protobuf:"varint,4,opt,name=amount,proto3" json:"amount,omitempty"`                                       // credited to the payer, payer currency
+	PayeeAmount    int64                  `protobuf:"varint,5,opt,name=payee_amount,json=payeeAmount,proto3" json:"payee_amount,omitempty"`          // debited from the payee, payee currency
+	RefundedAmount int64                  `protobuf:"varint,6,opt,name=refunded_amount,json=refundedAmount,proto3" json:"refunded_amount,omitempty"` // refunded so far
+	Legs           []*PayeeLeg            `protobuf:"bytes,7,rep,name=legs,proto3" json:"legs,omitempty"`                                            // taken back from each leg of a split payment
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RefundResponse) Reset() {
+	*x = RefundResponse{}
+	mi := &file_services_payments_service_proto_accounts_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefundResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefundResponse) ProtoMessage() {}
+
+func (x *RefundResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_payments_service_proto_accounts_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefundResponse.ProtoReflect.Descriptor instead.
+func (*RefundResponse) Descriptor() ([]byte, []int) {
+	return file_services_payments_service_proto_accounts_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *RefundResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *RefundResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *RefundResponse) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *RefundResponse) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *RefundResponse) GetPayeeAmount() int64 {
+	if x != nil {
+		return x.PayeeAmount
+	}
+	return 0
+}
+
+func (x *RefundResponse) GetRefundedAmount() int64 {
+	if x != nil {
+		return x.RefundedAmount
+	}
+	return 0
+}
+
+func (x *RefundResponse) GetLegs() []*PayeeLeg {
+	if x != nil {
+		return x.Legs
+	}
+	return nil
+}
+
+// ReleaseEscrow pays amount (payer currency) of the funds an escrow reservation
+// holds in escrow out to the payee; RefundEscrow returns it to the payer. 0 moves
+// everything in escrow. Repeating a movement_id returns the original movement.
+type EscrowMovementRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReferenceId   string                 `protobuf:"bytes,1,opt,name=reference_id,json=referenceId,proto3" json:"reference_id,omitempty"`
+	MovementId    string                 `protobuf:"bytes,2,opt,name=movement_id,json=movementId,proto3" json:"movement_id,omitempty"`
+	Amount        int64                  `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EscrowMovementRequest) Reset() {
+	*x = EscrowMovementRequest{}
+	mi := &file_services_payments_service_proto_accounts_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EscrowMovementRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EscrowMovementRequest) ProtoMessage() {}
+
+func (x *EscrowMovementRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_payments_service_proto_accounts_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EscrowMovementRequest.ProtoReflect.Descriptor instead.
+func (*EscrowMovementRequest) Descriptor() ([]byte, []int) {
+	return file_services_payments_service_proto_accounts_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *EscrowMovementRequest) GetReferenceId() string {
+	if x != nil {
+		return x.ReferenceId
+	}
+	return ""
+}
+
+func (x *EscrowMovementRequest) GetMovementId() string {
+	if x != nil {
+		return x.MovementId
+	}
+	return ""
+}
+
+func (x *EscrowMovementRequest) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+type EscrowMovementResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	Amount        int64                  `protobuf:"varint,4,opt,name=amount,proto3" json:"amount,omitempty"`                                 // taken out of escrow, payer currency
+	PayeeAmount   int64                  `protobuf:"varint,5,opt,name=payee_amount,json=payeeAmount,proto3" json:"payee_amount,omitempty"`    // credited to the payee by a release, payee currency
+	EscrowAmount  int64                  `protobuf:"varint,6,opt,name=escrow_amount,json=escrowAmount,proto3" json:"escrow_amount,omitempty"` // left in escrow
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EscrowMovementResponse) Reset() {
+	*x = EscrowMovementResponse{}
+	mi := &file_services_payments_service_proto_accounts_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EscrowMovementResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EscrowMovementResponse) ProtoMessage() {}
+
+func (x *EscrowMovementResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_payments_service_proto_accounts_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EscrowMovementResponse.ProtoReflect.Descriptor instead.
+func (*EscrowMovementResponse) Descriptor() ([]byte, []int) {
+	return file_services_payments_service_proto_accounts_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *EscrowMovementResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *EscrowMovementResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *EscrowMovementResponse) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *EscrowMovementResponse) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *EscrowMovementResponse) GetPayeeAmount() int64 {
+	if x != nil {
+		return x.PayeeAmount
+	}
+	return 0
+}
+
+func (x *EscrowMovementResponse) GetEscrowAmount() int64 {
+	if x != nil {
+		return x.EscrowAmount
+	}
+	return 0
+}
+
+// HoldDispute takes amount (payer currency, 0 for everything disputable) of what
+// a reservation paid its payee into the dispute hold account. Funds still in
+// escrow, refunded or already disputed cannot be disputed. Repeating a
+// dispute_id returns the original hold.
+type DisputeHoldRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReferenceId   string                 `protobuf:"bytes,1,opt,name=reference_id,json=referenceId,proto3" json:"reference_id,omitempty"`
+	DisputeId     string                 `protobuf:"bytes,2,opt,name=dispute_id,json=disputeId,proto3" json:"dispute_id,omitempty"`
+	Amount        int64                  `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisputeHoldRequest) Reset() {
+	*x = DisputeHoldRequest{}
+	mi := &file_services_payments_service_proto_accounts_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisputeHoldRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisputeHoldRequest) ProtoMessage() {}
+
+func (x *DisputeHoldRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_payments_service_proto_accounts_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisputeHoldRequest.ProtoReflect.Descriptor instead.
+func (*DisputeHoldRequest) Descriptor() ([]byte, []int) {
+	return file_services_payments_service_proto_accounts_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *DisputeHoldRequest) GetReferenceId() string {
+	if x != nil {
+		return x.ReferenceId
+	}
+	return ""
+}
+
+func (x *DisputeHoldRequest) GetDisputeId() string {
+	if x != nil {
+		return x.DisputeId
+	}
+	return ""
+}
+
+func (x *DisputeHoldRequest) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+// CloseDisputeHold returns a held dispute amount to the payee when the dispute
+// was won, or to the payer when it was lost; a lost dispute counts as a refund.
+// Closing a closed hold the same way again returns it unchanged.
+type CloseDisputeHoldRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReferenceId   string                 `protobuf:"bytes,1,opt,name=reference_id,json=referenceId,proto3" json:"reference_id,omitempty"`
+	DisputeId     string                 `protobuf:"bytes,2,opt,name=dispute_id,json=disputeId,proto3" json:"dispute_id,omitempty"`
+	Won           bool                   `protobuf:"varint,3,opt,name=won,proto3" json:"won,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CloseDisputeHoldRequest) Reset() {
+	*x = CloseDisputeHoldRequest{}
+	mi := &file_services_payments_service_proto_accounts_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CloseDisputeHoldRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloseDisputeHoldRequest) ProtoMessage() {}
+
+func (x *CloseDisputeHoldRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_payments_service_proto_accounts_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloseDisputeHoldRequest.ProtoReflect.Descriptor instead.
+func (*CloseDisputeHoldRequest) Descriptor() ([]byte, []int) {
+	return file_services_payments_service_proto_accounts_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *CloseDisputeHoldRequest) GetReferenceId() string {
+	if x != nil {
+		return x.ReferenceId
+	}
+	return ""
+}
+
+func (x *CloseDisputeHoldRequest) GetDisputeId() string {
+	if x != nil {
+		return x.DisputeId
+	}
+	return ""
+}
+
+func (x *CloseDisputeHoldRequest) GetWon() bool {
+	if x != nil {
+		return x.Won
+	}
+	return false
+}
+
+type DisputeHoldResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	Amount        int64                  `protobuf:"varint,4,opt,name=amount,proto3" json:"amount,omitempty"`                              // disputed, payer currency
+	PayeeAmount   int64                  `protobuf:"varint,5,opt,name=payee_amount,json=payeeAmount,proto3" json:"payee_amount,omitempty"` // held from the payee, payee currency
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisputeHoldResponse) Reset() {
+	*x = DisputeHoldResponse{}
+	mi := &file_services_payments_service_proto_accounts_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisputeHoldResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisputeHoldResponse) ProtoMessage() {}
+
+func (x *DisputeHoldResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_payments_service_proto_accounts_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisputeHoldResponse.ProtoReflect.Descriptor instead.
+func (*DisputeHoldResponse) Descriptor() ([]byte, []int) {
+	return file_services_payments_service_proto_accounts_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *DisputeHoldResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *DisputeHoldResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *DisputeHoldResponse) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *DisputeHoldResponse) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *DisputeHoldResponse) GetPayeeAmount() int64 {
+	if x != nil {
+		return x.PayeeAmount
+	}
+	return 0
+}
+
+var File_services_payments_service_proto_accounts_proto protoreflect.FileDescriptor
+
+const file_services_payments_service_proto_accounts_proto_rawDesc = "" +
+	"\n" +
+	".services/payments-service/proto/accounts.proto\x12\baccounts\"\x94\x01\n" +
+	"\x14CreateAccountRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"account_no\x18\x02 \x01(\tR\taccountNo\x12'\n" +
+	"\x0finitial_balance\x18\x04 \x01(\x03R\x0einitialBalance\x12\x1a\n" +
+	"\bcurrency\x18\x05 \x01(\tR\bcurrencyJ\x04\b\x03\x10\x04\"2\n" +
+	"\x11GetAccountRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\"\x8c\x01\n" +
+	"\x14UpdateBalanceRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12\x1b\n" +
+	"\tis_credit\x18\x03 \x01(\bR\bisCredit\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\x03R\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x05 \x01(\tR\bcurrencyJ\x04\b\x02\x10\x03\"\xe6\x02\n" +
+	"\x0fAccountResponse\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"account_no\x18\x03 \x01(\tR\taccountNo\x12\x18\n" +
+	"\abalance\x18\x06 \x01(\x03R\abalance\x12\x1a\n" +
+	"\breserved\x18\a \x01(\x03R\breserved\x12\x1a\n" +
+	"\bcurrency\x18\b \x01(\tR\bcurrency\x12\x16\n" +
+	"\x06status\x18\t \x01(\tR\x06status\x12#\n" +
+	"\rstatus_reason\x18\n" +
+	" \x01(\tR\fstatusReason\x12!\n" +
+	"\fcredit_limit\x18\v \x01(\x03R\vcreditLimit\x12\x1c\n" +
+	"\tavailable\x18\f \x01(\x03R\tavailable\x12%\n" +
+	"\x0eoverdraft_used\x18\r \x01(\x03R\roverdraftUsedJ\x04\b\x04\x10\x05J\x04\b\x05\x10\x06\"\x15\n" +
+	"\x13ListAccountsRequest\"M\n" +
+	"\x14ListAccountsResponse\x125\n" +
+	"\baccounts\x18\x01 \x03(\v2\x19.accounts.AccountResponseR\baccounts\"\xa8\x02\n" +
+	"\x0eReserveRequest\x12\x19\n" +
+	"\bpayer_id\x18\x01 \x01(\tR\apayerId\x12\x19\n" +
+	"\bpayee_id\x18\x02 \x01(\tR\apayeeId\x12!\n" +
+	"\freference_id\x18\x04 \x01(\tR\vreferenceId\x12\x16\n" +
+	"\x06amount\x18\x05 \x01(\x03R\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x06 \x01(\tR\bcurrency\x12\x19\n" +
+	"\bquote_id\x18\a \x01(\tR\aquoteId\x12(\n" +
+	"\x10hold_ttl_seconds\x18\b \x01(\x03R\x0eholdTtlSeconds\x12&\n" +
+	"\x04legs\x18\t \x03(\v2\x12.accounts.PayeeLegR\x04legs\x12\x16\n" +
+	"\x06escrow\x18\n" +
+	" \x01(\bR\x06escrowJ\x04\b\x03\x10\x04\"=\n" +
+	"\bPayeeLeg\x12\x19\n" +
+	"\bpayee_id\x18\x01 \x01(\tR\apayeeId\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x03R\x06amount\"z\n" +
+	"\x0fReserveResponse\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x04 \x01(\x03R\texpiresAt\"\x81\x01\n" +
+	"\x0fTransferRequest\x12!\n" +
+	"\freference_id\x18\x01 \x01(\tR\vreferenceId\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x03R\x06amount\x12\x14\n" +
+	"\x05final\x18\x03 \x01(\bR\x05final\x12\x1d\n" +
+	"\n" +
+	"capture_id\x18\x04 \x01(\tR\tcaptureId\"\xbc\x02\n" +
+	"\x10TransferResponse\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\x03R\x06amount\x12!\n" +
+	"\fpayee_amount\x18\x05 \x01(\x03R\vpayeeAmount\x12'\n" +
+	"\x0fcaptured_amount\x18\x06 \x01(\x03R\x0ecapturedAmount\x12'\n" +
+	"\x0freleased_amount\x18\a \x01(\x03R\x0ereleasedAmount\x12)\n" +
+	"\x10remaining_amount\x18\b \x01(\x03R\x0fremainingAmount\x12&\n" +
+	"\x04legs\x18\t \x03(\v2\x12.accounts.PayeeLegR\x04legs\"3\n" +
+	"\x0eReleaseRequest\x12!\n" +
+	"\freference_id\x18\x01 \x01(\tR\vreferenceId\"[\n" +
+	"\x0fReleaseResponse\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"p\n" +
+	"\x0eSetRateRequest\x12#\n" +
+	"\rbase_currency\x18\x01 \x01(\tR\fbaseCurrency\x12%\n" +
+	"\x0equote_currency\x18\x02 \x01(\tR\rquoteCurrency\x12\x12\n" +
+	"\x04rate\x18\x03 \x01(\tR\x04rate\"\x8d\x01\n" +
+	"\fRateResponse\x12#\n" +
+	"\rbase_currency\x18\x01 \x01(\tR\fbaseCurrency\x12%\n" +
+	"\x0equote_currency\x18\x02 \x01(\tR\rquoteCurrency\x12\x12\n" +
+	"\x04rate\x18\x03 \x01(\tR\x04rate\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x04 \x01(\x03R\tupdatedAt\"o\n" +
+	"\x0fGetQuoteRequest\x12\x16\n" +
+	"\x06amount\x18\x01 \x01(\x03R\x06amount\x12#\n" +
+	"\rfrom_currency\x18\x02 \x01(\tR\ffromCurrency\x12\x1f\n" +
+	"\vto_currency\x18\x03 \x01(\tR\n" +
+	"toCurrency\"\xed\x01\n" +
+	"\rQuoteResponse\x12\x19\n" +
+	"\bquote_id\x18\x01 \x01(\tR\aquoteId\x12#\n" +
+	"\rfrom_currency\x18\x02 \x01(\tR\ffromCurrency\x12\x1f\n" +
+	"\vto_currency\x18\x03 \x01(\tR\n" +
+	"toCurrency\x12\x12\n" +
+	"\x04rate\x18\x04 \x01(\tR\x04rate\x12#\n" +
+	"\rsource_amount\x18\x05 \x01(\x03R\fsourceAmount\x12#\n" +
+	"\rtarget_amount\x18\x06 \x01(\x03R\ftargetAmount\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\a \x01(\x03R\texpiresAt\"M\n" +
+	"\x14AccountStatusRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"{\n" +
+	"\x13CloseAccountRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12-\n" +
+	"\x13sweep_to_account_id\x18\x02 \x01(\tR\x10sweepToAccountId\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"\x9b\x01\n" +
+	"\x1aGetAccountStatementRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12\x12\n" +
+	"\x04from\x18\x02 \x01(\x03R\x04from\x12\x0e\n" +
+	"\x02to\x18\x03 \x01(\x03R\x02to\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x04 \x01(\tR\tpageToken\x12\x1b\n" +
+	"\tpage_size\x18\x05 \x01(\x05R\bpageSize\"\x82\x03\n" +
+	"\x0eStatementEntry\x12\x19\n" +
+	"\bentry_id\x18\x01 \x01(\tR\aentryId\x12\x1d\n" +
+	"\n" +
+	"entry_type\x18\x02 \x01(\tR\tentryType\x12!\n" +
+	"\freference_id\x18\x03 \x01(\tR\vreferenceId\x12'\n" +
+	"\x0fcounterparty_id\x18\x04 \x01(\tR\x0ecounterpartyId\x12%\n" +
+	"\x0ebalance_change\x18\x05 \x01(\x03R\rbalanceChange\x12'\n" +
+	"\x0freserved_change\x18\x06 \x01(\x03R\x0ereservedChange\x12'\n" +
+	"\x0frunning_balance\x18\a \x01(\x03R\x0erunningBalance\x12)\n" +
+	"\x10running_reserved\x18\b \x01(\x03R\x0frunningReserved\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\t \x01(\x03R\tcreatedAt\x12'\n" +
+	"\x0foverdraft_drawn\x18\n" +
+	" \x01(\x03R\x0eoverdraftDrawn\"\xd9\x02\n" +
+	"\x18AccountStatementResponse\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12\x1a\n" +
+	"\bcurrency\x18\x02 \x01(\tR\bcurrency\x12'\n" +
+	"\x0fopening_balance\x18\x03 \x01(\x03R\x0eopeningBalance\x12)\n" +
+	"\x10opening_reserved\x18\x04 \x01(\x03R\x0fopeningReserved\x12'\n" +
+	"\x0fclosing_balance\x18\x05 \x01(\x03R\x0eclosingBalance\x12)\n" +
+	"\x10closing_reserved\x18\x06 \x01(\x03R\x0fclosingReserved\x122\n" +
+	"\aentries\x18\a \x03(\v2\x18.accounts.StatementEntryR\aentries\x12&\n" +
+	"\x0fnext_page_token\x18\b \x01(\tR\rnextPageToken\"T\n" +
+	"\x15GetBalanceAsOfRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12\x1c\n" +
+	"\ttimestamp\x18\x02 \x01(\x03R\ttimestamp\"\xe7\x01\n" +
+	"\x13BalanceAsOfResponse\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12\x1a\n" +
+	"\bcurrency\x18\x02 \x01(\tR\bcurrency\x12\x18\n" +
+	"\abalance\x18\x03 \x01(\x03R\abalance\x12\x1a\n" +
+	"\breserved\x18\x04 \x01(\x03R\breserved\x12\x13\n" +
+	"\x05as_of\x18\x05 \x01(\x03R\x04asOf\x12\x1f\n" +
+	"\vsnapshot_at\x18\x06 \x01(\x03R\n" +
+	"snapshotAt\x12)\n" +
+	"\x10entries_replayed\x18\a \x01(\x05R\x0fentriesReplayed\"u\n" +
+	"\x15SetCreditLimitRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12!\n" +
+	"\fcredit_limit\x18\x02 \x01(\x03R\vcreditLimit\x12\x1a\n" +
+	"\bcurrency\x18\x03 \x01(\tR\bcurrency\"\x1e\n" +
+	"\x1cListOverdrawnAccountsRequest\"g\n" +
+	"\rRefundRequest\x12!\n" +
+	"\freference_id\x18\x01 \x01(\tR\vreferenceId\x12\x1b\n" +
+	"\trefund_id\x18\x02 \x01(\tR\brefundId\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x03R\x06amount\"\xe6\x01\n" +
+	"\x0eRefundResponse\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\x03R\x06amount\x12!\n" +
+	"\fpayee_amount\x18\x05 \x01(\x03R\vpayeeAmount\x12'\n" +
+	"\x0frefunded_amount\x18\x06 \x01(\x03R\x0erefundedAmount\x12&\n" +
+	"\x04legs\x18\a \x03(\v2\x12.accounts.PayeeLegR\x04legs\"s\n" +
+	"\x15EscrowMovementRequest\x12!\n" +
+	"\freference_id\x18\x01 \x01(\tR\vreferenceId\x12\x1f\n" +
+	"\vmovement_id\x18\x02 \x01(\tR\n" +
+	"movementId\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x03R\x06amount\"\xc2\x01\n" +
+	"\x16EscrowMovementResponse\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\x03R\x06amount\x12!\n" +
+	"\fpayee_amount\x18\x05 \x01(\x03R\vpayeeAmount\x12#\n" +
+	"\rescrow_amount\x18\x06 \x01(\x03R\fescrowAmount\"n\n" +
+	"\x12DisputeHoldRequest\x12!\n" +
+	"\freference_id\x18\x01 \x01(\tR\vreferenceId\x12\x1d\n" +
+	"\n" +
+	"dispute_id\x18\x02 \x01(\tR\tdisputeId\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x03R\x06amount\"m\n" +
+	"\x17CloseDisputeHoldRequest\x12!\n" +
+	"\freference_id\x18\x01 \x01(\tR\vreferenceId\x12\x1d\n" +
+	"\n" +
+	"dispute_id\x18\x02 \x01(\tR\tdisputeId\x12\x10\n" +
+	"\x03won\x18\x03 \x01(\bR\x03won\"\x9a\x01\n" +
+	"\x13DisputeHoldResponse\x12\x16\n" +
 	"\x06status\x18\x01 \x01(\tR\x06status\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage2\x8a\x04\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\x03R\x06amount\x12!\n" +
+	"\fpayee_amount\x18\x05 \x01(\x03R\vpayeeAmount2\xd3\f\n" +
 	"\x0eAccountService\x12J\n" +
 	"\rCreateAccount\x12\x1e.accounts.CreateAccountRequest\x1a\x19.accounts.AccountResponse\x12D\n" +
 	"\n" +
@@ -708,7 +2572,21 @@ const file_services_payments_service_proto_accounts_proto_rawDesc = "" +
 	"\fListAccounts\x12\x1d.accounts.ListAccountsRequest\x1a\x1e.accounts.ListAccountsResponse\x12C\n" +
 	"\fReserveFunds\x12\x18.accounts.ReserveRequest\x1a\x19.accounts.ReserveResponse\x12A\n" +
 	"\bTransfer\x12\x19.accounts.TransferRequest\x1a\x1a.accounts.TransferResponse\x12C\n" +
-	"\fReleaseFunds\x12\x18.accounts.ReleaseRequest\x1a\x19.accounts.ReleaseResponseB\tZ\a./protob\x06proto3"
+	"\fReleaseFunds\x12\x18.accounts.ReleaseRequest\x1a\x19.accounts.ReleaseResponse\x12;\n" +
+	"\aSetRate\x12\x18.accounts.SetRateRequest\x1a\x16.accounts.RateResponse\x12>\n" +
+	"\bGetQuote\x12\x19.accounts.GetQuoteRequest\x1a\x17.accounts.QuoteResponse\x12J\n" +
+	"\rFreezeAccount\x12\x1e.accounts.AccountStatusRequest\x1a\x19.accounts.AccountResponse\x12L\n" +
+	"\x0fUnfreezeAccount\x12\x1e.accounts.AccountStatusRequest\x1a\x19.accounts.AccountResponse\x12H\n" +
+	"\fCloseAccount\x12\x1d.accounts.CloseAccountRequest\x1a\x19.accounts.AccountResponse\x12_\n" +
+	"\x13GetAccountStatement\x12$.accounts.GetAccountStatementRequest\x1a\".accounts.AccountStatementResponse\x12P\n" +
+	"\x0eGetBalanceAsOf\x12\x1f.accounts.GetBalanceAsOfRequest\x1a\x1d.accounts.BalanceAsOfResponse\x12L\n" +
+	"\x0eSetCreditLimit\x12\x1f.accounts.SetCreditLimitRequest\x1a\x19.accounts.AccountResponse\x12_\n" +
+	"\x15ListOverdrawnAccounts\x12&.accounts.ListOverdrawnAccountsRequest\x1a\x1e.accounts.ListAccountsResponse\x12;\n" +
+	"\x06Refund\x12\x17.accounts.RefundRequest\x1a\x18.accounts.RefundResponse\x12R\n" +
+	"\rReleaseEscrow\x12\x1f.accounts.EscrowMovementRequest\x1a .accounts.EscrowMovementResponse\x12Q\n" +
+	"\fRefundEscrow\x12\x1f.accounts.EscrowMovementRequest\x1a .accounts.EscrowMovementResponse\x12J\n" +
+	"\vHoldDispute\x12\x1c.accounts.DisputeHoldRequest\x1a\x1d.accounts.DisputeHoldResponse\x12T\n" +
+	"\x10CloseDisputeHold\x12!.accounts.CloseDisputeHoldRequest\x1a\x1d.accounts.DisputeHoldResponseB\tZ\a./protob\x06proto3"
 
 var (
 	file_services_payments_service_proto_accounts_proto_rawDescOnce sync.Once
@@ -722,42 +2600,95 @@ func file_services_payments_service_proto_accounts_proto_rawDescGZIP() []byte {
 	return file_services_payments_service_proto_accounts_proto_rawDescData
 }
 
-var file_services_payments_service_proto_accounts_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_services_payments_service_proto_accounts_proto_msgTypes = make([]protoimpl.MessageInfo, 33)
 var file_services_payments_service_proto_accounts_proto_goTypes = []any{
-	(*CreateAccountRequest)(nil), // 0: accounts.CreateAccountRequest
-	(*GetAccountRequest)(nil),    // 1: accounts.GetAccountRequest
-	(*UpdateBalanceRequest)(nil), // 2: accounts.UpdateBalanceRequest
-	(*AccountResponse)(nil),      // 3: accounts.AccountResponse
-	(*ListAccountsRequest)(nil),  // 4: accounts.ListAccountsRequest
-	(*ListAccountsResponse)(nil), // 5: accounts.ListAccountsResponse
-	(*ReserveRequest)(nil),       // 6: accounts.ReserveRequest
-	(*ReserveResponse)(nil),      // 7: accounts.ReserveResponse
-	(*TransferRequest)(nil),      // 8: accounts.TransferRequest
-	(*TransferResponse)(nil),     // 9: accounts.TransferResponse
-	(*ReleaseRequest)(nil),       // 10: accounts.ReleaseRequest
-	(*ReleaseResponse)(nil),      // 11: accounts.ReleaseResponse
+	(*CreateAccountRequest)(nil),         // 0: accounts.CreateAccountRequest
+	(*GetAccountRequest)(nil),            // 1: accounts.GetAccountRequest
+	(*UpdateBalanceRequest)(nil),         // 2: accounts.UpdateBalanceRequest
+	(*AccountResponse)(nil),              // 3: accounts.AccountResponse
+	(*ListAccountsRequest)(nil),          // 4: accounts.ListAccountsRequest
+	(*ListAccountsResponse)(nil),         // 5: accounts.ListAccountsResponse
+	(*ReserveRequest)(nil),               // 6: accounts.ReserveRequest
+	(*PayeeLeg)(nil),                     // 7: accounts.PayeeLeg
+	(*ReserveResponse)(nil),              // 8: accounts.ReserveResponse
+	(*TransferRequest)(nil),              // 9: accounts.TransferRequest
+	(*TransferResponse)(nil),             // 10: accounts.TransferResponse
+	(*ReleaseRequest)(nil),               // 11: accounts.ReleaseRequest
+	(*ReleaseResponse)(nil),              // 12: accounts.ReleaseResponse
+	(*SetRateRequest)(nil),               // 13: accounts.SetRateRequest
+	(*RateResponse)(nil),                 // 14: accounts.RateResponse
+	(*GetQuoteRequest)(nil),              // 15: accounts.GetQuoteRequest
+	(*QuoteResponse)(nil),                // 16: accounts.QuoteResponse
+	(*AccountStatusRequest)(nil),         // 17: accounts.AccountStatusRequest
+	(*CloseAccountRequest)(nil),          // 18: accounts.CloseAccountRequest
+	(*GetAccountStatementRequest)(nil),   // 19: accounts.GetAccountStatementRequest
+	(*StatementEntry)(nil),               // 20: accounts.StatementEntry
+	(*AccountStatementResponse)(nil),     // 21: accounts.AccountStatementResponse
+	(*GetBalanceAsOfRequest)(nil),        // 22: accounts.GetBalanceAsOfRequest
+	(*BalanceAsOfResponse)(nil),          // 23: accounts.BalanceAsOfResponse
+	(*SetCreditLimitRequest)(nil),        // 24: accounts.SetCreditLimitRequest
+	(*ListOverdrawnAccountsRequest)(nil), // 25: accounts.ListOverdrawnAccountsRequest
+	(*RefundRequest)(nil),                // 26: accounts.RefundRequest
+	(*RefundResponse)(nil),               // 27: accounts.RefundResponse
+	(*EscrowMovementRequest)(nil),        // 28: accounts.EscrowMovementRequest
+	(*EscrowMovementResponse)(nil),       // 29: accounts.EscrowMovementResponse
+	(*DisputeHoldRequest)(nil),           // 30: accounts.DisputeHoldRequest
+	(*CloseDisputeHoldRequest)(nil),      // 31: accounts.CloseDisputeHoldRequest
+	(*DisputeHoldResponse)(nil),          // 32: accounts.DisputeHoldResponse
 }
 var file_services_payments_service_proto_accounts_proto_depIdxs = []int32{
 	3,  // 0: accounts.ListAccountsResponse.accounts:type_name -> accounts.AccountResponse
-	0,  // 1: accounts.AccountService.CreateAccount:input_type -> accounts.CreateAccountRequest
-	1,  // 2: accounts.AccountService.GetAccount:input_type -> accounts.GetAccountRequest
-	2,  // 3: accounts.AccountService.UpdateBalance:input_type -> accounts.UpdateBalanceRequest
-	4,  // 4: accounts.AccountService.ListAccounts:input_type -> accounts.ListAccountsRequest
-	6,  // 5: accounts.AccountService.ReserveFunds:input_type -> accounts.ReserveRequest
-	8,  // 6: accounts.AccountService.Transfer:input_type -> accounts.TransferRequest
-	10, // 7: accounts.AccountService.ReleaseFunds:input_type -> accounts.ReleaseRequest
-	3,  // 8: accounts.AccountService.CreateAccount:output_type -> accounts.AccountResponse
-	3,  // 9: accounts.AccountService.GetAccount:output_type -> accounts.AccountResponse
-	3,  // 10: accounts.AccountService.UpdateBalance:output_type -> accounts.AccountResponse
-	5,  // 11: accounts.AccountService.ListAccounts:output_type -> accounts.ListAccountsResponse
-	7,  // 12: accounts.AccountService.ReserveFunds:output_type -> accounts.ReserveResponse
-	9,  // 13: accounts.AccountService.Transfer:output_type -> accounts.TransferResponse
-	11, // 14: accounts.AccountService.ReleaseFunds:output_type -> accounts.ReleaseResponse
-	8,  // [8:15] is the sub-list for method output_type
-	1,  // [1:8] is the sub-list for method input_type
-	1,  // [1:1] is the sub-list for extension type_name
-	1,  // [1:1] is the sub-list for extension extendee
-	0,  // [0:1] is the sub-list for field type_name
+	7,  // 1: accounts.ReserveRequest.legs:type_name -> accounts.PayeeLeg
+	7,  // 2: accounts.TransferResponse.legs:type_name -> accounts.PayeeLeg
+	20, // 3: accounts.AccountStatementResponse.entries:type_name -> accounts.StatementEntry
+	7,  // 4: accounts.RefundResponse.legs:type_name -> accounts.PayeeLeg
+	0,  // 5: accounts.AccountService.CreateAccount:input_type -> accounts.CreateAccountRequest
+	1,  // 6: accounts.AccountService.GetAccount:input_type -> accounts.GetAccountRequest
+	2,  // 7: accounts.AccountService.UpdateBalance:input_type -> accounts.UpdateBalanceRequest
+	4,  // 8: accounts.AccountService.ListAccounts:input_type -> accounts.ListAccountsRequest
+	6,  // 9: accounts.AccountService.ReserveFunds:input_type -> accounts.ReserveRequest
+	9,  // 10: accounts.AccountService.Transfer:input_type -> accounts.TransferRequest
+	11, // 11: accounts.AccountService.ReleaseFunds:input_type -> accounts.ReleaseRequest
+	13, // 12: accounts.AccountService.SetRate:input_type -> accounts.SetRateRequest
+	15, // 13: accounts.AccountService.GetQuote:input_type -> accounts.GetQuoteRequest
+	17, // 14: accounts.AccountService.FreezeAccount:input_type -> accounts.AccountStatusRequest
+	17, // 15: accounts.AccountService.UnfreezeAccount:input_type -> accounts.AccountStatusRequest
+	18, // 16: accounts.AccountService.CloseAccount:input_type -> accounts.CloseAccountRequest
+	19, // 17: accounts.AccountService.GetAccountStatement:input_type -> accounts.GetAccountStatementRequest
+	22, // 18: accounts.AccountService.GetBalanceAsOf:input_type -> accounts.GetBalanceAsOfRequest
+	24, // 19: accounts.AccountService.SetCreditLimit:input_type -> accounts.SetCreditLimitRequest
+	25, // 20: accounts.AccountService.ListOverdrawnAccounts:input_type -> accounts.ListOverdrawnAccountsRequest
+	26, // 21: accounts.AccountService.Refund:input_type -> accounts.RefundRequest
+	28, // 22: accounts.AccountService.ReleaseEscrow:input_type -> accounts.EscrowMovementRequest
+	28, // 23: accounts.AccountService.RefundEscrow:input_type -> accounts.EscrowMovementRequest
+	30, // 24: accounts.AccountService.HoldDispute:input_type -> accounts.DisputeHoldRequest
+	31, // 25: accounts.AccountService.CloseDisputeHold:input_type -> accounts.CloseDisputeHoldRequest
+	3,  // 26: accounts.AccountService.CreateAccount:output_type -> accounts.AccountResponse
+	3,  // 27: accounts.AccountService.GetAccount:output_type -> accounts.AccountResponse
+	3,  // 28: accounts.AccountService.UpdateBalance:output_type -> accounts.AccountResponse
+	5,  // 29: accounts.AccountService.ListAccounts:output_type -> accounts.ListAccountsResponse
+	8,  // 30: accounts.AccountService.ReserveFunds:output_type -> accounts.ReserveResponse
+	10, // 31: accounts.AccountService.Transfer:output_type -> accounts.TransferResponse
+	12, // 32: accounts.AccountService.ReleaseFunds:output_type -> accounts.ReleaseResponse
+	14, // 33: accounts.AccountService.SetRate:output_type -> accounts.RateResponse
+	16, // 34: accounts.AccountService.GetQuote:output_type -> accounts.QuoteResponse
+	3,  // 35: accounts.AccountService.FreezeAccount:output_type -> accounts.AccountResponse
+	3,  // 36: accounts.AccountService.UnfreezeAccount:output_type -> accounts.AccountResponse
+	3,  // 37: accounts.AccountService.CloseAccount:output_type -> accounts.AccountResponse
+	21, // 38: accounts.AccountService.GetAccountStatement:output_type -> accounts.AccountStatementResponse
+	23, // 39: accounts.AccountService.GetBalanceAsOf:output_type -> accounts.BalanceAsOfResponse
+	3,  // 40: accounts.AccountService.SetCreditLimit:output_type -> accounts.AccountResponse
+	5,  // 41: accounts.AccountService.ListOverdrawnAccounts:output_type -> accounts.ListAccountsResponse
+	27, // 42: accounts.AccountService.Refund:output_type -> accounts.RefundResponse
+	29, // 43: accounts.AccountService.ReleaseEscrow:output_type -> accounts.EscrowMovementResponse
+	29, // 44: accounts.AccountService.RefundEscrow:output_type -> accounts.EscrowMovementResponse
+	32, // 45: accounts.AccountService.HoldDispute:output_type -> accounts.DisputeHoldResponse
+	32, // 46: accounts.AccountService.CloseDisputeHold:output_type -> accounts.DisputeHoldResponse
+	26, // [26:47] is the sub-list for method output_type
+	5,  // [5:26] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_services_payments_service_proto_accounts_proto_init() }
@@ -771,7 +2702,7 @@ func file_services_payments_service_proto_accounts_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_services_payments_service_proto_accounts_proto_rawDesc), len(file_services_payments_service_proto_accounts_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   12,
+			NumMessages:   33,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
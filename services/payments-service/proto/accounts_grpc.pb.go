@@ -19,13 +19,27 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	AccountService_CreateAccount_FullMethodName = "/accounts.AccountService/CreateAccount"
-	AccountService_GetAccount_FullMethodName    = "/accounts.AccountService/GetAccount"
-	AccountService_UpdateBalance_FullMethodName = "/accounts.AccountService/UpdateBalance"
-	AccountService_ListAccounts_FullMethodName  = "/accounts.AccountService/ListAccounts"
-	AccountService_ReserveFunds_FullMethodName  = "/accounts.AccountService/ReserveFunds"
-	AccountService_Transfer_FullMethodName      = "/accounts.AccountService/Transfer"
-	AccountService_ReleaseFunds_FullMethodName  = "/accounts.AccountService/ReleaseFunds"
+	AccountService_CreateAccount_FullMethodName         = "/accounts.AccountService/CreateAccount"
+	AccountService_GetAccount_FullMethodName            = "/accounts.AccountService/GetAccount"
+	AccountService_UpdateBalance_FullMethodName         = "/accounts.AccountService/UpdateBalance"
+	AccountService_ListAccounts_FullMethodName          = "/accounts.AccountService/ListAccounts"
+	AccountService_ReserveFunds_FullMethodName          = "/accounts.AccountService/ReserveFunds"
+	AccountService_Transfer_FullMethodName              = "/accounts.AccountService/Transfer"
+	AccountService_ReleaseFunds_FullMethodName          = "/accounts.AccountService/ReleaseFunds"
+	AccountService_SetRate_FullMethodName               = "/accounts.AccountService/SetRate"
+	AccountService_GetQuote_FullMethodName              = "/accounts.AccountService/GetQuote"
+	AccountService_FreezeAccount_FullMethodName         = "/accounts.AccountService/FreezeAccount"
+	AccountService_UnfreezeAccount_FullMethodName       = "/accounts.AccountService/UnfreezeAccount"
+	AccountService_CloseAccount_FullMethodName          = "/accounts.AccountService/CloseAccount"
+	AccountService_GetAccountStatement_FullMethodName   = "/accounts.AccountService/GetAccountStatement"
+	AccountService_GetBalanceAsOf_FullMethodName        = "/accounts.AccountService/GetBalanceAsOf"
+	AccountService_SetCreditLimit_FullMethodName        = "/accounts.AccountService/SetCreditLimit"
+	AccountService_ListOverdrawnAccounts_FullMethodName = "/accounts.AccountService/ListOverdrawnAccounts"
+	AccountService_Refund_FullMethodName                = "/accounts.AccountService/Refund"
+	AccountService_ReleaseEscrow_FullMethodName         = "/accounts.AccountService/ReleaseEscrow"
+	AccountService_RefundEscrow_FullMethodName          = "/accounts.AccountService/RefundEscrow"
+	AccountService_HoldDispute_FullMethodName           = "/accounts.AccountService/HoldDispute"
+	AccountService_CloseDisputeHold_FullMethodName      = "/accounts.AccountService/CloseDisputeHold"
 )
 
 // AccountServiceClient is the client API for AccountService service.
@@ -39,6 +53,20 @@ type AccountServiceClient interface {
 	ReserveFunds(ctx context.Context, in *ReserveRequest, opts ...grpc.CallOption) (*ReserveResponse, error)
 	Transfer(ctx context.Context, in *TransferRequest, opts ...grpc.CallOption) (*TransferResponse, error)
 	ReleaseFunds(ctx context.Context, in *ReleaseRequest, opts ...grpc.CallOption) (*ReleaseResponse, error)
+	SetRate(ctx context.Context, in *SetRateRequest, opts ...grpc.CallOption) (*RateResponse, error)
+	GetQuote(ctx context.Context, in *GetQuoteRequest, opts ...grpc.CallOption) (*QuoteResponse, error)
+	FreezeAccount(ctx context.Context, in *AccountStatusRequest, opts ...grpc.CallOption) (*AccountResponse, error)
+	UnfreezeAccount(ctx context.Context, in *AccountStatusRequest, opts ...grpc.CallOption) (*AccountResponse, error)
+	CloseAccount(ctx context.Context, in *CloseAccountRequest, opts ...grpc.CallOption) (*AccountResponse, error)
+	GetAccountStatement(ctx context.Context, in *GetAccountStatementRequest, opts ...grpc.CallOption) (*AccountStatementResponse, error)
+	GetBalanceAsOf(ctx context.Context, in *GetBalanceAsOfRequest, opts ...grpc.CallOption) (*BalanceAsOfResponse, error)
+	SetCreditLimit(ctx context.Context, in *SetCreditLimitRequest, opts ...grpc.CallOption) (*AccountResponse, error)
+	ListOverdrawnAccounts(ctx context.Context, in *ListOverdrawnAccountsRequest, opts ...grpc.CallOption) (*ListAccountsResponse, error)
+	Refund(ctx context.Context, in *RefundRequest, opts ...grpc.CallOption) (*RefundResponse, error)
+	ReleaseEscrow(ctx context.Context, in *EscrowMovementRequest, opts ...grpc.CallOption) (*EscrowMovementResponse, error)
+	RefundEscrow(ctx context.Context, in *EscrowMovementRequest, opts ...grpc.CallOption) (*EscrowMovementResponse, error)
+	HoldDispute(ctx context.Context, in *DisputeHoldRequest, opts ...grpc.CallOption) (*DisputeHoldResponse, error)
+	CloseDisputeHold(ctx context.Context, in *CloseDisputeHoldRequest, opts ...grpc.CallOption) (*DisputeHoldResponse, error)
 }
 
 type accountServiceClient struct {
@@ -112,6 +140,132 @@ func (c *accountServiceClient) ReleaseFunds(ctx context.Context, in *ReleaseRequ
 	return out, nil
 }
 
+func (c *accountServiceClient) SetRate(ctx context.Context, in *SetRateRequest, opts ...grpc.CallOption) (*RateResponse, error) {
+	out := new(RateResponse)
+	err := c.cc.Invoke(ctx, AccountService_SetRate_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountServiceClient) GetQuote(ctx context.Context, in *GetQuoteRequest, opts ...grpc.CallOption) (*QuoteResponse, error) {
+	out := new(QuoteResponse)
+	err := c.cc.Invoke(ctx, AccountService_GetQuote_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountServiceClient) FreezeAccount(ctx context.Context, in *AccountStatusRequest, opts ...grpc.CallOption) (*AccountResponse, error) {
+	out := new(AccountResponse)
+	err := c.cc.Invoke(ctx, AccountService_FreezeAccount_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountServiceClient) UnfreezeAccount(ctx context.Context, in *AccountStatusRequest, opts ...grpc.CallOption) (*AccountResponse, error) {
+	out := new(AccountResponse)
+	err := c.cc.Invoke(ctx, AccountService_UnfreezeAccount_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountServiceClient) CloseAccount(ctx context.Context, in *CloseAccountRequest, opts ...grpc.CallOption) (*AccountResponse, error) {
+	out := new(AccountResponse)
+	err := c.cc.Invoke(ctx, AccountService_CloseAccount_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountServiceClient) GetAccountStatement(ctx context.Context, in *GetAccountStatementRequest, opts ...grpc.CallOption) (*AccountStatementResponse, error) {
+	out := new(AccountStatementResponse)
+	err := c.cc.Invoke(ctx, AccountService_GetAccountStatement_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountServiceClient) GetBalanceAsOf(ctx context.Context, in *GetBalanceAsOfRequest, opts ...grpc.CallOption) (*BalanceAsOfResponse, error) {
+	out := new(BalanceAsOfResponse)
+	err := c.cc.Invoke(ctx, AccountService_GetBalanceAsOf_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountServiceClient) SetCreditLimit(ctx context.Context, in *SetCreditLimitRequest, opts ...grpc.CallOption) (*AccountResponse, error) {
+	out := new(AccountResponse)
+	err := c.cc.Invoke(ctx, AccountService_SetCreditLimit_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountServiceClient) ListOverdrawnAccounts(ctx context.Context, in *ListOverdrawnAccountsRequest, opts ...grpc.CallOption) (*ListAccountsResponse, error) {
+	out := new(ListAccountsResponse)
+	err := c.cc.Invoke(ctx, AccountService_ListOverdrawnAccounts_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountServiceClient) Refund(ctx context.Context, in *RefundRequest, opts ...grpc.CallOption) (*RefundResponse, error) {
+	out := new(RefundResponse)
+	err := c.cc.Invoke(ctx, AccountService_Refund_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountServiceClient) ReleaseEscrow(ctx context.Context, in *EscrowMovementRequest, opts ...grpc.CallOption) (*EscrowMovementResponse, error) {
+	out := new(EscrowMovementResponse)
+	err := c.cc.Invoke(ctx, AccountService_ReleaseEscrow_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountServiceClient) RefundEscrow(ctx context.Context, in *EscrowMovementRequest, opts ...grpc.CallOption) (*EscrowMovementResponse, error) {
+	out := new(EscrowMovementResponse)
+	err := c.cc.Invoke(ctx, AccountService_RefundEscrow_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountServiceClient) HoldDispute(ctx context.Context, in *DisputeHoldRequest, opts ...grpc.CallOption) (*DisputeHoldResponse, error) {
+	out := new(DisputeHoldResponse)
+	err := c.cc.Invoke(ctx, AccountService_HoldDispute_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountServiceClient) CloseDisputeHold(ctx context.Context, in *CloseDisputeHoldRequest, opts ...grpc.CallOption) (*DisputeHoldResponse, error) {
+	out := new(DisputeHoldResponse)
+	err := c.cc.Invoke(ctx, AccountService_CloseDisputeHold_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AccountServiceServer is the server API for AccountService service.
 // All implementations must embed UnimplementedAccountServiceServer
 // for forward compatibility
@@ -123,6 +277,20 @@ type AccountServiceServer interface {
 	ReserveFunds(context.Context, *ReserveRequest) (*ReserveResponse, error)
 	Transfer(context.Context, *TransferRequest) (*TransferResponse, error)
 	ReleaseFunds(context.Context, *ReleaseRequest) (*ReleaseResponse, error)
+	SetRate(context.Context, *SetRateRequest) (*RateResponse, error)
+	GetQuote(context.Context, *GetQuoteRequest) (*QuoteResponse, error)
+	FreezeAccount(context.Context, *AccountStatusRequest) (*AccountResponse, error)
+	UnfreezeAccount(context.Context, *AccountStatusRequest) (*AccountResponse, error)
+	CloseAccount(context.Context, *CloseAccountRequest) (*AccountResponse, error)
+	GetAccountStatement(context.Context, *GetAccountStatementRequest) (*AccountStatementResponse, error)
+	GetBalanceAsOf(context.Context, *GetBalanceAsOfRequest) (*BalanceAsOfResponse, error)
+	SetCreditLimit(context.Context, *SetCreditLimitRequest) (*AccountResponse, error)
+	ListOverdrawnAccounts(context.Context, *ListOverdrawnAccountsRequest) (*ListAccountsResponse, error)
+	Refund(context.Context, *RefundRequest) (*RefundResponse, error)
+	ReleaseEscrow(context.Context, *EscrowMovementRequest) (*EscrowMovementResponse, error)
+	RefundEscrow(context.Context, *EscrowMovementRequest) (*EscrowMovementResponse, error)
+	HoldDispute(context.Context, *DisputeHoldRequest) (*DisputeHoldResponse, error)
+	CloseDisputeHold(context.Context, *CloseDisputeHoldRequest) (*DisputeHoldResponse, error)
 	mustEmbedUnimplementedAccountServiceServer()
 }
 
@@ -151,6 +319,48 @@ func (UnimplementedAccountServiceServer) Transfer(context.Context, *TransferRequ
 func (UnimplementedAccountServiceServer) ReleaseFunds(context.Context, *ReleaseRequest) (*ReleaseResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ReleaseFunds not implemented")
 }
+func (UnimplementedAccountServiceServer) SetRate(context.Context, *SetRateRequest) (*RateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetRate not implemented")
+}
+func (UnimplementedAccountServiceServer) GetQuote(context.Context, *GetQuoteRequest) (*QuoteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetQuote not implemented")
+}
+func (UnimplementedAccountServiceServer) FreezeAccount(context.Context, *AccountStatusRequest) (*AccountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FreezeAccount not implemented")
+}
+func (UnimplementedAccountServiceServer) UnfreezeAccount(context.Context, *AccountStatusRequest) (*AccountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnfreezeAccount not implemented")
+}
+func (UnimplementedAccountServiceServer) CloseAccount(context.Context, *CloseAccountRequest) (*AccountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CloseAccount not implemented")
+}
+func (UnimplementedAccountServiceServer) GetAccountStatement(context.Context, *GetAccountStatementRequest) (*AccountStatementResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAccountStatement not implemented")
+}
+func (UnimplementedAccountServiceServer) GetBalanceAsOf(context.Context, *GetBalanceAsOfRequest) (*BalanceAsOfResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBalanceAsOf not implemented")
+}
+func (UnimplementedAccountServiceServer) SetCreditLimit(context.Context, *SetCreditLimitRequest) (*AccountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetCreditLimit not implemented")
+}
+func (UnimplementedAccountServiceServer) ListOverdrawnAccounts(context.Context, *ListOverdrawnAccountsRequest) (*ListAccountsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOverdrawnAccounts not implemented")
+}
+func (UnimplementedAccountServiceServer) Refund(context.Context, *RefundRequest) (*RefundResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Refund not implemented")
+}
+func (UnimplementedAccountServiceServer) ReleaseEscrow(context.Context, *EscrowMovementRequest) (*EscrowMovementResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseEscrow not implemented")
+}
+func (UnimplementedAccountServiceServer) RefundEscrow(context.Context, *EscrowMovementRequest) (*EscrowMovementResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefundEscrow not implemented")
+}
+func (UnimplementedAccountServiceServer) HoldDispute(context.Context, *DisputeHoldRequest) (*DisputeHoldResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HoldDispute not implemented")
+}
+func (UnimplementedAccountServiceServer) CloseDisputeHold(context.Context, *CloseDisputeHoldRequest) (*DisputeHoldResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CloseDisputeHold not implemented")
+}
 func (UnimplementedAccountServiceServer) mustEmbedUnimplementedAccountServiceServer() {}
 
 // UnsafeAccountServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -290,6 +500,258 @@ func _AccountService_ReleaseFunds_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AccountService_SetRate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetRateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServiceServer).SetRate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccountService_SetRate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServiceServer).SetRate(ctx, req.(*SetRateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountService_GetQuote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetQuoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServiceServer).GetQuote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccountService_GetQuote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServiceServer).GetQuote(ctx, req.(*GetQuoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountService_FreezeAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServiceServer).FreezeAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccountService_FreezeAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServiceServer).FreezeAccount(ctx, req.(*AccountStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountService_UnfreezeAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServiceServer).UnfreezeAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccountService_UnfreezeAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServiceServer).UnfreezeAccount(ctx, req.(*AccountStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountService_CloseAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CloseAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServiceServer).CloseAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccountService_CloseAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServiceServer).CloseAccount(ctx, req.(*CloseAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountService_GetAccountStatement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAccountStatementRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServiceServer).GetAccountStatement(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccountService_GetAccountStatement_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServiceServer).GetAccountStatement(ctx, req.(*GetAccountStatementRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountService_GetBalanceAsOf_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBalanceAsOfRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServiceServer).GetBalanceAsOf(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccountService_GetBalanceAsOf_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServiceServer).GetBalanceAsOf(ctx, req.(*GetBalanceAsOfRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountService_SetCreditLimit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetCreditLimitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServiceServer).SetCreditLimit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccountService_SetCreditLimit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServiceServer).SetCreditLimit(ctx, req.(*SetCreditLimitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountService_ListOverdrawnAccounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOverdrawnAccountsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServiceServer).ListOverdrawnAccounts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccountService_ListOverdrawnAccounts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServiceServer).ListOverdrawnAccounts(ctx, req.(*ListOverdrawnAccountsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountService_Refund_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefundRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServiceServer).Refund(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccountService_Refund_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServiceServer).Refund(ctx, req.(*RefundRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountService_ReleaseEscrow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EscrowMovementRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServiceServer).ReleaseEscrow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccountService_ReleaseEscrow_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServiceServer).ReleaseEscrow(ctx, req.(*EscrowMovementRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountService_RefundEscrow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EscrowMovementRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServiceServer).RefundEscrow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccountService_RefundEscrow_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServiceServer).RefundEscrow(ctx, req.(*EscrowMovementRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountService_HoldDispute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DisputeHoldRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServiceServer).HoldDispute(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccountService_HoldDispute_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServiceServer).HoldDispute(ctx, req.(*DisputeHoldRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountService_CloseDisputeHold_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CloseDisputeHoldRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServiceServer).CloseDisputeHold(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccountService_CloseDisputeHold_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServiceServer).CloseDisputeHold(ctx, req.(*CloseDisputeHoldRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // AccountService_ServiceDesc is the grpc.ServiceDesc for AccountService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -325,6 +787,62 @@ var AccountService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ReleaseFunds",
 			Handler:    _AccountService_ReleaseFunds_Handler,
 		},
+		{
+			MethodName: "SetRate",
+			Handler:    _AccountService_SetRate_Handler,
+		},
+		{
+			MethodName: "GetQuote",
+			Handler:    _AccountService_GetQuote_Handler,
+		},
+		{
+			MethodName: "FreezeAccount",
+			Handler:    _AccountService_FreezeAccount_Handler,
+		},
+		{
+			MethodName: "UnfreezeAccount",
+			Handler:    _AccountService_UnfreezeAccount_Handler,
+		},
+		{
+			MethodName: "CloseAccount",
+			Handler:    _AccountService_CloseAccount_Handler,
+		},
+		{
+			MethodName: "GetAccountStatement",
+			Handler:    _AccountService_GetAccountStatement_Handler,
+		},
+		{
+			MethodName: "GetBalanceAsOf",
+			Handler:    _AccountService_GetBalanceAsOf_Handler,
+		},
+		{
+			MethodName: "SetCreditLimit",
+			Handler:    _AccountService_SetCreditLimit_Handler,
+		},
+		{
+			MethodName: "ListOverdrawnAccounts",
+			Handler:    _AccountService_ListOverdrawnAccounts_Handler,
+		},
+		{
+			MethodName: "Refund",
+			Handler:    _AccountService_Refund_Handler,
+		},
+		{
+			MethodName: "ReleaseEscrow",
+			Handler:    _AccountService_ReleaseEscrow_Handler,
+		},
+		{
+			MethodName: "RefundEscrow",
+			Handler:    _AccountService_RefundEscrow_Handler,
+		},
+		{
+			MethodName: "HoldDispute",
+			Handler:    _AccountService_HoldDispute_Handler,
+		},
+		{
+			MethodName: "CloseDisputeHold",
+			Handler:    _AccountService_CloseDisputeHold_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "services/payments-service/proto/accounts.proto",
@@ -24,11 +24,16 @@ const (
 type PaymentStatus int32
 
 const (
-	PaymentStatus_UNKNOWN    PaymentStatus = 0
-	PaymentStatus_AUTHORIZED PaymentStatus = 1
-	PaymentStatus_CAPTURED   PaymentStatus = 2
-	PaymentStatus_FAILED     PaymentStatus = 3
-	PaymentStatus_REFUNDED   PaymentStatus = 4
+	PaymentStatus_UNKNOWN            PaymentStatus = 0
+	PaymentStatus_AUTHORIZED         PaymentStatus = 1
+	PaymentStatus_CAPTURED           PaymentStatus = 2
+	PaymentStatus_FAILED             PaymentStatus = 3
+	PaymentStatus_REFUNDED           PaymentStatus = 4
+	PaymentStatus_EXPIRED            PaymentStatus = 5
+	PaymentStatus_PARTIALLY_CAPTURED PaymentStatus = 6
+	PaymentStatus_CANCELED           PaymentStatus = 7
+	PaymentStatus_PARTIALLY_REFUNDED PaymentStatus = 8
+	PaymentStatus_PENDING_REVIEW     PaymentStatus = 9 // held by the risk rules until ReviewPaymentIntent; no funds reserved yet
 )
 
 // Enum value maps for PaymentStatus.
@@ -39,13 +44,23 @@ var (
 		2: "CAPTURED",
 		3: "FAILED",
 		4: "REFUNDED",
+		5: "EXPIRED",
+		6: "PARTIALLY_CAPTURED",
+		7: "CANCELED",
+		8: "PARTIALLY_REFUNDED",
+		9: "PENDING_REVIEW",
 	}
 	PaymentStatus_value = map[string]int32{
-		"UNKNOWN":    0,
-		"AUTHORIZED": 1,
-		"CAPTURED":   2,
-		"FAILED":     3,
-		"REFUNDED":   4,
+		"UNKNOWN":            0,
+		"AUTHORIZED":         1,
+		"CAPTURED":           2,
+		"FAILED":             3,
+		"REFUNDED":           4,
+		"EXPIRED":            5,
+		"PARTIALLY_CAPTURED": 6,
+		"CANCELED":           7,
+		"PARTIALLY_REFUNDED": 8,
+		"PENDING_REVIEW":     9,
 	}
 )
 
@@ -77,13 +92,23 @@ func (PaymentStatus) EnumDescriptor() ([]byte, []int) {
 }
 
 type CreatePaymentIntentRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	PayerId       string                 `protobuf:"bytes,1,opt,name=payer_id,json=payerId,proto3" json:"payer_id,omitempty"`
-	PayeeId       string                 `protobuf:"bytes,2,opt,name=payee_id,json=payeeId,proto3" json:"payee_id,omitempty"`
-	Amount        float64                `protobuf:"fixed64,3,opt,name=amount,proto3" json:"amount,omitempty"`
-	ReferenceId   string                 `protobuf:"bytes,5,opt,name=reference_id,json=referenceId,proto3" json:"reference_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	PayerId        string                 `protobuf:"bytes,1,opt,name=payer_id,json=payerId,proto3" json:"payer_id,omitempty"`
+	PayeeId        string                 `protobuf:"bytes,2,opt,name=payee_id,json=payeeId,proto3" json:"payee_id,omitempty"` // leave empty when legs are given
+	ReferenceId    string                 `protobuf:"bytes,5,opt,name=reference_id,json=referenceId,proto3" json:"reference_id,omitempty"`
+	Amount         int64                  `protobuf:"varint,6,opt,name=amount,proto3" json:"amount,omitempty"` // minor units of currency
+	Currency       string                 `protobuf:"bytes,7,opt,name=currency,proto3" json:"currency,omitempty"`
+	HoldTtlSeconds int64                  `protobuf:"varint,8,opt,name=hold_ttl_seconds,json=holdTtlSeconds,proto3" json:"hold_ttl_seconds,omitempty"` // how long the funds stay reserved; 0 uses the accounts-service default
+	IdempotencyKey string                 `protobuf:"bytes,9,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	// split payments: the amount is paid out to several payees, all in the payer's
+	// currency; the legs must add up to amount
+	Legs []*PaymentLeg `protobuf:"bytes,10,rep,name=legs,proto3" json:"legs,omitempty"`
+	// escrow: captures are held in escrow instead of being paid to the payee, until
+	// ReleaseEscrow, RefundEscrow or escrow_release_at (unix seconds, 0 for never)
+	Escrow          bool  `protobuf:"varint,11,opt,name=escrow,proto3" json:"escrow,omitempty"`
+	EscrowReleaseAt int64 `protobuf:"varint,12,opt,name=escrow_release_at,json=escrowReleaseAt,proto3" json:"escrow_release_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *CreatePaymentIntentRequest) Reset() {
@@ -130,32 +155,138 @@ func (x *CreatePaymentIntentRequest) GetPayeeId() string {
 	return ""
 }
 
-func (x *CreatePaymentIntentRequest) GetAmount() float64 {
+func (x *CreatePaymentIntentRequest) GetReferenceId() string {
+	if x != nil {
+		return x.ReferenceId
+	}
+	return ""
+}
+
+func (x *CreatePaymentIntentRequest) GetAmount() int64 {
 	if x != nil {
 		return x.Amount
 	}
 	return 0
 }
 
-func (x *CreatePaymentIntentRequest) GetReferenceId() string {
+func (x *CreatePaymentIntentRequest) GetCurrency() string {
 	if x != nil {
-		return x.ReferenceId
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *CreatePaymentIntentRequest) GetHoldTtlSeconds() int64 {
+	if x != nil {
+		return x.HoldTtlSeconds
+	}
+	return 0
+}
+
+func (x *CreatePaymentIntentRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *CreatePaymentIntentRequest) GetLegs() []*PaymentLeg {
+	if x != nil {
+		return x.Legs
+	}
+	return nil
+}
+
+func (x *CreatePaymentIntentRequest) GetEscrow() bool {
+	if x != nil {
+		return x.Escrow
+	}
+	return false
+}
+
+func (x *CreatePaymentIntentRequest) GetEscrowReleaseAt() int64 {
+	if x != nil {
+		return x.EscrowReleaseAt
+	}
+	return 0
+}
+
+// A payee's share of a split payment: a fixed amount in minor units, or
+// basis_points of the intent amount (10000 = 100%). Shares given in basis points
+// are rounded down and the minor units lost to rounding go to the first of them.
+type PaymentLeg struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PayeeId       string                 `protobuf:"bytes,1,opt,name=payee_id,json=payeeId,proto3" json:"payee_id,omitempty"`
+	Amount        int64                  `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	BasisPoints   int32                  `protobuf:"varint,3,opt,name=basis_points,json=basisPoints,proto3" json:"basis_points,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PaymentLeg) Reset() {
+	*x = PaymentLeg{}
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PaymentLeg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaymentLeg) ProtoMessage() {}
+
+func (x *PaymentLeg) ProtoReflect() protoreflect.Message {
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PaymentLeg.ProtoReflect.Descriptor instead.
+func (*PaymentLeg) Descriptor() ([]byte, []int) {
+	return file_services_payments_service_proto_payments_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PaymentLeg) GetPayeeId() string {
+	if x != nil {
+		return x.PayeeId
 	}
 	return ""
 }
 
+func (x *PaymentLeg) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *PaymentLeg) GetBasisPoints() int32 {
+	if x != nil {
+		return x.BasisPoints
+	}
+	return 0
+}
+
 type CreatePaymentIntentResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ReferenceId   string                 `protobuf:"bytes,1,opt,name=reference_id,json=referenceId,proto3" json:"reference_id,omitempty"`
 	Status        PaymentStatus          `protobuf:"varint,2,opt,name=status,proto3,enum=payments.PaymentStatus" json:"status,omitempty"`
 	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	ExpiresAt     int64                  `protobuf:"varint,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // unix seconds; the intent expires if not captured by then
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *CreatePaymentIntentResponse) Reset() {
 	*x = CreatePaymentIntentResponse{}
-	mi := &file_services_payments_service_proto_payments_proto_msgTypes[1]
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -167,7 +298,7 @@ func (x *CreatePaymentIntentResponse) String() string {
 func (*CreatePaymentIntentResponse) ProtoMessage() {}
 
 func (x *CreatePaymentIntentResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_services_payments_service_proto_payments_proto_msgTypes[1]
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -180,7 +311,7 @@ func (x *CreatePaymentIntentResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreatePaymentIntentResponse.ProtoReflect.Descriptor instead.
 func (*CreatePaymentIntentResponse) Descriptor() ([]byte, []int) {
-	return file_services_payments_service_proto_payments_proto_rawDescGZIP(), []int{1}
+	return file_services_payments_service_proto_payments_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *CreatePaymentIntentResponse) GetReferenceId() string {
@@ -204,16 +335,28 @@ func (x *CreatePaymentIntentResponse) GetMessage() string {
 	return ""
 }
 
+func (x *CreatePaymentIntentResponse) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+// amount is in the payer's currency, 0 captures everything still authorized. A
+// final capture releases the rest of the authorization back to the payer.
 type CapturePaymentRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ReferenceId   string                 `protobuf:"bytes,1,opt,name=reference_id,json=referenceId,proto3" json:"reference_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ReferenceId    string                 `protobuf:"bytes,1,opt,name=reference_id,json=referenceId,proto3" json:"reference_id,omitempty"`
+	Amount         int64                  `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	Final          bool                   `protobuf:"varint,3,opt,name=final,proto3" json:"final,omitempty"`
+	IdempotencyKey string                 `protobuf:"bytes,4,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *CapturePaymentRequest) Reset() {
 	*x = CapturePaymentRequest{}
-	mi := &file_services_payments_service_proto_payments_proto_msgTypes[2]
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -225,7 +368,7 @@ func (x *CapturePaymentRequest) String() string {
 func (*CapturePaymentRequest) ProtoMessage() {}
 
 func (x *CapturePaymentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_services_payments_service_proto_payments_proto_msgTypes[2]
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -238,7 +381,7 @@ func (x *CapturePaymentRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CapturePaymentRequest.ProtoReflect.Descriptor instead.
 func (*CapturePaymentRequest) Descriptor() ([]byte, []int) {
-	return file_services_payments_service_proto_payments_proto_rawDescGZIP(), []int{2}
+	return file_services_payments_service_proto_payments_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *CapturePaymentRequest) GetReferenceId() string {
@@ -248,18 +391,43 @@ func (x *CapturePaymentRequest) GetReferenceId() string {
 	return ""
 }
 
+func (x *CapturePaymentRequest) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *CapturePaymentRequest) GetFinal() bool {
+	if x != nil {
+		return x.Final
+	}
+	return false
+}
+
+func (x *CapturePaymentRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
 type CapturePaymentResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ReferenceId   string                 `protobuf:"bytes,1,opt,name=reference_id,json=referenceId,proto3" json:"reference_id,omitempty"`
-	Status        PaymentStatus          `protobuf:"varint,2,opt,name=status,proto3,enum=payments.PaymentStatus" json:"status,omitempty"`
-	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ReferenceId     string                 `protobuf:"bytes,1,opt,name=reference_id,json=referenceId,proto3" json:"reference_id,omitempty"`
+	Status          PaymentStatus          `protobuf:"varint,2,opt,name=status,proto3,enum=payments.PaymentStatus" json:"status,omitempty"`
+	Message         string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	CaptureId       string                 `protobuf:"bytes,4,opt,name=capture_id,json=captureId,proto3" json:"capture_id,omitempty"`
+	Amount          int64                  `protobuf:"varint,5,opt,name=amount,proto3" json:"amount,omitempty"`                                          // captured by this request
+	CapturedAmount  int64                  `protobuf:"varint,6,opt,name=captured_amount,json=capturedAmount,proto3" json:"captured_amount,omitempty"`    // captured so far
+	RemainingAmount int64                  `protobuf:"varint,7,opt,name=remaining_amount,json=remainingAmount,proto3" json:"remaining_amount,omitempty"` // still authorized
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *CapturePaymentResponse) Reset() {
 	*x = CapturePaymentResponse{}
-	mi := &file_services_payments_service_proto_payments_proto_msgTypes[3]
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -271,7 +439,7 @@ func (x *CapturePaymentResponse) String() string {
 func (*CapturePaymentResponse) ProtoMessage() {}
 
 func (x *CapturePaymentResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_services_payments_service_proto_payments_proto_msgTypes[3]
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -284,7 +452,7 @@ func (x *CapturePaymentResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CapturePaymentResponse.ProtoReflect.Descriptor instead.
 func (*CapturePaymentResponse) Descriptor() ([]byte, []int) {
-	return file_services_payments_service_proto_payments_proto_rawDescGZIP(), []int{3}
+	return file_services_payments_service_proto_payments_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *CapturePaymentResponse) GetReferenceId() string {
@@ -308,37 +476,3419 @@ func (x *CapturePaymentResponse) GetMessage() string {
 	return ""
 }
 
-var File_services_payments_service_proto_payments_proto protoreflect.FileDescriptor
+func (x *CapturePaymentResponse) GetCaptureId() string {
+	if x != nil {
+		return x.CaptureId
+	}
+	return ""
+}
 
-const file_services_payments_service_proto_payments_proto_rawDesc = "" +
-	"\n" +
-	".services/payments-service/proto/payments.proto\x12\bpayments\"\x8d\x01\n" +
-	"\x1aCreatePaymentIntentRequest\x12\x19\n" +
-	"\bpayer_id\x18\x01 \x01(\tR\apayerId\x12\x19\n" +
-	"\bpayee_id\x18\x02 \x01(\tR\apayeeId\x12\x16\n" +
-	"\x06amount\x18\x03 \x01(\x01R\x06amount\x12!\n" +
-	"\freference_id\x18\x05 \x01(\tR\vreferenceId\"\x8b\x01\n" +
-	"\x1bCreatePaymentIntentResponse\x12!\n" +
-	"\freference_id\x18\x01 \x01(\tR\vreferenceId\x12/\n" +
-	"\x06status\x18\x02 \x01(\x0e2\x17.payments.PaymentStatusR\x06status\x12\x18\n" +
-	"\amessage\x18\x03 \x01(\tR\amessage\":\n" +
-	"\x15CapturePaymentRequest\x12!\n" +
-	"\freference_id\x18\x01 \x01(\tR\vreferenceId\"\x86\x01\n" +
-	"\x16CapturePaymentResponse\x12!\n" +
-	"\freference_id\x18\x01 \x01(\tR\vreferenceId\x12/\n" +
-	"\x06status\x18\x02 \x01(\x0e2\x17.payments.PaymentStatusR\x06status\x12\x18\n" +
-	"\amessage\x18\x03 \x01(\tR\amessage*T\n" +
-	"\rPaymentStatus\x12\v\n" +
-	"\aUNKNOWN\x10\x00\x12\x0e\n" +
-	"\n" +
-	"AUTHORIZED\x10\x01\x12\f\n" +
-	"\bCAPTURED\x10\x02\x12\n" +
-	"\n" +
-	"\x06FAILED\x10\x03\x12\f\n" +
-	"\bREFUNDED\x10\x042\xc9\x01\n" +
-	"\x0ePaymentService\x12b\n" +
-	"\x13CreatePaymentIntent\x12$.payments.CreatePaymentIntentRequest\x1a%.payments.CreatePaymentIntentResponse\x12S\n" +
-	"\x0eCapturePayment\x12\x1f.payments.CapturePaymentRequest\x1a .payments.CapturePaymentResponseB\tZ\a./protob\x06proto3"
+func (x *CapturePaymentResponse) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *CapturePaymentResponse) GetCapturedAmount() int64 {
+	if x != nil {
+		return x.CapturedAmount
+	}
+	return 0
+}
+
+func (x *CapturePaymentResponse) GetRemainingAmount() int64 {
+	if x != nil {
+		return x.RemainingAmount
+	}
+	return 0
+}
+
+// amount is in the payer's currency, 0 refunds everything captured and not yet
+// refunded. Repeating an idempotency_key returns the original refund.
+type RefundPaymentRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ReferenceId    string                 `protobuf:"bytes,1,opt,name=reference_id,json=referenceId,proto3" json:"reference_id,omitempty"`
+	Amount         int64                  `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	Reason         string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	IdempotencyKey string                 `protobuf:"bytes,4,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RefundPaymentRequest) Reset() {
+	*x = RefundPaymentRequest{}
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefundPaymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefundPaymentRequest) ProtoMessage() {}
+
+func (x *RefundPaymentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefundPaymentRequest.ProtoReflect.Descriptor instead.
+func (*RefundPaymentRequest) Descriptor() ([]byte, []int) {
+	return file_services_payments_service_proto_payments_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RefundPaymentRequest) GetReferenceId() string {
+	if x != nil {
+		return x.ReferenceId
+	}
+	return ""
+}
+
+func (x *RefundPaymentRequest) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *RefundPaymentRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *RefundPaymentRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+type RefundPaymentResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ReferenceId    string                 `protobuf:"bytes,1,opt,name=reference_id,json=referenceId,proto3" json:"reference_id,omitempty"`
+	RefundId       string                 `protobuf:"bytes,2,opt,name=refund_id,json=refundId,proto3" json:"refund_id,omitempty"`
+	Status         PaymentStatus          `protobuf:"varint,3,opt,name=status,proto3,enum=payments.PaymentStatus" json:"status,omitempty"` // REFUNDED or FAILED
+	Message        string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	Amount         int64                  `protobuf:"varint,5,opt,name=amount,proto3" json:"amount,omitempty"`                                       // refunded by this request
+	RefundedAmount int64                  `protobuf:"varint,6,opt,name=refunded_amount,json=refundedAmount,proto3" json:"refunded_amount,omitempty"` // refunded so far
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RefundPaymentResponse) Reset() {
+	*x = RefundPaymentResponse{}
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefundPaymentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefundPaymentResponse) ProtoMessage() {}
+
+func (x *RefundPaymentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefundPaymentResponse.ProtoReflect.Descriptor instead.
+func (*RefundPaymentResponse) Descriptor() ([]byte, []int) {
+	return file_services_payments_service_proto_payments_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *RefundPaymentResponse) GetReferenceId() string {
+	if x != nil {
+		return x.ReferenceId
+	}
+	return ""
+}
+
+func (x *RefundPaymentResponse) GetRefundId() string {
+	if x != nil {
+		return x.RefundId
+	}
+	return ""
+}
+
+func (x *RefundPaymentResponse) GetStatus() PaymentStatus {
+	if x != nil {
+		return x.Status
+	}
+	return PaymentStatus_UNKNOWN
+}
+
+func (x *RefundPaymentResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *RefundPaymentResponse) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *RefundPaymentResponse) GetRefundedAmount() int64 {
+	if x != nil {
+		return x.RefundedAmount
+	}
+	return 0
+}
+
+// Pays amount (0 for everything) of what an escrow intent holds in escrow out to
+// the payee.
+type ReleaseEscrowRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ReferenceId    string                 `protobuf:"bytes,1,opt,name=reference_id,json=referenceId,proto3" json:"reference_id,omitempty"`
+	Amount         int64                  `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	IdempotencyKey string                 `protobuf:"bytes,3,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ReleaseEscrowRequest) Reset() {
+	*x = ReleaseEscrowRequest{}
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseEscrowRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseEscrowRequest) ProtoMessage() {}
+
+func (x *ReleaseEscrowRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseEscrowRequest.ProtoReflect.Descriptor instead.
+func (*ReleaseEscrowRequest) Descriptor() ([]byte, []int) {
+	return file_services_payments_service_proto_payments_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ReleaseEscrowRequest) GetReferenceId() string {
+	if x != nil {
+		return x.ReferenceId
+	}
+	return ""
+}
+
+func (x *ReleaseEscrowRequest) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *ReleaseEscrowRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+// Returns amount (0 for everything) of what an escrow intent holds in escrow to
+// the payer; it counts as a refund of the intent.
+type RefundEscrowRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ReferenceId    string                 `protobuf:"bytes,1,opt,name=reference_id,json=referenceId,proto3" json:"reference_id,omitempty"`
+	Amount         int64                  `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	Reason         string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	IdempotencyKey string                 `protobuf:"bytes,4,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RefundEscrowRequest) Reset() {
+	*x = RefundEscrowRequest{}
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefundEscrowRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefundEscrowRequest) ProtoMessage() {}
+
+func (x *RefundEscrowRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefundEscrowRequest.ProtoReflect.Descriptor instead.
+func (*RefundEscrowRequest) Descriptor() ([]byte, []int) {
+	return file_services_payments_service_proto_payments_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *RefundEscrowRequest) GetReferenceId() string {
+	if x != nil {
+		return x.ReferenceId
+	}
+	return ""
+}
+
+func (x *RefundEscrowRequest) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *RefundEscrowRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *RefundEscrowRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+type EscrowResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReferenceId   string                 `protobuf:"bytes,1,opt,name=reference_id,json=referenceId,proto3" json:"reference_id,omitempty"`
+	EscrowId      string                 `protobuf:"bytes,2,opt,name=escrow_id,json=escrowId,proto3" json:"escrow_id,omitempty"`          // id of the release or refund
+	Status        PaymentStatus          `protobuf:"varint,3,opt,name=status,proto3,enum=payments.PaymentStatus" json:"status,omitempty"` // status of the intent, or FAILED
+	Message       string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	Amount        int64                  `protobuf:"varint,5,opt,name=amount,proto3" json:"amount,omitempty"`                                 // taken out of escrow by this request
+	EscrowAmount  int64                  `protobuf:"varint,6,opt,name=escrow_amount,json=escrowAmount,proto3" json:"escrow_amount,omitempty"` // left in escrow
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EscrowResponse) Reset() {
+	*x = EscrowResponse{}
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EscrowResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EscrowResponse) ProtoMessage() {}
+
+func (x *EscrowResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EscrowResponse.ProtoReflect.Descriptor instead.
+func (*EscrowResponse) Descriptor() ([]byte, []int) {
+	return file_services_payments_service_proto_payments_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *EscrowResponse) GetReferenceId() string {
+	if x != nil {
+		return x.ReferenceId
+	}
+	return ""
+}
+
+func (x *EscrowResponse) GetEscrowId() string {
+	if x != nil {
+		return x.EscrowId
+	}
+	return ""
+}
+
+func (x *EscrowResponse) GetStatus() PaymentStatus {
+	if x != nil {
+		return x.Status
+	}
+	return PaymentStatus_UNKNOWN
+}
+
+func (x *EscrowResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *EscrowResponse) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *EscrowResponse) GetEscrowAmount() int64 {
+	if x != nil {
+		return x.EscrowAmount
+	}
+	return 0
+}
+
+// Cancels an AUTHORIZED intent and releases its hold. Canceling an intent that
+// is already canceled returns CANCELED again.
+type CancelPaymentIntentRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ReferenceId    string                 `protobuf:"bytes,1,opt,name=reference_id,json=referenceId,proto3" json:"reference_id,omitempty"`
+	ReasonCode     string                 `protobuf:"bytes,2,opt,name=reason_code,json=reasonCode,proto3" json:"reason_code,omitempty"` // e.g. CUSTOMER_REQUEST, DUPLICATE, FRAUD
+	IdempotencyKey string                 `protobuf:"bytes,3,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CancelPaymentIntentRequest) Reset() {
+	*x = CancelPaymentIntentRequest{}
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelPaymentIntentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelPaymentIntentRequest) ProtoMessage() {}
+
+func (x *CancelPaymentIntentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelPaymentIntentRequest.ProtoReflect.Descriptor instead.
+func (*CancelPaymentIntentRequest) Descriptor() ([]byte, []int) {
+	return file_services_payments_service_proto_payments_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *CancelPaymentIntentRequest) GetReferenceId() string {
+	if x != nil {
+		return x.ReferenceId
+	}
+	return ""
+}
+
+func (x *CancelPaymentIntentRequest) GetReasonCode() string {
+	if x != nil {
+		return x.ReasonCode
+	}
+	return ""
+}
+
+func (x *CancelPaymentIntentRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+type CancelPaymentIntentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReferenceId   string                 `protobuf:"bytes,1,opt,name=reference_id,json=referenceId,proto3" json:"reference_id,omitempty"`
+	Status        PaymentStatus          `protobuf:"varint,2,opt,name=status,proto3,enum=payments.PaymentStatus" json:"status,omitempty"` // CANCELED or FAILED
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelPaymentIntentResponse) Reset() {
+	*x = CancelPaymentIntentResponse{}
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelPaymentIntentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelPaymentIntentResponse) ProtoMessage() {}
+
+func (x *CancelPaymentIntentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelPaymentIntentResponse.ProtoReflect.Descriptor instead.
+func (*CancelPaymentIntentResponse) Descriptor() ([]byte, []int) {
+	return file_services_payments_service_proto_payments_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CancelPaymentIntentResponse) GetReferenceId() string {
+	if x != nil {
+		return x.ReferenceId
+	}
+	return ""
+}
+
+func (x *CancelPaymentIntentResponse) GetStatus() PaymentStatus {
+	if x != nil {
+		return x.Status
+	}
+	return PaymentStatus_UNKNOWN
+}
+
+func (x *CancelPaymentIntentResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Approves or declines an intent the risk rules held in PENDING_REVIEW. An
+// approved intent has its funds reserved and becomes AUTHORIZED, or FAILED when
+// they cannot be; a declined intent becomes FAILED.
+type ReviewPaymentIntentRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ReferenceId    string                 `protobuf:"bytes,1,opt,name=reference_id,json=referenceId,proto3" json:"reference_id,omitempty"`
+	Decision       string                 `protobuf:"bytes,2,opt,name=decision,proto3" json:"decision,omitempty"` // APPROVE or DECLINE
+	Reason         string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	IdempotencyKey string                 `protobuf:"bytes,4,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ReviewPaymentIntentRequest) Reset() {
+	*x = ReviewPaymentIntentRequest{}
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewPaymentIntentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewPaymentIntentRequest) ProtoMessage() {}
+
+func (x *ReviewPaymentIntentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewPaymentIntentRequest.ProtoReflect.Descriptor instead.
+func (*ReviewPaymentIntentRequest) Descriptor() ([]byte, []int) {
+	return file_services_payments_service_proto_payments_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ReviewPaymentIntentRequest) GetReferenceId() string {
+	if x != nil {
+		return x.ReferenceId
+	}
+	return ""
+}
+
+func (x *ReviewPaymentIntentRequest) GetDecision() string {
+	if x != nil {
+		return x.Decision
+	}
+	return ""
+}
+
+func (x *ReviewPaymentIntentRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *ReviewPaymentIntentRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+type ReviewPaymentIntentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReferenceId   string                 `protobuf:"bytes,1,opt,name=reference_id,json=referenceId,proto3" json:"reference_id,omitempty"`
+	Status        PaymentStatus          `protobuf:"varint,2,opt,name=status,proto3,enum=payments.PaymentStatus" json:"status,omitempty"` // AUTHORIZED or FAILED
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	ExpiresAt     int64                  `protobuf:"varint,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // unix seconds, for an AUTHORIZED intent
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReviewPaymentIntentResponse) Reset() {
+	*x = ReviewPaymentIntentResponse{}
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewPaymentIntentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewPaymentIntentResponse) ProtoMessage() {}
+
+func (x *ReviewPaymentIntentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewPaymentIntentResponse.ProtoReflect.Descriptor instead.
+func (*ReviewPaymentIntentResponse) Descriptor() ([]byte, []int) {
+	return file_services_payments_service_proto_payments_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ReviewPaymentIntentResponse) GetReferenceId() string {
+	if x != nil {
+		return x.ReferenceId
+	}
+	return ""
+}
+
+func (x *ReviewPaymentIntentResponse) GetStatus() PaymentStatus {
+	if x != nil {
+		return x.Status
+	}
+	return PaymentStatus_UNKNOWN
+}
+
+func (x *ReviewPaymentIntentResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ReviewPaymentIntentResponse) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+// A decision of the risk rules on a payment intent, made before its funds were
+// reserved. Intents that were denied are never stored, only their evaluation.
+type RiskEvaluation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ReferenceId   string                 `protobuf:"bytes,2,opt,name=reference_id,json=referenceId,proto3" json:"reference_id,omitempty"`
+	PayerId       string                 `protobuf:"bytes,3,opt,name=payer_id,json=payerId,proto3" json:"payer_id,omitempty"`
+	PayeeId       string                 `protobuf:"bytes,4,opt,name=payee_id,json=payeeId,proto3" json:"payee_id,omitempty"` // the payee, or the payee of the first leg
+	Amount        int64                  `protobuf:"varint,5,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency      string                 `protobuf:"bytes,6,opt,name=currency,proto3" json:"currency,omitempty"`
+	Decision      string                 `protobuf:"bytes,7,opt,name=decision,proto3" json:"decision,omitempty"` // ALLOW, REVIEW or DENY
+	Reasons       []string               `protobuf:"bytes,8,rep,name=reasons,proto3" json:"reasons,omitempty"`
+	RulesVersion  string                 `protobuf:"bytes,9,opt,name=rules_version,json=rulesVersion,proto3" json:"rules_version,omitempty"` // of the rules file, empty when no rules were loaded
+	CreatedAt     int64                  `protobuf:"varint,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RiskEvaluation) Reset() {
+	*x = RiskEvaluation{}
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RiskEvaluation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RiskEvaluation) ProtoMessage() {}
+
+func (x *RiskEvaluation) ProtoReflect() protoreflect.Message {
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RiskEvaluation.ProtoReflect.Descriptor instead.
+func (*RiskEvaluation) Descriptor() ([]byte, []int) {
+	return file_services_payments_service_proto_payments_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *RiskEvaluation) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *RiskEvaluation) GetReferenceId() string {
+	if x != nil {
+		return x.ReferenceId
+	}
+	return ""
+}
+
+func (x *RiskEvaluation) GetPayerId() string {
+	if x != nil {
+		return x.PayerId
+	}
+	return ""
+}
+
+func (x *RiskEvaluation) GetPayeeId() string {
+	if x != nil {
+		return x.PayeeId
+	}
+	return ""
+}
+
+func (x *RiskEvaluation) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *RiskEvaluation) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *RiskEvaluation) GetDecision() string {
+	if x != nil {
+		return x.Decision
+	}
+	return ""
+}
+
+func (x *RiskEvaluation) GetReasons() []string {
+	if x != nil {
+		return x.Reasons
+	}
+	return nil
+}
+
+func (x *RiskEvaluation) GetRulesVersion() string {
+	if x != nil {
+		return x.RulesVersion
+	}
+	return ""
+}
+
+func (x *RiskEvaluation) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+// Zero values do not filter; limit defaults to 50.
+type ListRiskEvaluationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReferenceId   string                 `protobuf:"bytes,1,opt,name=reference_id,json=referenceId,proto3" json:"reference_id,omitempty"`
+	PayerId       string                 `protobuf:"bytes,2,opt,name=payer_id,json=payerId,proto3" json:"payer_id,omitempty"`
+	Decision      string                 `protobuf:"bytes,3,opt,name=decision,proto3" json:"decision,omitempty"`
+	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRiskEvaluationsRequest) Reset() {
+	*x = ListRiskEvaluationsRequest{}
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRiskEvaluationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRiskEvaluationsRequest) ProtoMessage() {}
+
+func (x *ListRiskEvaluationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRiskEvaluationsRequest.ProtoReflect.Descriptor instead.
+func (*ListRiskEvaluationsRequest) Descriptor() ([]byte, []int) {
+	return file_services_payments_service_proto_payments_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListRiskEvaluationsRequest) GetReferenceId() string {
+	if x != nil {
+		return x.ReferenceId
+	}
+	return ""
+}
+
+func (x *ListRiskEvaluationsRequest) GetPayerId() string {
+	if x != nil {
+		return x.PayerId
+	}
+	return ""
+}
+
+func (x *ListRiskEvaluationsRequest) GetDecision() string {
+	if x != nil {
+		return x.Decision
+	}
+	return ""
+}
+
+func (x *ListRiskEvaluationsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListRiskEvaluationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Evaluations   []*RiskEvaluation      `protobuf:"bytes,1,rep,name=evaluations,proto3" json:"evaluations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRiskEvaluationsResponse) Reset() {
+	*x = ListRiskEvaluationsResponse{}
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRiskEvaluationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRiskEvaluationsResponse) ProtoMessage() {}
+
+func (x *ListRiskEvaluationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRiskEvaluationsResponse.ProtoReflect.Descriptor instead.
+func (*ListRiskEvaluationsResponse) Descriptor() ([]byte, []int) {
+	return file_services_payments_service_proto_payments_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListRiskEvaluationsResponse) GetEvaluations() []*RiskEvaluation {
+	if x != nil {
+		return x.Evaluations
+	}
+	return nil
+}
+
+// Amounts are minor units; payer side in currency, payee side in payee_currency.
+// Timestamps are unix seconds.
+type Payment struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ReferenceId     string                 `protobuf:"bytes,1,opt,name=reference_id,json=referenceId,proto3" json:"reference_id,omitempty"`
+	PayerId         string                 `protobuf:"bytes,2,opt,name=payer_id,json=payerId,proto3" json:"payer_id,omitempty"`
+	PayeeId         string                 `protobuf:"bytes,3,opt,name=payee_id,json=payeeId,proto3" json:"payee_id,omitempty"`
+	Amount          int64                  `protobuf:"varint,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency        string                 `protobuf:"bytes,5,opt,name=currency,proto3" json:"currency,omitempty"`
+	PayeeAmount     int64                  `protobuf:"varint,6,opt,name=payee_amount,json=payeeAmount,proto3" json:"payee_amount,omitempty"`
+	PayeeCurrency   string                 `protobuf:"bytes,7,opt,name=payee_currency,json=payeeCurrency,proto3" json:"payee_currency,omitempty"`
+	QuoteId         string                 `protobuf:"bytes,8,opt,name=quote_id,json=quoteId,proto3" json:"quote_id,omitempty"`
+	Status          PaymentStatus          `protobuf:"varint,9,opt,name=status,proto3,enum=payments.PaymentStatus" json:"status,omitempty"`
+	CapturedAmount  int64                  `protobuf:"varint,10,opt,name=captured_amount,json=capturedAmount,proto3" json:"captured_amount,omitempty"`
+	RefundedAmount  int64                  `protobuf:"varint,11,opt,name=refunded_amount,json=refundedAmount,proto3" json:"refunded_amount,omitempty"`
+	CancelReason    string                 `protobuf:"bytes,12,opt,name=cancel_reason,json=cancelReason,proto3" json:"cancel_reason,omitempty"`
+	ExpiresAt       int64                  `protobuf:"varint,13,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	CreatedAt       int64                  `protobuf:"varint,14,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt       int64                  `protobuf:"varint,15,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Legs            []*PaymentLeg          `protobuf:"bytes,16,rep,name=legs,proto3" json:"legs,omitempty"` // payees of a split payment with their amounts
+	Escrow          bool                   `protobuf:"varint,17,opt,name=escrow,proto3" json:"escrow,omitempty"`
+	EscrowAmount    int64                  `protobuf:"varint,18,opt,name=escrow_amount,json=escrowAmount,proto3" json:"escrow_amount,omitempty"`            // captured and held in escrow now
+	EscrowReleaseAt int64                  `protobuf:"varint,19,opt,name=escrow_release_at,json=escrowReleaseAt,proto3" json:"escrow_release_at,omitempty"` // unix seconds, 0 when only released on request
+	DisputedAmount  int64                  `protobuf:"varint,20,opt,name=disputed_amount,json=disputedAmount,proto3" json:"disputed_amount,omitempty"`      // held from the payee for open disputes
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Payment) Reset() {
+	*x = Payment{}
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Payment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Payment) ProtoMessage() {}
+
+func (x *Payment) ProtoReflect() protoreflect.Message {
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Payment.ProtoReflect.Descriptor instead.
+func (*Payment) Descriptor() ([]byte, []int) {
+	return file_services_payments_service_proto_payments_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *Payment) GetReferenceId() string {
+	if x != nil {
+		return x.ReferenceId
+	}
+	return ""
+}
+
+func (x *Payment) GetPayerId() string {
+	if x != nil {
+		return x.PayerId
+	}
+	return ""
+}
+
+func (x *Payment) GetPayeeId() string {
+	if x != nil {
+		return x.PayeeId
+	}
+	return ""
+}
+
+func (x *Payment) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Payment) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Payment) GetPayeeAmount() int64 {
+	if x != nil {
+		return x.PayeeAmount
+	}
+	return 0
+}
+
+func (x *Payment) GetPayeeCurrency() string {
+	if x != nil {
+		return x.PayeeCurrency
+	}
+	return ""
+}
+
+func (x *Payment) GetQuoteId() string {
+	if x != nil {
+		return x.QuoteId
+	}
+	return ""
+}
+
+func (x *Payment) GetStatus() PaymentStatus {
+	if x != nil {
+		return x.Status
+	}
+	return PaymentStatus_UNKNOWN
+}
+
+func (x *Payment) GetCapturedAmount() int64 {
+	if x != nil {
+		return x.CapturedAmount
+	}
+	return 0
+}
+
+func (x *Payment) GetRefundedAmount() int64 {
+	if x != nil {
+		return x.RefundedAmount
+	}
+	return 0
+}
+
+func (x *Payment) GetCancelReason() string {
+	if x != nil {
+		return x.CancelReason
+	}
+	return ""
+}
+
+func (x *Payment) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+func (x *Payment) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *Payment) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+func (x *Payment) GetLegs() []*PaymentLeg {
+	if x != nil {
+		return x.Legs
+	}
+	return nil
+}
+
+func (x *Payment) GetEscrow() bool {
+	if x != nil {
+		return x.Escrow
+	}
+	return false
+}
+
+func (x *Payment) GetEscrowAmount() int64 {
+	if x != nil {
+		return x.EscrowAmount
+	}
+	return 0
+}
+
+func (x *Payment) GetEscrowReleaseAt() int64 {
+	if x != nil {
+		return x.EscrowReleaseAt
+	}
+	return 0
+}
+
+func (x *Payment) GetDisputedAmount() int64 {
+	if x != nil {
+		return x.DisputedAmount
+	}
+	return 0
+}
+
+// A DEBIT or CREDIT row of a capture or refund; capture_id is the capture or refund id.
+type PaymentTransaction struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	CaptureId     string                 `protobuf:"bytes,2,opt,name=capture_id,json=captureId,proto3" json:"capture_id,omitempty"`
+	AccountId     string                 `protobuf:"bytes,3,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	TxnType       string                 `protobuf:"bytes,4,opt,name=txn_type,json=txnType,proto3" json:"txn_type,omitempty"`
+	Amount        int64                  `protobuf:"varint,5,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency      string                 `protobuf:"bytes,6,opt,name=currency,proto3" json:"currency,omitempty"`
+	Status        string                 `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PaymentTransaction) Reset() {
+	*x = PaymentTransaction{}
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PaymentTransaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaymentTransaction) ProtoMessage() {}
+
+func (x *PaymentTransaction) ProtoReflect() protoreflect.Message {
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PaymentTransaction.ProtoReflect.Descriptor instead.
+func (*PaymentTransaction) Descriptor() ([]byte, []int) {
+	return file_services_payments_service_proto_payments_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *PaymentTransaction) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *PaymentTransaction) GetCaptureId() string {
+	if x != nil {
+		return x.CaptureId
+	}
+	return ""
+}
+
+func (x *PaymentTransaction) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *PaymentTransaction) GetTxnType() string {
+	if x != nil {
+		return x.TxnType
+	}
+	return ""
+}
+
+func (x *PaymentTransaction) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *PaymentTransaction) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *PaymentTransaction) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *PaymentTransaction) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+type OutboxEventStatus struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	EventType     string                 `protobuf:"bytes,2,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"` // PENDING, PUBLISHED or FAILED
+	RetryCount    int32                  `protobuf:"varint,4,opt,name=retry_count,json=retryCount,proto3" json:"retry_count,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     int64                  `protobuf:"varint,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OutboxEventStatus) Reset() {
+	*x = OutboxEventStatus{}
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OutboxEventStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OutboxEventStatus) ProtoMessage() {}
+
+func (x *OutboxEventStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OutboxEventStatus.ProtoReflect.Descriptor instead.
+func (*OutboxEventStatus) Descriptor() ([]byte, []int) {
+	return file_services_payments_service_proto_payments_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *OutboxEventStatus) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *OutboxEventStatus) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
+}
+
+func (x *OutboxEventStatus) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *OutboxEventStatus) GetRetryCount() int32 {
+	if x != nil {
+		return x.RetryCount
+	}
+	return 0
+}
+
+func (x *OutboxEventStatus) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *OutboxEventStatus) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+// One status change of a payment; from_status is empty for the first one.
+type StatusTransition struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FromStatus    string                 `protobuf:"bytes,1,opt,name=from_status,json=fromStatus,proto3" json:"from_status,omitempty"`
+	ToStatus      string                 `protobuf:"bytes,2,opt,name=to_status,json=toStatus,proto3" json:"to_status,omitempty"`
+	Actor         string                 `protobuf:"bytes,3,opt,name=actor,proto3" json:"actor,omitempty"`
+	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StatusTransition) Reset() {
+	*x = StatusTransition{}
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatusTransition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatusTransition) ProtoMessage() {}
+
+func (x *StatusTransition) ProtoReflect() protoreflect.Message {
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatusTransition.ProtoReflect.Descriptor instead.
+func (*StatusTransition) Descriptor() ([]byte, []int) {
+	return file_services_payments_service_proto_payments_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *StatusTransition) GetFromStatus() string {
+	if x != nil {
+		return x.FromStatus
+	}
+	return ""
+}
+
+func (x *StatusTransition) GetToStatus() string {
+	if x != nil {
+		return x.ToStatus
+	}
+	return ""
+}
+
+func (x *StatusTransition) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *StatusTransition) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *StatusTransition) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+type GetPaymentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReferenceId   string                 `protobuf:"bytes,1,opt,name=reference_id,json=referenceId,proto3" json:"reference_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPaymentRequest) Reset() {
+	*x = GetPaymentRequest{}
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPaymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPaymentRequest) ProtoMessage() {}
+
+func (x *GetPaymentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPaymentRequest.ProtoReflect.Descriptor instead.
+func (*GetPaymentRequest) Descriptor() ([]byte, []int) {
+	return file_services_payments_service_proto_payments_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetPaymentRequest) GetReferenceId() string {
+	if x != nil {
+		return x.ReferenceId
+	}
+	return ""
+}
+
+type GetPaymentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Payment       *Payment               `protobuf:"bytes,1,opt,name=payment,proto3" json:"payment,omitempty"`
+	Transactions  []*PaymentTransaction  `protobuf:"bytes,2,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	Events        []*OutboxEventStatus   `protobuf:"bytes,3,rep,name=events,proto3" json:"events,omitempty"`
+	History       []*StatusTransition    `protobuf:"bytes,4,rep,name=history,proto3" json:"history,omitempty"` // oldest first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPaymentResponse) Reset() {
+	*x = GetPaymentResponse{}
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPaymentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPaymentResponse) ProtoMessage() {}
+
+func (x *GetPaymentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPaymentResponse.ProtoReflect.Descriptor instead.
+func (*GetPaymentResponse) Descriptor() ([]byte, []int) {
+	return file_services_payments_service_proto_payments_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetPaymentResponse) GetPayment() *Payment {
+	if x != nil {
+		return x.Payment
+	}
+	return nil
+}
+
+func (x *GetPaymentResponse) GetTransactions() []*PaymentTransaction {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+func (x *GetPaymentResponse) GetEvents() []*OutboxEventStatus {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *GetPaymentResponse) GetHistory() []*StatusTransition {
+	if x != nil {
+		return x.History
+	}
+	return nil
+}
+
+// Zero values do not filter. created_from/created_to is [from, to) in unix seconds.
+type ListPaymentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PayerId       string                 `protobuf:"bytes,1,opt,name=payer_id,json=payerId,proto3" json:"payer_id,omitempty"`
+	PayeeId       string                 `protobuf:"bytes,2,opt,name=payee_id,json=payeeId,proto3" json:"payee_id,omitempty"`
+	Status        PaymentStatus          `protobuf:"varint,3,opt,name=status,proto3,enum=payments.PaymentStatus" json:"status,omitempty"`
+	MinAmount     int64                  `protobuf:"varint,4,opt,name=min_amount,json=minAmount,proto3" json:"min_amount,omitempty"`
+	MaxAmount     int64                  `protobuf:"varint,5,opt,name=max_amount,json=maxAmount,proto3" json:"max_amount,omitempty"`
+	CreatedFrom   int64                  `protobuf:"varint,6,opt,name=created_from,json=createdFrom,proto3" json:"created_from,omitempty"`
+	CreatedTo     int64                  `protobuf:"varint,7,opt,name=created_to,json=createdTo,proto3" json:"created_to,omitempty"`
+	PageToken     string                 `protobuf:"bytes,8,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	PageSize      int32                  `protobuf:"varint,9,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"` // defaults to 50, max 500
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPaymentsRequest) Reset() {
+	*x = ListPaymentsRequest{}
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPaymentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPaymentsRequest) ProtoMessage() {}
+
+func (x *ListPaymentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPaymentsRequest.ProtoReflect.Descriptor instead.
+func (*ListPaymentsRequest) Descriptor() ([]byte, []int) {
+	return file_services_payments_service_proto_payments_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ListPaymentsRequest) GetPayerId() string {
+	if x != nil {
+		return x.PayerId
+	}
+	return ""
+}
+
+func (x *ListPaymentsRequest) GetPayeeId() string {
+	if x != nil {
+		return x.PayeeId
+	}
+	return ""
+}
+
+func (x *ListPaymentsRequest) GetStatus() PaymentStatus {
+	if x != nil {
+		return x.Status
+	}
+	return PaymentStatus_UNKNOWN
+}
+
+func (x *ListPaymentsRequest) GetMinAmount() int64 {
+	if x != nil {
+		return x.MinAmount
+	}
+	return 0
+}
+
+func (x *ListPaymentsRequest) GetMaxAmount() int64 {
+	if x != nil {
+		return x.MaxAmount
+	}
+	return 0
+}
+
+func (x *ListPaymentsRequest) GetCreatedFrom() int64 {
+	if x != nil {
+		return x.CreatedFrom
+	}
+	return 0
+}
+
+func (x *ListPaymentsRequest) GetCreatedTo() int64 {
+	if x != nil {
+		return x.CreatedTo
+	}
+	return 0
+}
+
+func (x *ListPaymentsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListPaymentsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type ListPaymentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Payments      []*Payment             `protobuf:"bytes,1,rep,name=payments,proto3" json:"payments,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPaymentsResponse) Reset() {
+	*x = ListPaymentsResponse{}
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPaymentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPaymentsResponse) ProtoMessage() {}
+
+func (x *ListPaymentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPaymentsResponse.ProtoReflect.Descriptor instead.
+func (*ListPaymentsResponse) Descriptor() ([]byte, []int) {
+	return file_services_payments_service_proto_payments_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ListPaymentsResponse) GetPayments() []*Payment {
+	if x != nil {
+		return x.Payments
+	}
+	return nil
+}
+
+func (x *ListPaymentsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// event_types is a subset of PAYMENT_AUTHORIZED, PAYMENT_CAPTURED,
+// PAYMENT_REFUNDED, PAYMENT_CANCELED and PAYMENT_SETTLED; empty subscribes to all.
+type RegisterWebhookEndpointRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	AccountId      string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"` // the payee whose payments are notified
+	Url            string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	EventTypes     []string               `protobuf:"bytes,3,rep,name=event_types,json=eventTypes,proto3" json:"event_types,omitempty"`
+	IdempotencyKey string                 `protobuf:"bytes,4,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RegisterWebhookEndpointRequest) Reset() {
+	*x = RegisterWebhookEndpointRequest{}
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterWebhookEndpointRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterWebhookEndpointRequest) ProtoMessage() {}
+
+func (x *RegisterWebhookEndpointRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterWebhookEndpointRequest.ProtoReflect.Descriptor instead.
+func (*RegisterWebhookEndpointRequest) Descriptor() ([]byte, []int) {
+	return file_services_payments_service_proto_payments_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *RegisterWebhookEndpointRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *RegisterWebhookEndpointRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *RegisterWebhookEndpointRequest) GetEventTypes() []string {
+	if x != nil {
+		return x.EventTypes
+	}
+	return nil
+}
+
+func (x *RegisterWebhookEndpointRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+type WebhookEndpoint struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	AccountId     string                 `protobuf:"bytes,2,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Url           string                 `protobuf:"bytes,3,opt,name=url,proto3" json:"url,omitempty"`
+	EventTypes    []string               `protobuf:"bytes,4,rep,name=event_types,json=eventTypes,proto3" json:"event_types,omitempty"`
+	Active        bool                   `protobuf:"varint,5,opt,name=active,proto3" json:"active,omitempty"`
+	Secret        string                 `protobuf:"bytes,6,opt,name=secret,proto3" json:"secret,omitempty"` // signing secret, only returned by RegisterWebhookEndpoint
+	CreatedAt     int64                  `protobuf:"varint,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebhookEndpoint) Reset() {
+	*x = WebhookEndpoint{}
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookEndpoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookEndpoint) ProtoMessage() {}
+
+func (x *WebhookEndpoint) ProtoReflect() protoreflect.Message {
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookEndpoint.ProtoReflect.Descriptor instead.
+func (*WebhookEndpoint) Descriptor() ([]byte, []int) {
+	return file_services_payments_service_proto_payments_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *WebhookEndpoint) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *WebhookEndpoint) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *WebhookEndpoint) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *WebhookEndpoint) GetEventTypes() []string {
+	if x != nil {
+		return x.EventTypes
+	}
+	return nil
+}
+
+func (x *WebhookEndpoint) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+func (x *WebhookEndpoint) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *WebhookEndpoint) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+type ListWebhookEndpointsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWebhookEndpointsRequest) Reset() {
+	*x = ListWebhookEndpointsRequest{}
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebhookEndpointsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebhookEndpointsRequest) ProtoMessage() {}
+
+func (x *ListWebhookEndpointsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWebhookEndpointsRequest.ProtoReflect.Descriptor instead.
+func (*ListWebhookEndpointsRequest) Descriptor() ([]byte, []int) {
+	return file_services_payments_service_proto_payments_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ListWebhookEndpointsRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+type ListWebhookEndpointsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Endpoints     []*WebhookEndpoint     `protobuf:"bytes,1,rep,name=endpoints,proto3" json:"endpoints,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWebhookEndpointsResponse) Reset() {
+	*x = ListWebhookEndpointsResponse{}
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebhookEndpointsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebhookEndpointsResponse) ProtoMessage() {}
+
+func (x *ListWebhookEndpointsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWebhookEndpointsResponse.ProtoReflect.Descriptor instead.
+func (*ListWebhookEndpointsResponse) Descriptor() ([]byte, []int) {
+	return file_services_payments_service_proto_payments_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ListWebhookEndpointsResponse) GetEndpoints() []*WebhookEndpoint {
+	if x != nil {
+		return x.Endpoints
+	}
+	return nil
+}
+
+// Disabled endpoints get no new deliveries.
+type DisableWebhookEndpointRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EndpointId    string                 `protobuf:"bytes,1,opt,name=endpoint_id,json=endpointId,proto3" json:"endpoint_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisableWebhookEndpointRequest) Reset() {
+	*x = DisableWebhookEndpointRequest{}
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisableWebhookEndpointRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisableWebhookEndpointRequest) ProtoMessage() {}
+
+func (x *DisableWebhookEndpointRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisableWebhookEndpointRequest.ProtoReflect.Descriptor instead.
+func (*DisableWebhookEndpointRequest) Descriptor() ([]byte, []int) {
+	return file_services_payments_service_proto_payments_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *DisableWebhookEndpointRequest) GetEndpointId() string {
+	if x != nil {
+		return x.EndpointId
+	}
+	return ""
+}
+
+type WebhookAttempt struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Attempt       int32                  `protobuf:"varint,1,opt,name=attempt,proto3" json:"attempt,omitempty"`
+	StatusCode    int32                  `protobuf:"varint,2,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"` // 0 when the endpoint did not respond
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	DurationMs    int64                  `protobuf:"varint,4,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebhookAttempt) Reset() {
+	*x = WebhookAttempt{}
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookAttempt) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookAttempt) ProtoMessage() {}
+
+func (x *WebhookAttempt) ProtoReflect() protoreflect.Message {
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookAttempt.ProtoReflect.Descriptor instead.
+func (*WebhookAttempt) Descriptor() ([]byte, []int) {
+	return file_services_payments_service_proto_payments_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *WebhookAttempt) GetAttempt() int32 {
+	if x != nil {
+		return x.Attempt
+	}
+	return 0
+}
+
+func (x *WebhookAttempt) GetStatusCode() int32 {
+	if x != nil {
+		return x.StatusCode
+	}
+	return 0
+}
+
+func (x *WebhookAttempt) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *WebhookAttempt) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+func (x *WebhookAttempt) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+// Timestamps are unix seconds.
+type WebhookDelivery struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	EndpointId     string                 `protobuf:"bytes,2,opt,name=endpoint_id,json=endpointId,proto3" json:"endpoint_id,omitempty"`
+	EventId        string                 `protobuf:"bytes,3,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	EventType      string                 `protobuf:"bytes,4,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
+	ReferenceId    string                 `protobuf:"bytes,5,opt,name=reference_id,json=referenceId,proto3" json:"reference_id,omitempty"`
+	Status         string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"` // PENDING, DELIVERED or FAILED
+	Attempts       int32                  `protobuf:"varint,7,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	LastStatusCode int32                  `protobuf:"varint,8,opt,name=last_status_code,json=lastStatusCode,proto3" json:"last_status_code,omitempty"`
+	LastError      string                 `protobuf:"bytes,9,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	NextAttemptAt  int64                  `protobuf:"varint,10,opt,name=next_attempt_at,json=nextAttemptAt,proto3" json:"next_attempt_at,omitempty"`
+	ExpiresAt      int64                  `protobuf:"varint,11,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // retries stop after this
+	CreatedAt      int64                  `protobuf:"varint,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Payload        string                 `protobuf:"bytes,13,opt,name=payload,proto3" json:"payload,omitempty"`                         // the JSON body sent
+	AttemptLog     []*WebhookAttempt      `protobuf:"bytes,14,rep,name=attempt_log,json=attemptLog,proto3" json:"attempt_log,omitempty"` // only returned by GetWebhookDelivery
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *WebhookDelivery) Reset() {
+	*x = WebhookDelivery{}
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookDelivery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookDelivery) ProtoMessage() {}
+
+func (x *WebhookDelivery) ProtoReflect() protoreflect.Message {
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookDelivery.ProtoReflect.Descriptor instead.
+func (*WebhookDelivery) Descriptor() ([]byte, []int) {
+	return file_services_payments_service_proto_payments_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *WebhookDelivery) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *WebhookDelivery) GetEndpointId() string {
+	if x != nil {
+		return x.EndpointId
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetReferenceId() string {
+	if x != nil {
+		return x.ReferenceId
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *WebhookDelivery) GetLastStatusCode() int32 {
+	if x != nil {
+		return x.LastStatusCode
+	}
+	return 0
+}
+
+func (x *WebhookDelivery) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetNextAttemptAt() int64 {
+	if x != nil {
+		return x.NextAttemptAt
+	}
+	return 0
+}
+
+func (x *WebhookDelivery) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+func (x *WebhookDelivery) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *WebhookDelivery) GetPayload() string {
+	if x != nil {
+		return x.Payload
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetAttemptLog() []*WebhookAttempt {
+	if x != nil {
+		return x.AttemptLog
+	}
+	return nil
+}
+
+// Zero values do not filter; limit defaults to 50.
+type ListWebhookDeliveriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EndpointId    string                 `protobuf:"bytes,1,opt,name=endpoint_id,json=endpointId,proto3" json:"endpoint_id,omitempty"`
+	ReferenceId   string                 `protobuf:"bytes,2,opt,name=reference_id,json=referenceId,proto3" json:"reference_id,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWebhookDeliveriesRequest) Reset() {
+	*x = ListWebhookDeliveriesRequest{}
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebhookDeliveriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebhookDeliveriesRequest) ProtoMessage() {}
+
+func (x *ListWebhookDeliveriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWebhookDeliveriesRequest.ProtoReflect.Descriptor instead.
+func (*ListWebhookDeliveriesRequest) Descriptor() ([]byte, []int) {
+	return file_services_payments_service_proto_payments_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ListWebhookDeliveriesRequest) GetEndpointId() string {
+	if x != nil {
+		return x.EndpointId
+	}
+	return ""
+}
+
+func (x *ListWebhookDeliveriesRequest) GetReferenceId() string {
+	if x != nil {
+		return x.ReferenceId
+	}
+	return ""
+}
+
+func (x *ListWebhookDeliveriesRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListWebhookDeliveriesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListWebhookDeliveriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deliveries    []*WebhookDelivery     `protobuf:"bytes,1,rep,name=deliveries,proto3" json:"deliveries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWebhookDeliveriesResponse) Reset() {
+	*x = ListWebhookDeliveriesResponse{}
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebhookDeliveriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebhookDeliveriesResponse) ProtoMessage() {}
+
+func (x *ListWebhookDeliveriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWebhookDeliveriesResponse.ProtoReflect.Descriptor instead.
+func (*ListWebhookDeliveriesResponse) Descriptor() ([]byte, []int) {
+	return file_services_payments_service_proto_payments_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ListWebhookDeliveriesResponse) GetDeliveries() []*WebhookDelivery {
+	if x != nil {
+		return x.Deliveries
+	}
+	return nil
+}
+
+type GetWebhookDeliveryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeliveryId    int64                  `protobuf:"varint,1,opt,name=delivery_id,json=deliveryId,proto3" json:"delivery_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWebhookDeliveryRequest) Reset() {
+	*x = GetWebhookDeliveryRequest{}
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWebhookDeliveryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWebhookDeliveryRequest) ProtoMessage() {}
+
+func (x *GetWebhookDeliveryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWebhookDeliveryRequest.ProtoReflect.Descriptor instead.
+func (*GetWebhookDeliveryRequest) Descriptor() ([]byte, []int) {
+	return file_services_payments_service_proto_payments_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *GetWebhookDeliveryRequest) GetDeliveryId() int64 {
+	if x != nil {
+		return x.DeliveryId
+	}
+	return 0
+}
+
+// Sends a delivery again, whatever its status, with the same event id.
+type ReplayWebhookDeliveryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeliveryId    int64                  `protobuf:"varint,1,opt,name=delivery_id,json=deliveryId,proto3" json:"delivery_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplayWebhookDeliveryRequest) Reset() {
+	*x = ReplayWebhookDeliveryRequest{}
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplayWebhookDeliveryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplayWebhookDeliveryRequest) ProtoMessage() {}
+
+func (x *ReplayWebhookDeliveryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplayWebhookDeliveryRequest.ProtoReflect.Descriptor instead.
+func (*ReplayWebhookDeliveryRequest) Descriptor() ([]byte, []int) {
+	return file_services_payments_service_proto_payments_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ReplayWebhookDeliveryRequest) GetDeliveryId() int64 {
+	if x != nil {
+		return x.DeliveryId
+	}
+	return 0
+}
+
+// A payout file has one row per payout with payer_id, payee_id, amount (minor
+// units), an optional currency (the payer's by default) and a reference that is
+// unique across all payouts and becomes the reference_id of the payment. A CSV
+// file starts with a header row naming the columns; a JSONL file has one JSON
+// object per line.
+type SubmitPayoutBatchRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Format         string                 `protobuf:"bytes,1,opt,name=format,proto3" json:"format,omitempty"` // CSV or JSONL
+	File           string                 `protobuf:"bytes,2,opt,name=file,proto3" json:"file,omitempty"`
+	IdempotencyKey string                 `protobuf:"bytes,3,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SubmitPayoutBatchRequest) Reset() {
+	*x = SubmitPayoutBatchRequest{}
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitPayoutBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitPayoutBatchRequest) ProtoMessage() {}
+
+func (x *SubmitPayoutBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitPayoutBatchRequest.ProtoReflect.Descriptor instead.
+func (*SubmitPayoutBatchRequest) Descriptor() ([]byte, []int) {
+	return file_services_payments_service_proto_payments_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *SubmitPayoutBatchRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *SubmitPayoutBatchRequest) GetFile() string {
+	if x != nil {
+		return x.File
+	}
+	return ""
+}
+
+func (x *SubmitPayoutBatchRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+type GetPayoutBatchRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	BatchId           string                 `protobuf:"bytes,1,opt,name=batch_id,json=batchId,proto3" json:"batch_id,omitempty"`
+	IncludeResultFile bool                   `protobuf:"varint,2,opt,name=include_result_file,json=includeResultFile,proto3" json:"include_result_file,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *GetPayoutBatchRequest) Reset() {
+	*x = GetPayoutBatchRequest{}
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPayoutBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPayoutBatchRequest) ProtoMessage() {}
+
+func (x *GetPayoutBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPayoutBatchRequest.ProtoReflect.Descriptor instead.
+func (*GetPayoutBatchRequest) Descriptor() ([]byte, []int) {
+	return file_services_payments_service_proto_payments_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *GetPayoutBatchRequest) GetBatchId() string {
+	if x != nil {
+		return x.BatchId
+	}
+	return ""
+}
+
+func (x *GetPayoutBatchRequest) GetIncludeResultFile() bool {
+	if x != nil {
+		return x.IncludeResultFile
+	}
+	return false
+}
+
+type PayoutBatch struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BatchId       string                 `protobuf:"bytes,1,opt,name=batch_id,json=batchId,proto3" json:"batch_id,omitempty"`
+	Format        string                 `protobuf:"bytes,2,opt,name=format,proto3" json:"format,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"` // PROCESSING or COMPLETED
+	TotalRows     int32                  `protobuf:"varint,4,opt,name=total_rows,json=totalRows,proto3" json:"total_rows,omitempty"`
+	PendingRows   int32                  `protobuf:"varint,5,opt,name=pending_rows,json=pendingRows,proto3" json:"pending_rows,omitempty"`
+	SucceededRows int32                  `protobuf:"varint,6,opt,name=succeeded_rows,json=succeededRows,proto3" json:"succeeded_rows,omitempty"`
+	FailedRows    int32                  `protobuf:"varint,7,opt,name=failed_rows,json=failedRows,proto3" json:"failed_rows,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	CompletedAt   int64                  `protobuf:"varint,9,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"` // 0 while PROCESSING
+	// CSV of every row with its status, capture_id and failure message; only
+	// returned by GetPayoutBatch with include_result_file
+	ResultFile    string `protobuf:"bytes,10,opt,name=result_file,json=resultFile,proto3" json:"result_file,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PayoutBatch) Reset() {
+	*x = PayoutBatch{}
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PayoutBatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PayoutBatch) ProtoMessage() {}
+
+func (x *PayoutBatch) ProtoReflect() protoreflect.Message {
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PayoutBatch.ProtoReflect.Descriptor instead.
+func (*PayoutBatch) Descriptor() ([]byte, []int) {
+	return file_services_payments_service_proto_payments_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *PayoutBatch) GetBatchId() string {
+	if x != nil {
+		return x.BatchId
+	}
+	return ""
+}
+
+func (x *PayoutBatch) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *PayoutBatch) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *PayoutBatch) GetTotalRows() int32 {
+	if x != nil {
+		return x.TotalRows
+	}
+	return 0
+}
+
+func (x *PayoutBatch) GetPendingRows() int32 {
+	if x != nil {
+		return x.PendingRows
+	}
+	return 0
+}
+
+func (x *PayoutBatch) GetSucceededRows() int32 {
+	if x != nil {
+		return x.SucceededRows
+	}
+	return 0
+}
+
+func (x *PayoutBatch) GetFailedRows() int32 {
+	if x != nil {
+		return x.FailedRows
+	}
+	return 0
+}
+
+func (x *PayoutBatch) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *PayoutBatch) GetCompletedAt() int64 {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return 0
+}
+
+func (x *PayoutBatch) GetResultFile() string {
+	if x != nil {
+		return x.ResultFile
+	}
+	return ""
+}
+
+// Opens a dispute (chargeback) over amount (0 for everything disputable) of a
+// captured payment: what the payee received and has not refunded. The amount is
+// taken from the payee into a dispute hold until the dispute is resolved. Split
+// payments cannot be disputed.
+type OpenDisputeRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ReferenceId    string                 `protobuf:"bytes,1,opt,name=reference_id,json=referenceId,proto3" json:"reference_id,omitempty"`
+	Amount         int64                  `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	Reason         string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"` // e.g. the card network reason code
+	IdempotencyKey string                 `protobuf:"bytes,4,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *OpenDisputeRequest) Reset() {
+	*x = OpenDisputeRequest{}
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OpenDisputeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OpenDisputeRequest) ProtoMessage() {}
+
+func (x *OpenDisputeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OpenDisputeRequest.ProtoReflect.Descriptor instead.
+func (*OpenDisputeRequest) Descriptor() ([]byte, []int) {
+	return file_services_payments_service_proto_payments_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *OpenDisputeRequest) GetReferenceId() string {
+	if x != nil {
+		return x.ReferenceId
+	}
+	return ""
+}
+
+func (x *OpenDisputeRequest) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *OpenDisputeRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *OpenDisputeRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+// Adds evidence to an OPEN or UNDER_REVIEW dispute, which is then UNDER_REVIEW.
+type SubmitDisputeEvidenceRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	DisputeId      string                 `protobuf:"bytes,1,opt,name=dispute_id,json=disputeId,proto3" json:"dispute_id,omitempty"`
+	Description    string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Content        string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"` // the evidence itself or a link to it
+	IdempotencyKey string                 `protobuf:"bytes,4,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SubmitDisputeEvidenceRequest) Reset() {
+	*x = SubmitDisputeEvidenceRequest{}
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitDisputeEvidenceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitDisputeEvidenceRequest) ProtoMessage() {}
+
+func (x *SubmitDisputeEvidenceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitDisputeEvidenceRequest.ProtoReflect.Descriptor instead.
+func (*SubmitDisputeEvidenceRequest) Descriptor() ([]byte, []int) {
+	return file_services_payments_service_proto_payments_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *SubmitDisputeEvidenceRequest) GetDisputeId() string {
+	if x != nil {
+		return x.DisputeId
+	}
+	return ""
+}
+
+func (x *SubmitDisputeEvidenceRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *SubmitDisputeEvidenceRequest) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *SubmitDisputeEvidenceRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+// Resolves an OPEN or UNDER_REVIEW dispute. WON returns the held amount to the
+// payee; LOST returns it to the payer and counts as a refund of the payment.
+type ResolveDisputeRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	DisputeId      string                 `protobuf:"bytes,1,opt,name=dispute_id,json=disputeId,proto3" json:"dispute_id,omitempty"`
+	Outcome        string                 `protobuf:"bytes,2,opt,name=outcome,proto3" json:"outcome,omitempty"` // WON or LOST
+	IdempotencyKey string                 `protobuf:"bytes,3,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ResolveDisputeRequest) Reset() {
+	*x = ResolveDisputeRequest{}
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveDisputeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveDisputeRequest) ProtoMessage() {}
+
+func (x *ResolveDisputeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveDisputeRequest.ProtoReflect.Descriptor instead.
+func (*ResolveDisputeRequest) Descriptor() ([]byte, []int) {
+	return file_services_payments_service_proto_payments_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ResolveDisputeRequest) GetDisputeId() string {
+	if x != nil {
+		return x.DisputeId
+	}
+	return ""
+}
+
+func (x *ResolveDisputeRequest) GetOutcome() string {
+	if x != nil {
+		return x.Outcome
+	}
+	return ""
+}
+
+func (x *ResolveDisputeRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+type GetDisputeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DisputeId     string                 `protobuf:"bytes,1,opt,name=dispute_id,json=disputeId,proto3" json:"dispute_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDisputeRequest) Reset() {
+	*x = GetDisputeRequest{}
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDisputeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDisputeRequest) ProtoMessage() {}
+
+func (x *GetDisputeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDisputeRequest.ProtoReflect.Descriptor instead.
+func (*GetDisputeRequest) Descriptor() ([]byte, []int) {
+	return file_services_payments_service_proto_payments_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *GetDisputeRequest) GetDisputeId() string {
+	if x != nil {
+		return x.DisputeId
+	}
+	return ""
+}
+
+type DisputeEvidence struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Content       string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	SubmittedBy   string                 `protobuf:"bytes,4,opt,name=submitted_by,json=submittedBy,proto3" json:"submitted_by,omitempty"` // the actor that submitted it
+	CreatedAt     int64                  `protobuf:"varint,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisputeEvidence) Reset() {
+	*x = DisputeEvidence{}
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisputeEvidence) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisputeEvidence) ProtoMessage() {}
+
+func (x *DisputeEvidence) ProtoReflect() protoreflect.Message {
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisputeEvidence.ProtoReflect.Descriptor instead.
+func (*DisputeEvidence) Descriptor() ([]byte, []int) {
+	return file_services_payments_service_proto_payments_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *DisputeEvidence) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *DisputeEvidence) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *DisputeEvidence) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *DisputeEvidence) GetSubmittedBy() string {
+	if x != nil {
+		return x.SubmittedBy
+	}
+	return ""
+}
+
+func (x *DisputeEvidence) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+type Dispute struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	DisputeId   string                 `protobuf:"bytes,1,opt,name=dispute_id,json=disputeId,proto3" json:"dispute_id,omitempty"`
+	ReferenceId string                 `protobuf:"bytes,2,opt,name=reference_id,json=referenceId,proto3" json:"reference_id,omitempty"`
+	// OPENING until the amount is held, then OPEN, UNDER_REVIEW once evidence is
+	// submitted, and WON or LOST; FAILED when the amount could not be held
+	Status        string             `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	Amount        int64              `protobuf:"varint,4,opt,name=amount,proto3" json:"amount,omitempty"` // payer currency
+	Currency      string             `protobuf:"bytes,5,opt,name=currency,proto3" json:"currency,omitempty"`
+	PayeeAmount   int64              `protobuf:"varint,6,opt,name=payee_amount,json=payeeAmount,proto3" json:"payee_amount,omitempty"` // held from the payee
+	PayeeCurrency string             `protobuf:"bytes,7,opt,name=payee_currency,json=payeeCurrency,proto3" json:"payee_currency,omitempty"`
+	Reason        string             `protobuf:"bytes,8,opt,name=reason,proto3" json:"reason,omitempty"`
+	Outcome       string             `protobuf:"bytes,9,opt,name=outcome,proto3" json:"outcome,omitempty"` // WON or LOST while the resolution is in progress
+	Message       string             `protobuf:"bytes,10,opt,name=message,proto3" json:"message,omitempty"`
+	CreatedAt     int64              `protobuf:"varint,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ResolvedAt    int64              `protobuf:"varint,12,opt,name=resolved_at,json=resolvedAt,proto3" json:"resolved_at,omitempty"` // 0 until WON or LOST
+	Evidence      []*DisputeEvidence `protobuf:"bytes,13,rep,name=evidence,proto3" json:"evidence,omitempty"`                        // oldest first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Dispute) Reset() {
+	*x = Dispute{}
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Dispute) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Dispute) ProtoMessage() {}
+
+func (x *Dispute) ProtoReflect() protoreflect.Message {
+	mi := &file_services_payments_service_proto_payments_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Dispute.ProtoReflect.Descriptor instead.
+func (*Dispute) Descriptor() ([]byte, []int) {
+	return file_services_payments_service_proto_payments_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *Dispute) GetDisputeId() string {
+	if x != nil {
+		return x.DisputeId
+	}
+	return ""
+}
+
+func (x *Dispute) GetReferenceId() string {
+	if x != nil {
+		return x.ReferenceId
+	}
+	return ""
+}
+
+func (x *Dispute) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Dispute) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Dispute) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Dispute) GetPayeeAmount() int64 {
+	if x != nil {
+		return x.PayeeAmount
+	}
+	return 0
+}
+
+func (x *Dispute) GetPayeeCurrency() string {
+	if x != nil {
+		return x.PayeeCurrency
+	}
+	return ""
+}
+
+func (x *Dispute) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *Dispute) GetOutcome() string {
+	if x != nil {
+		return x.Outcome
+	}
+	return ""
+}
+
+func (x *Dispute) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *Dispute) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *Dispute) GetResolvedAt() int64 {
+	if x != nil {
+		return x.ResolvedAt
+	}
+	return 0
+}
+
+func (x *Dispute) GetEvidence() []*DisputeEvidence {
+	if x != nil {
+		return x.Evidence
+	}
+	return nil
+}
+
+var File_services_payments_service_proto_payments_proto protoreflect.FileDescriptor
+
+const file_services_payments_service_proto_payments_proto_rawDesc = "" +
+	"\n" +
+	".services/payments-service/proto/payments.proto\x12\bpayments\"\xf0\x02\n" +
+	"\x1aCreatePaymentIntentRequest\x12\x19\n" +
+	"\bpayer_id\x18\x01 \x01(\tR\apayerId\x12\x19\n" +
+	"\bpayee_id\x18\x02 \x01(\tR\apayeeId\x12!\n" +
+	"\freference_id\x18\x05 \x01(\tR\vreferenceId\x12\x16\n" +
+	"\x06amount\x18\x06 \x01(\x03R\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\a \x01(\tR\bcurrency\x12(\n" +
+	"\x10hold_ttl_seconds\x18\b \x01(\x03R\x0eholdTtlSeconds\x12'\n" +
+	"\x0fidempotency_key\x18\t \x01(\tR\x0eidempotencyKey\x12(\n" +
+	"\x04legs\x18\n" +
+	" \x03(\v2\x14.payments.PaymentLegR\x04legs\x12\x16\n" +
+	"\x06escrow\x18\v \x01(\bR\x06escrow\x12*\n" +
+	"\x11escrow_release_at\x18\f \x01(\x03R\x0fescrowReleaseAtJ\x04\b\x03\x10\x04\"b\n" +
+	"\n" +
+	"PaymentLeg\x12\x19\n" +
+	"\bpayee_id\x18\x01 \x01(\tR\apayeeId\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x03R\x06amount\x12!\n" +
+	"\fbasis_points\x18\x03 \x01(\x05R\vbasisPoints\"\xaa\x01\n" +
+	"\x1bCreatePaymentIntentResponse\x12!\n" +
+	"\freference_id\x18\x01 \x01(\tR\vreferenceId\x12/\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x17.payments.PaymentStatusR\x06status\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x04 \x01(\x03R\texpiresAt\"\x91\x01\n" +
+	"\x15CapturePaymentRequest\x12!\n" +
+	"\freference_id\x18\x01 \x01(\tR\vreferenceId\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x03R\x06amount\x12\x14\n" +
+	"\x05final\x18\x03 \x01(\bR\x05final\x12'\n" +
+	"\x0fidempotency_key\x18\x04 \x01(\tR\x0eidempotencyKey\"\x91\x02\n" +
+	"\x16CapturePaymentResponse\x12!\n" +
+	"\freference_id\x18\x01 \x01(\tR\vreferenceId\x12/\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x17.payments.PaymentStatusR\x06status\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x12\x1d\n" +
+	"\n" +
+	"capture_id\x18\x04 \x01(\tR\tcaptureId\x12\x16\n" +
+	"\x06amount\x18\x05 \x01(\x03R\x06amount\x12'\n" +
+	"\x0fcaptured_amount\x18\x06 \x01(\x03R\x0ecapturedAmount\x12)\n" +
+	"\x10remaining_amount\x18\a \x01(\x03R\x0fremainingAmount\"\x92\x01\n" +
+	"\x14RefundPaymentRequest\x12!\n" +
+	"\freference_id\x18\x01 \x01(\tR\vreferenceId\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x03R\x06amount\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12'\n" +
+	"\x0fidempotency_key\x18\x04 \x01(\tR\x0eidempotencyKey\"\xe3\x01\n" +
+	"\x15RefundPaymentResponse\x12!\n" +
+	"\freference_id\x18\x01 \x01(\tR\vreferenceId\x12\x1b\n" +
+	"\trefund_id\x18\x02 \x01(\tR\brefundId\x12/\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x17.payments.PaymentStatusR\x06status\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\x12\x16\n" +
+	"\x06amount\x18\x05 \x01(\x03R\x06amount\x12'\n" +
+	"\x0frefunded_amount\x18\x06 \x01(\x03R\x0erefundedAmount\"z\n" +
+	"\x14ReleaseEscrowRequest\x12!\n" +
+	"\freference_id\x18\x01 \x01(\tR\vreferenceId\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x03R\x06amount\x12'\n" +
+	"\x0fidempotency_key\x18\x03 \x01(\tR\x0eidempotencyKey\"\x91\x01\n" +
+	"\x13RefundEscrowRequest\x12!\n" +
+	"\freference_id\x18\x01 \x01(\tR\vreferenceId\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x03R\x06amount\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12'\n" +
+	"\x0fidempotency_key\x18\x04 \x01(\tR\x0eidempotencyKey\"\xd8\x01\n" +
+	"\x0eEscrowResponse\x12!\n" +
+	"\freference_id\x18\x01 \x01(\tR\vreferenceId\x12\x1b\n" +
+	"\tescrow_id\x18\x02 \x01(\tR\bescrowId\x12/\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x17.payments.PaymentStatusR\x06status\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\x12\x16\n" +
+	"\x06amount\x18\x05 \x01(\x03R\x06amount\x12#\n" +
+	"\rescrow_amount\x18\x06 \x01(\x03R\fescrowAmount\"\x89\x01\n" +
+	"\x1aCancelPaymentIntentRequest\x12!\n" +
+	"\freference_id\x18\x01 \x01(\tR\vreferenceId\x12\x1f\n" +
+	"\vreason_code\x18\x02 \x01(\tR\n" +
+	"reasonCode\x12'\n" +
+	"\x0fidempotency_key\x18\x03 \x01(\tR\x0eidempotencyKey\"\x8b\x01\n" +
+	"\x1bCancelPaymentIntentResponse\x12!\n" +
+	"\freference_id\x18\x01 \x01(\tR\vreferenceId\x12/\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x17.payments.PaymentStatusR\x06status\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"\x9c\x01\n" +
+	"\x1aReviewPaymentIntentRequest\x12!\n" +
+	"\freference_id\x18\x01 \x01(\tR\vreferenceId\x12\x1a\n" +
+	"\bdecision\x18\x02 \x01(\tR\bdecision\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12'\n" +
+	"\x0fidempotency_key\x18\x04 \x01(\tR\x0eidempotencyKey\"\xaa\x01\n" +
+	"\x1bReviewPaymentIntentResponse\x12!\n" +
+	"\freference_id\x18\x01 \x01(\tR\vreferenceId\x12/\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x17.payments.PaymentStatusR\x06status\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x04 \x01(\x03R\texpiresAt\"\xa7\x02\n" +
+	"\x0eRiskEvaluation\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12!\n" +
+	"\freference_id\x18\x02 \x01(\tR\vreferenceId\x12\x19\n" +
+	"\bpayer_id\x18\x03 \x01(\tR\apayerId\x12\x19\n" +
+	"\bpayee_id\x18\x04 \x01(\tR\apayeeId\x12\x16\n" +
+	"\x06amount\x18\x05 \x01(\x03R\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x06 \x01(\tR\bcurrency\x12\x1a\n" +
+	"\bdecision\x18\a \x01(\tR\bdecision\x12\x18\n" +
+	"\areasons\x18\b \x03(\tR\areasons\x12#\n" +
+	"\rrules_version\x18\t \x01(\tR\frulesVersion\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\x03R\tcreatedAt\"\x8c\x01\n" +
+	"\x1aListRiskEvaluationsRequest\x12!\n" +
+	"\freference_id\x18\x01 \x01(\tR\vreferenceId\x12\x19\n" +
+	"\bpayer_id\x18\x02 \x01(\tR\apayerId\x12\x1a\n" +
+	"\bdecision\x18\x03 \x01(\tR\bdecision\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\"Y\n" +
+	"\x1bListRiskEvaluationsResponse\x12:\n" +
+	"\vevaluations\x18\x01 \x03(\v2\x18.payments.RiskEvaluationR\vevaluations\"\xbc\x05\n" +
+	"\aPayment\x12!\n" +
+	"\freference_id\x18\x01 \x01(\tR\vreferenceId\x12\x19\n" +
+	"\bpayer_id\x18\x02 \x01(\tR\apayerId\x12\x19\n" +
+	"\bpayee_id\x18\x03 \x01(\tR\apayeeId\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\x03R\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x05 \x01(\tR\bcurrency\x12!\n" +
+	"\fpayee_amount\x18\x06 \x01(\x03R\vpayeeAmount\x12%\n" +
+	"\x0epayee_currency\x18\a \x01(\tR\rpayeeCurrency\x12\x19\n" +
+	"\bquote_id\x18\b \x01(\tR\aquoteId\x12/\n" +
+	"\x06status\x18\t \x01(\x0e2\x17.payments.PaymentStatusR\x06status\x12'\n" +
+	"\x0fcaptured_amount\x18\n" +
+	" \x01(\x03R\x0ecapturedAmount\x12'\n" +
+	"\x0frefunded_amount\x18\v \x01(\x03R\x0erefundedAmount\x12#\n" +
+	"\rcancel_reason\x18\f \x01(\tR\fcancelReason\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\r \x01(\x03R\texpiresAt\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x0e \x01(\x03R\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x0f \x01(\x03R\tupdatedAt\x12(\n" +
+	"\x04legs\x18\x10 \x03(\v2\x14.payments.PaymentLegR\x04legs\x12\x16\n" +
+	"\x06escrow\x18\x11 \x01(\bR\x06escrow\x12#\n" +
+	"\rescrow_amount\x18\x12 \x01(\x03R\fescrowAmount\x12*\n" +
+	"\x11escrow_release_at\x18\x13 \x01(\x03R\x0fescrowReleaseAt\x12'\n" +
+	"\x0fdisputed_amount\x18\x14 \x01(\x03R\x0edisputedAmount\"\xe8\x01\n" +
+	"\x12PaymentTransaction\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1d\n" +
+	"\n" +
+	"capture_id\x18\x02 \x01(\tR\tcaptureId\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x03 \x01(\tR\taccountId\x12\x19\n" +
+	"\btxn_type\x18\x04 \x01(\tR\atxnType\x12\x16\n" +
+	"\x06amount\x18\x05 \x01(\x03R\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x06 \x01(\tR\bcurrency\x12\x16\n" +
+	"\x06status\x18\a \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\b \x01(\x03R\tcreatedAt\"\xb9\x01\n" +
+	"\x11OutboxEventStatus\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1d\n" +
+	"\n" +
+	"event_type\x18\x02 \x01(\tR\teventType\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x1f\n" +
+	"\vretry_count\x18\x04 \x01(\x05R\n" +
+	"retryCount\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\x03R\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\x03R\tupdatedAt\"\x9d\x01\n" +
+	"\x10StatusTransition\x12\x1f\n" +
+	"\vfrom_status\x18\x01 \x01(\tR\n" +
+	"fromStatus\x12\x1b\n" +
+	"\tto_status\x18\x02 \x01(\tR\btoStatus\x12\x14\n" +
+	"\x05actor\x18\x03 \x01(\tR\x05actor\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\x03R\tcreatedAt\"6\n" +
+	"\x11GetPaymentRequest\x12!\n" +
+	"\freference_id\x18\x01 \x01(\tR\vreferenceId\"\xee\x01\n" +
+	"\x12GetPaymentResponse\x12+\n" +
+	"\apayment\x18\x01 \x01(\v2\x11.payments.PaymentR\apayment\x12@\n" +
+	"\ftransactions\x18\x02 \x03(\v2\x1c.payments.PaymentTransactionR\ftransactions\x123\n" +
+	"\x06events\x18\x03 \x03(\v2\x1b.payments.OutboxEventStatusR\x06events\x124\n" +
+	"\ahistory\x18\x04 \x03(\v2\x1a.payments.StatusTransitionR\ahistory\"\xb8\x02\n" +
+	"\x13ListPaymentsRequest\x12\x19\n" +
+	"\bpayer_id\x18\x01 \x01(\tR\apayerId\x12\x19\n" +
+	"\bpayee_id\x18\x02 \x01(\tR\apayeeId\x12/\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x17.payments.PaymentStatusR\x06status\x12\x1d\n" +
+	"\n" +
+	"min_amount\x18\x04 \x01(\x03R\tminAmount\x12\x1d\n" +
+	"\n" +
+	"max_amount\x18\x05 \x01(\x03R\tmaxAmount\x12!\n" +
+	"\fcreated_from\x18\x06 \x01(\x03R\vcreatedFrom\x12\x1d\n" +
+	"\n" +
+	"created_to\x18\a \x01(\x03R\tcreatedTo\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\b \x01(\tR\tpageToken\x12\x1b\n" +
+	"\tpage_size\x18\t \x01(\x05R\bpageSize\"m\n" +
+	"\x14ListPaymentsResponse\x12-\n" +
+	"\bpayments\x18\x01 \x03(\v2\x11.payments.PaymentR\bpayments\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\x9b\x01\n" +
+	"\x1eRegisterWebhookEndpointRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12\x1f\n" +
+	"\vevent_types\x18\x03 \x03(\tR\n" +
+	"eventTypes\x12'\n" +
+	"\x0fidempotency_key\x18\x04 \x01(\tR\x0eidempotencyKey\"\xc2\x01\n" +
+	"\x0fWebhookEndpoint\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x02 \x01(\tR\taccountId\x12\x10\n" +
+	"\x03url\x18\x03 \x01(\tR\x03url\x12\x1f\n" +
+	"\vevent_types\x18\x04 \x03(\tR\n" +
+	"eventTypes\x12\x16\n" +
+	"\x06active\x18\x05 \x01(\bR\x06active\x12\x16\n" +
+	"\x06secret\x18\x06 \x01(\tR\x06secret\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\a \x01(\x03R\tcreatedAt\"<\n" +
+	"\x1bListWebhookEndpointsRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\"W\n" +
+	"\x1cListWebhookEndpointsResponse\x127\n" +
+	"\tendpoints\x18\x01 \x03(\v2\x19.payments.WebhookEndpointR\tendpoints\"@\n" +
+	"\x1dDisableWebhookEndpointRequest\x12\x1f\n" +
+	"\vendpoint_id\x18\x01 \x01(\tR\n" +
+	"endpointId\"\xa1\x01\n" +
+	"\x0eWebhookAttempt\x12\x18\n" +
+	"\aattempt\x18\x01 \x01(\x05R\aattempt\x12\x1f\n" +
+	"\vstatus_code\x18\x02 \x01(\x05R\n" +
+	"statusCode\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12\x1f\n" +
+	"\vduration_ms\x18\x04 \x01(\x03R\n" +
+	"durationMs\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\x03R\tcreatedAt\"\xd7\x03\n" +
+	"\x0fWebhookDelivery\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1f\n" +
+	"\vendpoint_id\x18\x02 \x01(\tR\n" +
+	"endpointId\x12\x19\n" +
+	"\bevent_id\x18\x03 \x01(\tR\aeventId\x12\x1d\n" +
+	"\n" +
+	"event_type\x18\x04 \x01(\tR\teventType\x12!\n" +
+	"\freference_id\x18\x05 \x01(\tR\vreferenceId\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12\x1a\n" +
+	"\battempts\x18\a \x01(\x05R\battempts\x12(\n" +
+	"\x10last_status_code\x18\b \x01(\x05R\x0elastStatusCode\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\t \x01(\tR\tlastError\x12&\n" +
+	"\x0fnext_attempt_at\x18\n" +
+	" \x01(\x03R\rnextAttemptAt\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\v \x01(\x03R\texpiresAt\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\f \x01(\x03R\tcreatedAt\x12\x18\n" +
+	"\apayload\x18\r \x01(\tR\apayload\x129\n" +
+	"\vattempt_log\x18\x0e \x03(\v2\x18.payments.WebhookAttemptR\n" +
+	"attemptLog\"\x90\x01\n" +
+	"\x1cListWebhookDeliveriesRequest\x12\x1f\n" +
+	"\vendpoint_id\x18\x01 \x01(\tR\n" +
+	"endpointId\x12!\n" +
+	"\freference_id\x18\x02 \x01(\tR\vreferenceId\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\"Z\n" +
+	"\x1dListWebhookDeliveriesResponse\x129\n" +
+	"\n" +
+	"deliveries\x18\x01 \x03(\v2\x19.payments.WebhookDeliveryR\n" +
+	"deliveries\"<\n" +
+	"\x19GetWebhookDeliveryRequest\x12\x1f\n" +
+	"\vdelivery_id\x18\x01 \x01(\x03R\n" +
+	"deliveryId\"?\n" +
+	"\x1cReplayWebhookDeliveryRequest\x12\x1f\n" +
+	"\vdelivery_id\x18\x01 \x01(\x03R\n" +
+	"deliveryId\"o\n" +
+	"\x18SubmitPayoutBatchRequest\x12\x16\n" +
+	"\x06format\x18\x01 \x01(\tR\x06format\x12\x12\n" +
+	"\x04file\x18\x02 \x01(\tR\x04file\x12'\n" +
+	"\x0fidempotency_key\x18\x03 \x01(\tR\x0eidempotencyKey\"b\n" +
+	"\x15GetPayoutBatchRequest\x12\x19\n" +
+	"\bbatch_id\x18\x01 \x01(\tR\abatchId\x12.\n" +
+	"\x13include_result_file\x18\x02 \x01(\bR\x11includeResultFile\"\xc5\x02\n" +
+	"\vPayoutBatch\x12\x19\n" +
+	"\bbatch_id\x18\x01 \x01(\tR\abatchId\x12\x16\n" +
+	"\x06format\x18\x02 \x01(\tR\x06format\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"total_rows\x18\x04 \x01(\x05R\ttotalRows\x12!\n" +
+	"\fpending_rows\x18\x05 \x01(\x05R\vpendingRows\x12%\n" +
+	"\x0esucceeded_rows\x18\x06 \x01(\x05R\rsucceededRows\x12\x1f\n" +
+	"\vfailed_rows\x18\a \x01(\x05R\n" +
+	"failedRows\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\b \x01(\x03R\tcreatedAt\x12!\n" +
+	"\fcompleted_at\x18\t \x01(\x03R\vcompletedAt\x12\x1f\n" +
+	"\vresult_file\x18\n" +
+	" \x01(\tR\n" +
+	"resultFile\"\x90\x01\n" +
+	"\x12OpenDisputeRequest\x12!\n" +
+	"\freference_id\x18\x01 \x01(\tR\vreferenceId\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x03R\x06amount\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12'\n" +
+	"\x0fidempotency_key\x18\x04 \x01(\tR\x0eidempotencyKey\"\xa2\x01\n" +
+	"\x1cSubmitDisputeEvidenceRequest\x12\x1d\n" +
+	"\n" +
+	"dispute_id\x18\x01 \x01(\tR\tdisputeId\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x18\n" +
+	"\acontent\x18\x03 \x01(\tR\acontent\x12'\n" +
+	"\x0fidempotency_key\x18\x04 \x01(\tR\x0eidempotencyKey\"y\n" +
+	"\x15ResolveDisputeRequest\x12\x1d\n" +
+	"\n" +
+	"dispute_id\x18\x01 \x01(\tR\tdisputeId\x12\x18\n" +
+	"\aoutcome\x18\x02 \x01(\tR\aoutcome\x12'\n" +
+	"\x0fidempotency_key\x18\x03 \x01(\tR\x0eidempotencyKey\"2\n" +
+	"\x11GetDisputeRequest\x12\x1d\n" +
+	"\n" +
+	"dispute_id\x18\x01 \x01(\tR\tdisputeId\"\x9f\x01\n" +
+	"\x0fDisputeEvidence\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x18\n" +
+	"\acontent\x18\x03 \x01(\tR\acontent\x12!\n" +
+	"\fsubmitted_by\x18\x04 \x01(\tR\vsubmittedBy\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\x03R\tcreatedAt\"\xa4\x03\n" +
+	"\aDispute\x12\x1d\n" +
+	"\n" +
+	"dispute_id\x18\x01 \x01(\tR\tdisputeId\x12!\n" +
+	"\freference_id\x18\x02 \x01(\tR\vreferenceId\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\x03R\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x05 \x01(\tR\bcurrency\x12!\n" +
+	"\fpayee_amount\x18\x06 \x01(\x03R\vpayeeAmount\x12%\n" +
+	"\x0epayee_currency\x18\a \x01(\tR\rpayeeCurrency\x12\x16\n" +
+	"\x06reason\x18\b \x01(\tR\x06reason\x12\x18\n" +
+	"\aoutcome\x18\t \x01(\tR\aoutcome\x12\x18\n" +
+	"\amessage\x18\n" +
+	" \x01(\tR\amessage\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\v \x01(\x03R\tcreatedAt\x12\x1f\n" +
+	"\vresolved_at\x18\f \x01(\x03R\n" +
+	"resolvedAt\x125\n" +
+	"\bevidence\x18\r \x03(\v2\x19.payments.DisputeEvidenceR\bevidence*\xb3\x01\n" +
+	"\rPaymentStatus\x12\v\n" +
+	"\aUNKNOWN\x10\x00\x12\x0e\n" +
+	"\n" +
+	"AUTHORIZED\x10\x01\x12\f\n" +
+	"\bCAPTURED\x10\x02\x12\n" +
+	"\n" +
+	"\x06FAILED\x10\x03\x12\f\n" +
+	"\bREFUNDED\x10\x04\x12\v\n" +
+	"\aEXPIRED\x10\x05\x12\x16\n" +
+	"\x12PARTIALLY_CAPTURED\x10\x06\x12\f\n" +
+	"\bCANCELED\x10\a\x12\x16\n" +
+	"\x12PARTIALLY_REFUNDED\x10\b\x12\x12\n" +
+	"\x0ePENDING_REVIEW\x10\t2\xe6\x0e\n" +
+	"\x0ePaymentService\x12b\n" +
+	"\x13CreatePaymentIntent\x12$.payments.CreatePaymentIntentRequest\x1a%.payments.CreatePaymentIntentResponse\x12S\n" +
+	"\x0eCapturePayment\x12\x1f.payments.CapturePaymentRequest\x1a .payments.CapturePaymentResponse\x12P\n" +
+	"\rRefundPayment\x12\x1e.payments.RefundPaymentRequest\x1a\x1f.payments.RefundPaymentResponse\x12b\n" +
+	"\x13CancelPaymentIntent\x12$.payments.CancelPaymentIntentRequest\x1a%.payments.CancelPaymentIntentResponse\x12G\n" +
+	"\n" +
+	"GetPayment\x12\x1b.payments.GetPaymentRequest\x1a\x1c.payments.GetPaymentResponse\x12M\n" +
+	"\fListPayments\x12\x1d.payments.ListPaymentsRequest\x1a\x1e.payments.ListPaymentsResponse\x12^\n" +
+	"\x17RegisterWebhookEndpoint\x12(.payments.RegisterWebhookEndpointRequest\x1a\x19.payments.WebhookEndpoint\x12e\n" +
+	"\x14ListWebhookEndpoints\x12%.payments.ListWebhookEndpointsRequest\x1a&.payments.ListWebhookEndpointsResponse\x12\\\n" +
+	"\x16DisableWebhookEndpoint\x12'.payments.DisableWebhookEndpointRequest\x1a\x19.payments.WebhookEndpoint\x12h\n" +
+	"\x15ListWebhookDeliveries\x12&.payments.ListWebhookDeliveriesRequest\x1a'.payments.ListWebhookDeliveriesResponse\x12T\n" +
+	"\x12GetWebhookDelivery\x12#.payments.GetWebhookDeliveryRequest\x1a\x19.payments.WebhookDelivery\x12Z\n" +
+	"\x15ReplayWebhookDelivery\x12&.payments.ReplayWebhookDeliveryRequest\x1a\x19.payments.WebhookDelivery\x12N\n" +
+	"\x11SubmitPayoutBatch\x12\".payments.SubmitPayoutBatchRequest\x1a\x15.payments.PayoutBatch\x12H\n" +
+	"\x0eGetPayoutBatch\x12\x1f.payments.GetPayoutBatchRequest\x1a\x15.payments.PayoutBatch\x12I\n" +
+	"\rReleaseEscrow\x12\x1e.payments.ReleaseEscrowRequest\x1a\x18.payments.EscrowResponse\x12G\n" +
+	"\fRefundEscrow\x12\x1d.payments.RefundEscrowRequest\x1a\x18.payments.EscrowResponse\x12>\n" +
+	"\vOpenDispute\x12\x1c.payments.OpenDisputeRequest\x1a\x11.payments.Dispute\x12R\n" +
+	"\x15SubmitDisputeEvidence\x12&.payments.SubmitDisputeEvidenceRequest\x1a\x11.payments.Dispute\x12D\n" +
+	"\x0eResolveDispute\x12\x1f.payments.ResolveDisputeRequest\x1a\x11.payments.Dispute\x12<\n" +
+	"\n" +
+	"GetDispute\x12\x1b.payments.GetDisputeRequest\x1a\x11.payments.Dispute\x12b\n" +
+	"\x13ReviewPaymentIntent\x12$.payments.ReviewPaymentIntentRequest\x1a%.payments.ReviewPaymentIntentResponse\x12b\n" +
+	"\x13ListRiskEvaluations\x12$.payments.ListRiskEvaluationsRequest\x1a%.payments.ListRiskEvaluationsResponseB\tZ\a./protob\x06proto3"
 
 var (
 	file_services_payments_service_proto_payments_proto_rawDescOnce sync.Once
@@ -353,26 +3903,125 @@ func file_services_payments_service_proto_payments_proto_rawDescGZIP() []byte {
 }
 
 var file_services_payments_service_proto_payments_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_services_payments_service_proto_payments_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_services_payments_service_proto_payments_proto_msgTypes = make([]protoimpl.MessageInfo, 45)
 var file_services_payments_service_proto_payments_proto_goTypes = []any{
-	(PaymentStatus)(0),                  // 0: payments.PaymentStatus
-	(*CreatePaymentIntentRequest)(nil),  // 1: payments.CreatePaymentIntentRequest
-	(*CreatePaymentIntentResponse)(nil), // 2: payments.CreatePaymentIntentResponse
-	(*CapturePaymentRequest)(nil),       // 3: payments.CapturePaymentRequest
-	(*CapturePaymentResponse)(nil),      // 4: payments.CapturePaymentResponse
+	(PaymentStatus)(0),                     // 0: payments.PaymentStatus
+	(*CreatePaymentIntentRequest)(nil),     // 1: payments.CreatePaymentIntentRequest
+	(*PaymentLeg)(nil),                     // 2: payments.PaymentLeg
+	(*CreatePaymentIntentResponse)(nil),    // 3: payments.CreatePaymentIntentResponse
+	(*CapturePaymentRequest)(nil),          // 4: payments.CapturePaymentRequest
+	(*CapturePaymentResponse)(nil),         // 5: payments.CapturePaymentResponse
+	(*RefundPaymentRequest)(nil),           // 6: payments.RefundPaymentRequest
+	(*RefundPaymentResponse)(nil),          // 7: payments.RefundPaymentResponse
+	(*ReleaseEscrowRequest)(nil),           // 8: payments.ReleaseEscrowRequest
+	(*RefundEscrowRequest)(nil),            // 9: payments.RefundEscrowRequest
+	(*EscrowResponse)(nil),                 // 10: payments.EscrowResponse
+	(*CancelPaymentIntentRequest)(nil),     // 11: payments.CancelPaymentIntentRequest
+	(*CancelPaymentIntentResponse)(nil),    // 12: payments.CancelPaymentIntentResponse
+	(*ReviewPaymentIntentRequest)(nil),     // 13: payments.ReviewPaymentIntentRequest
+	(*ReviewPaymentIntentResponse)(nil),    // 14: payments.ReviewPaymentIntentResponse
+	(*RiskEvaluation)(nil),                 // 15: payments.RiskEvaluation
+	(*ListRiskEvaluationsRequest)(nil),     // 16: payments.ListRiskEvaluationsRequest
+	(*ListRiskEvaluationsResponse)(nil),    // 17: payments.ListRiskEvaluationsResponse
+	(*Payment)(nil),                        // 18: payments.Payment
+	(*PaymentTransaction)(nil),             // 19: payments.PaymentTransaction
+	(*OutboxEventStatus)(nil),              // 20: payments.OutboxEventStatus
+	(*StatusTransition)(nil),               // 21: payments.StatusTransition
+	(*GetPaymentRequest)(nil),              // 22: payments.GetPaymentRequest
+	(*GetPaymentResponse)(nil),             // 23: payments.GetPaymentResponse
+	(*ListPaymentsRequest)(nil),            // 24: payments.ListPaymentsRequest
+	(*ListPaymentsResponse)(nil),           // 25: payments.ListPaymentsResponse
+	(*RegisterWebhookEndpointRequest)(nil), // 26: payments.RegisterWebhookEndpointRequest
+	(*WebhookEndpoint)(nil),                // 27: payments.WebhookEndpoint
+	(*ListWebhookEndpointsRequest)(nil),    // 28: payments.ListWebhookEndpointsRequest
+	(*ListWebhookEndpointsResponse)(nil),   // 29: payments.ListWebhookEndpointsResponse
+	(*DisableWebhookEndpointRequest)(nil),  // 30: payments.DisableWebhookEndpointRequest
+	(*WebhookAttempt)(nil),                 // 31: payments.WebhookAttempt
+	(*WebhookDelivery)(nil),                // 32: payments.WebhookDelivery
+	(*ListWebhookDeliveriesRequest)(nil),   // 33: payments.ListWebhookDeliveriesRequest
+	(*ListWebhookDeliveriesResponse)(nil),  // 34: payments.ListWebhookDeliveriesResponse
+	(*GetWebhookDeliveryRequest)(nil),      // 35: payments.GetWebhookDeliveryRequest
+	(*ReplayWebhookDeliveryRequest)(nil),   // 36: payments.ReplayWebhookDeliveryRequest
+	(*SubmitPayoutBatchRequest)(nil),       // 37: payments.SubmitPayoutBatchRequest
+	(*GetPayoutBatchRequest)(nil),          // 38: payments.GetPayoutBatchRequest
+	(*PayoutBatch)(nil),                    // 39: payments.PayoutBatch
+	(*OpenDisputeRequest)(nil),             // 40: payments.OpenDisputeRequest
+	(*SubmitDisputeEvidenceRequest)(nil),   // 41: payments.SubmitDisputeEvidenceRequest
+	(*ResolveDisputeRequest)(nil),          // 42: payments.ResolveDisputeRequest
+	(*GetDisputeRequest)(nil),              // 43: payments.GetDisputeRequest
+	(*DisputeEvidence)(nil),                // 44: payments.DisputeEvidence
+	(*Dispute)(nil),                        // 45: payments.Dispute
 }
 var file_services_payments_service_proto_payments_proto_depIdxs = []int32{
-	0, // 0: payments.CreatePaymentIntentResponse.status:type_name -> payments.PaymentStatus
-	0, // 1: payments.CapturePaymentResponse.status:type_name -> payments.PaymentStatus
-	1, // 2: payments.PaymentService.CreatePaymentIntent:input_type -> payments.CreatePaymentIntentRequest
-	3, // 3: payments.PaymentService.CapturePayment:input_type -> payments.CapturePaymentRequest
-	2, // 4: payments.PaymentService.CreatePaymentIntent:output_type -> payments.CreatePaymentIntentResponse
-	4, // 5: payments.PaymentService.CapturePayment:output_type -> payments.CapturePaymentResponse
-	4, // [4:6] is the sub-list for method output_type
-	2, // [2:4] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	2,  // 0: payments.CreatePaymentIntentRequest.legs:type_name -> payments.PaymentLeg
+	0,  // 1: payments.CreatePaymentIntentResponse.status:type_name -> payments.PaymentStatus
+	0,  // 2: payments.CapturePaymentResponse.status:type_name -> payments.PaymentStatus
+	0,  // 3: payments.RefundPaymentResponse.status:type_name -> payments.PaymentStatus
+	0,  // 4: payments.EscrowResponse.status:type_name -> payments.PaymentStatus
+	0,  // 5: payments.CancelPaymentIntentResponse.status:type_name -> payments.PaymentStatus
+	0,  // 6: payments.ReviewPaymentIntentResponse.status:type_name -> payments.PaymentStatus
+	15, // 7: payments.ListRiskEvaluationsResponse.evaluations:type_name -> payments.RiskEvaluation
+	0,  // 8: payments.Payment.status:type_name -> payments.PaymentStatus
+	2,  // 9: payments.Payment.legs:type_name -> payments.PaymentLeg
+	18, // 10: payments.GetPaymentResponse.payment:type_name -> payments.Payment
+	19, // 11: payments.GetPaymentResponse.transactions:type_name -> payments.PaymentTransaction
+	20, // 12: payments.GetPaymentResponse.events:type_name -> payments.OutboxEventStatus
+	21, // 13: payments.GetPaymentResponse.history:type_name -> payments.StatusTransition
+	0,  // 14: payments.ListPaymentsRequest.status:type_name -> payments.PaymentStatus
+	18, // 15: payments.ListPaymentsResponse.payments:type_name -> payments.Payment
+	27, // 16: payments.ListWebhookEndpointsResponse.endpoints:type_name -> payments.WebhookEndpoint
+	31, // 17: payments.WebhookDelivery.attempt_log:type_name -> payments.WebhookAttempt
+	32, // 18: payments.ListWebhookDeliveriesResponse.deliveries:type_name -> payments.WebhookDelivery
+	44, // 19: payments.Dispute.evidence:type_name -> payments.DisputeEvidence
+	1,  // 20: payments.PaymentService.CreatePaymentIntent:input_type -> payments.CreatePaymentIntentRequest
+	4,  // 21: payments.PaymentService.CapturePayment:input_type -> payments.CapturePaymentRequest
+	6,  // 22: payments.PaymentService.RefundPayment:input_type -> payments.RefundPaymentRequest
+	11, // 23: payments.PaymentService.CancelPaymentIntent:input_type -> payments.CancelPaymentIntentRequest
+	22, // 24: payments.PaymentService.GetPayment:input_type -> payments.GetPaymentRequest
+	24, // 25: payments.PaymentService.ListPayments:input_type -> payments.ListPaymentsRequest
+	26, // 26: payments.PaymentService.RegisterWebhookEndpoint:input_type -> payments.RegisterWebhookEndpointRequest
+	28, // 27: payments.PaymentService.ListWebhookEndpoints:input_type -> payments.ListWebhookEndpointsRequest
+	30, // 28: payments.PaymentService.DisableWebhookEndpoint:input_type -> payments.DisableWebhookEndpointRequest
+	33, // 29: payments.PaymentService.ListWebhookDeliveries:input_type -> payments.ListWebhookDeliveriesRequest
+	35, // 30: payments.PaymentService.GetWebhookDelivery:input_type -> payments.GetWebhookDeliveryRequest
+	36, // 31: payments.PaymentService.ReplayWebhookDelivery:input_type -> payments.ReplayWebhookDeliveryRequest
+	37, // 32: payments.PaymentService.SubmitPayoutBatch:input_type -> payments.SubmitPayoutBatchRequest
+	38, // 33: payments.PaymentService.GetPayoutBatch:input_type -> payments.GetPayoutBatchRequest
+	8,  // 34: payments.PaymentService.ReleaseEscrow:input_type -> payments.ReleaseEscrowRequest
+	9,  // 35: payments.PaymentService.RefundEscrow:input_type -> payments.RefundEscrowRequest
+	40, // 36: payments.PaymentService.OpenDispute:input_type -> payments.OpenDisputeRequest
+	41, // 37: payments.PaymentService.SubmitDisputeEvidence:input_type -> payments.SubmitDisputeEvidenceRequest
+	42, // 38: payments.PaymentService.ResolveDispute:input_type -> payments.ResolveDisputeRequest
+	43, // 39: payments.PaymentService.GetDispute:input_type -> payments.GetDisputeRequest
+	13, // 40: payments.PaymentService.ReviewPaymentIntent:input_type -> payments.ReviewPaymentIntentRequest
+	16, // 41: payments.PaymentService.ListRiskEvaluations:input_type -> payments.ListRiskEvaluationsRequest
+	3,  // 42: payments.PaymentService.CreatePaymentIntent:output_type -> payments.CreatePaymentIntentResponse
+	5,  // 43: payments.PaymentService.CapturePayment:output_type -> payments.CapturePaymentResponse
+	7,  // 44: payments.PaymentService.RefundPayment:output_type -> payments.RefundPaymentResponse
+	12, // 45: payments.PaymentService.CancelPaymentIntent:output_type -> payments.CancelPaymentIntentResponse
+	23, // 46: payments.PaymentService.GetPayment:output_type -> payments.GetPaymentResponse
+	25, // 47: payments.PaymentService.ListPayments:output_type -> payments.ListPaymentsResponse
+	27, // 48: payments.PaymentService.RegisterWebhookEndpoint:output_type -> payments.WebhookEndpoint
+	29, // 49: payments.PaymentService.ListWebhookEndpoints:output_type -> payments.ListWebhookEndpointsResponse
+	27, // 50: payments.PaymentService.DisableWebhookEndpoint:output_type -> payments.WebhookEndpoint
+	34, // 51: payments.PaymentService.ListWebhookDeliveries:output_type -> payments.ListWebhookDeliveriesResponse
+	32, // 52: payments.PaymentService.GetWebhookDelivery:output_type -> payments.WebhookDelivery
+	32, // 53: payments.PaymentService.ReplayWebhookDelivery:output_type -> payments.WebhookDelivery
+	39, // 54: payments.PaymentService.SubmitPayoutBatch:output_type -> payments.PayoutBatch
+	39, // 55: payments.PaymentService.GetPayoutBatch:output_type -> payments.PayoutBatch
+	10, // 56: payments.PaymentService.ReleaseEscrow:output_type -> payments.EscrowResponse
+	10, // 57: payments.PaymentService.RefundEscrow:output_type -> payments.EscrowResponse
+	45, // 58: payments.PaymentService.OpenDispute:output_type -> payments.Dispute
+	45, // 59: payments.PaymentService.SubmitDisputeEvidence:output_type -> payments.Dispute
+	45, // 60: payments.PaymentService.ResolveDispute:output_type -> payments.Dispute
+	45, // 61: payments.PaymentService.GetDispute:output_type -> payments.Dispute
+	14, // 62: payments.PaymentService.ReviewPaymentIntent:output_type -> payments.ReviewPaymentIntentResponse
+	17, // 63: payments.PaymentService.ListRiskEvaluations:output_type -> payments.ListRiskEvaluationsResponse
+	42, // [42:64] is the sub-list for method output_type
+	20, // [20:42] is the sub-list for method input_type
+	20, // [20:20] is the sub-list for extension type_name
+	20, // [20:20] is the sub-list for extension extendee
+	0,  // [0:20] is the sub-list for field type_name
 }
 
 func init() { file_services_payments_service_proto_payments_proto_init() }
@@ -386,7 +4035,7 @@ func file_services_payments_service_proto_payments_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_services_payments_service_proto_payments_proto_rawDesc), len(file_services_payments_service_proto_payments_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   4,
+			NumMessages:   45,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -26,3 +26,5 @@ require (
 	golang.org/x/text v0.27.0 // indirect
 	google.golang.org/grpc v1.76.0
 )
+
+replace github.com/parasagrawal71/bank-settlement-system/shared => ../../shared
@@ -4,11 +4,14 @@ import (
 	"fmt"
 
 	"github.com/parasagrawal71/bank-settlement-system/shared/env"
+	"github.com/parasagrawal71/bank-settlement-system/shared/mtls"
 )
 
 type Config struct {
 	DBUrl    string
 	GRPCPort string
+	// server certificate, client CA and peer allow-list
+	TLS mtls.Config
 }
 
 type DBConfig struct {
@@ -36,5 +39,5 @@ func Load() *Config {
 	db := fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=%s", dbConfig.DBUser, dbConfig.DBPassword, dbConfig.DBHost, dbConfig.DBPort, dbConfig.DBName, dbConfig.SSLMode)
 
 	port := env.GetEnvString("SETTLEMENT_GRPC_PORT", "")
-	return &Config{DBUrl: db, GRPCPort: port}
+	return &Config{DBUrl: db, GRPCPort: port, TLS: mtls.ConfigFromEnv("SETTLEMENT")}
 }
@@ -3,19 +3,60 @@ package events
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"log"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/parasagrawal71/bank-settlement-system/services/settlement-service/internal/repository"
+	"github.com/parasagrawal71/bank-settlement-system/shared/money"
 	"github.com/segmentio/kafka-go"
 )
 
 type PaymentCapturedEvent struct {
-	ReferenceID string  `json:"reference_id"`
-	PayerID     string  `json:"payer_id"`
-	PayeeID     string  `json:"payee_id"`
-	Amount      float64 `json:"amount"`
-	Status      string  `json:"status"`
+	EventType   string      `json:"event_type"`
+	ReferenceID string      `json:"reference_id"`
+	CaptureID   string      `json:"capture_id"`
+	EscrowID    string      `json:"escrow_id"`  // release of funds an escrow payment held in escrow
+	DisputeID   string      `json:"dispute_id"` // lost dispute clawed back from the payee
+	Leg         int         `json:"leg"`        // leg of a split payment, 0 for a single payee
+	PayerID     string      `json:"payer_id"`
+	PayeeID     string      `json:"payee_id"`
+	Amount      eventAmount `json:"amount"`       // payer currency
+	PayeeAmount eventAmount `json:"payee_amount"` // what the payee is credited, in its own currency
+	Status      string      `json:"status"`
+}
+
+// eventAmount is an amount of an event: a money object, or a plain number of
+// major units of money.DefaultCurrency in events published before amounts
+// carried their currency.
+type eventAmount money.Money
+
+func (a *eventAmount) UnmarshalJSON(data []byte) error {
+	var m money.Money
+	if err := json.Unmarshal(data, &m); err == nil {
+		*a = eventAmount(m)
+		return nil
+	}
+	var n json.Number
+	if err := json.Unmarshal(data, &n); err != nil {
+		return fmt.Errorf("amount: %w", err)
+	}
+	m, err := money.Parse(n.String(), money.DefaultCurrency)
+	if err != nil {
+		return fmt.Errorf("amount: %w", err)
+	}
+	*a = eventAmount(m)
+	return nil
+}
+
+// settledAmount is what the payee of an event is settled: its payee amount,
+// which differs from the amount for a cross-currency payment, or the amount in
+// events that carry none.
+func (ev PaymentCapturedEvent) settledAmount() money.Money {
+	if ev.PayeeAmount.Currency != "" {
+		return money.Money(ev.PayeeAmount)
+	}
+	return money.Money(ev.Amount)
 }
 
 type Consumer struct {
@@ -48,11 +89,38 @@ func (c *Consumer) Start(ctx context.Context) {
 			continue
 		}
 
+		// only captures and escrow releases reach the payee and are settled,
+		// and lost disputes are clawed back from it; events from before event
+		// types were published are all captures
+		switch ev.EventType {
+		case "", "PAYMENT_CAPTURED", "ESCROW_RELEASED", "DISPUTE_LOST":
+		default:
+			if err := c.reader.CommitMessages(ctx, msg); err != nil {
+				log.Printf("failed to commit message: %v", err)
+			}
+			continue
+		}
+
+		// events from before partial captures carry no capture id
+		captureID := ev.CaptureID
+		if captureID == "" {
+			captureID = ev.ReferenceID
+		}
+		kind := "CAPTURE"
+		switch ev.EventType {
+		case "ESCROW_RELEASED":
+			captureID = ev.EscrowID
+		case "DISPUTE_LOST":
+			captureID, kind = ev.DisputeID, "CLAWBACK"
+		}
 		settlement := repository.Settlement{
 			ReferenceID: ev.ReferenceID,
+			CaptureID:   captureID,
+			Leg:         ev.Leg,
+			Kind:        kind,
 			PayerID:     ev.PayerID,
 			PayeeID:     ev.PayeeID,
-			Amount:      ev.Amount,
+			Amount:      ev.settledAmount(),
 			Status:      "PENDING",
 		}
 
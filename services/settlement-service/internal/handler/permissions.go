@@ -0,0 +1,11 @@
+package handler
+
+import (
+	pb "github.com/parasagrawal71/bank-settlement-system/services/settlement-service/proto"
+	"github.com/parasagrawal71/bank-settlement-system/shared/auth"
+)
+
+// Permissions lists who may call each RPC.
+var Permissions = auth.Permissions{
+	pb.SettlementService_GetSettlementStatus_FullMethodName: {auth.RoleAdmin, auth.RoleOperator, auth.RoleClient},
+}
@@ -5,13 +5,17 @@ import (
 	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/parasagrawal71/bank-settlement-system/shared/money"
 )
 
 type Settlement struct {
 	PayerID     string
 	PayeeID     string
-	Amount      float64
+	Amount      money.Money
 	ReferenceID string
+	CaptureID   string
+	Leg         int    // leg of a split payment, 0 for a single payee
+	Kind        string // CAPTURE, or CLAWBACK of a lost dispute from the payee
 	Status      string
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
@@ -26,22 +30,34 @@ func NewSettlementRepository(pool *pgxpool.Pool) *SettlementRepository {
 }
 
 func (r *SettlementRepository) CreateOrUpdate(ctx context.Context, s Settlement) error {
+	if s.Kind == "" {
+		s.Kind = "CAPTURE"
+	}
 	_, err := r.pool.Exec(ctx, `
-		INSERT INTO settlements (payer_id, payee_id, amount, reference_id, status)
-		VALUES ($1, $2, $3, $4, $5)
-		ON CONFLICT (reference_id) DO UPDATE SET status = EXCLUDED.status, updated_at = now()
-	`, s.PayerID, s.PayeeID, s.Amount, s.ReferenceID, s.Status)
+		INSERT INTO settlements (payer_id, payee_id, amount, currency, reference_id, capture_id, leg, kind, status)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (capture_id, leg) DO UPDATE SET status = EXCLUDED.status, updated_at = now()
+	`, s.PayerID, s.PayeeID, s.Amount.Amount, s.Amount.Currency, s.ReferenceID, s.CaptureID, s.Leg, s.Kind, s.Status)
 	return err
 }
 
+// GetByReferenceID sums the settlements of all captures of a payment, over all
+// legs of a split payment, whose PayeeID is then the payee of the first leg.
+// Claw-backs of lost disputes are taken off the sum. The payment is PENDING
+// while any settlement is, then FAILED if any settlement failed.
 func (r *SettlementRepository) GetByReferenceID(ctx context.Context, ref string) (*Settlement, error) {
 	row := r.pool.QueryRow(ctx, `
-		SELECT payer_id, payee_id, amount, reference_id, status, created_at, updated_at
+		SELECT payer_id, (array_agg(payee_id ORDER BY leg))[1], SUM(CASE WHEN kind = 'CLAWBACK' THEN -amount ELSE amount END)::BIGINT, currency, reference_id,
+			CASE WHEN bool_or(status = 'PENDING') THEN 'PENDING'
+				WHEN bool_or(status = 'FAILED') THEN 'FAILED'
+				ELSE 'SETTLED' END,
+			MIN(created_at), MAX(updated_at)
 		FROM settlements WHERE reference_id=$1
+		GROUP BY payer_id, currency, reference_id
 	`, ref)
 
 	var s Settlement
-	err := row.Scan(&s.PayerID, &s.PayeeID, &s.Amount, &s.ReferenceID, &s.Status, &s.CreatedAt, &s.UpdatedAt)
+	err := row.Scan(&s.PayerID, &s.PayeeID, &s.Amount.Amount, &s.Amount.Currency, &s.ReferenceID, &s.Status, &s.CreatedAt, &s.UpdatedAt)
 	if err != nil {
 		return nil, err
 	}
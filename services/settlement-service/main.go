@@ -13,7 +13,9 @@ import (
 	"github.com/parasagrawal71/bank-settlement-system/services/settlement-service/internal/events"
 	"github.com/parasagrawal71/bank-settlement-system/services/settlement-service/internal/handler"
 	pb "github.com/parasagrawal71/bank-settlement-system/services/settlement-service/proto"
+	"github.com/parasagrawal71/bank-settlement-system/shared/auth"
 	"github.com/parasagrawal71/bank-settlement-system/shared/db"
+	"github.com/parasagrawal71/bank-settlement-system/shared/mtls"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
 )
@@ -35,7 +37,18 @@ func main() {
 	if err != nil {
 		log.Fatalf("listen: %v", err)
 	}
-	grpcServer := grpc.NewServer()
+	// every call needs an API key or a JWT whose roles handler.Permissions allows
+	authenticator, err := auth.NewAuthenticator(auth.ConfigFromEnv())
+	if err != nil {
+		log.Fatalf("auth: %v", err)
+	}
+	guard := auth.NewGuard(authenticator, handler.Permissions, auth.Reflection)
+	// TLS, verifying client certificates, when a certificate is configured
+	serverOpts, err := mtls.ServerOptions(cfg.TLS, "settlement-service")
+	if err != nil {
+		log.Fatalf("tls: %v", err)
+	}
+	grpcServer := grpc.NewServer(append(serverOpts, guard.ServerOptions()...)...)
 	pb.RegisterSettlementServiceServer(grpcServer,
 		handler.NewSettlementHandler(pool))
 
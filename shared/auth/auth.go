@@ -0,0 +1,209 @@
+// Package auth authenticates gRPC callers and authorizes their calls. A caller
+// presents an API key (x-api-key metadata) or a signed JWT (authorization:
+// Bearer <token>, HS256 or RS256), which identifies a Principal with roles. Each
+// service checks every call against its own Permissions table.
+package auth
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/parasagrawal71/bank-settlement-system/shared/env"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	HeaderAPIKey        = "x-api-key"
+	HeaderAuthorization = "authorization"
+)
+
+var (
+	ErrNoCredentials      = errors.New("no credentials")
+	ErrInvalidCredentials = errors.New("invalid credentials")
+)
+
+// Principal is an authenticated caller.
+type Principal struct {
+	Name  string // API key name or JWT subject
+	Roles []string
+}
+
+func (p *Principal) HasRole(role string) bool {
+	return slices.Contains(p.Roles, role)
+}
+
+type principalKey struct{}
+
+// PrincipalFromContext returns the caller of a request, set by the server
+// interceptors, or nil.
+func PrincipalFromContext(ctx context.Context) *Principal {
+	p, _ := ctx.Value(principalKey{}).(*Principal)
+	return p
+}
+
+func withPrincipal(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// Authenticator checks the credentials of incoming requests. Without any keys
+// configured it refuses every request, unless it is disabled.
+type Authenticator struct {
+	disabled bool
+	apiKeys  map[string]*Principal // by hex SHA-256 of the key
+	jwt      jwtVerifier
+}
+
+// Config lists where the credentials accepted by a service come from.
+type Config struct {
+	// Disabled lets every request through without credentials; for local
+	// development only
+	Disabled bool
+	// APIKeysFile is a JSON file of the accepted API keys:
+	// {"keys": [{"name": "ops-console", "sha256": "<hex>", "roles": ["admin"]}]}.
+	// Only the SHA-256 of each key is stored.
+	APIKeysFile string
+	// JWTs are accepted when signed with the HS256 secret or the RS256 public
+	// key (PEM) of these files, and, when set, issued by JWTIssuer for
+	// JWTAudience.
+	JWTSecretFile    string
+	JWTPublicKeyFile string
+	JWTIssuer        string
+	JWTAudience      string
+}
+
+func ConfigFromEnv() Config {
+	return Config{
+		Disabled:         env.GetEnvBool("AUTH_DISABLED", false),
+		APIKeysFile:      env.GetEnvString("AUTH_API_KEYS_FILE", ""),
+		JWTSecretFile:    env.GetEnvString("AUTH_JWT_HS256_SECRET_FILE", ""),
+		JWTPublicKeyFile: env.GetEnvString("AUTH_JWT_RS256_PUBLIC_KEY_FILE", ""),
+		JWTIssuer:        env.GetEnvString("AUTH_JWT_ISSUER", ""),
+		JWTAudience:      env.GetEnvString("AUTH_JWT_AUDIENCE", ""),
+	}
+}
+
+// NewAuthenticator loads the keys of cfg. It fails when none are configured
+// and authentication is not disabled, so a service never runs open by mistake.
+func NewAuthenticator(cfg Config) (*Authenticator, error) {
+	a := &Authenticator{disabled: cfg.Disabled, apiKeys: map[string]*Principal{}}
+	if cfg.Disabled {
+		log.Printf("auth: AUTH_DISABLED is set, every request is let through unauthenticated")
+		return a, nil
+	}
+	if cfg.APIKeysFile != "" {
+		if err := a.loadAPIKeys(cfg.APIKeysFile); err != nil {
+			return nil, err
+		}
+	}
+	a.jwt = jwtVerifier{issuer: cfg.JWTIssuer, audience: cfg.JWTAudience}
+	if cfg.JWTSecretFile != "" {
+		secret, err := os.ReadFile(cfg.JWTSecretFile)
+		if err != nil {
+			return nil, fmt.Errorf("jwt secret: %w", err)
+		}
+		a.jwt.secret = []byte(strings.TrimSpace(string(secret)))
+		if len(a.jwt.secret) < 32 {
+			return nil, errors.New("jwt secret: must be at least 32 bytes")
+		}
+	}
+	if cfg.JWTPublicKeyFile != "" {
+		key, err := loadRSAPublicKey(cfg.JWTPublicKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("jwt public key: %w", err)
+		}
+		a.jwt.publicKey = key
+	}
+	if len(a.apiKeys) == 0 && a.jwt.secret == nil && a.jwt.publicKey == nil {
+		return nil, errors.New("no API keys or JWT keys configured; set AUTH_API_KEYS_FILE or AUTH_JWT_*, or AUTH_DISABLED=true for local development")
+	}
+	return a, nil
+}
+
+func (a *Authenticator) loadAPIKeys(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("api keys: %w", err)
+	}
+	var file struct {
+		Keys []struct {
+			Name   string   `json:"name"`
+			SHA256 string   `json:"sha256"`
+			Roles  []string `json:"roles"`
+		} `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("api keys %s: %w", path, err)
+	}
+	for i, k := range file.Keys {
+		sum, err := hex.DecodeString(k.SHA256)
+		if k.Name == "" || err != nil || len(sum) != sha256.Size {
+			return fmt.Errorf("api keys %s: key %d needs a name and the hex SHA-256 of the key", path, i+1)
+		}
+		a.apiKeys[strings.ToLower(k.SHA256)] = &Principal{Name: k.Name, Roles: k.Roles}
+	}
+	return nil
+}
+
+func loadRSAPublicKey(path string) (*rsa.PublicKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block")
+	}
+	if cert, err := x509.ParseCertificate(block.Bytes); err == nil {
+		if key, ok := cert.PublicKey.(*rsa.PublicKey); ok {
+			return key, nil
+		}
+		return nil, errors.New("certificate does not hold an RSA key")
+	}
+	if key, err := x509.ParsePKCS1PublicKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	key, ok := parsed.(*rsa.PublicKey)
+	if !ok {
+		return nil, errors.New("not an RSA public key")
+	}
+	return key, nil
+}
+
+// Authenticate returns the caller of a request from its metadata. A disabled
+// authenticator returns nil and no error.
+func (a *Authenticator) Authenticate(ctx context.Context) (*Principal, error) {
+	if a.disabled {
+		return nil, nil
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	if v := md.Get(HeaderAPIKey); len(v) > 0 && v[0] != "" {
+		sum := sha256.Sum256([]byte(v[0]))
+		if p, ok := a.apiKeys[hex.EncodeToString(sum[:])]; ok {
+			return p, nil
+		}
+		return nil, fmt.Errorf("%w: unknown API key", ErrInvalidCredentials)
+	}
+	if v := md.Get(HeaderAuthorization); len(v) > 0 && v[0] != "" {
+		token, ok := strings.CutPrefix(v[0], "Bearer ")
+		if !ok {
+			return nil, fmt.Errorf("%w: authorization must be a Bearer token", ErrInvalidCredentials)
+		}
+		return a.jwt.verify(strings.TrimSpace(token))
+	}
+	return nil, ErrNoCredentials
+}
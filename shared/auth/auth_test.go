@@ -0,0 +1,124 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/metadata"
+)
+
+func keyHash(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+func writeFile(t *testing.T, name, data string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestAuthenticateAPIKey(t *testing.T) {
+	keys := writeFile(t, "keys.json", `{"keys": [
+		{"name": "ops", "sha256": "`+keyHash("ops-key")+`", "roles": ["operator"]},
+		{"name": "shop", "sha256": "`+strings.ToUpper(keyHash("shop-key"))+`", "roles": ["client"]}
+	]}`)
+	a, err := NewAuthenticator(Config{APIKeysFile: keys, JWTSecretFile: writeFile(t, "secret", string(testSecret)+"\n")})
+	if err != nil {
+		t.Fatalf("NewAuthenticator() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		md   metadata.MD
+		want string
+		err  error
+	}{
+		{"operator key", metadata.Pairs(HeaderAPIKey, "ops-key"), "ops", nil},
+		{"upper-case hash in file", metadata.Pairs(HeaderAPIKey, "shop-key"), "shop", nil},
+		{"unknown key", metadata.Pairs(HeaderAPIKey, "guess"), "", ErrInvalidCredentials},
+		{"key wins over token", metadata.Pairs(HeaderAPIKey, "guess", HeaderAuthorization, "Bearer x.y.z"), "", ErrInvalidCredentials},
+		{"token without Bearer", metadata.Pairs(HeaderAuthorization, "x.y.z"), "", ErrInvalidCredentials},
+		{"bad token", metadata.Pairs(HeaderAuthorization, "Bearer x.y.z"), "", ErrInvalidCredentials},
+		{"nothing", metadata.MD{}, "", ErrNoCredentials},
+		{"empty key", metadata.Pairs(HeaderAPIKey, ""), "", ErrNoCredentials},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := a.Authenticate(metadata.NewIncomingContext(context.Background(), tt.md))
+			if !errors.Is(err, tt.err) || (tt.err == nil) != (err == nil) {
+				t.Fatalf("Authenticate() error = %v, want %v", err, tt.err)
+			}
+			if tt.want != "" && (p == nil || p.Name != tt.want) {
+				t.Fatalf("Authenticate() = %+v, want %s", p, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewAuthenticator(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+		err  string
+	}{
+		{"disabled", Config{Disabled: true}, ""},
+		{"no keys", Config{}, "no API keys or JWT keys configured"},
+		{"empty keys file", Config{APIKeysFile: writeFile(t, "keys.json", `{"keys": []}`)}, "no API keys or JWT keys configured"},
+		{"key without name", Config{APIKeysFile: writeFile(t, "keys.json", `{"keys": [{"sha256": "`+keyHash("k")+`"}]}`)}, "key 1 needs a name"},
+		{"key not hashed", Config{APIKeysFile: writeFile(t, "keys.json", `{"keys": [{"name": "k", "sha256": "plain-key"}]}`)}, "key 1 needs a name"},
+		{"missing keys file", Config{APIKeysFile: filepath.Join(t.TempDir(), "none.json")}, "api keys"},
+		{"short secret", Config{JWTSecretFile: writeFile(t, "secret", "short")}, "must be at least 32 bytes"},
+		{"bad public key", Config{JWTPublicKeyFile: writeFile(t, "key.pem", "not pem")}, "no PEM block"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewAuthenticator(tt.cfg)
+			if tt.err == "" {
+				if err != nil {
+					t.Fatalf("NewAuthenticator() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Fatalf("NewAuthenticator() error = %v, want %q", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestPermissionsAllowed(t *testing.T) {
+	perms := Permissions{
+		"/svc.S/Open":   {RoleClient, RoleOperator},
+		"/svc.S/Admin":  {RoleAdmin},
+		"/svc.Other/*":  {Any},
+		"/svc.S/Closed": {},
+	}
+	client := &Principal{Name: "shop", Roles: []string{RoleClient}}
+	tests := []struct {
+		method string
+		caller *Principal
+		want   bool
+	}{
+		{"/svc.S/Open", client, true},
+		{"/svc.S/Admin", client, false},
+		{"/svc.S/Admin", &Principal{Roles: []string{RoleAdmin}}, true},
+		{"/svc.S/Closed", client, false},
+		{"/svc.S/Unlisted", client, false},
+		{"/svc.Other/Anything", &Principal{}, true},
+	}
+	for _, tt := range tests {
+		if got := perms.Allowed(tt.method, tt.caller); got != tt.want {
+			t.Errorf("Allowed(%s, %v) = %v, want %v", tt.method, tt.caller.Roles, got, tt.want)
+		}
+	}
+}
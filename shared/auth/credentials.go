@@ -0,0 +1,21 @@
+package auth
+
+import (
+	"context"
+)
+
+// APIKey authenticates the calls of a gRPC client with an API key:
+//
+//	grpc.NewClient(addr, grpc.WithPerRPCCredentials(auth.APIKey(key)), ...)
+type APIKey string
+
+func (k APIKey) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	if k == "" {
+		return nil, nil
+	}
+	return map[string]string{HeaderAPIKey: string(k)}, nil
+}
+
+// RequireTransportSecurity is false so that services can still talk over the
+// plaintext connections of a local setup.
+func (k APIKey) RequireTransportSecurity() bool { return false }
@@ -0,0 +1,135 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Roles callers are granted in the API keys file or the roles claim of their
+// token.
+const (
+	RoleAdmin    = "admin"    // everything, including minting money
+	RoleOperator = "operator" // back office: reviews, disputes, freezes
+	RoleClient   = "client"   // merchants and apps making payments
+	// RolePaymentsService is payments-service calling accounts-service.
+	RolePaymentsService = "payments-service"
+)
+
+// Any allows a method to every authenticated caller, whatever its roles.
+const Any = "*"
+
+// Permissions maps full gRPC method names ("/accounts.AccountService/Transfer")
+// to the roles allowed to call them. A "/package.Service/*" entry covers every
+// method of a service without an entry of its own. Methods not listed are
+// denied.
+type Permissions map[string][]string
+
+// Reflection allows every authenticated caller to list services with grpcurl.
+var Reflection = Permissions{
+	"/grpc.reflection.v1.ServerReflection/*":      {Any},
+	"/grpc.reflection.v1alpha.ServerReflection/*": {Any},
+}
+
+// Health allows every authenticated caller to check the health of a service,
+// as balancing clients do.
+var Health = Permissions{
+	"/grpc.health.v1.Health/*": {Any},
+}
+
+func (p Permissions) rolesFor(method string) ([]string, bool) {
+	if roles, ok := p[method]; ok {
+		return roles, true
+	}
+	if i := strings.LastIndex(method, "/"); i > 0 {
+		roles, ok := p[method[:i+1]+"*"]
+		return roles, ok
+	}
+	return nil, false
+}
+
+// Allowed reports whether a caller may call method.
+func (p Permissions) Allowed(method string, caller *Principal) bool {
+	roles, ok := p.rolesFor(method)
+	if !ok {
+		return false
+	}
+	for _, role := range roles {
+		if role == Any || caller.HasRole(role) {
+			return true
+		}
+	}
+	return false
+}
+
+// Guard authenticates every call a server receives and authorizes it against
+// a permission table.
+type Guard struct {
+	auth  *Authenticator
+	perms Permissions
+}
+
+func NewGuard(a *Authenticator, perms ...Permissions) *Guard {
+	all := Permissions{}
+	for _, p := range perms {
+		for method, roles := range p {
+			all[method] = roles
+		}
+	}
+	return &Guard{auth: a, perms: all}
+}
+
+// ServerOptions installs the guard on a gRPC server.
+func (g *Guard) ServerOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(g.UnaryInterceptor),
+		grpc.ChainStreamInterceptor(g.StreamInterceptor),
+	}
+}
+
+func (g *Guard) check(ctx context.Context, method string) (context.Context, error) {
+	caller, err := g.auth.Authenticate(ctx)
+	if err != nil {
+		if errors.Is(err, ErrNoCredentials) {
+			return nil, status.Error(codes.Unauthenticated, "an API key or bearer token is required")
+		}
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if caller == nil {
+		// authentication disabled
+		return ctx, nil
+	}
+	if !g.perms.Allowed(method, caller) {
+		log.Printf("auth: %s (roles %v) denied %s", caller.Name, caller.Roles, method)
+		return nil, status.Errorf(codes.PermissionDenied, "%s may not call %s", caller.Name, method)
+	}
+	return withPrincipal(ctx, caller), nil
+}
+
+func (g *Guard) UnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	ctx, err := g.check(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (g *Guard) StreamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := g.check(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, &guardedStream{ServerStream: ss, ctx: ctx})
+}
+
+type guardedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *guardedStream) Context() context.Context { return s.ctx }
@@ -0,0 +1,115 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// clockSkew is how far the clocks of a token issuer and a service may drift.
+const clockSkew = time.Minute
+
+type jwtVerifier struct {
+	secret    []byte         // HS256
+	publicKey *rsa.PublicKey // RS256
+	issuer    string
+	audience  string
+}
+
+type jwtClaims struct {
+	Subject   string      `json:"sub"`
+	Issuer    string      `json:"iss"`
+	Audience  jwtAudience `json:"aud"`
+	ExpiresAt int64       `json:"exp"`
+	NotBefore int64       `json:"nbf"`
+	Roles     []string    `json:"roles"`
+}
+
+// jwtAudience is the aud claim, a string or an array of strings.
+type jwtAudience []string
+
+func (a *jwtAudience) UnmarshalJSON(data []byte) error {
+	var one string
+	if err := json.Unmarshal(data, &one); err == nil {
+		*a = jwtAudience{one}
+		return nil
+	}
+	var many []string
+	if err := json.Unmarshal(data, &many); err != nil {
+		return err
+	}
+	*a = many
+	return nil
+}
+
+// verify checks the signature and claims of a compact JWT. Only HS256 and
+// RS256 are accepted, each only when its key is configured, and the token must
+// expire.
+func (v *jwtVerifier) verify(token string) (*Principal, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: malformed token", ErrInvalidCredentials)
+	}
+	var header struct {
+		Alg string `json:"alg"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("%w: token header: %v", ErrInvalidCredentials, err)
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: token signature: %v", ErrInvalidCredentials, err)
+	}
+	signed := []byte(parts[0] + "." + parts[1])
+	switch {
+	case header.Alg == "HS256" && v.secret != nil:
+		mac := hmac.New(sha256.New, v.secret)
+		mac.Write(signed)
+		if !hmac.Equal(sig, mac.Sum(nil)) {
+			return nil, fmt.Errorf("%w: bad token signature", ErrInvalidCredentials)
+		}
+	case header.Alg == "RS256" && v.publicKey != nil:
+		digest := sha256.Sum256(signed)
+		if err := rsa.VerifyPKCS1v15(v.publicKey, crypto.SHA256, digest[:], sig); err != nil {
+			return nil, fmt.Errorf("%w: bad token signature", ErrInvalidCredentials)
+		}
+	default:
+		return nil, fmt.Errorf("%w: token algorithm %q not accepted", ErrInvalidCredentials, header.Alg)
+	}
+
+	var claims jwtClaims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("%w: token claims: %v", ErrInvalidCredentials, err)
+	}
+	now := time.Now()
+	switch {
+	case claims.ExpiresAt == 0:
+		return nil, fmt.Errorf("%w: token has no expiry", ErrInvalidCredentials)
+	case now.After(time.Unix(claims.ExpiresAt, 0).Add(clockSkew)):
+		return nil, fmt.Errorf("%w: token expired", ErrInvalidCredentials)
+	case claims.NotBefore != 0 && now.Add(clockSkew).Before(time.Unix(claims.NotBefore, 0)):
+		return nil, fmt.Errorf("%w: token not valid yet", ErrInvalidCredentials)
+	case claims.Subject == "":
+		return nil, fmt.Errorf("%w: token has no subject", ErrInvalidCredentials)
+	case v.issuer != "" && claims.Issuer != v.issuer:
+		return nil, fmt.Errorf("%w: token issuer %q not accepted", ErrInvalidCredentials, claims.Issuer)
+	case v.audience != "" && !slices.Contains(claims.Audience, v.audience):
+		return nil, fmt.Errorf("%w: token not issued for %q", ErrInvalidCredentials, v.audience)
+	}
+	return &Principal{Name: claims.Subject, Roles: claims.Roles}, nil
+}
+
+func decodeSegment(seg string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(seg)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
@@ -0,0 +1,139 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testSecret = []byte("0123456789abcdef0123456789abcdef")
+
+func segment(t *testing.T, v any) string {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func signHS256(t *testing.T, secret []byte, header, claims any) string {
+	t.Helper()
+	signed := segment(t, header) + "." + segment(t, claims)
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(signed))
+	return signed + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func signRS256(t *testing.T, key *rsa.PrivateKey, claims any) string {
+	t.Helper()
+	signed := segment(t, map[string]string{"alg": "RS256", "typ": "JWT"}) + "." + segment(t, claims)
+	digest := sha256.Sum256([]byte(signed))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+func TestJWTVerifyClaims(t *testing.T) {
+	hs := map[string]string{"alg": "HS256", "typ": "JWT"}
+	now := time.Now().Unix()
+	exp := now + 3600
+	v := &jwtVerifier{secret: testSecret, issuer: "auth.example", audience: "payments"}
+
+	tests := []struct {
+		name   string
+		claims map[string]any
+		err    string
+	}{
+		{"valid", map[string]any{"sub": "ops", "iss": "auth.example", "aud": "payments", "exp": exp, "roles": []string{"operator"}}, ""},
+		{"audience array", map[string]any{"sub": "ops", "iss": "auth.example", "aud": []string{"accounts", "payments"}, "exp": exp}, ""},
+		{"expired within skew", map[string]any{"sub": "ops", "iss": "auth.example", "aud": "payments", "exp": now - 30}, ""},
+		{"not before within skew", map[string]any{"sub": "ops", "iss": "auth.example", "aud": "payments", "exp": exp, "nbf": now + 30}, ""},
+		{"no expiry", map[string]any{"sub": "ops", "iss": "auth.example", "aud": "payments"}, "token has no expiry"},
+		{"expired", map[string]any{"sub": "ops", "iss": "auth.example", "aud": "payments", "exp": now - 120}, "token expired"},
+		{"not valid yet", map[string]any{"sub": "ops", "iss": "auth.example", "aud": "payments", "exp": exp, "nbf": now + 120}, "token not valid yet"},
+		{"no subject", map[string]any{"iss": "auth.example", "aud": "payments", "exp": exp}, "token has no subject"},
+		{"other issuer", map[string]any{"sub": "ops", "iss": "evil.example", "aud": "payments", "exp": exp}, `token issuer "evil.example" not accepted`},
+		{"other audience", map[string]any{"sub": "ops", "iss": "auth.example", "aud": []string{"accounts"}, "exp": exp}, `token not issued for "payments"`},
+		{"no audience", map[string]any{"sub": "ops", "iss": "auth.example", "exp": exp}, `token not issued for "payments"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := v.verify(signHS256(t, testSecret, hs, tt.claims))
+			if tt.err != "" {
+				if !errors.Is(err, ErrInvalidCredentials) || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("verify() error = %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("verify() error = %v", err)
+			}
+			if p.Name != "ops" {
+				t.Fatalf("verify() = %+v, want subject ops", p)
+			}
+		})
+	}
+}
+
+func TestJWTVerifySignature(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	claims := map[string]any{"sub": "svc", "exp": time.Now().Add(time.Hour).Unix(), "roles": []string{"admin"}}
+	hsToken := signHS256(t, testSecret, map[string]string{"alg": "HS256"}, claims)
+	rsToken := signRS256(t, key, claims)
+	parts := strings.Split(hsToken, ".")
+
+	both := &jwtVerifier{secret: testSecret, publicKey: &key.PublicKey}
+	tests := []struct {
+		name     string
+		verifier *jwtVerifier
+		token    string
+		err      string
+	}{
+		{"hs256", both, hsToken, ""},
+		{"rs256", both, rsToken, ""},
+		{"hs256 wrong secret", both, signHS256(t, []byte(strings.Repeat("x", 32)), map[string]string{"alg": "HS256"}, claims), "bad token signature"},
+		{"rs256 wrong key", both, signRS256(t, other, claims), "bad token signature"},
+		{"hs256 without secret", &jwtVerifier{publicKey: &key.PublicKey}, hsToken, `token algorithm "HS256" not accepted`},
+		{"rs256 without key", &jwtVerifier{secret: testSecret}, rsToken, `token algorithm "RS256" not accepted`},
+		{"alg none", both, segment(t, map[string]string{"alg": "none"}) + "." + parts[1] + ".", `token algorithm "none" not accepted`},
+		{"claims swapped", both, parts[0] + "." + segment(t, map[string]any{"sub": "root", "exp": claims["exp"], "roles": []string{"admin"}}) + "." + parts[2], "bad token signature"},
+		{"two segments", both, parts[0] + "." + parts[1], "malformed token"},
+		{"bad header", both, "!!." + parts[1] + "." + parts[2], "token header"},
+		{"bad signature encoding", both, parts[0] + "." + parts[1] + ".!!", "token signature"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := tt.verifier.verify(tt.token)
+			if tt.err != "" {
+				if !errors.Is(err, ErrInvalidCredentials) || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("verify() error = %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("verify() error = %v", err)
+			}
+			if p.Name != "svc" || !p.HasRole("admin") {
+				t.Fatalf("verify() = %+v, want svc with admin", p)
+			}
+		})
+	}
+}
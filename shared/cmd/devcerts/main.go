@@ -0,0 +1,56 @@
+// Command devcerts writes a local CA and a certificate for every service and
+// development client, for the docker-compose setup:
+//
+//	cd shared && go run ./cmd/devcerts -out ../infra/tls/certs
+//
+// An existing CA in the output directory is reused, so running it again
+// rotates the service certificates without breaking trust; -new-ca replaces
+// the CA as well.
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/parasagrawal71/bank-settlement-system/shared/mtls"
+)
+
+func main() {
+	out := flag.String("out", "certs", "output directory")
+	names := flag.String("names", "accounts-service,payments-service,settlement-service,gateway-service,dev-client", "comma-separated identities to issue certificates for")
+	days := flag.Int("days", 30, "validity of the issued certificates, in days")
+	newCA := flag.Bool("new-ca", false, "replace an existing CA")
+	flag.Parse()
+
+	if err := os.MkdirAll(*out, 0o755); err != nil {
+		log.Fatal(err)
+	}
+	caCert, caKey := filepath.Join(*out, "ca.crt"), filepath.Join(*out, "ca.key")
+	ca, err := mtls.LoadDevCA(caCert, caKey)
+	if err != nil || *newCA {
+		if ca, err = mtls.NewDevCA(10 * 365 * 24 * time.Hour); err != nil {
+			log.Fatal(err)
+		}
+		if err := ca.WriteFiles(*out); err != nil {
+			log.Fatal(err)
+		}
+		log.Printf("wrote CA %s", caCert)
+	}
+
+	validFor := time.Duration(*days) * 24 * time.Hour
+	for _, name := range strings.Split(*names, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		// localhost so the published ports can be reached from the host
+		if err := ca.IssueFiles(*out, name, validFor, "localhost", "127.0.0.1"); err != nil {
+			log.Fatal(err)
+		}
+		log.Printf("wrote %s.crt and %s.key", name, name)
+	}
+}
@@ -0,0 +1,76 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+)
+
+var ErrInvalidRate = errors.New("money: invalid exchange rate")
+
+// ParseRate parses a positive decimal exchange rate such as "83.125".
+func ParseRate(s string) (*big.Rat, error) {
+	rate, ok := new(big.Rat).SetString(strings.TrimSpace(s))
+	if !ok || rate.Sign() <= 0 {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidRate, s)
+	}
+	return rate, nil
+}
+
+// Convert converts m into currency to at rate, expressed as units of to per one
+// unit of m.Currency. The result is rounded half away from zero to the minor unit
+// of the target currency.
+func Convert(m Money, to string, rate *big.Rat) (Money, error) {
+	if rate == nil || rate.Sign() <= 0 {
+		return Money{}, ErrInvalidRate
+	}
+	fromExp, err := Exponent(m.Currency)
+	if err != nil {
+		return Money{}, err
+	}
+	to, err = NormalizeCurrency(to)
+	if err != nil {
+		return Money{}, err
+	}
+	toExp := minorUnits[to]
+
+	// minor_to = minor_from * rate * 10^(toExp - fromExp)
+	v := new(big.Rat).Mul(new(big.Rat).SetInt64(m.Amount), rate)
+	scale := new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(abs(toExp-fromExp))), nil))
+	if toExp >= fromExp {
+		v.Mul(v, scale)
+	} else {
+		v.Quo(v, scale)
+	}
+
+	rounded, err := roundHalfAway(v)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{Amount: rounded, Currency: to}, nil
+}
+
+func roundHalfAway(v *big.Rat) (int64, error) {
+	num := new(big.Int).Abs(v.Num())
+	den := v.Denom()
+	q, r := new(big.Int).QuoRem(num, den, new(big.Int))
+	if new(big.Int).Mul(r, big.NewInt(2)).Cmp(den) >= 0 {
+		q.Add(q, big.NewInt(1))
+	}
+	if v.Sign() < 0 {
+		q.Neg(q)
+	}
+	if !q.IsInt64() || q.Int64() == math.MinInt64 {
+		return 0, ErrOverflow
+	}
+	return q.Int64(), nil
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
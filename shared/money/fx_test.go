@@ -0,0 +1,74 @@
+package money
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		name string
+		m    Money
+		to   string
+		rate string
+		want Money
+		err  error
+	}{
+		{"same exponent", Money{10000, "USD"}, "INR", "83.25", Money{832500, "INR"}, nil},
+		{"rounds down", Money{1, "USD"}, "INR", "83.124", Money{83, "INR"}, nil},
+		{"half rounds up", Money{1, "USD"}, "INR", "83.5", Money{84, "INR"}, nil},
+		{"negative half rounds away", Money{-1, "USD"}, "INR", "83.5", Money{-84, "INR"}, nil},
+		{"to fewer decimals", Money{10050, "USD"}, "JPY", "150", Money{15075, "JPY"}, nil},
+		{"to fewer decimals rounds", Money{1, "USD"}, "JPY", "150.5", Money{2, "JPY"}, nil},
+		{"to more decimals", Money{1000, "JPY"}, "KWD", "0.002", Money{2000, "KWD"}, nil},
+		{"normalizes target", Money{100, "USD"}, "eur", "0.9", Money{90, "EUR"}, nil},
+		{"overflows", Money{math.MaxInt64, "USD"}, "INR", "2", Money{}, ErrOverflow},
+		{"unsupported target", Money{100, "USD"}, "XYZ", "1", Money{}, ErrUnsupportedCurrency},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rate, err := ParseRate(tt.rate)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := Convert(tt.m, tt.to, rate)
+			if !errors.Is(err, tt.err) || got != tt.want {
+				t.Fatalf("Convert(%v, %s, %s) = %v, %v, want %v, %v", tt.m, tt.to, tt.rate, got, err, tt.want, tt.err)
+			}
+		})
+	}
+}
+
+func TestConvertInvalidRate(t *testing.T) {
+	for _, rate := range []*big.Rat{nil, big.NewRat(0, 1), big.NewRat(-1, 2)} {
+		if _, err := Convert(Money{100, "USD"}, "INR", rate); !errors.Is(err, ErrInvalidRate) {
+			t.Errorf("Convert at %v = %v, want ErrInvalidRate", rate, err)
+		}
+	}
+}
+
+func TestParseRate(t *testing.T) {
+	tests := []struct {
+		s  string
+		ok bool
+	}{
+		{"83.125", true},
+		{" 1 ", true},
+		{"1/3", true},
+		{"0", false},
+		{"-1.5", false},
+		{"abc", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		_, err := ParseRate(tt.s)
+		if (err == nil) != tt.ok {
+			t.Errorf("ParseRate(%q) = %v, want ok %v", tt.s, err, tt.ok)
+		}
+		if err != nil && !errors.Is(err, ErrInvalidRate) {
+			t.Errorf("ParseRate(%q) = %v, want ErrInvalidRate", tt.s, err)
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// DefaultCurrency is used wherever a request or a legacy row carries no currency.
+const DefaultCurrency = "INR"
+
+var (
+	ErrOverflow            = errors.New("money: amount overflows int64 minor units")
+	ErrCurrencyMismatch    = errors.New("money: currency mismatch")
+	ErrUnsupportedCurrency = errors.New("money: unsupported currency")
+	ErrInvalidAmount       = errors.New("money: invalid amount")
+)
+
+// minorUnits maps ISO 4217 codes to the number of decimal places of the minor unit.
+var minorUnits = map[string]int{
+	"AED": 2, "AUD": 2, "BHD": 3, "BRL": 2, "CAD": 2, "CHF": 2, "CNY": 2,
+	"DKK": 2, "EUR": 2, "GBP": 2, "HKD": 2, "IDR": 2, "INR": 2, "JPY": 0,
+	"KRW": 0, "KWD": 3, "MXN": 2, "NOK": 2, "NZD": 2, "OMR": 3, "SAR": 2,
+	"SEK": 2, "SGD": 2, "THB": 2, "USD": 2, "ZAR": 2,
+}
+
+// Money is an exact amount expressed in the minor unit (paise, cents, ...) of its currency.
+type Money struct {
+	Amount   int64  `json:"minor"`
+	Currency string `json:"currency"`
+}
+
+// New validates the currency code and returns the amount in that currency.
+func New(amount int64, currency string) (Money, error) {
+	cur, err := NormalizeCurrency(currency)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{Amount: amount, Currency: cur}, nil
+}
+
+// NormalizeCurrency upper-cases the code, falls back to DefaultCurrency when empty
+// and rejects codes that are not supported.
+func NormalizeCurrency(currency string) (string, error) {
+	cur := strings.ToUpper(strings.TrimSpace(currency))
+	if cur == "" {
+		cur = DefaultCurrency
+	}
+	if _, ok := minorUnits[cur]; !ok {
+		return "", fmt.Errorf("%w: %q", ErrUnsupportedCurrency, currency)
+	}
+	return cur, nil
+}
+
+// Exponent returns the number of decimal places of the currency's minor unit.
+func Exponent(currency string) (int, error) {
+	cur, err := NormalizeCurrency(currency)
+	if err != nil {
+		return 0, err
+	}
+	return minorUnits[cur], nil
+}
+
+// Parse converts a decimal string such as "2000.50" into minor units. It rejects
+// more fractional digits than the currency allows instead of rounding them away.
+func Parse(s string, currency string) (Money, error) {
+	cur, err := NormalizeCurrency(currency)
+	if err != nil {
+		return Money{}, err
+	}
+	exp := minorUnits[cur]
+
+	s = strings.TrimSpace(s)
+	neg := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "-"), "+")
+	whole, frac, hasFrac := strings.Cut(s, ".")
+	if whole == "" || (hasFrac && frac == "") || len(frac) > exp {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+	}
+	frac += strings.Repeat("0", exp-len(frac))
+
+	var amount int64
+	for _, r := range whole + frac {
+		if r < '0' || r > '9' {
+			return Money{}, fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+		}
+		if amount, err = MulInt64(amount, 10); err != nil {
+			return Money{}, err
+		}
+		if amount, err = AddInt64(amount, int64(r-'0')); err != nil {
+			return Money{}, err
+		}
+	}
+	if neg {
+		amount = -amount
+	}
+	return Money{Amount: amount, Currency: cur}, nil
+}
+
+// String formats the amount in major units, e.g. "2000.50 INR".
+func (m Money) String() string {
+	exp := minorUnits[m.Currency]
+	sign := ""
+	abs := uint64(m.Amount)
+	if m.Amount < 0 {
+		sign = "-"
+		abs = uint64(-(m.Amount + 1)) + 1
+	}
+	if exp == 0 {
+		return fmt.Sprintf("%s%d %s", sign, abs, m.Currency)
+	}
+	pow := uint64(math.Pow10(exp))
+	return fmt.Sprintf("%s%d.%0*d %s", sign, abs/pow, exp, abs%pow, m.Currency)
+}
+
+func (m Money) IsZero() bool     { return m.Amount == 0 }
+func (m Money) IsPositive() bool { return m.Amount > 0 }
+func (m Money) IsNegative() bool { return m.Amount < 0 }
+
+// Add returns m + o. Both amounts must be in the same currency.
+func (m Money) Add(o Money) (Money, error) {
+	if m.Currency != o.Currency {
+		return Money{}, fmt.Errorf("%w: %s + %s", ErrCurrencyMismatch, m.Currency, o.Currency)
+	}
+	sum, err := AddInt64(m.Amount, o.Amount)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{Amount: sum, Currency: m.Currency}, nil
+}
+
+// Sub returns m - o. Both amounts must be in the same currency.
+func (m Money) Sub(o Money) (Money, error) {
+	if m.Currency != o.Currency {
+		return Money{}, fmt.Errorf("%w: %s - %s", ErrCurrencyMismatch, m.Currency, o.Currency)
+	}
+	diff, err := SubInt64(m.Amount, o.Amount)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{Amount: diff, Currency: m.Currency}, nil
+}
+
+// Cmp compares two amounts of the same currency and returns -1, 0 or +1.
+func (m Money) Cmp(o Money) (int, error) {
+	if m.Currency != o.Currency {
+		return 0, fmt.Errorf("%w: %s vs %s", ErrCurrencyMismatch, m.Currency, o.Currency)
+	}
+	switch {
+	case m.Amount < o.Amount:
+		return -1, nil
+	case m.Amount > o.Amount:
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// AddInt64 adds two minor-unit amounts, failing instead of wrapping around.
+func AddInt64(a, b int64) (int64, error) {
+	if (b > 0 && a > math.MaxInt64-b) || (b < 0 && a < math.MinInt64-b) {
+		return 0, ErrOverflow
+	}
+	return a + b, nil
+}
+
+// SubInt64 subtracts two minor-unit amounts, failing instead of wrapping around.
+func SubInt64(a, b int64) (int64, error) {
+	if (b < 0 && a > math.MaxInt64+b) || (b > 0 && a < math.MinInt64+b) {
+		return 0, ErrOverflow
+	}
+	return a - b, nil
+}
+
+// MulInt64 multiplies two integers, failing instead of wrapping around.
+func MulInt64(a, b int64) (int64, error) {
+	if a == 0 || b == 0 {
+		return 0, nil
+	}
+	c := a * b
+	if c/b != a || (a == -1 && b == math.MinInt64) || (b == -1 && a == math.MinInt64) {
+		return 0, ErrOverflow
+	}
+	return c, nil
+}
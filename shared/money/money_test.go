@@ -0,0 +1,112 @@
+package money
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		s        string
+		currency string
+		want     Money
+		err      error
+	}{
+		{"2000.50", "INR", Money{200050, "INR"}, nil},
+		{"2000.5", "inr", Money{200050, "INR"}, nil},
+		{"2000", "", Money{200000, "INR"}, nil},
+		{" -12.34 ", "USD", Money{-1234, "USD"}, nil},
+		{"+1", "USD", Money{100, "USD"}, nil},
+		{"0.001", "KWD", Money{1, "KWD"}, nil},
+		{"1500", "JPY", Money{1500, "JPY"}, nil},
+		{"92233720368547758.07", "USD", Money{math.MaxInt64, "USD"}, nil},
+		{"92233720368547758.08", "USD", Money{}, ErrOverflow},
+		{"99999999999999999999", "JPY", Money{}, ErrOverflow},
+		{"1.005", "USD", Money{}, ErrInvalidAmount},
+		{"1.5", "JPY", Money{}, ErrInvalidAmount},
+		{"1.", "USD", Money{}, ErrInvalidAmount},
+		{".5", "USD", Money{}, ErrInvalidAmount},
+		{"", "USD", Money{}, ErrInvalidAmount},
+		{"1,000", "USD", Money{}, ErrInvalidAmount},
+		{"1e3", "USD", Money{}, ErrInvalidAmount},
+		{"--1", "USD", Money{}, ErrInvalidAmount},
+		{"1", "XYZ", Money{}, ErrUnsupportedCurrency},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s+" "+tt.currency, func(t *testing.T) {
+			got, err := Parse(tt.s, tt.currency)
+			if !errors.Is(err, tt.err) || got != tt.want {
+				t.Fatalf("Parse(%q, %q) = %v, %v, want %v, %v", tt.s, tt.currency, got, err, tt.want, tt.err)
+			}
+		})
+	}
+}
+
+func TestString(t *testing.T) {
+	tests := []struct {
+		m    Money
+		want string
+	}{
+		{Money{200050, "INR"}, "2000.50 INR"},
+		{Money{-5, "USD"}, "-0.05 USD"},
+		{Money{0, "USD"}, "0.00 USD"},
+		{Money{1500, "JPY"}, "1500 JPY"},
+		{Money{1234, "KWD"}, "1.234 KWD"},
+		{Money{math.MinInt64, "USD"}, "-92233720368547758.08 USD"},
+	}
+	for _, tt := range tests {
+		if got := tt.m.String(); got != tt.want {
+			t.Errorf("String(%d %s) = %q, want %q", tt.m.Amount, tt.m.Currency, got, tt.want)
+		}
+	}
+}
+
+func TestArithmeticOverflow(t *testing.T) {
+	tests := []struct {
+		name string
+		fn   func(a, b int64) (int64, error)
+		a, b int64
+		want int64
+		err  error
+	}{
+		{"add", AddInt64, 2, 3, 5, nil},
+		{"add to max", AddInt64, math.MaxInt64 - 1, 1, math.MaxInt64, nil},
+		{"add past max", AddInt64, math.MaxInt64, 1, 0, ErrOverflow},
+		{"add past min", AddInt64, math.MinInt64, -1, 0, ErrOverflow},
+		{"sub", SubInt64, 2, 3, -1, nil},
+		{"sub to min", SubInt64, math.MinInt64 + 1, 1, math.MinInt64, nil},
+		{"sub past min", SubInt64, math.MinInt64, 1, 0, ErrOverflow},
+		{"sub past max", SubInt64, math.MaxInt64, -1, 0, ErrOverflow},
+		{"sub min from zero", SubInt64, 0, math.MinInt64, 0, ErrOverflow},
+		{"mul", MulInt64, -4, 5, -20, nil},
+		{"mul zero", MulInt64, 0, math.MinInt64, 0, nil},
+		{"mul past max", MulInt64, math.MaxInt64/2 + 1, 2, 0, ErrOverflow},
+		{"mul min by -1", MulInt64, math.MinInt64, -1, 0, ErrOverflow},
+		{"mul -1 by min", MulInt64, -1, math.MinInt64, 0, ErrOverflow},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.fn(tt.a, tt.b)
+			if !errors.Is(err, tt.err) || got != tt.want {
+				t.Fatalf("%s(%d, %d) = %d, %v, want %d, %v", tt.name, tt.a, tt.b, got, err, tt.want, tt.err)
+			}
+		})
+	}
+}
+
+func TestAddSubCurrency(t *testing.T) {
+	inr, usd := Money{100, "INR"}, Money{100, "USD"}
+	if _, err := inr.Add(usd); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("Add across currencies = %v, want ErrCurrencyMismatch", err)
+	}
+	if _, err := inr.Sub(usd); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("Sub across currencies = %v, want ErrCurrencyMismatch", err)
+	}
+	if _, err := inr.Cmp(usd); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("Cmp across currencies = %v, want ErrCurrencyMismatch", err)
+	}
+	if _, err := (Money{math.MaxInt64, "INR"}).Add(inr); !errors.Is(err, ErrOverflow) {
+		t.Errorf("Add past max = %v, want ErrOverflow", err)
+	}
+}
@@ -0,0 +1,88 @@
+package mtls
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AllowList maps the identities of client certificates to the RPCs they may
+// call, from a JSON file that is reloaded when it changes:
+//
+//	{"peers": {
+//	  "payments-service": ["/accounts.AccountService/Transfer", "/accounts.AccountService/GetAccount"],
+//	  "gateway-service":  ["/accounts.AccountService/*"],
+//	  "ops-console":      ["*"]
+//	}}
+//
+// Entries are full method names, "/package.Service/*" for every method of a
+// service, or "*" for everything. Identities not listed may call nothing.
+type AllowList struct {
+	path  string
+	mu    sync.Mutex
+	files *watchedFiles
+	peers map[string][]string
+}
+
+func LoadAllowList(path string, reloadInterval time.Duration) (*AllowList, error) {
+	a := &AllowList{path: path, files: newWatchedFiles(reloadInterval, path)}
+	a.files.changed()
+	peers, err := readAllowList(path)
+	if err != nil {
+		return nil, err
+	}
+	a.peers = peers
+	return a, nil
+}
+
+func readAllowList(path string) (map[string][]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("peer allow-list: %w", err)
+	}
+	var file struct {
+		Peers map[string][]string `json:"peers"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("peer allow-list %s: %w", path, err)
+	}
+	for id, methods := range file.Peers {
+		for _, m := range methods {
+			if m != "*" && !strings.HasPrefix(m, "/") {
+				return nil, fmt.Errorf("peer allow-list %s: %s: %q is not a full method name", path, id, m)
+			}
+		}
+	}
+	return file.Peers, nil
+}
+
+func (a *AllowList) current() map[string][]string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.files.changed() {
+		if peers, err := readAllowList(a.path); err != nil {
+			log.Printf("%v; keeping the allow-list in use", err)
+		} else {
+			a.peers = peers
+			log.Printf("tls: loaded peer allow-list %s", a.path)
+		}
+	}
+	return a.peers
+}
+
+// Allowed reports whether the peer identity may call method.
+func (a *AllowList) Allowed(identity, method string) bool {
+	for _, m := range a.current()[identity] {
+		if m == "*" || m == method {
+			return true
+		}
+		if service, ok := strings.CutSuffix(m, "/*"); ok && strings.HasPrefix(method, service+"/") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,105 @@
+package mtls
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// ServerOptions returns the options securing a gRPC server: its TLS
+// credentials and, with an allow-list, the interceptors enforcing it. Without
+// a certificate the server stays plaintext and no options are returned.
+func ServerOptions(cfg Config, service string) ([]grpc.ServerOption, error) {
+	if cfg.CertFile == "" {
+		log.Printf("tls: no certificate for %s, serving plaintext", service)
+		return nil, nil
+	}
+	src, err := NewSource(cfg)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig, err := src.ServerConfig("h2")
+	if err != nil {
+		return nil, err
+	}
+	opts := []grpc.ServerOption{grpc.Creds(credentials.NewTLS(tlsConfig))}
+	if cfg.PeerAllowListFile != "" {
+		allow, err := LoadAllowList(cfg.PeerAllowListFile, cfg.ReloadInterval)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts,
+			grpc.ChainUnaryInterceptor(allow.UnaryInterceptor),
+			grpc.ChainStreamInterceptor(allow.StreamInterceptor),
+		)
+	}
+	return opts, nil
+}
+
+// DialOption returns the transport credentials of a client of target, TLS
+// with the service's certificate, or plaintext without a CA.
+func DialOption(cfg Config, target string) (grpc.DialOption, error) {
+	if cfg.CAFile == "" {
+		log.Printf("tls: no CA configured, dialing %s in plaintext", target)
+		return grpc.WithTransportCredentials(insecure.NewCredentials()), nil
+	}
+	src, err := NewSource(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return grpc.WithTransportCredentials(credentials.NewTLS(src.ClientConfig())), nil
+}
+
+// PeerIdentity returns the identity of the verified client certificate of a
+// request: its common name, or its first DNS name without one.
+func PeerIdentity(ctx context.Context) (string, bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return "", false
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.VerifiedChains) == 0 || len(info.State.VerifiedChains[0]) == 0 {
+		return "", false
+	}
+	leaf := info.State.VerifiedChains[0][0]
+	if leaf.Subject.CommonName != "" {
+		return leaf.Subject.CommonName, true
+	}
+	if len(leaf.DNSNames) > 0 {
+		return leaf.DNSNames[0], true
+	}
+	return "", false
+}
+
+func (a *AllowList) check(ctx context.Context, method string) error {
+	id, ok := PeerIdentity(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "a client certificate is required")
+	}
+	if !a.Allowed(id, method) {
+		log.Printf("tls: peer %s denied %s", id, method)
+		return status.Error(codes.PermissionDenied, fmt.Sprintf("peer %s may not call %s", id, method))
+	}
+	return nil
+}
+
+func (a *AllowList) UnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if err := a.check(ctx, info.FullMethod); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (a *AllowList) StreamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := a.check(ss.Context(), info.FullMethod); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
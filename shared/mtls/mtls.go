@@ -0,0 +1,250 @@
+// Package mtls secures gRPC and HTTP connections between the services and with
+// external clients. Servers present a certificate and verify client
+// certificates against a CA, clients verify servers against the same CA and
+// present their own certificate, and a peer allow-list limits which RPCs each
+// client identity may call. Certificates, keys and the CA are read again when
+// their files change, so they can be rotated without a restart.
+package mtls
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/parasagrawal71/bank-settlement-system/shared/env"
+)
+
+// Config locates the certificate files of one service. Without a certificate
+// its server listens in plaintext; without a CA its clients dial in plaintext.
+type Config struct {
+	CertFile string // PEM certificate chain presented to peers
+	KeyFile  string
+	CAFile   string // PEM CA certificates peers are verified against
+	// ClientAuth is "require" (the default), "request" or "none": whether a
+	// server asks clients for a certificate and refuses those without one.
+	// Certificates that are presented are always verified.
+	ClientAuth string
+	// PeerAllowListFile limits the RPCs each client certificate identity may
+	// call; see AllowList. Empty allows every verified client.
+	PeerAllowListFile string
+	// how often the files are checked for changes, at most
+	ReloadInterval time.Duration
+}
+
+// ConfigFromEnv reads the configuration of a service from <prefix>_TLS_*
+// variables, falling back to the TLS_* variables every service shares:
+//
+//	TLS_CA_FILE, TLS_CLIENT_AUTH, TLS_PEER_ALLOWLIST_FILE, TLS_RELOAD_INTERVAL_SECONDS
+//	ACCOUNTS_TLS_CERT_FILE, ACCOUNTS_TLS_KEY_FILE, ACCOUNTS_TLS_CLIENT_AUTH, ...
+func ConfigFromEnv(prefix string) Config {
+	get := func(name, def string) string {
+		return env.GetEnvString(prefix+"_"+name, env.GetEnvString(name, def))
+	}
+	return Config{
+		CertFile:          env.GetEnvString(prefix+"_TLS_CERT_FILE", ""),
+		KeyFile:           env.GetEnvString(prefix+"_TLS_KEY_FILE", ""),
+		CAFile:            get("TLS_CA_FILE", ""),
+		ClientAuth:        get("TLS_CLIENT_AUTH", "require"),
+		PeerAllowListFile: get("TLS_PEER_ALLOWLIST_FILE", ""),
+		ReloadInterval:    time.Duration(env.GetEnvInt("TLS_RELOAD_INTERVAL_SECONDS", 10)) * time.Second,
+	}
+}
+
+func (c Config) clientAuth() (tls.ClientAuthType, error) {
+	switch c.ClientAuth {
+	case "", "require":
+		return tls.RequireAndVerifyClientCert, nil
+	case "request":
+		return tls.VerifyClientCertIfGiven, nil
+	case "none":
+		return tls.NoClientCert, nil
+	}
+	return 0, fmt.Errorf("client auth must be require, request or none, got %q", c.ClientAuth)
+}
+
+// watchedFiles tells when any of a set of files has changed, checking their
+// modification times at most once per interval.
+type watchedFiles struct {
+	paths    []string
+	interval time.Duration
+	checked  time.Time
+	modTimes []time.Time
+}
+
+func newWatchedFiles(interval time.Duration, paths ...string) *watchedFiles {
+	var set []string
+	for _, p := range paths {
+		if p != "" {
+			set = append(set, p)
+		}
+	}
+	return &watchedFiles{paths: set, interval: interval}
+}
+
+// changed reports whether a file has changed since the last call that
+// returned true, and always true on the first call. Files that cannot be read
+// count as unchanged so the caller keeps what it loaded.
+func (w *watchedFiles) changed() bool {
+	if !w.checked.IsZero() && time.Since(w.checked) < w.interval {
+		return false
+	}
+	w.checked = time.Now()
+	mods := make([]time.Time, len(w.paths))
+	for i, p := range w.paths {
+		info, err := os.Stat(p)
+		if err != nil {
+			return false
+		}
+		mods[i] = info.ModTime()
+	}
+	if w.modTimes != nil && equalTimes(mods, w.modTimes) {
+		return false
+	}
+	w.modTimes = mods
+	return true
+}
+
+func equalTimes(a, b []time.Time) bool {
+	for i := range a {
+		if !a[i].Equal(b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Source holds the certificate and CA of a Config, reloading them when their
+// files change. A file that fails to load is logged and the previous
+// certificate stays in use, so a rotation that writes the certificate and the
+// key one after the other is picked up once both are in place.
+type Source struct {
+	cfg   Config
+	mu    sync.Mutex
+	files *watchedFiles
+	cert  *tls.Certificate
+	roots *x509.CertPool
+}
+
+// NewSource loads the files of cfg; they must be valid at startup.
+func NewSource(cfg Config) (*Source, error) {
+	if (cfg.CertFile == "") != (cfg.KeyFile == "") {
+		return nil, errors.New("tls: a certificate needs both a cert and a key file")
+	}
+	s := &Source{cfg: cfg, files: newWatchedFiles(cfg.ReloadInterval, cfg.CertFile, cfg.KeyFile, cfg.CAFile)}
+	s.files.changed()
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *Source) load() error {
+	var cert *tls.Certificate
+	if s.cfg.CertFile != "" {
+		c, err := tls.LoadX509KeyPair(s.cfg.CertFile, s.cfg.KeyFile)
+		if err != nil {
+			return fmt.Errorf("tls: load %s: %w", s.cfg.CertFile, err)
+		}
+		cert = &c
+	}
+	var roots *x509.CertPool
+	if s.cfg.CAFile != "" {
+		data, err := os.ReadFile(s.cfg.CAFile)
+		if err != nil {
+			return fmt.Errorf("tls: %w", err)
+		}
+		roots = x509.NewCertPool()
+		if !roots.AppendCertsFromPEM(data) {
+			return fmt.Errorf("tls: no CA certificates in %s", s.cfg.CAFile)
+		}
+	}
+	s.cert, s.roots = cert, roots
+	return nil
+}
+
+// current returns the certificate and CA in use, reloading them first if
+// their files have changed.
+func (s *Source) current() (*tls.Certificate, *x509.CertPool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.files.changed() {
+		if err := s.load(); err != nil {
+			log.Printf("%v; keeping the certificates in use", err)
+		} else if s.cert != nil && s.cert.Leaf != nil {
+			log.Printf("tls: loaded %s, valid until %s", s.cfg.CertFile, s.cert.Leaf.NotAfter.Format(time.RFC3339))
+		}
+	}
+	return s.cert, s.roots
+}
+
+// ServerConfig returns the TLS configuration of a server offering nextProtos
+// over ALPN, or nil without a certificate.
+func (s *Source) ServerConfig(nextProtos ...string) (*tls.Config, error) {
+	if s.cfg.CertFile == "" {
+		return nil, nil
+	}
+	clientAuth, err := s.cfg.clientAuth()
+	if err != nil {
+		return nil, fmt.Errorf("tls: %w", err)
+	}
+	if clientAuth != tls.NoClientCert && s.cfg.CAFile == "" {
+		return nil, errors.New("tls: verifying client certificates needs a CA file")
+	}
+	// a fresh configuration per handshake picks up rotated files
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			cert, roots := s.current()
+			return &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				Certificates: []tls.Certificate{*cert},
+				ClientCAs:    roots,
+				ClientAuth:   clientAuth,
+				NextProtos:   nextProtos,
+			}, nil
+		},
+	}, nil
+}
+
+// ClientConfig returns the TLS configuration of a client, which presents the
+// certificate, if any, and verifies servers against the CA, or nil without a
+// CA.
+func (s *Source) ClientConfig() *tls.Config {
+	if s.cfg.CAFile == "" {
+		return nil
+	}
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			cert, _ := s.current()
+			if cert == nil {
+				return &tls.Certificate{}, nil
+			}
+			return cert, nil
+		},
+		// the server is verified in VerifyConnection instead, against the CA
+		// in use at the time rather than the one loaded at startup
+		InsecureSkipVerify: true,
+		VerifyConnection: func(cs tls.ConnectionState) error {
+			_, roots := s.current()
+			if len(cs.PeerCertificates) == 0 {
+				return errors.New("tls: server presented no certificate")
+			}
+			opts := x509.VerifyOptions{
+				Roots:         roots,
+				DNSName:       cs.ServerName,
+				Intermediates: x509.NewCertPool(),
+			}
+			for _, c := range cs.PeerCertificates[1:] {
+				opts.Intermediates.AddCert(c)
+			}
+			_, err := cs.PeerCertificates[0].Verify(opts)
+			return err
+		},
+	}
+}
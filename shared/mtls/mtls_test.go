@@ -0,0 +1,287 @@
+package mtls
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func newCA(t *testing.T) *DevCA {
+	t.Helper()
+	ca, err := NewDevCA(time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ca
+}
+
+func parseCert(t *testing.T, certPEM []byte) *x509.Certificate {
+	t.Helper()
+	block, _ := pem.Decode(certPEM)
+	if block == nil {
+		t.Fatal("no PEM block")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert
+}
+
+// touch moves the modification time of path forward, so a reload sees the
+// change even within the file system's timestamp resolution.
+func touch(t *testing.T, path string, by time.Duration) {
+	t.Helper()
+	mod := time.Now().Add(by)
+	if err := os.Chtimes(path, mod, mod); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func peerContext(chains ...[]*x509.Certificate) context.Context {
+	info := credentials.TLSInfo{State: tls.ConnectionState{VerifiedChains: chains}}
+	return peer.NewContext(context.Background(), &peer.Peer{AuthInfo: info})
+}
+
+func writeAllowList(t *testing.T, data string) *AllowList {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "peers.json")
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	a, err := LoadAllowList(path, 0)
+	if err != nil {
+		t.Fatalf("LoadAllowList() error = %v", err)
+	}
+	return a
+}
+
+func TestAllowListAllowed(t *testing.T) {
+	a := writeAllowList(t, `{"peers": {
+		"payments-service": ["/accounts.AccountService/Transfer", "/accounts.AccountService/GetAccount"],
+		"gateway-service":  ["/accounts.AccountService/*"],
+		"ops-console":      ["*"],
+		"nobody":           []
+	}}`)
+	tests := []struct {
+		identity string
+		method   string
+		want     bool
+	}{
+		{"payments-service", "/accounts.AccountService/Transfer", true},
+		{"payments-service", "/accounts.AccountService/CloseAccount", false},
+		{"gateway-service", "/accounts.AccountService/CloseAccount", true},
+		{"gateway-service", "/accounts.AccountServiceAdmin/CloseAccount", false},
+		{"gateway-service", "/payments.PaymentService/CapturePayment", false},
+		{"ops-console", "/payments.PaymentService/CapturePayment", true},
+		{"nobody", "/accounts.AccountService/GetAccount", false},
+		{"stranger", "/accounts.AccountService/GetAccount", false},
+	}
+	for _, tt := range tests {
+		if got := a.Allowed(tt.identity, tt.method); got != tt.want {
+			t.Errorf("Allowed(%s, %s) = %v, want %v", tt.identity, tt.method, got, tt.want)
+		}
+	}
+}
+
+func TestAllowListReload(t *testing.T) {
+	a := writeAllowList(t, `{"peers": {"gateway-service": ["*"]}}`)
+	method := "/accounts.AccountService/GetAccount"
+
+	if err := os.WriteFile(a.path, []byte(`{"peers": {"gateway-service": ["GetAccount"]}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	touch(t, a.path, time.Minute)
+	if !a.Allowed("gateway-service", method) {
+		t.Fatal("invalid allow-list replaced the one in use")
+	}
+
+	if err := os.WriteFile(a.path, []byte(`{"peers": {"gateway-service": ["/payments.PaymentService/*"]}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	touch(t, a.path, 2*time.Minute)
+	if a.Allowed("gateway-service", method) {
+		t.Fatal("changed allow-list was not reloaded")
+	}
+}
+
+func TestPeerIdentity(t *testing.T) {
+	ca := newCA(t)
+	certPEM, _, err := ca.Issue("payments-service", time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	named := parseCert(t, certPEM)
+	dnsOnly := &x509.Certificate{DNSNames: []string{"gateway.internal"}}
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want string
+		ok   bool
+	}{
+		{"common name", peerContext([]*x509.Certificate{named, ca.Cert}), "payments-service", true},
+		{"dns name without common name", peerContext([]*x509.Certificate{dnsOnly}), "gateway.internal", true},
+		{"no names", peerContext([]*x509.Certificate{{}}), "", false},
+		{"unverified", peerContext(), "", false},
+		{"plaintext", peer.NewContext(context.Background(), &peer.Peer{}), "", false},
+		{"no peer", context.Background(), "", false},
+	}
+	for _, tt := range tests {
+		got, ok := PeerIdentity(tt.ctx)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("%s: PeerIdentity() = %q, %v, want %q, %v", tt.name, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestAllowListCheck(t *testing.T) {
+	a := writeAllowList(t, `{"peers": {"payments-service": ["/accounts.AccountService/Transfer"]}}`)
+	payments := peerContext([]*x509.Certificate{{DNSNames: []string{"payments-service"}}})
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		method string
+		code   codes.Code
+	}{
+		{"allowed", payments, "/accounts.AccountService/Transfer", codes.OK},
+		{"not allowed", payments, "/accounts.AccountService/CloseAccount", codes.PermissionDenied},
+		{"no certificate", context.Background(), "/accounts.AccountService/Transfer", codes.Unauthenticated},
+		{"unverified certificate", peerContext(), "/accounts.AccountService/Transfer", codes.Unauthenticated},
+	}
+	for _, tt := range tests {
+		if got := status.Code(a.check(tt.ctx, tt.method)); got != tt.code {
+			t.Errorf("%s: check() = %s, want %s", tt.name, got, tt.code)
+		}
+	}
+}
+
+func TestSourceReload(t *testing.T) {
+	dir := t.TempDir()
+	ca := newCA(t)
+	if err := ca.WriteFiles(dir); err != nil {
+		t.Fatal(err)
+	}
+	if err := ca.IssueFiles(dir, "accounts-service", time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	cfg := Config{
+		CertFile: filepath.Join(dir, "accounts-service.crt"),
+		KeyFile:  filepath.Join(dir, "accounts-service.key"),
+		CAFile:   filepath.Join(dir, "ca.crt"),
+	}
+	src, err := NewSource(cfg)
+	if err != nil {
+		t.Fatalf("NewSource() error = %v", err)
+	}
+	serverCert := func() *x509.Certificate {
+		t.Helper()
+		tlsConfig, err := src.ServerConfig("h2")
+		if err != nil {
+			t.Fatal(err)
+		}
+		perHandshake, err := tlsConfig.GetConfigForClient(&tls.ClientHelloInfo{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		cert, err := x509.ParseCertificate(perHandshake.Certificates[0].Certificate[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		return cert
+	}
+	first := serverCert()
+
+	// a key written without its certificate does not match; the old pair stays
+	_, keyPEM, err := ca.Issue("accounts-service", time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(cfg.KeyFile, keyPEM, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	touch(t, cfg.KeyFile, time.Minute)
+	if got := serverCert(); got.SerialNumber.Cmp(first.SerialNumber) != 0 {
+		t.Fatal("a mismatched key replaced the certificate in use")
+	}
+
+	if err := ca.IssueFiles(dir, "accounts-service", time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	touch(t, cfg.KeyFile, 2*time.Minute)
+	touch(t, cfg.CertFile, 2*time.Minute)
+	rotated := serverCert()
+	if rotated.SerialNumber.Cmp(first.SerialNumber) == 0 {
+		t.Fatal("rotated certificate was not reloaded")
+	}
+	if rotated.Subject.CommonName != "accounts-service" {
+		t.Fatalf("rotated certificate for %q", rotated.Subject.CommonName)
+	}
+}
+
+func TestClientVerifyConnection(t *testing.T) {
+	dir := t.TempDir()
+	ca := newCA(t)
+	if err := ca.WriteFiles(dir); err != nil {
+		t.Fatal(err)
+	}
+	src, err := NewSource(Config{CAFile: filepath.Join(dir, "ca.crt")})
+	if err != nil {
+		t.Fatalf("NewSource() error = %v", err)
+	}
+	verify := src.ClientConfig().VerifyConnection
+
+	issue := func(ca *DevCA, name string, hosts ...string) *x509.Certificate {
+		t.Helper()
+		certPEM, _, err := ca.Issue(name, time.Hour, hosts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return parseCert(t, certPEM)
+	}
+	other := newCA(t)
+	accounts := issue(ca, "accounts-service", "127.0.0.1")
+	foreign := issue(other, "accounts-service")
+
+	tests := []struct {
+		name       string
+		serverName string
+		chain      []*x509.Certificate
+		ok         bool
+	}{
+		{"signed by the CA", "accounts-service", []*x509.Certificate{accounts}, true},
+		{"ip address", "127.0.0.1", []*x509.Certificate{accounts}, true},
+		{"other name", "payments-service", []*x509.Certificate{accounts}, false},
+		{"other CA", "accounts-service", []*x509.Certificate{foreign}, false},
+		{"no certificate", "accounts-service", nil, false},
+	}
+	for _, tt := range tests {
+		err := verify(tls.ConnectionState{ServerName: tt.serverName, PeerCertificates: tt.chain})
+		if (err == nil) != tt.ok {
+			t.Errorf("%s: VerifyConnection() error = %v, want ok %v", tt.name, err, tt.ok)
+		}
+	}
+
+	// once the CA file is rotated, servers of the new CA are trusted instead
+	if err := other.WriteFiles(dir); err != nil {
+		t.Fatal(err)
+	}
+	touch(t, filepath.Join(dir, "ca.crt"), time.Minute)
+	if err := verify(tls.ConnectionState{ServerName: "accounts-service", PeerCertificates: []*x509.Certificate{foreign}}); err != nil {
+		t.Fatalf("VerifyConnection() after CA rotation error = %v", err)
+	}
+	if err := verify(tls.ConnectionState{ServerName: "accounts-service", PeerCertificates: []*x509.Certificate{accounts}}); err == nil {
+		t.Fatal("VerifyConnection() trusted the old CA after rotation")
+	}
+}
@@ -11,6 +11,10 @@ protoc --go_out=services/accounts-service/. --go-grpc_out=services/accounts-serv
 protoc --go_out=services/payments-service/. --go-grpc_out=services/payments-service/. services/payments-service/proto/payments.proto services/payments-service/proto/accounts.proto
 protoc --go_out=services/settlement-service/. --go-grpc_out=services/settlement-service/. services/settlement-service/proto/settlement.proto
 
+# development CA and service certificates for mutual TLS, generated once; run
+# the command again to rotate the service certificates (the CA is kept)
+[ -f infra/tls/certs/ca.crt ] || (cd shared && go run ./cmd/devcerts -out ../infra/tls/certs)
+
 
 docker compose \
   -f docker-compose.yml \
@@ -19,4 +23,5 @@ docker compose \
   -f services/accounts-service/docker-compose.accounts.yml \
   -f services/payments-service/docker-compose.payments.yml \
   -f services/settlement-service/docker-compose.settlement.yml \
+  -f services/gateway-service/docker-compose.gateway.yml \
   up --build -d
\ No newline at end of file
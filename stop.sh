@@ -5,5 +5,6 @@ docker compose \
   -f services/accounts-service/docker-compose.accounts.yml \
   -f services/payments-service/docker-compose.payments.yml \
   -f services/settlement-service/docker-compose.settlement.yml \
+  -f services/gateway-service/docker-compose.gateway.yml \
   down -v
   # -v will remove volumes
\ No newline at end of file